@@ -0,0 +1,272 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gotmc/ivi/internal/block"
+)
+
+// maxEmptyReads is how many reads returning no data and no error ReadBlock
+// tolerates before giving up, as [bufio.Reader] does.
+const maxEmptyReads = 100
+
+// indefiniteChunk is the size of each read of an indefinite length block.
+const indefiniteChunk = 4096
+
+// BlockValue is the type of an element of binary block data, such as a
+// waveform sample.
+type BlockValue interface {
+	~int8 | ~uint8 | ~int16 | ~uint16 | ~int32 | ~uint32 |
+		~float32 | ~float64
+}
+
+// ParseBlock parses IEEE 488.2 arbitrary block data at the start of p,
+// returning the block's data and the bytes that follow it. A definite length
+// block, #<n><length><data>, ends after length bytes of data. An indefinite
+// length block, #0<data>, extends to the end of p, less a trailing newline.
+// The data is a subslice of p.
+func ParseBlock(p []byte) (data, rest []byte, err error) {
+	return block.Parse(p)
+}
+
+// AppendBlock appends data to dst as a definite length block,
+// #<n><length><data>, and returns the extended slice.
+func AppendBlock(dst, data []byte) []byte {
+	return block.Append(dst, data)
+}
+
+// AppendIndefiniteBlock appends data to dst as an indefinite length block,
+// #0<data>, and returns the extended slice. The program message must end
+// right after it, with the newline and END that terminate the block.
+func AppendIndefiniteBlock(dst, data []byte) []byte {
+	dst = append(dst, '#', '0')
+
+	return append(dst, data...)
+}
+
+// WriteBlock sends a program message whose parameter is data as a definite
+// length block, such as "DATA:ARB #3100<100 bytes>", with one WriteBinary
+// call. The message ends with a newline.
+func WriteBlock(
+	ctx context.Context,
+	w BinaryWriter,
+	header string,
+	data []byte,
+) error {
+	msg := make([]byte, 0, len(header)+len(data)+13)
+	if header != "" {
+		msg = append(msg, header...)
+		msg = append(msg, ' ')
+	}
+
+	msg = AppendBlock(msg, data)
+	msg = append(msg, '\n')
+
+	if _, err := w.WriteBinary(ctx, msg); err != nil {
+		return fmt.Errorf("WriteBlock: %w", err)
+	}
+
+	return nil
+}
+
+// ReadBlock reads IEEE 488.2 arbitrary block data in response to a query
+// sent with Command, along with the newline that ends the response. It reads
+// only the bytes the header says belong to a definite length block, so the
+// transport is left ready for the next response.
+//
+// An indefinite length block, #0<data>, ends with a newline sent with END,
+// which a BinaryReader does not report. ReadBlock reads it in chunks of 4096
+// bytes and takes the block to end at the first read that ends with a
+// newline; the newline is not returned. Binary data holding 0x0A may
+// therefore be truncated, if a read happens to end on that byte; read such
+// data as a definite length block.
+func ReadBlock(ctx context.Context, r BinaryReader) ([]byte, error) {
+	br := &blockReader{ctx: ctx, r: r}
+
+	var header [2]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, fmt.Errorf("ReadBlock: %w", blockReadError(err))
+	}
+
+	if header[0] != '#' || header[1] < '0' || header[1] > '9' {
+		return nil, fmt.Errorf(
+			"ReadBlock: %w: header %q", ErrInvalidBlock, header[:],
+		)
+	}
+
+	digits := int(header[1] - '0')
+	if digits == 0 {
+		data, err := br.readIndefinite()
+		if err != nil {
+			return nil, fmt.Errorf("ReadBlock: %w", err)
+		}
+
+		return data, nil
+	}
+
+	lengthDigits := make([]byte, digits)
+	if _, err := io.ReadFull(br, lengthDigits); err != nil {
+		return nil, fmt.Errorf("ReadBlock: %w", blockReadError(err))
+	}
+
+	length, err := block.ParseLength(lengthDigits)
+	if err != nil {
+		return nil, fmt.Errorf("ReadBlock: %w", err)
+	}
+
+	// Grow the buffer as data arrives rather than trusting the header to
+	// size it.
+	var data bytes.Buffer
+	if _, err := io.CopyN(&data, br, int64(length)); err != nil {
+		return nil, fmt.Errorf("ReadBlock: %w", blockReadError(err))
+	}
+
+	if err := br.readTerminator(); err != nil {
+		return nil, fmt.Errorf("ReadBlock: %w", err)
+	}
+
+	return data.Bytes(), nil
+}
+
+// ReadBlockValues reads block data with [ReadBlock] and decodes it with
+// [DecodeBlockValues].
+func ReadBlockValues[T BlockValue](
+	ctx context.Context,
+	r BinaryReader,
+	order binary.ByteOrder,
+) ([]T, error) {
+	data, err := ReadBlock(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeBlockValues[T](data, order)
+}
+
+// DecodeBlockValues decodes block data as consecutive values of type T in
+// the given byte order, such as int16 waveform samples sent most significant
+// byte first with binary.BigEndian. The length of data must be a multiple of
+// the size of T.
+func DecodeBlockValues[T BlockValue](
+	data []byte,
+	order binary.ByteOrder,
+) ([]T, error) {
+	var zero T
+
+	size := binary.Size(zero)
+	if len(data)%size != 0 {
+		return nil, fmt.Errorf(
+			"%w: %d bytes is not a whole number of %d-byte values",
+			ErrInvalidBlock, len(data), size,
+		)
+	}
+
+	values := make([]T, len(data)/size)
+	if _, err := binary.Decode(data, order, values); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBlock, err)
+	}
+
+	return values, nil
+}
+
+// EncodeBlockValues encodes values in the given byte order as data for
+// [AppendBlock] or [WriteBlock].
+func EncodeBlockValues[T BlockValue](
+	values []T,
+	order binary.ByteOrder,
+) []byte {
+	data, _ := binary.Append(nil, order, values)
+
+	return data
+}
+
+// blockReader adapts a BinaryReader to an io.Reader, failing with
+// io.ErrNoProgress rather than looping forever on a transport that keeps
+// returning no data.
+type blockReader struct {
+	ctx   context.Context
+	r     BinaryReader
+	empty int
+}
+
+func (b *blockReader) Read(p []byte) (int, error) {
+	n, err := b.r.ReadBinary(b.ctx, p)
+	if n > 0 || err != nil {
+		b.empty = 0
+		return n, err
+	}
+
+	b.empty++
+	if b.empty >= maxEmptyReads {
+		return 0, io.ErrNoProgress
+	}
+
+	return 0, nil
+}
+
+// readIndefinite reads the data of an indefinite length block up to the
+// newline ending a read.
+func (b *blockReader) readIndefinite() ([]byte, error) {
+	var data []byte
+
+	chunk := make([]byte, indefiniteChunk)
+
+	for {
+		n, err := b.Read(chunk)
+		data = append(data, chunk[:n]...)
+
+		if n > 0 && chunk[n-1] == '\n' {
+			return data[:len(data)-1], nil
+		}
+
+		if errors.Is(err, io.EOF) {
+			return data, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readTerminator reads the newline, or carriage return and newline, ending
+// a response.
+func (b *blockReader) readTerminator() error {
+	var c [1]byte
+	if _, err := io.ReadFull(b, c[:]); err != nil {
+		return blockReadError(err)
+	}
+
+	if c[0] == '\r' {
+		if _, err := io.ReadFull(b, c[:]); err != nil {
+			return blockReadError(err)
+		}
+	}
+
+	if c[0] != '\n' {
+		return fmt.Errorf(
+			"%w: %q after the block, want a newline", ErrInvalidBlock, c[0],
+		)
+	}
+
+	return nil
+}
+
+// blockReadError reports a response ending early as invalid block data.
+func blockReadError(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %w", ErrInvalidBlock, io.ErrUnexpectedEOF)
+	}
+
+	return err
+}
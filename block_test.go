@@ -0,0 +1,281 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+)
+
+// chunkReader is a BinaryReader returning at most chunk bytes per read, as
+// a transport returns a response in pieces.
+type chunkReader struct {
+	data  []byte
+	chunk int
+}
+
+func (c *chunkReader) ReadBinary(_ context.Context, p []byte) (int, error) {
+	if len(c.data) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p[:min(len(p), c.chunk)], c.data)
+	c.data = c.data[n:]
+
+	return n, nil
+}
+
+// binaryRecorder is a BinaryWriter recording what was written.
+type binaryRecorder struct {
+	written []byte
+}
+
+func (b *binaryRecorder) WriteBinary(_ context.Context, p []byte) (int, error) {
+	b.written = append(b.written, p...)
+	return len(p), nil
+}
+
+func TestParseBlock(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		wantData string
+		wantRest string
+		wantErr  bool
+	}{
+		{"definite", "#15hello\n", "hello", "\n", false},
+		{"definite empty", "#10", "", "", false},
+		{"two length digits", "#210abcdefghij;", "abcdefghij", ";", false},
+		{"indefinite", "#0hello\n", "hello", "", false},
+		{"indefinite without newline", "#0hi", "hi", "", false},
+		{"short data", "#15hell", "", "", true},
+		{"truncated length", "#3", "", "", true},
+		{"signed length", "#2+1x", "", "", true},
+		{"no hash", "15hello", "", "", true},
+		{"no digit", "#x", "", "", true},
+		{"empty", "", "", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, rest, err := ParseBlock([]byte(tc.input))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseBlock() error = %v, want error %t",
+					err, tc.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidBlock) {
+					t.Errorf("error = %v, want ErrInvalidBlock", err)
+				}
+				return
+			}
+			if string(data) != tc.wantData || string(rest) != tc.wantRest {
+				t.Errorf("ParseBlock() = %q, %q, want %q, %q",
+					data, rest, tc.wantData, tc.wantRest)
+			}
+		})
+	}
+}
+
+func TestReadBlock(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{"definite", "#15hello\n", "hello", nil},
+		{"definite with CR LF", "#15hello\r\n", "hello", nil},
+		{"newline in data", "#13a\nb\n", "a\nb", nil},
+		{"indefinite", "#0hello\n", "hello", nil},
+		{"indefinite to EOF", "#0hello", "hello", nil},
+		{"short data", "#15hel", "", ErrInvalidBlock},
+		{"no terminator", "#15hello", "", ErrInvalidBlock},
+		{"wrong terminator", "#15hello;", "", ErrInvalidBlock},
+		{"bad length", "#2x5hello\n", "", ErrInvalidBlock},
+		{"not a block", "1.5\n", "", ErrInvalidBlock},
+		{"empty", "", "", ErrInvalidBlock},
+	}
+
+	for _, tc := range testCases {
+		for _, chunk := range []int{1, 3, 4096} {
+			r := &chunkReader{data: []byte(tc.input), chunk: chunk}
+
+			got, err := ReadBlock(t.Context(), r)
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("%s, %d-byte reads: ReadBlock() error = %v, want %v",
+					tc.name, chunk, err, tc.wantErr)
+				continue
+			}
+			if err == nil && string(got) != tc.want {
+				t.Errorf("%s, %d-byte reads: ReadBlock() = %q, want %q",
+					tc.name, chunk, got, tc.want)
+			}
+			if err == nil && tc.input[1] != '0' && len(r.data) != 0 {
+				t.Errorf("%s: ReadBlock() left %q unread", tc.name, r.data)
+			}
+		}
+	}
+}
+
+func TestReadBlock_LeavesNextResponse(t *testing.T) {
+	r := &chunkReader{data: []byte("#13abc\n#12de\n"), chunk: 64}
+
+	for _, want := range []string{"abc", "de"} {
+		got, err := ReadBlock(t.Context(), r)
+		if err != nil || string(got) != want {
+			t.Errorf("ReadBlock() = %q, %v, want %q", got, err, want)
+		}
+	}
+}
+
+type emptyReader struct{}
+
+func (emptyReader) ReadBinary(context.Context, []byte) (int, error) {
+	return 0, nil
+}
+
+func TestReadBlock_NoProgress(t *testing.T) {
+	_, err := ReadBlock(t.Context(), emptyReader{})
+	if !errors.Is(err, io.ErrNoProgress) {
+		t.Errorf("ReadBlock() error = %v, want io.ErrNoProgress", err)
+	}
+}
+
+func TestWriteBlock(t *testing.T) {
+	var w binaryRecorder
+
+	data := EncodeBlockValues([]int16{1, -2}, binary.BigEndian)
+	err := WriteBlock(t.Context(), &w, "DATA:ARB:DAC arb1,", data)
+	if err != nil {
+		t.Fatalf("WriteBlock() error: %v", err)
+	}
+
+	want := "DATA:ARB:DAC arb1, #14\x00\x01\xff\xfe\n"
+	if string(w.written) != want {
+		t.Errorf("WriteBlock() wrote %q, want %q", w.written, want)
+	}
+
+	got := AppendIndefiniteBlock([]byte("X "), []byte("ab"))
+	if string(got) != "X #0ab" {
+		t.Errorf("AppendIndefiniteBlock() = %q, want \"X #0ab\"", got)
+	}
+}
+
+func TestDecodeBlockValues(t *testing.T) {
+	data := []byte{0x3f, 0x80, 0x00, 0x00, 0xc0, 0x00, 0x00, 0x00}
+
+	f32, err := DecodeBlockValues[float32](data, binary.BigEndian)
+	if err != nil || !slices.Equal(f32, []float32{1, -2}) {
+		t.Errorf("float32 = %v, %v, want [1 -2]", f32, err)
+	}
+
+	i16, err := DecodeBlockValues[int16](data[:4], binary.LittleEndian)
+	if err != nil || !slices.Equal(i16, []int16{-32705, 0}) {
+		t.Errorf("int16 = %v, %v, want [-32705 0]", i16, err)
+	}
+
+	i8, err := DecodeBlockValues[int8](data[4:5], binary.BigEndian)
+	if err != nil || !slices.Equal(i8, []int8{-64}) {
+		t.Errorf("int8 = %v, %v, want [-64]", i8, err)
+	}
+
+	f64 := []float64{1.5, -0.25}
+	got, err := DecodeBlockValues[float64](
+		EncodeBlockValues(f64, binary.LittleEndian), binary.LittleEndian,
+	)
+	if err != nil || !slices.Equal(got, f64) {
+		t.Errorf("float64 round trip = %v, %v, want %v", got, err, f64)
+	}
+
+	_, err = DecodeBlockValues[float64](data[:5], binary.BigEndian)
+	if !errors.Is(err, ErrInvalidBlock) {
+		t.Errorf("odd length error = %v, want ErrInvalidBlock", err)
+	}
+}
+
+func TestReadBlockValues(t *testing.T) {
+	r := &chunkReader{data: []byte("#14\x00\x01\xff\xfe\n"), chunk: 2}
+
+	got, err := ReadBlockValues[int16](t.Context(), r, binary.BigEndian)
+	if err != nil || !slices.Equal(got, []int16{1, -2}) {
+		t.Errorf("ReadBlockValues() = %v, %v, want [1 -2]", got, err)
+	}
+}
+
+func FuzzParseBlock(f *testing.F) {
+	for _, seed := range []string{
+		"#15hello\n", "#0abc\n", "#10", "#9999999999", "#2-1x", "#", "",
+		"#3\xff\xff\xff", "#41000abc",
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, p []byte) {
+		data, rest, err := ParseBlock(p)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidBlock) {
+				t.Fatalf("error %v does not wrap ErrInvalidBlock", err)
+			}
+			return
+		}
+
+		if p[1] == '0' {
+			return
+		}
+
+		// A definite block re-encodes to the bytes it was parsed from, less
+		// any leading zeros in its length.
+		encoded := append(AppendBlock(nil, data), rest...)
+
+		again, againRest, err := ParseBlock(encoded)
+		if err != nil || !bytes.Equal(again, data) ||
+			!bytes.Equal(againRest, rest) {
+			t.Fatalf("re-encoded block parsed as %q, %q, %v, want %q, %q",
+				again, againRest, err, data, rest)
+		}
+	})
+}
+
+func FuzzReadBlock(f *testing.F) {
+	for _, seed := range []string{
+		"#15hello\n", "#0abc\n", "#13a\nb\r\n", "#9999999999", "#1", "#x",
+		"#15hel",
+	} {
+		f.Add([]byte(seed), 3)
+	}
+
+	f.Fuzz(func(t *testing.T, p []byte, chunk int) {
+		if chunk < 1 || chunk > 1<<16 {
+			return
+		}
+
+		got, err := ReadBlock(t.Context(), &chunkReader{data: p, chunk: chunk})
+		if err != nil {
+			if !errors.Is(err, ErrInvalidBlock) {
+				t.Fatalf("error %v does not wrap ErrInvalidBlock", err)
+			}
+			return
+		}
+
+		if len(got) > len(p) {
+			t.Fatalf("read %d bytes of data from %d bytes", len(got), len(p))
+		}
+
+		if p[1] != '0' {
+			data, _, err := ParseBlock(p)
+			if err != nil || !bytes.Equal(data, got) {
+				t.Fatalf("ReadBlock() = %q, ParseBlock() = %q, %v",
+					got, data, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"slices"
+	"sync"
+)
+
+// AttributeCache remembers attribute values that a driver has set on or read
+// from the instrument, so that repeated reads are served without I/O. This is
+// the state caching described in IVI-3.1: Driver Architecture Specification,
+// enabled per driver with [WithCache].
+//
+// Keys are chosen by the driver and must be unique within one driver
+// instance; drivers use the IVI attribute name, prefixed with the repeated
+// capability name for channel attributes. A nil *AttributeCache is valid and
+// caches nothing, so drivers use the same code path whether or not caching
+// is enabled. An AttributeCache is safe for concurrent use.
+//
+// The cache holds the value the driver last set, not a value coerced by the
+// instrument. Drivers cache with CachedSet only attributes whose value the
+// instrument stores as given, use CoercedSet for attributes it may round or
+// clamp, and must invalidate coupled attributes that the instrument changes
+// as a side effect of a set.
+type AttributeCache struct {
+	mu     sync.Mutex
+	values map[string]any
+}
+
+// NewAttributeCache returns an empty AttributeCache.
+func NewAttributeCache() *AttributeCache {
+	return &AttributeCache{values: make(map[string]any)}
+}
+
+// Invalidate discards the cached values of the given attributes, so that the
+// next read of each queries the instrument.
+func (c *AttributeCache) Invalidate(keys ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.values, key)
+	}
+}
+
+// InvalidateAll discards every cached value.
+func (c *AttributeCache) InvalidateAll() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.values)
+}
+
+func (c *AttributeCache) load(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.values[key]
+
+	return v, ok
+}
+
+func (c *AttributeCache) store(key string, v any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[key] = v
+}
+
+// CachedGet returns the cached value of the attribute key when there is one,
+// and otherwise reads it with get and caches the result. With a nil cache it
+// always calls get.
+func CachedGet[T any](
+	c *AttributeCache,
+	key string,
+	get func() (T, error),
+) (T, error) {
+	if c == nil {
+		return get()
+	}
+
+	if v, ok := c.load(key); ok {
+		if t, ok := v.(T); ok {
+			return t, nil
+		}
+	}
+
+	t, err := get()
+	if err != nil {
+		return t, err
+	}
+
+	c.store(key, t)
+
+	return t, nil
+}
+
+// CachedSet writes the attribute key with set and, on success, caches value
+// as its current value. The coupled attributes, whose values the instrument
+// may change as a side effect, are invalidated whether or not set succeeds.
+// If set fails the attribute's own cached value is discarded too, since the
+// instrument's state is then unknown. With a nil cache it only calls set.
+func CachedSet[T any](
+	c *AttributeCache,
+	key string,
+	value T,
+	set func() error,
+	coupled ...string,
+) error {
+	if c == nil {
+		return set()
+	}
+
+	c.Invalidate(coupled...)
+
+	if err := set(); err != nil {
+		c.Invalidate(key)
+		return err
+	}
+
+	c.store(key, value)
+
+	return nil
+}
+
+// CoercedSet writes the attribute key with set without caching the requested
+// value. It suits attributes the instrument coerces, such as a bandwidth
+// rounded to the nearest available filter or a level clamped to its range:
+// the attribute and its coupled attributes are invalidated after set, so the
+// next read queries the instrument for the value it actually applied. With a
+// nil cache it only calls set.
+func CoercedSet(
+	c *AttributeCache,
+	key string,
+	set func() error,
+	coupled ...string,
+) error {
+	if c == nil {
+		return set()
+	}
+
+	c.Invalidate(coupled...)
+	defer c.Invalidate(slices.Concat(coupled, []string{key})...)
+
+	return set()
+}
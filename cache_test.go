@@ -0,0 +1,192 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"errors"
+	"testing"
+)
+
+// counter returns a getter that reports how often it has been called.
+func counter(v float64) (func() (float64, error), *int) {
+	calls := 0
+	return func() (float64, error) {
+		calls++
+		return v, nil
+	}, &calls
+}
+
+func TestCachedGet_NilCache(t *testing.T) {
+	get, calls := counter(1.5)
+	for range 2 {
+		if _, err := CachedGet(nil, "Frequency", get); err != nil {
+			t.Fatalf("CachedGet() error: %v", err)
+		}
+	}
+	if *calls != 2 {
+		t.Errorf("get called %d times, want 2", *calls)
+	}
+}
+
+func TestCachedGet(t *testing.T) {
+	c := NewAttributeCache()
+	get, calls := counter(1.5)
+	for range 3 {
+		got, err := CachedGet(c, "Frequency", get)
+		if err != nil {
+			t.Fatalf("CachedGet() error: %v", err)
+		}
+		if got != 1.5 {
+			t.Errorf("CachedGet() = %g, want 1.5", got)
+		}
+	}
+	if *calls != 1 {
+		t.Errorf("get called %d times, want 1", *calls)
+	}
+
+	c.Invalidate("Frequency")
+	if _, err := CachedGet(c, "Frequency", get); err != nil {
+		t.Fatalf("CachedGet() error: %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("get called %d times after Invalidate, want 2", *calls)
+	}
+}
+
+func TestCachedGet_ErrorNotCached(t *testing.T) {
+	c := NewAttributeCache()
+	wantErr := errors.New("timeout")
+	_, err := CachedGet(c, "Frequency", func() (float64, error) {
+		return 0, wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("CachedGet() error = %v, want %v", err, wantErr)
+	}
+	get, calls := counter(2)
+	if got, _ := CachedGet(c, "Frequency", get); got != 2 || *calls != 1 {
+		t.Errorf("CachedGet() = %g after %d calls, want 2 after 1", got, *calls)
+	}
+}
+
+func TestCachedSet(t *testing.T) {
+	c := NewAttributeCache()
+	get, calls := counter(1)
+	if _, err := CachedGet(c, "DutyCycle", get); err != nil {
+		t.Fatalf("CachedGet() error: %v", err)
+	}
+
+	err := CachedSet(c, "Frequency", 1e3, func() error { return nil }, "DutyCycle")
+	if err != nil {
+		t.Fatalf("CachedSet() error: %v", err)
+	}
+
+	freq, calls2 := counter(0)
+	if got, _ := CachedGet(c, "Frequency", freq); got != 1e3 || *calls2 != 0 {
+		t.Errorf("Frequency = %g after %d reads, want 1000 from cache", got, *calls2)
+	}
+	if _, err := CachedGet(c, "DutyCycle", get); err != nil {
+		t.Fatalf("CachedGet() error: %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("coupled DutyCycle read %d times, want 2", *calls)
+	}
+}
+
+func TestCachedSet_ErrorInvalidates(t *testing.T) {
+	c := NewAttributeCache()
+	ok := func() error { return nil }
+	if err := CachedSet(c, "Frequency", 1e3, ok); err != nil {
+		t.Fatalf("CachedSet() error: %v", err)
+	}
+	wantErr := errors.New("timeout")
+	err := CachedSet(c, "Frequency", 2e3, func() error { return wantErr })
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("CachedSet() error = %v, want %v", err, wantErr)
+	}
+	get, calls := counter(5e2)
+	if got, _ := CachedGet(c, "Frequency", get); got != 5e2 || *calls != 1 {
+		t.Errorf("Frequency = %g, want 500 read from the instrument", got)
+	}
+}
+
+func TestCoercedSet(t *testing.T) {
+	c := NewAttributeCache()
+	ok := func() error { return nil }
+	if err := CachedSet(c, "Bandwidth", 1e3, ok); err != nil {
+		t.Fatalf("CachedSet() error: %v", err)
+	}
+	if err := CoercedSet(c, "Bandwidth", ok); err != nil {
+		t.Fatalf("CoercedSet() error: %v", err)
+	}
+	get, calls := counter(3e3)
+	for range 2 {
+		if got, _ := CachedGet(c, "Bandwidth", get); got != 3e3 {
+			t.Errorf("Bandwidth = %g, want coerced 3000", got)
+		}
+	}
+	if *calls != 1 {
+		t.Errorf("Bandwidth read %d times, want 1", *calls)
+	}
+}
+
+func TestCoercedSet_KeepsCoupled(t *testing.T) {
+	// A coupled slice with spare capacity must not have the key written
+	// into its backing array.
+	c := NewAttributeCache()
+	backing := []string{"Span", "Sweep"}
+	coupled := backing[:1]
+
+	ok := func() error { return nil }
+	if err := CoercedSet(c, "Bandwidth", ok, coupled...); err != nil {
+		t.Fatalf("CoercedSet() error: %v", err)
+	}
+	if backing[1] != "Sweep" {
+		t.Errorf("coupled backing array = %q, want Sweep kept", backing)
+	}
+}
+
+func TestInherent_ResetInvalidatesCache(t *testing.T) {
+	mock := &mockScriptedInst{}
+	setup, err := NewDriverSetup(mock, InherentBase{}, []DriverOption{
+		WithoutIDQuery(),
+		WithCache(),
+	})
+	if err != nil {
+		t.Fatalf("NewDriverSetup() error: %v", err)
+	}
+	if setup.Cache == nil {
+		t.Fatal("DriverSetup.Cache is nil with WithCache")
+	}
+
+	ok := func() error { return nil }
+	for name, invalidate := range map[string]func(){
+		"Reset": func() {
+			if err := setup.Inherent.Reset(t.Context()); err != nil {
+				t.Fatalf("Reset() error: %v", err)
+			}
+		},
+		"InvalidateAllAttributes": setup.Inherent.InvalidateAllAttributes,
+	} {
+		_ = CachedSet(setup.Cache, "Frequency", 1e3, ok)
+		invalidate()
+		get, calls := counter(0)
+		if _, _ = CachedGet(setup.Cache, "Frequency", get); *calls != 1 {
+			t.Errorf("cache not invalidated by %s", name)
+		}
+	}
+}
+
+func TestNewDriverSetup_NoCacheByDefault(t *testing.T) {
+	setup, err := NewDriverSetup(
+		&mockScriptedInst{}, InherentBase{}, []DriverOption{WithoutIDQuery()},
+	)
+	if err != nil {
+		t.Fatalf("NewDriverSetup() error: %v", err)
+	}
+	if setup.Cache != nil {
+		t.Error("DriverSetup.Cache is not nil without WithCache")
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// probeTimeout bounds each method call made while probing for stubs.
+const probeTimeout = time.Second
+
+// CapabilityGroup is an IVI capability group of an instrument class, such as
+// IviDCPwrMeasurement, with the Go interfaces a driver implements for it: one
+// for the driver and one for its repeated capability, the channel. Either
+// may be nil. Each class package lists its groups in a Groups variable.
+type CapabilityGroup struct {
+	Name    string
+	Driver  reflect.Type
+	Channel reflect.Type
+}
+
+// Capabilities reports what a driver implements for one instrument model.
+type Capabilities struct {
+	// Model is the instrument model the driver was created for.
+	Model string
+	// Groups lists the capability groups whose interfaces the driver and
+	// its channel implement, in the class's order.
+	Groups []string
+	// Stubs lists the methods of those groups that return an error wrapping
+	// [ErrNotImplemented] for the model, as "Group.Method".
+	Stubs []string
+}
+
+// Introspect reports which of groups a driver implements and which of their
+// methods are stubs. The channel is the one returned by the driver's
+// Channel(int) method for index 0, when it has one. Stubs are found by
+// calling every method of the implemented groups with zero arguments, which
+// would reconfigure a real instrument, so Introspect probes only a driver
+// created with [WithSimulate], as reported by its Simulate method. For any
+// other driver it returns an error wrapping [ErrFunctionNotSupported] without
+// calling any method; use [ImplementedGroups] to list its groups.
+func Introspect(
+	ctx context.Context,
+	groups []CapabilityGroup,
+	driver any,
+) (Capabilities, error) {
+	var caps Capabilities
+
+	if s, ok := driver.(interface{ Simulate() bool }); !ok || !s.Simulate() {
+		return caps, fmt.Errorf(
+			"Introspect: %w: %T is not simulated, so probing it would "+
+				"reconfigure the instrument",
+			ErrFunctionNotSupported, driver,
+		)
+	}
+
+	if m, ok := driver.(interface {
+		InstrumentModel(ctx context.Context) (string, error)
+	}); ok {
+		caps.Model, _ = m.InstrumentModel(ctx)
+	}
+
+	channel := firstChannel(driver)
+
+	for _, g := range groups {
+		if !implements(g, driver, channel) {
+			continue
+		}
+
+		caps.Groups = append(caps.Groups, g.Name)
+
+		if g.Driver != nil {
+			caps.Stubs = append(
+				caps.Stubs, probeStubs(ctx, g, g.Driver, driver)...,
+			)
+		}
+
+		if g.Channel != nil {
+			caps.Stubs = append(
+				caps.Stubs, probeStubs(ctx, g, g.Channel, channel)...,
+			)
+		}
+	}
+
+	return caps, nil
+}
+
+// ImplementedGroups returns the names of the groups whose interfaces driver
+// and its channel implement, as [Introspect] does, without probing for stubs.
+// Drivers' tests compare it with their GroupCapabilities.
+func ImplementedGroups(groups []CapabilityGroup, driver any) []string {
+	channel := firstChannel(driver)
+
+	var names []string
+
+	for _, g := range groups {
+		if implements(g, driver, channel) {
+			names = append(names, g.Name)
+		}
+	}
+
+	return names
+}
+
+// CheckGroupCapabilities returns an error when the driver's GroupCapabilities
+// differ from the groups its Go interfaces implement. Groups that no class
+// interface describes yet are skipped, since they cannot be checked.
+func CheckGroupCapabilities(
+	groups []CapabilityGroup,
+	declared []string,
+	driver any,
+) error {
+	implemented := ImplementedGroups(groups, driver)
+
+	var errs []error
+
+	for _, name := range declared {
+		i := slices.IndexFunc(groups, func(g CapabilityGroup) bool {
+			return g.Name == name
+		})
+
+		switch {
+		case i < 0:
+			errs = append(errs, fmt.Errorf("%q is not a group of the class", name))
+		case groups[i].Driver == nil && groups[i].Channel == nil:
+		case !slices.Contains(implemented, name):
+			errs = append(errs, fmt.Errorf(
+				"%q is declared but its interfaces are not implemented", name,
+			))
+		}
+	}
+
+	for _, name := range implemented {
+		if !slices.Contains(declared, name) {
+			errs = append(errs, fmt.Errorf(
+				"%q is implemented but not declared", name,
+			))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// implements reports whether driver and channel implement a group's
+// interfaces. A group with neither interface is never implemented, since
+// nothing shows it is.
+func implements(g CapabilityGroup, driver, channel any) bool {
+	if g.Driver == nil && g.Channel == nil {
+		return false
+	}
+
+	if g.Driver != nil && !satisfies(driver, g.Driver) {
+		return false
+	}
+
+	return g.Channel == nil || satisfies(channel, g.Channel)
+}
+
+func satisfies(v any, iface reflect.Type) bool {
+	return v != nil && reflect.TypeOf(v).Implements(iface)
+}
+
+// firstChannel returns the channel at index 0 of a driver with a
+// Channel(int) (T, error) method, or nil.
+func firstChannel(driver any) any {
+	if driver == nil {
+		return nil
+	}
+
+	m := reflect.ValueOf(driver).MethodByName("Channel")
+	if !m.IsValid() {
+		return nil
+	}
+
+	t := m.Type()
+	if t.NumIn() != 1 || t.In(0).Kind() != reflect.Int || t.NumOut() != 2 {
+		return nil
+	}
+
+	out := m.Call([]reflect.Value{reflect.ValueOf(0).Convert(t.In(0))})
+	if !out[1].IsNil() || !out[0].CanInterface() {
+		return nil
+	}
+
+	return out[0].Interface()
+}
+
+// probeStubs calls each method of iface on v with zero arguments and returns
+// those that report ErrNotImplemented.
+func probeStubs(
+	ctx context.Context,
+	g CapabilityGroup,
+	iface reflect.Type,
+	v any,
+) []string {
+	var stubs []string
+
+	value := reflect.ValueOf(v)
+
+	for i := range iface.NumMethod() {
+		name := iface.Method(i).Name
+		if probe(ctx, value.MethodByName(name)) {
+			stubs = append(stubs, g.Name+"."+name)
+		}
+	}
+
+	return stubs
+}
+
+var (
+	contextType = reflect.TypeFor[context.Context]()
+	errorType   = reflect.TypeFor[error]()
+)
+
+// probe calls m with zero arguments, and a context bounded by probeTimeout
+// for a context parameter, and reports whether it returned an error wrapping
+// ErrNotImplemented. A method that panics on its zero arguments is not a
+// stub.
+func probe(ctx context.Context, m reflect.Value) (stub bool) {
+	t := m.Type()
+	if t.NumOut() == 0 || t.Out(t.NumOut()-1) != errorType || t.IsVariadic() {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	args := make([]reflect.Value, t.NumIn())
+	for i := range args {
+		if t.In(i) == contextType {
+			args[i] = reflect.ValueOf(ctx)
+		} else {
+			args[i] = reflect.Zero(t.In(i))
+		}
+	}
+
+	defer func() {
+		if recover() != nil {
+			stub = false
+		}
+	}()
+
+	out := m.Call(args)
+	err, _ := out[len(out)-1].Interface().(error)
+
+	return errors.Is(err, ErrNotImplemented)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi_test
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/dcload"
+	"github.com/gotmc/ivi/dcload/siglent/sdl1000x"
+	"github.com/gotmc/ivi/dcpwr"
+	"github.com/gotmc/ivi/dcpwr/keysight/e36000"
+	"github.com/gotmc/ivi/dcpwr/kikusui/pmx"
+	"github.com/gotmc/ivi/dcpwr/rigol/dp800"
+	"github.com/gotmc/ivi/dmm"
+	"github.com/gotmc/ivi/dmm/fluke/fluke45"
+	"github.com/gotmc/ivi/dmm/keysight/kt34400"
+	"github.com/gotmc/ivi/dsa"
+	"github.com/gotmc/ivi/dsa/keysight/kt35670"
+	"github.com/gotmc/ivi/fgen"
+	"github.com/gotmc/ivi/fgen/keysight/kt33000"
+	"github.com/gotmc/ivi/fgen/srs/ds345"
+	"github.com/gotmc/ivi/lcr"
+	"github.com/gotmc/ivi/lcr/keysight/kte4980"
+	"github.com/gotmc/ivi/scope"
+	"github.com/gotmc/ivi/scope/keysight/infiniivision"
+	"github.com/gotmc/ivi/specan"
+	"github.com/gotmc/ivi/specan/keysight/esa"
+	"github.com/gotmc/ivi/swtch"
+	"github.com/gotmc/ivi/swtch/keysight/u2751a"
+	"github.com/gotmc/ivi/tempmon"
+	"github.com/gotmc/ivi/tempmon/srs/sr630"
+)
+
+// opener creates a simulated driver with the given options.
+type opener func(opts ...ivi.DriverOption) (any, error)
+
+func open[D any](
+	newDriver func(ivi.Transport, ...ivi.DriverOption) (D, error),
+) opener {
+	return func(opts ...ivi.DriverOption) (any, error) {
+		d, err := newDriver(nil, append(opts, ivi.WithSimulate())...)
+		if err != nil {
+			return nil, err
+		}
+
+		return d, nil
+	}
+}
+
+// registered opens a simulated driver through a class package's registry.
+func registered[T any](r *ivi.Registry[T]) opener {
+	return func(opts ...ivi.DriverOption) (any, error) {
+		d, err := r.Open(
+			context.Background(), nil, append(opts, ivi.WithSimulate())...,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return d, nil
+	}
+}
+
+// testDrivers lists every driver, with its class's capability groups, its
+// constructor, and its class's registry.
+var testDrivers = []struct {
+	name       string
+	groups     []ivi.CapabilityGroup
+	open       opener
+	registered opener
+}{
+	{
+		"sdl1000x", dcload.Groups, open(sdl1000x.New),
+		registered(&dcload.Drivers),
+	},
+	{"e36000", dcpwr.Groups, open(e36000.New), registered(&dcpwr.Drivers)},
+	{"pmx", dcpwr.Groups, open(pmx.New), registered(&dcpwr.Drivers)},
+	{"dp800", dcpwr.Groups, open(dp800.New), registered(&dcpwr.Drivers)},
+	{"fluke45", dmm.Groups, open(fluke45.New), registered(&dmm.Drivers)},
+	{"kt34400", dmm.Groups, open(kt34400.New), registered(&dmm.Drivers)},
+	{"kt35670", dsa.Groups, open(kt35670.New), registered(&dsa.Drivers)},
+	{"kt33000", fgen.Groups, open(kt33000.New), registered(&fgen.Drivers)},
+	{"ds345", fgen.Groups, open(ds345.New), registered(&fgen.Drivers)},
+	{"kte4980", lcr.Groups, open(kte4980.New), registered(&lcr.Drivers)},
+	{
+		"infiniivision", scope.Groups, open(infiniivision.New),
+		registered(&scope.Drivers),
+	},
+	{"esa", specan.Groups, open(esa.New), registered(&specan.Drivers)},
+	{"u2751a", swtch.Groups, open(u2751a.New), registered(&swtch.Drivers)},
+	{"sr630", tempmon.Groups, open(sr630.New), registered(&tempmon.Drivers)},
+}
+
+// inherentBase returns the InherentBase a driver embeds through ivi.Inherent.
+func inherentBase(t *testing.T, driver any) ivi.InherentBase {
+	t.Helper()
+
+	base, ok := reflect.ValueOf(driver).Elem().
+		FieldByName("InherentBase").Interface().(ivi.InherentBase)
+	if !ok {
+		t.Fatalf("%T does not embed ivi.Inherent", driver)
+	}
+
+	return base
+}
+
+// TestGroupCapabilities checks, for every driver and every model it
+// supports, that the GroupCapabilities the driver declares are the groups
+// whose class interfaces it implements.
+func TestGroupCapabilities(t *testing.T) {
+	for _, d := range testDrivers {
+		t.Run(d.name, func(t *testing.T) {
+			driver, err := d.open()
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+
+			models := inherentBase(t, driver).SupportedInstrumentModels
+			for _, model := range models {
+				driver, err := d.open(ivi.WithSimulatedModel(model))
+				if err != nil {
+					t.Fatalf("New(%s) error: %v", model, err)
+				}
+
+				declared := inherentBase(t, driver).GroupCapabilities
+				err = ivi.CheckGroupCapabilities(d.groups, declared, driver)
+				if err != nil {
+					t.Errorf("%s: %v", model, err)
+				}
+
+				caps, err := ivi.Introspect(t.Context(), d.groups, driver)
+				if err != nil {
+					t.Fatalf("Introspect(%s) error: %v", model, err)
+				}
+				if caps.Model != model {
+					t.Errorf("Introspect() Model = %q, want %q", caps.Model, model)
+				}
+			}
+		})
+	}
+}
+
+// TestDriversOpen checks that every driver is registered with its class
+// package, so that the class registry opens the driver for each of its
+// models.
+func TestDriversOpen(t *testing.T) {
+	for _, d := range testDrivers {
+		t.Run(d.name, func(t *testing.T) {
+			driver, err := d.open()
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+
+			models := inherentBase(t, driver).SupportedInstrumentModels
+			for _, model := range models {
+				opened, err := d.registered(ivi.WithSimulatedModel(model))
+				if err != nil {
+					t.Fatalf("Drivers.Open(%s) error: %v", model, err)
+				}
+
+				if reflect.TypeOf(opened) != reflect.TypeOf(driver) {
+					t.Errorf("Drivers.Open(%s) returned %T, want %T",
+						model, opened, driver)
+				}
+			}
+		})
+	}
+}
+
+func TestIntrospect_Stubs(t *testing.T) {
+	tests := []struct {
+		model    string
+		wantStub bool
+	}{
+		// The E3631A has no OUTPut:PROTection:CLEar; the E36102B has.
+		{"E3631A", true},
+		{"E36102B", false},
+	}
+
+	const stub = "IviDCPwrBase.ResetOutputProtection"
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			d, err := e36000.New(nil, ivi.WithSimulatedModel(tt.model))
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+
+			caps, err := ivi.Introspect(t.Context(), dcpwr.Groups, d)
+			if err != nil {
+				t.Fatalf("Introspect() error: %v", err)
+			}
+			if got := slices.Contains(caps.Stubs, stub); got != tt.wantStub {
+				t.Errorf("Stubs = %q, want %s listed: %t",
+					caps.Stubs, stub, tt.wantStub)
+			}
+		})
+	}
+}
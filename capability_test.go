@@ -0,0 +1,143 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type fakeBase interface {
+	Level(ctx context.Context) (float64, error)
+	SetLevel(ctx context.Context, v float64) error
+}
+
+type fakeBaseChannel interface {
+	Name() string
+	Enabled(ctx context.Context) (bool, error)
+}
+
+type fakeTrigger interface {
+	Trigger(ctx context.Context) error
+}
+
+type fakeMeasure interface {
+	Measure(ctx context.Context) (float64, error)
+}
+
+var fakeGroups = []CapabilityGroup{
+	{
+		Name:    "FakeBase",
+		Driver:  reflect.TypeFor[fakeBase](),
+		Channel: reflect.TypeFor[fakeBaseChannel](),
+	},
+	{Name: "FakeTrigger", Driver: reflect.TypeFor[fakeTrigger]()},
+	{Name: "FakeMeasure", Channel: reflect.TypeFor[fakeMeasure]()},
+	{Name: "FakeUndescribed"},
+}
+
+type fakeDriver struct {
+	simulated bool
+}
+
+func (d fakeDriver) Simulate() bool { return d.simulated }
+
+func (fakeDriver) InstrumentModel(context.Context) (string, error) {
+	return "F100", nil
+}
+
+func (fakeDriver) Level(context.Context) (float64, error) { return 1, nil }
+
+func (fakeDriver) SetLevel(context.Context, float64) error {
+	return ErrNotImplemented
+}
+
+func (fakeDriver) Trigger(context.Context) error { panic("unexpected") }
+
+func (fakeDriver) Channel(i int) (fakeChannel, error) {
+	return fakeChannel{}, nil
+}
+
+type fakeChannel struct{}
+
+func (fakeChannel) Name() string { return "CH1" }
+
+func (fakeChannel) Enabled(context.Context) (bool, error) {
+	return false, ErrNotImplemented
+}
+
+func TestImplementedGroups(t *testing.T) {
+	got := ImplementedGroups(fakeGroups, fakeDriver{})
+	want := []string{"FakeBase", "FakeTrigger"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ImplementedGroups() = %q, want %q", got, want)
+	}
+}
+
+func TestIntrospect(t *testing.T) {
+	driver := fakeDriver{simulated: true}
+
+	caps, err := Introspect(t.Context(), fakeGroups, driver)
+	if err != nil {
+		t.Fatalf("Introspect() error: %v", err)
+	}
+
+	if caps.Model != "F100" {
+		t.Errorf("Model = %q, want F100", caps.Model)
+	}
+	if want := []string{"FakeBase", "FakeTrigger"}; !slices.Equal(
+		caps.Groups, want,
+	) {
+		t.Errorf("Groups = %q, want %q", caps.Groups, want)
+	}
+	// Trigger panics on its zero arguments, which is not a stub.
+	want := []string{"FakeBase.SetLevel", "FakeBase.Enabled"}
+	if !slices.Equal(caps.Stubs, want) {
+		t.Errorf("Stubs = %q, want %q", caps.Stubs, want)
+	}
+}
+
+func TestIntrospect_NotSimulated(t *testing.T) {
+	// Probing calls Trigger, which panics, so an error shows nothing was
+	// probed.
+	_, err := Introspect(t.Context(), fakeGroups, fakeDriver{})
+	if !errors.Is(err, ErrFunctionNotSupported) {
+		t.Errorf("Introspect() error = %v, want ErrFunctionNotSupported", err)
+	}
+}
+
+func TestCheckGroupCapabilities(t *testing.T) {
+	testCases := []struct {
+		name     string
+		declared []string
+		wantErr  bool
+	}{
+		{"consistent", []string{"FakeBase", "FakeTrigger"}, false},
+		{
+			"undescribed group",
+			[]string{"FakeBase", "FakeTrigger", "FakeUndescribed"},
+			false,
+		},
+		{"not implemented", []string{
+			"FakeBase", "FakeTrigger", "FakeMeasure",
+		}, true},
+		{"not declared", []string{"FakeBase"}, true},
+		{"misspelled", []string{"FakeBase", "Faketrigger"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckGroupCapabilities(fakeGroups, tc.declared, fakeDriver{})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("CheckGroupCapabilities() error = %v, want error %t",
+					err, tc.wantErr)
+			}
+		})
+	}
+}
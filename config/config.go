@@ -0,0 +1,391 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+// Package config provides a configuration store that maps logical names,
+// such as "MainSupply", to the driver, resource string, driver options, and
+// virtual channel names of an instrument, as the IVI Configuration Server
+// does. A test station then moves to other hardware by editing its store
+// rather than its code.
+//
+// A store is a JSON file:
+//
+//	{
+//	  "logicalNames": {
+//	    "MainSupply": {
+//	      "driver": "e36000",
+//	      "resource": "TCPIP0::10.0.0.5::5025::SOCKET",
+//	      "options": {"timeout": "5s", "reset": true},
+//	      "virtualChannels": {"Rail5V": "P6V", "Rail12V": "P25V"}
+//	    }
+//	  }
+//	}
+//
+// Open the session a logical name refers to with the OpenDriver method of the
+// class package's driver registry, after importing the drivers the store may
+// name:
+//
+//	store, err := config.Load("station.json")
+//	if err != nil {
+//		return err
+//	}
+//	supply, err := config.Open(
+//		ctx, store, "MainSupply", dcpwr.Drivers.OpenDriver,
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	defer supply.Close()
+//
+// A resource that leaves out an address, such as "TCPIP0::10.0.0.5::SOCKET"
+// or "GPIB0::INSTR", is completed with the connection defaults the driver
+// registers with [ivi.RegisterConnectionDefaults] before it is dialed.
+//
+// Virtual channel names are a lookup table only: the driver is created with
+// the instrument's own channel names, so a program translates a virtual name,
+// such as "Rail5V", with [Session.PhysicalChannel] before using it to find
+// the driver's channel.
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/socket"
+)
+
+// Errors returned by the config package.
+var (
+	// ErrUnknownLogicalName indicates a logical name is not in the store.
+	ErrUnknownLogicalName = errors.New("unknown logical name")
+	// ErrInvalidStore indicates a store file could not be parsed or holds an
+	// invalid session.
+	ErrInvalidStore = errors.New("invalid configuration store")
+)
+
+// Store maps logical names to driver sessions.
+type Store struct {
+	// LogicalNames maps each logical name to its session.
+	LogicalNames map[string]Session `json:"logicalNames"`
+	// Dial opens the transport for a session's resource. When nil, TCPIP
+	// SOCKET resources are dialed with [socket.DialResource] and other
+	// resources cannot be opened.
+	Dial Dialer `json:"-"`
+}
+
+// Dialer opens a transport to the instrument at a resource, using the
+// connection settings made by combining the session's resource with its
+// driver's connection defaults. The transport is closed with the session when
+// it is an [io.Closer].
+type Dialer func(
+	ctx context.Context,
+	settings ivi.ConnectionSettings,
+) (ivi.Transport, error)
+
+// Session describes how to open one instrument.
+type Session struct {
+	// Driver is the name the driver registers with its class package
+	// (e.g., "e36000" or "dp800").
+	Driver string `json:"driver"`
+	// Resource is the VISA resource string of the instrument. It may be
+	// empty for a simulated session.
+	Resource string `json:"resource,omitempty"`
+	// Options holds the driver options.
+	Options Options `json:"options"`
+	// VirtualChannels maps the channel names used by programs to the
+	// channel names of the instrument (e.g., "Rail5V" to "P6V"). The map is
+	// only consulted by [Session.PhysicalChannel]; the driver itself knows
+	// nothing of it and accepts only the instrument's channel names.
+	VirtualChannels map[string]string `json:"virtualChannels,omitempty"`
+}
+
+// Options holds the driver options of a session, named after the IVI
+// inherent attributes they set.
+type Options struct {
+	// Timeout is the I/O timeout, such as "5s". Empty means the driver
+	// default.
+	Timeout Duration `json:"timeout,omitzero"`
+	// Reset resets the instrument when the driver is created.
+	Reset bool `json:"reset,omitempty"`
+	// IDQuery, when false, skips the *IDN? model check. It defaults to
+	// true.
+	IDQuery *bool `json:"idQuery,omitempty"`
+	// Standalone configures the instrument to run without a controller,
+	// for drivers that support it.
+	Standalone bool `json:"standalone,omitempty"`
+	// Cache enables state caching, for drivers that support it.
+	Cache bool `json:"cache,omitempty"`
+	// QueryInstrumentStatus checks the error queue after each command.
+	QueryInstrumentStatus bool `json:"queryInstrumentStatus,omitempty"`
+	// RangeCheck checks values against the model's limits before sending
+	// them, for drivers that record the limits.
+	RangeCheck bool `json:"rangeCheck,omitempty"`
+	// Simulate simulates the instrument rather than opening the resource.
+	Simulate bool `json:"simulate,omitempty"`
+	// SimulatedModel is the model to simulate.
+	SimulatedModel string `json:"simulatedModel,omitempty"`
+}
+
+// Duration is a [time.Duration] that is written in JSON as a string such as
+// "1.5s".
+type Duration time.Duration
+
+// MarshalJSON encodes the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON decodes a duration string, such as "500ms".
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"5s\": %w", err)
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(v)
+
+	return nil
+}
+
+// Load reads the store in the named file.
+func Load(name string) (*Store, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	store, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return store, nil
+}
+
+// Read reads a store from r and validates each session. Unknown fields are
+// errors, so that a misspelled option is not silently ignored.
+func Read(r io.Reader) (*Store, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	var store Store
+	if err := dec.Decode(&store); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidStore, err)
+	}
+
+	for name, session := range store.LogicalNames {
+		if err := session.validate(); err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidStore, name, err)
+		}
+	}
+
+	return &store, nil
+}
+
+// Save writes the store to w as indented JSON.
+func (s *Store) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(s)
+}
+
+// Session returns the session of a logical name.
+func (s *Store) Session(name string) (Session, error) {
+	session, ok := s.LogicalNames[name]
+	if !ok {
+		return Session{}, fmt.Errorf("%w: %q", ErrUnknownLogicalName, name)
+	}
+
+	return session, nil
+}
+
+func (s Session) validate() error {
+	if s.Driver == "" {
+		return errors.New("missing driver")
+	}
+
+	if s.Resource == "" {
+		if !s.Options.Simulate {
+			return errors.New("missing resource")
+		}
+
+		return nil
+	}
+
+	_, err := ivi.ParseResource(s.Resource)
+
+	return err
+}
+
+// DriverOptions returns the session's options as driver options.
+func (o Options) DriverOptions() []ivi.DriverOption {
+	var opts []ivi.DriverOption
+
+	if o.Timeout > 0 {
+		opts = append(opts, ivi.WithTimeout(time.Duration(o.Timeout)))
+	}
+
+	if o.Reset {
+		opts = append(opts, ivi.WithReset())
+	}
+
+	if o.IDQuery != nil && !*o.IDQuery {
+		opts = append(opts, ivi.WithoutIDQuery())
+	}
+
+	if o.Standalone {
+		opts = append(opts, ivi.WithStandalone())
+	}
+
+	if o.Cache {
+		opts = append(opts, ivi.WithCache())
+	}
+
+	if o.QueryInstrumentStatus {
+		opts = append(opts, ivi.WithQueryInstrumentStatus())
+	}
+
+	if o.RangeCheck {
+		opts = append(opts, ivi.WithRangeCheck())
+	}
+
+	if o.Simulate {
+		opts = append(opts, ivi.WithSimulate())
+	}
+
+	if o.SimulatedModel != "" {
+		opts = append(opts, ivi.WithSimulatedModel(o.SimulatedModel))
+	}
+
+	return opts
+}
+
+// PhysicalChannel returns the instrument channel name a virtual channel
+// name maps to. A name the session does not map is returned unchanged, so
+// programs may also use the instrument's own channel names.
+func (s Session) PhysicalChannel(name string) string {
+	if physical, ok := s.VirtualChannels[name]; ok {
+		return physical
+	}
+
+	return name
+}
+
+// Instrument is an open session: the driver created for a logical name and
+// the transport it uses.
+type Instrument[T any] struct {
+	// Driver is the driver, as the class interface T.
+	Driver T
+	// Transport is the transport opened for the session, or nil for a
+	// simulated session.
+	Transport ivi.Transport
+	// Session is the configuration the instrument was opened with.
+	Session Session
+}
+
+// Close closes the driver, returning the instrument to local control, and
+// then the transport.
+func (inst *Instrument[T]) Close() error {
+	var errs []error
+
+	if c, ok := any(inst.Driver).(io.Closer); ok {
+		errs = append(errs, c.Close())
+	}
+
+	if c, ok := inst.Transport.(io.Closer); ok {
+		errs = append(errs, c.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// OpenFunc creates the named driver of an instrument class, as the
+// OpenDriver method of a class package's driver registry does.
+type OpenFunc[T any] func(
+	driver string,
+	inst ivi.Transport,
+	opts ...ivi.DriverOption,
+) (T, error)
+
+// Open opens the transport of the session a logical name refers to and
+// creates its driver with open. A simulated session opens no transport. The
+// session's virtual channel names are not applied to the driver; translate
+// them with [Session.PhysicalChannel].
+func Open[T any](
+	ctx context.Context,
+	s *Store,
+	name string,
+	open OpenFunc[T],
+) (*Instrument[T], error) {
+	session, err := s.Session(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var inst ivi.Transport
+
+	if !session.Options.Simulate {
+		inst, err = s.dial(ctx, session)
+		if err != nil {
+			return nil, fmt.Errorf("opening %q: %w", name, err)
+		}
+	}
+
+	driver, err := open(session.Driver, inst, session.Options.DriverOptions()...)
+	if err != nil {
+		if c, ok := inst.(io.Closer); ok {
+			_ = c.Close()
+		}
+
+		return nil, fmt.Errorf("opening %q: %w", name, err)
+	}
+
+	return &Instrument[T]{Driver: driver, Transport: inst, Session: session}, nil
+}
+
+// dial opens a transport to the session's resource with the store's Dialer,
+// or with a socket connection when the store has none. The resource is first
+// completed with the connection defaults registered for the session's driver,
+// which fill in a missing socket port or GPIB address and supply the serial
+// baud rate and data frame.
+func (s *Store) dial(
+	ctx context.Context,
+	session Session,
+) (ivi.Transport, error) {
+	r, err := ivi.ParseResource(session.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults, _ := ivi.LookupConnectionDefaults(session.Driver)
+
+	settings, err := r.Settings(defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Dial != nil {
+		return s.Dial(ctx, settings)
+	}
+
+	conn, err := socket.DialResource(ctx, settings.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return conn, nil
+}
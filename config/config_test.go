@@ -0,0 +1,264 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/dcpwr"
+	"github.com/gotmc/ivi/dcpwr/keysight/e36000"
+	_ "github.com/gotmc/ivi/dcpwr/rigol/dp800"
+	"github.com/gotmc/ivi/internal/ivitest"
+)
+
+const station = `{
+  "logicalNames": {
+    "MainSupply": {
+      "driver": "e36000",
+      "resource": "TCPIP0::10.0.0.5::5025::SOCKET",
+      "options": {"timeout": "5s", "reset": true, "idQuery": false},
+      "virtualChannels": {"Rail5V": "P6V", "Rail12V": "P25V"}
+    },
+    "SpareSupply": {
+      "driver": "dp800",
+      "options": {"simulate": true, "simulatedModel": "DP832"}
+    }
+  }
+}`
+
+// openSupply creates the IviDCPwr driver a session names.
+var openSupply = dcpwr.Drivers.OpenDriver
+
+func TestRead(t *testing.T) {
+	store, err := Read(strings.NewReader(station))
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+
+	main, err := store.Session("MainSupply")
+	if err != nil {
+		t.Fatalf("Session() error: %v", err)
+	}
+	if main.Driver != "e36000" ||
+		main.Resource != "TCPIP0::10.0.0.5::5025::SOCKET" {
+		t.Errorf("Session() = %+v", main)
+	}
+	if got := main.PhysicalChannel("Rail12V"); got != "P25V" {
+		t.Errorf("PhysicalChannel(Rail12V) = %q, want P25V", got)
+	}
+	if got := main.PhysicalChannel("N25V"); got != "N25V" {
+		t.Errorf("PhysicalChannel(N25V) = %q, want N25V", got)
+	}
+
+	cfg := ivi.ApplyOptions(main.Options.DriverOptions())
+	want := ivi.DriverConfig{
+		Timeout:     5 * time.Second,
+		Reset:       true,
+		SkipIDQuery: true,
+	}
+	if cfg.Timeout != want.Timeout || cfg.Reset != want.Reset ||
+		cfg.SkipIDQuery != want.SkipIDQuery || cfg.Simulate {
+		t.Errorf("DriverOptions() applied = %+v, want %+v", cfg, want)
+	}
+
+	if _, err := store.Session("AuxSupply"); !errors.Is(
+		err, ErrUnknownLogicalName,
+	) {
+		t.Errorf("Session(AuxSupply) error = %v, want ErrUnknownLogicalName",
+			err)
+	}
+}
+
+func TestRead_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		store string
+	}{
+		{"not json", `logicalNames: {}`},
+		{"unknown field", `{"logicalNames": {"PS": {
+			"driver": "e36000", "resource": "GPIB0::5", "timout": "5s"}}}`},
+		{"unknown option", `{"logicalNames": {"PS": {
+			"driver": "e36000", "resource": "GPIB0::5",
+			"options": {"rest": true}}}}`},
+		{"missing driver", `{"logicalNames": {"PS": {
+			"resource": "GPIB0::5"}}}`},
+		{"missing resource", `{"logicalNames": {"PS": {
+			"driver": "e36000"}}}`},
+		{"invalid resource", `{"logicalNames": {"PS": {
+			"driver": "e36000", "resource": "GPIB0::99"}}}`},
+		{"invalid timeout", `{"logicalNames": {"PS": {
+			"driver": "e36000", "resource": "GPIB0::5",
+			"options": {"timeout": "5 parsecs"}}}}`},
+		{"numeric timeout", `{"logicalNames": {"PS": {
+			"driver": "e36000", "resource": "GPIB0::5",
+			"options": {"timeout": 5}}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.store))
+			if !errors.Is(err, ErrInvalidStore) {
+				t.Errorf("Read() error = %v, want ErrInvalidStore", err)
+			}
+		})
+	}
+}
+
+func TestLoadAndSave(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "station.json")
+	if err := os.WriteFile(name, []byte(station), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := Load(name)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := store.Save(&buf); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	again, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() of saved store error: %v", err)
+	}
+	got, _ := again.Session("MainSupply")
+	if got.Options.Timeout != Duration(5*time.Second) ||
+		got.PhysicalChannel("Rail5V") != "P6V" {
+		t.Errorf("saved session = %+v", got)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Load() of a missing file returned no error")
+	}
+}
+
+func TestOpen(t *testing.T) {
+	ctx := t.Context()
+	store, err := Read(strings.NewReader(station))
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+
+	sim := ivitest.NewSimulator(ivitest.SimModel{
+		IDN: "Keysight Technologies,E36312A,MY12345678,2.1.0-1.0.4-1.12",
+	})
+	var dialed ivi.Resource
+	store.Dial = func(
+		_ context.Context,
+		settings ivi.ConnectionSettings,
+	) (ivi.Transport, error) {
+		dialed = settings.Resource
+		return sim, nil
+	}
+
+	supply, err := Open(ctx, store, "MainSupply", openSupply)
+	if err != nil {
+		t.Fatalf("Open(MainSupply) error: %v", err)
+	}
+	if dialed.Host != "10.0.0.5" || dialed.Port != 5025 {
+		t.Errorf("dialed %v, want TCPIP0::10.0.0.5::5025::SOCKET", dialed)
+	}
+	d, ok := supply.Driver.(*e36000.Driver)
+	if !ok {
+		t.Fatalf("Open(MainSupply) driver is %T, want *e36000.Driver",
+			supply.Driver)
+	}
+	if d.Timeout() != 5*time.Second {
+		t.Errorf("Timeout() = %v, want 5s", d.Timeout())
+	}
+	if err := supply.Close(); err != nil {
+		t.Errorf("Close() error: %v", err)
+	}
+
+	spare, err := Open(ctx, store, "SpareSupply", openSupply)
+	if err != nil {
+		t.Fatalf("Open(SpareSupply) error: %v", err)
+	}
+	if spare.Transport != nil {
+		t.Errorf("simulated session opened transport %T", spare.Transport)
+	}
+	if got := spare.Driver.OutputChannelCount(); got != 3 {
+		t.Errorf("DP832 OutputChannelCount() = %d, want 3", got)
+	}
+
+	if _, err := Open(ctx, store, "AuxSupply", openSupply); !errors.Is(
+		err, ErrUnknownLogicalName,
+	) {
+		t.Errorf("Open(AuxSupply) error = %v, want ErrUnknownLogicalName", err)
+	}
+}
+
+func TestOpen_ConnectionDefaults(t *testing.T) {
+	store := &Store{LogicalNames: map[string]Session{
+		"GPIB":   {Driver: "e36000", Resource: "GPIB0::INSTR"},
+		"Serial": {Driver: "e36000", Resource: "ASRL1::INSTR"},
+		"LAN":    {Driver: "e36000", Resource: "TCPIP0::10.0.0.5::SOCKET"},
+	}}
+
+	var dialed []ivi.ConnectionSettings
+	store.Dial = func(
+		_ context.Context,
+		settings ivi.ConnectionSettings,
+	) (ivi.Transport, error) {
+		dialed = append(dialed, settings)
+		return ivitest.NewSimulator(ivitest.SimModel{
+			IDN: "Keysight Technologies,E3631A,MY12345678,2.1-5.0-1.0",
+		}), nil
+	}
+
+	for _, name := range []string{"GPIB", "Serial", "LAN"} {
+		_, err := Open(t.Context(), store, name, openSupply)
+		if err != nil {
+			t.Fatalf("Open(%s) error: %v", name, err)
+		}
+	}
+
+	if got := dialed[0].Resource.PrimaryAddress; got != 5 {
+		t.Errorf("GPIB primary address = %d, want the driver's default 5", got)
+	}
+	if got := dialed[1]; got.BaudRate != 9600 || got.DataFrame != "8N2" {
+		t.Errorf("serial settings = %d %s, want the driver's 9600 8N2",
+			got.BaudRate, got.DataFrame)
+	}
+	if got := dialed[2].Resource.Port; got != 5025 {
+		t.Errorf("socket port = %d, want the driver's default 5025", got)
+	}
+}
+
+func TestOpen_UnknownDriver(t *testing.T) {
+	store := &Store{LogicalNames: map[string]Session{
+		"Load": {Driver: "sdl1000x", Options: Options{Simulate: true}},
+	}}
+
+	if _, err := Open(t.Context(), store, "Load", openSupply); !errors.Is(
+		err, ivi.ErrUnknownDriver,
+	) {
+		t.Errorf("Open() error = %v, want ErrUnknownDriver", err)
+	}
+}
+
+func TestOpen_ResourceWithoutDialer(t *testing.T) {
+	store := &Store{LogicalNames: map[string]Session{
+		"PS": {Driver: "e36000", Resource: "GPIB0::5::INSTR"},
+	}}
+
+	if _, err := Open(t.Context(), store, "PS", openSupply); !errors.Is(
+		err, ivi.ErrInvalidResource,
+	) {
+		t.Errorf("Open() error = %v, want ErrInvalidResource", err)
+	}
+}
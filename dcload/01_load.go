@@ -11,3 +11,35 @@ included in the IVI standard.
 Files are split based on the class capability groups.
 */
 package dcload
+
+import (
+	"reflect"
+
+	"github.com/gotmc/ivi"
+)
+
+// Groups lists the capability groups of the electronic load class, with the Go
+// interfaces that implement each, for [ivi.Introspect] and
+// [ivi.CheckGroupCapabilities].
+var Groups = []ivi.CapabilityGroup{
+	{
+		Name:    "DCLoadBase",
+		Driver:  reflect.TypeFor[Base](),
+		Channel: reflect.TypeFor[BaseChannel](),
+	},
+	{Name: "DCLoadLevel", Channel: reflect.TypeFor[LevelChannel]()},
+}
+
+// Drivers holds the DC electronic load drivers, by the instrument models they
+// support. Each driver registers itself from an init function, so importing a
+// driver package, if only for its side effects, makes it available to
+// Drivers.Open, which picks the driver for the connected instrument's model,
+// and to Drivers.OpenDriver, which creates a driver by name. Both return the
+// driver as a Base; assert it to other DC electronic load interfaces, or to the
+// driver's own type, for the capabilities beyond the base group. See
+// [ivi.Registry].
+//
+//	import _ "github.com/gotmc/ivi/dcload/siglent/sdl1000x"
+//
+//	load, err := dcload.Drivers.Open(ctx, inst)
+var Drivers ivi.Registry[Base]
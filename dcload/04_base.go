@@ -5,14 +5,31 @@
 
 package dcload
 
+import "context"
+
 // Base is the interface required of every electronic-load driver; it
-// provides discovery of the load's channel count.
+// provides discovery of the load's channels and access to each by name.
 type Base interface {
 	OutputCount() int
+	OutputName(index int) (string, error)
+	OutputItem(name string) (BaseChannel, error)
 }
 
 // BaseChannel is the per-channel interface required of every electronic-load
 // driver.
 type BaseChannel interface {
-	SetMode(mode string) error
+	SetMode(ctx context.Context, mode string) error
+}
+
+// LevelChannel is the per-channel interface of an electronic-load driver
+// that sets the levels of the constant current and constant power modes and
+// switches the input on and off, which is what a [GuardedChannel] wraps.
+type LevelChannel interface {
+	BaseChannel
+	CurrentLevel(ctx context.Context) (float64, error)
+	SetCurrentLevel(ctx context.Context, amps float64) error
+	PowerLevel(ctx context.Context) (float64, error)
+	SetPowerLevel(ctx context.Context, watts float64) error
+	InputEnabled(ctx context.Context) (bool, error)
+	SetInputEnabled(ctx context.Context, b bool) error
 }
@@ -0,0 +1,117 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package dcload
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gotmc/ivi"
+)
+
+// SafetyLimits are the most one input may draw from a device under test.
+// They belong to the device, so they are set by the user and are independent
+// of the electronic load's ratings. A zero field is not checked.
+type SafetyLimits struct {
+	// MaxCurrent is the largest constant current level in amperes.
+	MaxCurrent float64
+	// MaxPower is the largest constant power level in watts.
+	MaxPower float64
+}
+
+// GuardedChannel is a [LevelChannel] that rejects levels exceeding its
+// [SafetyLimits] before they reach the instrument, returning an error
+// wrapping [ivi.ErrSafetyLimit]. Setting the current or power level, and
+// enabling the input, are checked. Enabling reads both levels back from the
+// instrument, so it also catches levels set before the channel was guarded.
+// The guard does not check the constant voltage or constant resistance
+// modes, whose current depends on the device. All other methods pass through
+// to the wrapped channel.
+type GuardedChannel struct {
+	LevelChannel
+	limits SafetyLimits
+}
+
+// Guard wraps ch so that its levels are checked against limits.
+func Guard(ch LevelChannel, limits SafetyLimits) *GuardedChannel {
+	return &GuardedChannel{LevelChannel: ch, limits: limits}
+}
+
+// Limits returns the safety limits the channel is guarded by.
+func (g *GuardedChannel) Limits() SafetyLimits {
+	return g.limits
+}
+
+// SetCurrentLevel sets the constant current level after checking it against
+// the safety limits.
+func (g *GuardedChannel) SetCurrentLevel(
+	ctx context.Context,
+	amps float64,
+) error {
+	err := ivi.CheckSafetyLimit("current", "A", amps, g.limits.MaxCurrent)
+	if err != nil {
+		return fmt.Errorf("SetCurrentLevel: %w", err)
+	}
+
+	return g.LevelChannel.SetCurrentLevel(ctx, amps)
+}
+
+// SetPowerLevel sets the constant power level after checking it against the
+// safety limits.
+func (g *GuardedChannel) SetPowerLevel(
+	ctx context.Context,
+	watts float64,
+) error {
+	err := ivi.CheckSafetyLimit("power", "W", watts, g.limits.MaxPower)
+	if err != nil {
+		return fmt.Errorf("SetPowerLevel: %w", err)
+	}
+
+	return g.LevelChannel.SetPowerLevel(ctx, watts)
+}
+
+// SetInputEnabled enables or disables the input. Before enabling, it reads
+// the current and power levels back and checks them against the safety
+// limits. Disabling is never checked.
+func (g *GuardedChannel) SetInputEnabled(ctx context.Context, b bool) error {
+	if b {
+		if err := g.checkLevels(ctx); err != nil {
+			return fmt.Errorf("SetInputEnabled: %w", err)
+		}
+	}
+
+	return g.LevelChannel.SetInputEnabled(ctx, b)
+}
+
+// checkLevels reads the limited levels from the instrument and checks them
+// against the safety limits.
+func (g *GuardedChannel) checkLevels(ctx context.Context) error {
+	if g.limits.MaxCurrent != 0 {
+		amps, err := g.CurrentLevel(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = ivi.CheckSafetyLimit("current", "A", amps, g.limits.MaxCurrent)
+		if err != nil {
+			return err
+		}
+	}
+
+	if g.limits.MaxPower != 0 {
+		watts, err := g.PowerLevel(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = ivi.CheckSafetyLimit("power", "W", watts, g.limits.MaxPower)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package dcload_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/dcload"
+	"github.com/gotmc/ivi/dcload/siglent/sdl1000x"
+)
+
+// simulatedInput returns the input of a simulated SDL1030X.
+func simulatedInput(t *testing.T) *sdl1000x.Channel {
+	t.Helper()
+
+	d, err := sdl1000x.New(nil, ivi.WithSimulatedModel("SDL1030X"))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	ch, err := d.Channel(0)
+	if err != nil {
+		t.Fatalf("Channel(0) error: %v", err)
+	}
+
+	return ch
+}
+
+func TestGuardedChannel(t *testing.T) {
+	ctx := t.Context()
+	ch := simulatedInput(t)
+
+	// Set the power around the guard, as a program might have done before
+	// guarding the channel.
+	if err := ch.SetPowerLevel(ctx, 50); err != nil {
+		t.Fatalf("SetPowerLevel(50) error: %v", err)
+	}
+
+	g := dcload.Guard(ch, dcload.SafetyLimits{MaxCurrent: 2, MaxPower: 10})
+
+	if err := g.SetCurrentLevel(ctx, 1.5); err != nil {
+		t.Fatalf("SetCurrentLevel(1.5) error: %v", err)
+	}
+	if err := g.SetCurrentLevel(ctx, 2.5); !errors.Is(err, ivi.ErrSafetyLimit) {
+		t.Errorf("SetCurrentLevel(2.5) error = %v, want ErrSafetyLimit", err)
+	}
+	if got, _ := ch.CurrentLevel(ctx); got != 1.5 {
+		t.Errorf("CurrentLevel() = %v, want 1.5", got)
+	}
+
+	err := g.SetInputEnabled(ctx, true)
+	if !errors.Is(err, ivi.ErrSafetyLimit) {
+		t.Errorf("SetInputEnabled(true) error = %v, want ErrSafetyLimit", err)
+	}
+	if on, _ := ch.InputEnabled(ctx); on {
+		t.Error("input enabled despite exceeding the safety limit")
+	}
+
+	if err := g.SetPowerLevel(ctx, 8); err != nil {
+		t.Fatalf("SetPowerLevel(8) error: %v", err)
+	}
+	if err := g.SetInputEnabled(ctx, true); err != nil {
+		t.Fatalf("SetInputEnabled(true) error: %v", err)
+	}
+	if on, _ := ch.InputEnabled(ctx); !on {
+		t.Error("InputEnabled() = false, want true")
+	}
+}
+
+func TestGuardedChannel_InstrumentRange(t *testing.T) {
+	// The guard's limit is above the SDL1020X's 200 W rating, so the
+	// simulated instrument rejects the level.
+	d, err := sdl1000x.New(nil, ivi.WithSimulatedModel("SDL1020X"))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	ch, _ := d.Channel(0)
+	g := dcload.Guard(ch, dcload.SafetyLimits{MaxPower: 500})
+
+	err = g.SetPowerLevel(t.Context(), 250)
+	if !errors.Is(err, ivi.ErrValueNotSupported) {
+		t.Errorf("SetPowerLevel(250) error = %v, want ErrValueNotSupported",
+			err)
+	}
+}
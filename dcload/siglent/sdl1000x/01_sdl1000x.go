@@ -7,9 +7,13 @@
 // SDL1020X, SDL1030X-E, and SDL1030X DC electronic loads.
 //
 // State Caching: Not implemented
+//
+// Capability Groups: DCLoadBase and DCLoadLevel, so its input can be wrapped
+// with [dcload.Guard].
 package sdl1000x
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -23,6 +27,16 @@ const (
 	specRevision     = "N/A"
 )
 
+// Register the driver with [dcload.Drivers] so that Open can select it by
+// model, and its connection defaults so that a config session can complete its
+// resource.
+func init() {
+	dcload.Drivers.Register(
+		"sdl1000x", supportedModels(), ivi.Factory[dcload.Base](New),
+	)
+	ivi.RegisterConnectionDefaults("sdl1000x", ConnectionDefaults())
+}
+
 // Driver provides the IVI driver for the Siglent SDL1000X and SDL1030X DC
 // Electronic Loads.
 type Driver struct {
@@ -38,16 +52,15 @@ type Driver struct {
 // override the default I/O timeout.
 func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	s, err := ivi.NewDriverSetup(inst, ivi.InherentBase{
-		ClassSpecMajorVersion: specMajorVersion,
-		ClassSpecMinorVersion: specMinorVersion,
-		ClassSpecRevision:     specRevision,
-		ResetDelay:            500 * time.Millisecond,
-		ClearDelay:            500 * time.Millisecond,
-		ReturnToLocal:         true,
-		GroupCapabilities:     []string{},
-		SupportedInstrumentModels: []string{
-			"SDL1020X-E", "SDL1020X",
-			"SDL1030X-E", "SDL1030X"},
+		ClassSpecMajorVersion:     specMajorVersion,
+		ClassSpecMinorVersion:     specMinorVersion,
+		ClassSpecRevision:         specRevision,
+		ResetDelay:                500 * time.Millisecond,
+		ClearDelay:                500 * time.Millisecond,
+		ReturnToLocal:             true,
+		GroupCapabilities:         []string{"DCLoadBase", "DCLoadLevel"},
+		SupportedInstrumentModels: supportedModels(),
+		Simulation:                &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
@@ -59,17 +72,17 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	channelNames := []string{"Input"}
 	channels := make([]Channel, len(channelNames))
 	for i, ch := range channelNames {
-		channels[i] = Channel{dcload.NewChannel(i, ch, inst)}
+		channels[i] = Channel{dcload.NewChannel(i, ch, s.Transport)}
 	}
 
 	driver := Driver{
-		inst:     inst,
+		inst:     s.Transport,
 		channels: channels,
 		Inherent: s.Inherent,
 	}
 
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
+		if err := driver.Reset(context.Background()); err != nil {
 			return nil, err
 		}
 	}
@@ -77,6 +90,14 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	return &driver, nil
 }
 
+// supportedModels returns the model numbers the driver supports.
+func supportedModels() []string {
+	return []string{
+		"SDL1020X-E", "SDL1020X",
+		"SDL1030X-E", "SDL1030X",
+	}
+}
+
 // Channel returns the Channel at the given index, with bounds checking.
 func (d *Driver) Channel(index int) (*Channel, error) {
 	if index < 0 || index >= len(d.channels) {
@@ -126,3 +147,15 @@ func SerialDataFrames() []string {
 func DefaultSerialDataFrame() string {
 	return "8N2"
 }
+
+// ConnectionDefaults returns the model's connection defaults, which
+// complete a VISA resource string with [ivi.Resource.Settings].
+func ConnectionDefaults() ivi.ConnectionDefaults {
+	return ivi.ConnectionDefaults{
+		Interfaces:      AvailableCOMPorts(),
+		GPIBAddress:     DefaultGPIBAddress(),
+		SerialBaudRates: SerialBaudRates(),
+		SerialBaudRate:  DefaultSerialBaudRate(),
+		SerialDataFrame: DefaultSerialDataFrame(),
+	}
+}
@@ -6,6 +6,11 @@
 package sdl1000x
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gotmc/ivi"
 	"github.com/gotmc/ivi/dcload"
 )
 
@@ -20,3 +25,25 @@ const (
 type Channel struct {
 	dcload.Channel
 }
+
+// modeFunctions maps the modes accepted by SetMode to the SDL1000X SCPI
+// function names.
+var modeFunctions = map[string]string{
+	"CURRENT":    "CURR",
+	"VOLTAGE":    "VOLT",
+	"POWER":      "POW",
+	"RESISTANCE": "RES",
+	"LED":        "LED",
+}
+
+// SetMode selects the load's static operating mode: "current", "voltage",
+// "power", "resistance", or "led", in any case, for constant current,
+// voltage, power, or resistance, or LED emulation.
+func (ch *Channel) SetMode(ctx context.Context, mode string) error {
+	fcn, ok := modeFunctions[strings.ToUpper(mode)]
+	if !ok {
+		return fmt.Errorf("SetMode %q: %w", mode, ivi.ErrValueNotSupported)
+	}
+
+	return ch.Set(ctx, ":SOUR:FUNC %s", fcn)
+}
@@ -5,6 +5,80 @@
 
 package sdl1000x
 
-// Make sure the IviDCPwrBase capability group has been implemented.
-// var _ load.Base = (*Driver)(nil)
-// var _ load.BaseChannel = (*Channel)(nil)
+import (
+	"context"
+	"fmt"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/dcload"
+)
+
+// Make sure the DCLoadBase capability group has been implemented, along with
+// the levels a [dcload.GuardedChannel] checks.
+var (
+	_ dcload.Base         = (*Driver)(nil)
+	_ dcload.BaseChannel  = (*Channel)(nil)
+	_ dcload.LevelChannel = (*Channel)(nil)
+)
+
+// OutputCount returns the number of input channels of the load.
+func (d *Driver) OutputCount() int {
+	return len(d.channels)
+}
+
+// OutputName returns the name of the channel at index, which runs from 0 to
+// OutputCount()-1.
+func (d *Driver) OutputName(index int) (string, error) {
+	ch, err := d.Channel(index)
+	if err != nil {
+		return "", fmt.Errorf("OutputName: %w", err)
+	}
+
+	return ch.Name(), nil
+}
+
+// OutputItem returns the channel with the given name, as returned by
+// OutputName.
+func (d *Driver) OutputItem(name string) (dcload.BaseChannel, error) {
+	for i := range d.channels {
+		if d.channels[i].Name() == name {
+			return &d.channels[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("OutputItem: %q: %w", name, ivi.ErrChannelNotFound)
+}
+
+// CurrentLevel queries the current the load draws in constant current mode.
+func (ch *Channel) CurrentLevel(ctx context.Context) (float64, error) {
+	return ch.QueryFloat64(ctx, ":SOUR:CURR?")
+}
+
+// SetCurrentLevel sets the current the load draws in constant current mode.
+func (ch *Channel) SetCurrentLevel(ctx context.Context, amps float64) error {
+	return ch.Set(ctx, ":SOUR:CURR %f", amps)
+}
+
+// PowerLevel queries the power the load draws in constant power mode.
+func (ch *Channel) PowerLevel(ctx context.Context) (float64, error) {
+	return ch.QueryFloat64(ctx, ":SOUR:POW?")
+}
+
+// SetPowerLevel sets the power the load draws in constant power mode.
+func (ch *Channel) SetPowerLevel(ctx context.Context, watts float64) error {
+	return ch.Set(ctx, ":SOUR:POW %f", watts)
+}
+
+// InputEnabled queries whether the load's input is on.
+func (ch *Channel) InputEnabled(ctx context.Context) (bool, error) {
+	return ch.QueryBool(ctx, ":SOUR:INP:STAT?")
+}
+
+// SetInputEnabled switches the load's input on or off.
+func (ch *Channel) SetInputEnabled(ctx context.Context, b bool) error {
+	if b {
+		return ch.Set(ctx, ":SOUR:INP:STAT ON")
+	}
+
+	return ch.Set(ctx, ":SOUR:INP:STAT OFF")
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package sdl1000x
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gotmc/ivi/internal/ivitest"
+)
+
+func TestChannel_Levels(t *testing.T) {
+	ctx := t.Context()
+	mock := &ivitest.Mock{
+		QueryResp: "Siglent Technologies,SDL1030X,SDL13GCC1R0001,1.1.1.21",
+	}
+	d, err := New(mock)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	ch, _ := d.Channel(0)
+	mock.CommandsSent = nil
+
+	if err := ch.SetCurrentLevel(ctx, 1.5); err != nil {
+		t.Fatalf("SetCurrentLevel() error: %v", err)
+	}
+	if err := ch.SetPowerLevel(ctx, 20); err != nil {
+		t.Fatalf("SetPowerLevel() error: %v", err)
+	}
+	if err := ch.SetInputEnabled(ctx, true); err != nil {
+		t.Fatalf("SetInputEnabled(true) error: %v", err)
+	}
+	if err := ch.SetInputEnabled(ctx, false); err != nil {
+		t.Fatalf("SetInputEnabled(false) error: %v", err)
+	}
+
+	want := []string{
+		":SOUR:CURR 1.500000",
+		":SOUR:POW 20.000000",
+		":SOUR:INP:STAT ON",
+		":SOUR:INP:STAT OFF",
+	}
+	if !slices.Equal(mock.CommandsSent, want) {
+		t.Errorf("sent %q, want %q", mock.CommandsSent, want)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package sdl1000x
+
+import "github.com/gotmc/ivi"
+
+// simulation describes the instrument simulated under [ivi.WithSimulate],
+// which starts as after *RST with the input off. Measurements read 0 V and
+// 0 A. The level bounds are the rated 30 A of every model and the rated
+// power of each.
+var simulation = ivi.Simulation{
+	Manufacturer: "Siglent Technologies",
+	Attributes: map[string]ivi.SimulatedAttribute{
+		"SOUR:CURR":     {Min: 0, Max: 30},
+		"SOUR:POW":      {Min: 0, Max: 300},
+		"SOUR:INP:STAT": {Default: "0"},
+	},
+	ModelAttributes: map[string]map[string]ivi.SimulatedAttribute{
+		"SDL1020X-E": {"SOUR:POW": {Min: 0, Max: 200}},
+		"SDL1020X":   {"SOUR:POW": {Min: 0, Max: 200}},
+	},
+}
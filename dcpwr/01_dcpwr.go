@@ -11,7 +11,36 @@
 // IviDCPwr Group Names in the IVI-4.4 IviDCPwr Class Specification.
 package dcpwr
 
-import "errors"
+import (
+	"errors"
+	"reflect"
+
+	"github.com/gotmc/ivi"
+)
+
+// Groups lists the capability groups of the IviDCPwr class, with the Go
+// interfaces that implement each, for [ivi.Introspect] and
+// [ivi.CheckGroupCapabilities].
+var Groups = []ivi.CapabilityGroup{
+	{
+		Name:    "IviDCPwrBase",
+		Driver:  reflect.TypeFor[Base](),
+		Channel: reflect.TypeFor[BaseChannel](),
+	},
+	{
+		Name:    "IviDCPwrTrigger",
+		Driver:  reflect.TypeFor[Trigger](),
+		Channel: reflect.TypeFor[TriggerChannel](),
+	},
+	{
+		Name:   "IviDCPwrSoftwareTrigger",
+		Driver: reflect.TypeFor[SoftwareTrigger](),
+	},
+	{
+		Name:    "IviDCPwrMeasurement",
+		Channel: reflect.TypeFor[MeasurementChannel](),
+	},
+}
 
 // Error codes related to the IviDCPwr Class Specification.
 var (
@@ -42,3 +71,16 @@ var commTypes = map[CommType]string{
 func (ct CommType) String() string {
 	return commTypes[ct]
 }
+
+// Drivers holds the IviDCPwr drivers, by the instrument models they support.
+// Each driver registers itself from an init function, so importing a driver
+// package, if only for its side effects, makes it available to Drivers.Open,
+// which picks the driver for the connected instrument's model, and to
+// Drivers.OpenDriver, which creates a driver by name. Both return the driver as
+// a Base; assert it to other IviDCPwr interfaces, or to the driver's own type,
+// for the capabilities beyond the base group. See [ivi.Registry].
+//
+//	import _ "github.com/gotmc/ivi/dcpwr/keysight/e36000"
+//
+//	supply, err := dcpwr.Drivers.Open(ctx, inst)
+var Drivers ivi.Registry[Base]
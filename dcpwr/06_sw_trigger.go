@@ -5,6 +5,8 @@
 
 package dcpwr
 
+import "context"
+
 /*
 
 # Section 6 IviDCPwrSoftwareTrigger Extension Group
@@ -26,5 +28,5 @@ Below are the .NET functions, since they are the basis for the Go interfaces.
 // SoftwareTrigger provides the interface required for the
 // IviDCPwrSoftwareTrigger extension group.
 type SoftwareTrigger interface {
-	SendSoftwareTrigger() error
+	SendSoftwareTrigger(ctx context.Context) error
 }
@@ -5,6 +5,8 @@
 
 package dcpwr
 
+import "context"
+
 /*
 
 # Section 7 IviDCPwrMeasurement Extension Group
@@ -26,7 +28,7 @@ Below are the .NET functions, since they are the basis for the Go interfaces.
 // MeasurementChannel provides the interface for the channel repeated
 // capability for the IviDCPwrMeasurement capability group.
 type MeasurementChannel interface {
-	Measure(msrType MeasurementType) (float64, error)
-	MeasureVoltage() (float64, error)
-	MeasureCurrent() (float64, error)
+	Measure(ctx context.Context, msrType MeasurementType) (float64, error)
+	MeasureVoltage(ctx context.Context) (float64, error)
+	MeasureCurrent(ctx context.Context) (float64, error)
 }
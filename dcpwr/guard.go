@@ -0,0 +1,162 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package dcpwr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gotmc/ivi"
+)
+
+// SafetyLimits are the most one output may apply to a device under test. They
+// belong to the device, so they are set by the user and are independent of
+// the power supply's ratings. A zero field is not checked.
+type SafetyLimits struct {
+	// MaxVoltage is the largest voltage level magnitude in volts.
+	MaxVoltage float64
+	// MaxCurrent is the largest current limit in amperes.
+	MaxCurrent float64
+	// MaxPower is the largest product of the voltage level and the current
+	// limit in watts.
+	MaxPower float64
+}
+
+// GuardedChannel is a [BaseChannel] that rejects settings exceeding its
+// [SafetyLimits] before they reach the instrument, returning an error
+// wrapping [ivi.ErrSafetyLimit]. Setting the voltage level or the current
+// limit, and enabling the output, are checked. A check that needs the
+// setting not being changed, such as the power check when setting the
+// voltage level, reads it back from the instrument, so enabling the output
+// also catches settings made before the channel was guarded. All other
+// methods pass through to the wrapped channel.
+type GuardedChannel struct {
+	BaseChannel
+	limits SafetyLimits
+}
+
+// Guard wraps ch so that its settings are checked against limits.
+func Guard(ch BaseChannel, limits SafetyLimits) *GuardedChannel {
+	return &GuardedChannel{BaseChannel: ch, limits: limits}
+}
+
+// Limits returns the safety limits the channel is guarded by.
+func (g *GuardedChannel) Limits() SafetyLimits {
+	return g.limits
+}
+
+// SetVoltageLevel sets the voltage level after checking it, and its product
+// with the current limit, against the safety limits.
+func (g *GuardedChannel) SetVoltageLevel(
+	ctx context.Context,
+	level float64,
+) error {
+	if err := g.check(ctx, &level, nil); err != nil {
+		return fmt.Errorf("SetVoltageLevel: %w", err)
+	}
+
+	return g.BaseChannel.SetVoltageLevel(ctx, level)
+}
+
+// SetCurrentLimit sets the current limit after checking it, and its product
+// with the voltage level, against the safety limits.
+func (g *GuardedChannel) SetCurrentLimit(
+	ctx context.Context,
+	limit float64,
+) error {
+	if err := g.check(ctx, nil, &limit); err != nil {
+		return fmt.Errorf("SetCurrentLimit: %w", err)
+	}
+
+	return g.BaseChannel.SetCurrentLimit(ctx, limit)
+}
+
+// ConfigureCurrentLimit configures the current limit after checking it, and
+// its product with the voltage level, against the safety limits.
+func (g *GuardedChannel) ConfigureCurrentLimit(
+	ctx context.Context,
+	behavior CurrentLimitBehavior,
+	limit float64,
+) error {
+	if err := g.check(ctx, nil, &limit); err != nil {
+		return fmt.Errorf("ConfigureCurrentLimit: %w", err)
+	}
+
+	return g.BaseChannel.ConfigureCurrentLimit(ctx, behavior, limit)
+}
+
+// SetOutputEnabled enables or disables the output. Before enabling, it reads
+// the voltage level and current limit back and checks them against the
+// safety limits. Disabling is never checked.
+func (g *GuardedChannel) SetOutputEnabled(ctx context.Context, b bool) error {
+	if b {
+		if err := g.check(ctx, nil, nil); err != nil {
+			return fmt.Errorf("SetOutputEnabled: %w", err)
+		}
+	}
+
+	return g.BaseChannel.SetOutputEnabled(ctx, b)
+}
+
+// EnableOutput enables the output after reading the voltage level and
+// current limit back and checking them against the safety limits.
+func (g *GuardedChannel) EnableOutput(ctx context.Context) error {
+	if err := g.check(ctx, nil, nil); err != nil {
+		return fmt.Errorf("EnableOutput: %w", err)
+	}
+
+	return g.BaseChannel.EnableOutput(ctx)
+}
+
+// check checks a voltage level and current limit against the safety limits.
+// A nil setting is one not being changed; it is read from the instrument
+// when the power check needs it, or when enabling, which passes neither.
+func (g *GuardedChannel) check(
+	ctx context.Context,
+	voltage, current *float64,
+) error {
+	enabling := voltage == nil && current == nil
+
+	if voltage == nil &&
+		(g.limits.MaxPower != 0 || enabling && g.limits.MaxVoltage != 0) {
+		v, err := g.VoltageLevel(ctx)
+		if err != nil {
+			return err
+		}
+		voltage = &v
+	}
+
+	if current == nil &&
+		(g.limits.MaxPower != 0 || enabling && g.limits.MaxCurrent != 0) {
+		c, err := g.CurrentLimit(ctx)
+		if err != nil {
+			return err
+		}
+		current = &c
+	}
+
+	if voltage != nil {
+		err := ivi.CheckSafetyLimit("voltage", "V", *voltage, g.limits.MaxVoltage)
+		if err != nil {
+			return err
+		}
+	}
+
+	if current != nil {
+		err := ivi.CheckSafetyLimit("current", "A", *current, g.limits.MaxCurrent)
+		if err != nil {
+			return err
+		}
+	}
+
+	if voltage != nil && current != nil {
+		power := *voltage * *current
+
+		return ivi.CheckSafetyLimit("power", "W", power, g.limits.MaxPower)
+	}
+
+	return nil
+}
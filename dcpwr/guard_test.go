@@ -0,0 +1,96 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package dcpwr_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/dcpwr"
+	"github.com/gotmc/ivi/dcpwr/keysight/e36000"
+)
+
+// guardedP25V returns the guarded P25V output of a simulated E3631A.
+func guardedP25V(
+	t *testing.T,
+	limits dcpwr.SafetyLimits,
+) *dcpwr.GuardedChannel {
+	t.Helper()
+
+	d, err := e36000.New(nil, ivi.WithSimulate())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	ch, err := d.Channel(1)
+	if err != nil {
+		t.Fatalf("Channel(1) error: %v", err)
+	}
+
+	return dcpwr.Guard(ch, limits)
+}
+
+func TestGuardedChannel(t *testing.T) {
+	ctx := t.Context()
+	g := guardedP25V(t, dcpwr.SafetyLimits{
+		MaxVoltage: 15, MaxCurrent: 0.5, MaxPower: 4,
+	})
+
+	if err := g.SetCurrentLimit(ctx, 0.3); err != nil {
+		t.Fatalf("SetCurrentLimit(0.3) error: %v", err)
+	}
+	if err := g.SetVoltageLevel(ctx, 12); err != nil {
+		t.Fatalf("SetVoltageLevel(12) error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		set  func() error
+	}{
+		{"voltage", func() error { return g.SetVoltageLevel(ctx, 16) }},
+		{"current", func() error { return g.SetCurrentLimit(ctx, 0.6) }},
+		{"power from voltage", func() error { return g.SetVoltageLevel(ctx, 15) }},
+		{"power from current", func() error {
+			return g.ConfigureCurrentLimit(ctx, dcpwr.CurrentRegulate, 0.45)
+		}},
+	}
+
+	for _, tt := range tests {
+		if err := tt.set(); !errors.Is(err, ivi.ErrSafetyLimit) {
+			t.Errorf("%s: error = %v, want ErrSafetyLimit", tt.name, err)
+		}
+	}
+
+	if got, _ := g.VoltageLevel(ctx); got != 12 {
+		t.Errorf("VoltageLevel() = %v after rejected settings, want 12", got)
+	}
+	if err := g.EnableOutput(ctx); err != nil {
+		t.Errorf("EnableOutput() error: %v", err)
+	}
+}
+
+func TestGuardedChannel_EnableChecksPriorSettings(t *testing.T) {
+	ctx := t.Context()
+	g := guardedP25V(t, dcpwr.SafetyLimits{MaxVoltage: 5})
+
+	// Set the voltage around the guard, as a program might have done before
+	// guarding the channel.
+	if err := g.BaseChannel.SetVoltageLevel(ctx, 12); err != nil {
+		t.Fatalf("SetVoltageLevel(12) error: %v", err)
+	}
+
+	if err := g.SetOutputEnabled(ctx, true); !errors.Is(
+		err, ivi.ErrSafetyLimit,
+	) {
+		t.Errorf("SetOutputEnabled(true) error = %v, want ErrSafetyLimit", err)
+	}
+	if on, _ := g.OutputEnabled(ctx); on {
+		t.Error("output enabled despite exceeding the safety limit")
+	}
+	if err := g.SetOutputEnabled(ctx, false); err != nil {
+		t.Errorf("SetOutputEnabled(false) error: %v", err)
+	}
+}
@@ -15,6 +15,11 @@
 // way and is recorded per model in [supportedSupplies].
 //
 // State Caching: Not implemented
+//
+// Range Checking: Voltage level and current limit, when the driver is created
+// with [ivi.WithRangeCheck]. Only the models whose output ratings are recorded
+// in [supportedSupplies] support it; for any other model New returns an error
+// wrapping [ivi.ErrFunctionNotSupported].
 package e36000
 
 import (
@@ -44,6 +49,16 @@ var (
 	_ dcpwr.SoftwareTrigger    = (*Driver)(nil)
 )
 
+// Register the driver with [dcpwr.Drivers] so that Open can select it by model,
+// and its connection defaults so that a config session can complete its
+// resource.
+func init() {
+	dcpwr.Drivers.Register(
+		"e36000", supportedModels(), ivi.Factory[dcpwr.Base](New),
+	)
+	ivi.RegisterConnectionDefaults("e36000", ConnectionDefaults())
+}
+
 // Driver provides the IVI driver for the Agilent/Keysight E3600 series of DC
 // power supplies.
 type Driver struct {
@@ -63,6 +78,7 @@ type Channel struct {
 	family     scpiFamily
 	protection protectionSupport
 	timeout    time.Duration
+	limits     *outputRating // nil unless range checking the output
 }
 
 // New creates a new IVI driver for the Keysight/Agilent E3600 series of DC
@@ -77,6 +93,7 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		ResetDelay:            700 * time.Millisecond,
 		ClearDelay:            700 * time.Millisecond,
 		ReturnToLocal:         true,
+		SupportsOPC:           true,
 		GroupCapabilities: []string{
 			"IviDCPwrBase",
 			"IviDCPwrMeasurement",
@@ -85,13 +102,14 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		},
 		SupportedInstrumentModels: supportedModels(),
 		SupportedBusInterfaces:    []string{"TCPIP", "USB", "GPIB", "SERIAL"},
+		Simulation:                &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	// Channel configuration and command spelling depend on the model.
-	model, err := s.Inherent.InstrumentModel()
+	model, err := s.Inherent.InstrumentModel(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("error determining instrument model: %w", err)
 	}
@@ -101,21 +119,29 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		return nil, err
 	}
 
+	if s.Config.RangeCheck && len(supply.ratings) == 0 {
+		return nil, ivi.RangeLimitsNotRecorded(model)
+	}
+
 	channels := make([]Channel, len(supply.channels))
 
 	for i, name := range supply.channels {
 		channels[i] = Channel{
 			name:       name,
-			inst:       inst,
+			inst:       s.Transport,
 			num:        i,
 			family:     supply.family,
 			protection: supply.protection,
 			timeout:    s.Timeout,
 		}
+
+		if s.Config.RangeCheck {
+			channels[i].limits = &supply.ratings[i]
+		}
 	}
 
 	driver := Driver{
-		inst:     inst,
+		inst:     s.Transport,
 		supply:   supply,
 		channels: channels,
 		timeout:  s.Timeout,
@@ -123,7 +149,7 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	}
 
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
+		if err := driver.Reset(context.Background()); err != nil {
 			return nil, err
 		}
 	}
@@ -202,8 +228,52 @@ type powerSupply struct {
 	// protection records the output protection subsystems the model
 	// implements.
 	protection protectionSupport
+	// ratings holds the programming range of each output, in channel order,
+	// which [ivi.WithRangeCheck] checks settings against. It is nil for
+	// models whose ranges have not been recorded, which New refuses to
+	// create with range checking.
+	ratings []outputRating
 }
 
+// outputRating is the programming range of one output.
+type outputRating struct {
+	voltage ivi.Range
+	current ivi.Range
+}
+
+// Output ratings shared by the entries in supportedSupplies. Each range is
+// the programming range from the model's data sheet, which extends 3% past
+// the rated output (2% for the E36100 series). A model with two output
+// ranges is recorded with the voltage of its high range and the current of
+// its low range, the widest values it accepts in either range.
+var (
+	p6V5A  = outputRating{ivi.Range{Max: 6.18}, ivi.Range{Max: 5.15}}
+	p6V10A = outputRating{ivi.Range{Max: 6.18}, ivi.Range{Max: 10.3}}
+	p25V1A = outputRating{ivi.Range{Max: 25.75}, ivi.Range{Max: 1.03}}
+	p25V2A = outputRating{ivi.Range{Max: 25.75}, ivi.Range{Max: 2.06}}
+	n25V1A = outputRating{ivi.Range{Min: -25.75}, ivi.Range{Max: 1.03}}
+	p30V1A = outputRating{ivi.Range{Max: 30.9}, ivi.Range{Max: 1.03}}
+
+	// E36100 series, 6 V/5 A through 100 V/0.4 A. p6V5A2 is the E36102 output
+	// with its 2% margin.
+	p6V5A2   = outputRating{ivi.Range{Max: 6.12}, ivi.Range{Max: 5.1}}
+	p20V2A   = outputRating{ivi.Range{Max: 20.4}, ivi.Range{Max: 2.04}}
+	p35V1A   = outputRating{ivi.Range{Max: 35.7}, ivi.Range{Max: 1.02}}
+	p60V06A  = outputRating{ivi.Range{Max: 61.2}, ivi.Range{Max: 0.612}}
+	p100V04A = outputRating{ivi.Range{Max: 102}, ivi.Range{Max: 0.408}}
+
+	// Two-range models, named by the widest voltage and current they accept.
+	p30V7A  = outputRating{ivi.Range{Max: 30.9}, ivi.Range{Max: 7.21}}
+	p20V20A = outputRating{ivi.Range{Max: 20.6}, ivi.Range{Max: 20.6}}
+	p50V7A  = outputRating{ivi.Range{Max: 51.5}, ivi.Range{Max: 7.21}}
+	p20V3A  = outputRating{ivi.Range{Max: 20.6}, ivi.Range{Max: 3.09}}
+	p20V5A  = outputRating{ivi.Range{Max: 20.6}, ivi.Range{Max: 5.15}}
+	p20V8A  = outputRating{ivi.Range{Max: 20.6}, ivi.Range{Max: 8.24}}
+	p60V08A = outputRating{ivi.Range{Max: 61.8}, ivi.Range{Max: 0.824}}
+	p60V14A = outputRating{ivi.Range{Max: 61.8}, ivi.Range{Max: 1.442}}
+	p60V22A = outputRating{ivi.Range{Max: 61.8}, ivi.Range{Max: 2.266}}
+)
+
 // Channel name sets shared by the entries in supportedSupplies. The slices are
 // read-only; New copies each name into the Channel it creates.
 // For the instSelect family these names are spliced into "INST <name>; ", so
@@ -235,7 +305,11 @@ var e36100Protection = protectionSupport{
 var supportedSupplies = []powerSupply{
 	// The E3631A selects its outputs with INSTrument[:SELect] P6V | P25V |
 	// N25V and has no protection subsystem of its own.
-	{model: "E3631A", channels: []string{"P6V", "P25V", "N25V"}},
+	{
+		model:    "E3631A",
+		channels: []string{"P6V", "P25V", "N25V"},
+		ratings:  []outputRating{p6V5A, p25V1A, n25V1A},
+	},
 
 	// E36100B series. Verified against the Keysight E36100B Series Operating
 	// and Service Guide: the command tree has no INSTrument subsystem, so
@@ -245,16 +319,46 @@ var supportedSupplies = []powerSupply{
 	// single-output topology, so they are given the same command set; their
 	// protection capability has not been verified against a programming
 	// guide and is left unclaimed.
-	{model: "E36102A", channels: oneOutput, family: singleOutput},
-	{model: "E36103A", channels: oneOutput, family: singleOutput},
-	{model: "E36104A", channels: oneOutput, family: singleOutput},
-	{model: "E36105A", channels: oneOutput, family: singleOutput},
-	{model: "E36106A", channels: oneOutput, family: singleOutput},
-	{model: "E36102B", channels: oneOutput, family: singleOutput, protection: e36100Protection},
-	{model: "E36103B", channels: oneOutput, family: singleOutput, protection: e36100Protection},
-	{model: "E36104B", channels: oneOutput, family: singleOutput, protection: e36100Protection},
-	{model: "E36105B", channels: oneOutput, family: singleOutput, protection: e36100Protection},
-	{model: "E36106B", channels: oneOutput, family: singleOutput, protection: e36100Protection},
+	{
+		model: "E36102A", channels: oneOutput, family: singleOutput,
+		ratings: []outputRating{p6V5A2},
+	},
+	{
+		model: "E36103A", channels: oneOutput, family: singleOutput,
+		ratings: []outputRating{p20V2A},
+	},
+	{
+		model: "E36104A", channels: oneOutput, family: singleOutput,
+		ratings: []outputRating{p35V1A},
+	},
+	{
+		model: "E36105A", channels: oneOutput, family: singleOutput,
+		ratings: []outputRating{p60V06A},
+	},
+	{
+		model: "E36106A", channels: oneOutput, family: singleOutput,
+		ratings: []outputRating{p100V04A},
+	},
+	{
+		model: "E36102B", channels: oneOutput, family: singleOutput,
+		protection: e36100Protection, ratings: []outputRating{p6V5A2},
+	},
+	{
+		model: "E36103B", channels: oneOutput, family: singleOutput,
+		protection: e36100Protection, ratings: []outputRating{p20V2A},
+	},
+	{
+		model: "E36104B", channels: oneOutput, family: singleOutput,
+		protection: e36100Protection, ratings: []outputRating{p35V1A},
+	},
+	{
+		model: "E36105B", channels: oneOutput, family: singleOutput,
+		protection: e36100Protection, ratings: []outputRating{p60V06A},
+	},
+	{
+		model: "E36106B", channels: oneOutput, family: singleOutput,
+		protection: e36100Protection, ratings: []outputRating{p100V04A},
+	},
 
 	// The models below are still on the instSelect command set they have
 	// always used. The single-output models among them almost certainly
@@ -263,21 +367,36 @@ var supportedSupplies = []powerSupply{
 	// confirm, so it waits on a programming guide. Their "Output" name is a
 	// legal SCPI token, so it parses; it simply names an output that the
 	// INSTrument subsystem, where one exists at all, does not know.
-	{model: "E3632A", channels: oneOutput},
-	{model: "E3633A", channels: oneOutput},
-	{model: "E3634A", channels: oneOutput},
-	{model: "E3640A", channels: oneOutput},
-	{model: "E3641A", channels: oneOutput},
-	{model: "E3642A", channels: oneOutput},
-	{model: "E3643A", channels: oneOutput},
-	{model: "E3644A", channels: oneOutput},
-	{model: "E3645A", channels: oneOutput},
+	{model: "E3632A", channels: oneOutput, ratings: []outputRating{p30V7A}},
+	{model: "E3633A", channels: oneOutput, ratings: []outputRating{p20V20A}},
+	{model: "E3634A", channels: oneOutput, ratings: []outputRating{p50V7A}},
+	{model: "E3640A", channels: oneOutput, ratings: []outputRating{p20V3A}},
+	{model: "E3641A", channels: oneOutput, ratings: []outputRating{p60V08A}},
+	{model: "E3642A", channels: oneOutput, ratings: []outputRating{p20V5A}},
+	{model: "E3643A", channels: oneOutput, ratings: []outputRating{p60V14A}},
+	{model: "E3644A", channels: oneOutput, ratings: []outputRating{p20V8A}},
+	{model: "E3645A", channels: oneOutput, ratings: []outputRating{p60V22A}},
 	// The E3646A through E3649A select their two outputs with
 	// INSTrument[:SELect] OUT1 | OUT2.
-	{model: "E3646A", channels: twoNumbered},
-	{model: "E3647A", channels: twoNumbered},
-	{model: "E3648A", channels: twoNumbered},
-	{model: "E3649A", channels: twoNumbered},
+	{
+		model: "E3646A", channels: twoNumbered,
+		ratings: []outputRating{p20V3A, p20V3A},
+	},
+	{
+		model: "E3647A", channels: twoNumbered,
+		ratings: []outputRating{p60V08A, p60V08A},
+	},
+	{
+		model: "E3648A", channels: twoNumbered,
+		ratings: []outputRating{p20V5A, p20V5A},
+	},
+	{
+		model: "E3649A", channels: twoNumbered,
+		ratings: []outputRating{p60V14A, p60V14A},
+	},
+	// The ratings of the E36150, E36200, E36400, and E36700 series have not
+	// been verified against their data sheets, so New refuses range checking
+	// for them.
 	{model: "E36154A", channels: oneOutput},
 	{model: "E36155A", channels: oneOutput},
 	{model: "E36231A", channels: oneOutput},
@@ -286,9 +405,21 @@ var supportedSupplies = []powerSupply{
 	{model: "E36234A", channels: oneOutput},
 	// The E36300 series selects its three outputs with
 	// INSTrument[:SELect] CH1 | CH2 | CH3.
-	{model: "E36311A", channels: threeChannels},
-	{model: "E36312A", channels: threeChannels},
-	{model: "E36313A", channels: threeChannels},
+	{
+		model:    "E36311A",
+		channels: threeChannels,
+		ratings:  []outputRating{p6V5A, p25V1A, n25V1A},
+	},
+	{
+		model:    "E36312A",
+		channels: threeChannels,
+		ratings:  []outputRating{p6V5A, p25V1A, p25V1A},
+	},
+	{
+		model:    "E36313A",
+		channels: threeChannels,
+		ratings:  []outputRating{p6V10A, p25V2A, p25V2A},
+	},
 	// The E36400 series names the outputs a command applies to with a
 	// trailing channel list rather than selecting one beforehand, so its
 	// channel names are descriptive and never reach the wire.
@@ -298,7 +429,11 @@ var supportedSupplies = []powerSupply{
 		family:   channelList,
 	},
 	{model: "E36731A", channels: oneOutput},
-	{model: "EDU36311A", channels: threeChannels},
+	{
+		model:    "EDU36311A",
+		channels: threeChannels,
+		ratings:  []outputRating{p6V5A, p30V1A, p30V1A},
+	},
 }
 
 // supportedModels returns the model numbers described by supportedSupplies,
@@ -409,14 +544,20 @@ func (ch *Channel) outputGetCmd(body string) string {
 	return body
 }
 
-// newContext creates a context with the driver's configured timeout.
-func (d *Driver) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), d.timeout)
+// newContext derives a context from ctx that carries the driver's configured
+// timeout, unless ctx already has a deadline of its own.
+func (d *Driver) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, d.timeout)
 }
 
-// newContext creates a context with the channel's configured timeout.
-func (ch *Channel) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), ch.timeout)
+// newContext derives a context from ctx that carries the channel's configured
+// timeout, unless ctx already has a deadline of its own.
+func (ch *Channel) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, ch.timeout)
 }
 
 // Close properly shuts down the power supply by returning it to local control.
@@ -424,9 +565,17 @@ func (d *Driver) Close() error {
 	return d.Inherent.Close()
 }
 
-// AvailableCOMPorts lists the available COM ports, including optional ports.
+// AvailableCOMPorts lists the available COM ports, including optional ports,
+// across the supported models. The E3631A has GPIB and RS-232; the E36100 and
+// E36300 series add LAN and USB.
 func AvailableCOMPorts() []string {
-	return []string{"GPIB", "RS232"}
+	return []string{"GPIB", "LAN", "RS232", "USB"}
+}
+
+// LANPorts returns the LAN ports of the models that have one, keyed by port
+// type.
+func LANPorts() map[string]int {
+	return map[string]int{"socket": 5025}
 }
 
 // DefaultGPIBAddress lists the default GPIB interface address.
@@ -463,3 +612,16 @@ func SerialDataFrames() []string {
 func DefaultSerialDataFrame() string {
 	return "8N2"
 }
+
+// ConnectionDefaults returns the model's connection defaults, which
+// complete a VISA resource string with [ivi.Resource.Settings].
+func ConnectionDefaults() ivi.ConnectionDefaults {
+	return ivi.ConnectionDefaults{
+		Interfaces:      AvailableCOMPorts(),
+		GPIBAddress:     DefaultGPIBAddress(),
+		LANPorts:        LANPorts(),
+		SerialBaudRates: SerialBaudRates(),
+		SerialBaudRate:  DefaultSerialBaudRate(),
+		SerialDataFrame: DefaultSerialDataFrame(),
+	}
+}
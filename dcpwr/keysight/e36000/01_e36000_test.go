@@ -205,37 +205,39 @@ func TestChannelCommandSpelling(t *testing.T) {
 // sentinel error without sending anything, which is exactly the behavior the
 // sweep should tolerate. Only the strings that do reach the wire matter here.
 func exerciseChannel(ch *Channel) {
-	_, _ = ch.CurrentLimit()
-	_ = ch.SetCurrentLimit(1.2)
-	_, _ = ch.CurrentLimitBehavior()
-	_ = ch.SetCurrentLimitBehavior(dcpwr.CurrentRegulate)
-	_ = ch.SetCurrentLimitBehavior(dcpwr.CurrentTrip)
-	_, _ = ch.OutputEnabled()
-	_ = ch.SetOutputEnabled(true)
-	_ = ch.DisableOutput()
-	_ = ch.EnableOutput()
-	_, _ = ch.OVPEnabled()
-	_ = ch.SetOVPEnabled(true)
-	_ = ch.DisableOVP()
-	_ = ch.EnableOVP()
-	_, _ = ch.OVPLimit()
-	_ = ch.SetOVPLimit(6.0)
-	_, _ = ch.VoltageLevel()
-	_ = ch.SetVoltageLevel(4.1)
-	_ = ch.ConfigureCurrentLimit(dcpwr.CurrentRegulate, 1.2)
-	_ = ch.ConfigureOVP(true, 6.0)
-	_, _ = ch.QueryOutputState(dcpwr.ConstantVoltage)
-	_ = ch.ResetOutputProtection()
-	_, _ = ch.Measure(dcpwr.VoltageMeasurement)
-	_, _ = ch.Measure(dcpwr.CurrentMeasurement)
-	_, _ = ch.MeasureVoltage()
-	_, _ = ch.MeasureCurrent()
-	_, _ = ch.TriggerSource()
-	_ = ch.SetTriggerSource(dcpwr.TriggerSourceImmediate)
-	_, _ = ch.TriggeredCurrentLimit()
-	_ = ch.SetTriggeredCurrentLimit(1.2)
-	_, _ = ch.TriggeredVoltageLevel()
-	_ = ch.SetTriggeredVoltageLevel(4.1)
+	ctx := context.Background()
+
+	_, _ = ch.CurrentLimit(ctx)
+	_ = ch.SetCurrentLimit(ctx, 1.2)
+	_, _ = ch.CurrentLimitBehavior(ctx)
+	_ = ch.SetCurrentLimitBehavior(ctx, dcpwr.CurrentRegulate)
+	_ = ch.SetCurrentLimitBehavior(ctx, dcpwr.CurrentTrip)
+	_, _ = ch.OutputEnabled(ctx)
+	_ = ch.SetOutputEnabled(ctx, true)
+	_ = ch.DisableOutput(ctx)
+	_ = ch.EnableOutput(ctx)
+	_, _ = ch.OVPEnabled(ctx)
+	_ = ch.SetOVPEnabled(ctx, true)
+	_ = ch.DisableOVP(ctx)
+	_ = ch.EnableOVP(ctx)
+	_, _ = ch.OVPLimit(ctx)
+	_ = ch.SetOVPLimit(ctx, 6.0)
+	_, _ = ch.VoltageLevel(ctx)
+	_ = ch.SetVoltageLevel(ctx, 4.1)
+	_ = ch.ConfigureCurrentLimit(ctx, dcpwr.CurrentRegulate, 1.2)
+	_ = ch.ConfigureOVP(ctx, true, 6.0)
+	_, _ = ch.QueryOutputState(ctx, dcpwr.ConstantVoltage)
+	_ = ch.ResetOutputProtection(ctx)
+	_, _ = ch.Measure(ctx, dcpwr.VoltageMeasurement)
+	_, _ = ch.Measure(ctx, dcpwr.CurrentMeasurement)
+	_, _ = ch.MeasureVoltage(ctx)
+	_, _ = ch.MeasureCurrent(ctx)
+	_, _ = ch.TriggerSource(ctx)
+	_ = ch.SetTriggerSource(ctx, dcpwr.TriggerSourceImmediate)
+	_, _ = ch.TriggeredCurrentLimit(ctx)
+	_ = ch.SetTriggeredCurrentLimit(ctx, 1.2)
+	_, _ = ch.TriggeredVoltageLevel(ctx)
+	_ = ch.SetTriggeredVoltageLevel(ctx, 4.1)
 }
 
 // TestAllModels_EmitValidSCPI drives every supported model, on every one of
@@ -301,3 +303,61 @@ func TestSupportedSupplies_ChannelNamesAreSCPITokens(t *testing.T) {
 		}
 	}
 }
+
+func TestDCPwrOpen(t *testing.T) {
+	if !slices.Contains(dcpwr.Drivers.Names(), "e36000") {
+		t.Fatalf("dcpwr.Drivers.Names() = %q, want e36000 registered",
+			dcpwr.Drivers.Names())
+	}
+
+	sim := ivitest.NewSimulator(ivitest.SimModel{
+		IDN: "Keysight Technologies,E36312A,MY12345678,2.1.0-1.0.4-1.12",
+	})
+
+	supply, err := dcpwr.Drivers.Open(t.Context(), sim)
+	if err != nil {
+		t.Fatalf("dcpwr.Drivers.Open() error: %v", err)
+	}
+
+	d, ok := supply.(*Driver)
+	if !ok {
+		t.Fatalf("dcpwr.Drivers.Open() returned %T, want *Driver", supply)
+	}
+	if got := d.OutputChannelCount(); got != 3 {
+		t.Errorf("OutputChannelCount() = %d, want 3", got)
+	}
+}
+
+func TestDCPwrOpen_ChannelAccess(t *testing.T) {
+	supply, err := dcpwr.Drivers.Open(t.Context(), nil,
+		ivi.WithSimulate(), ivi.WithSimulatedModel("E36312A"))
+	if err != nil {
+		t.Fatalf("dcpwr.Drivers.Open() error: %v", err)
+	}
+
+	name, err := supply.OutputChannelName(1)
+	if err != nil {
+		t.Fatalf("OutputChannelName(1) error: %v", err)
+	}
+	ch, err := supply.OutputChannelItem(name)
+	if err != nil {
+		t.Fatalf("OutputChannelItem(%q) error: %v", name, err)
+	}
+	if ch.Name() != name {
+		t.Errorf("OutputChannelItem(%q).Name() = %q", name, ch.Name())
+	}
+	if _, err := ch.VoltageLevel(t.Context()); err != nil {
+		t.Errorf("VoltageLevel() error: %v", err)
+	}
+
+	_, err = supply.OutputChannelName(3)
+	if !errors.Is(err, ivi.ErrChannelNotFound) {
+		t.Errorf("OutputChannelName(3) error = %v, want %v",
+			err, ivi.ErrChannelNotFound)
+	}
+	_, err = supply.OutputChannelItem("P50V")
+	if !errors.Is(err, ivi.ErrChannelNotFound) {
+		t.Errorf("OutputChannelItem(P50V) error = %v, want %v",
+			err, ivi.ErrChannelNotFound)
+	}
+}
@@ -6,6 +6,7 @@
 package e36000
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gotmc/ivi"
@@ -22,6 +23,39 @@ func (d *Driver) OutputChannelCount() int {
 	return len(d.channels)
 }
 
+// OutputChannelName returns the name of the output channel at index, which
+// runs from 0 to OutputChannelCount()-1.
+//
+// OutputChannelName is the getter for the read-only IviDCPwrBase Attribute
+// Output Channel Name described in Section 4.2.9 of IVI-4.4: IviDCPwr Class
+// Specification.
+func (d *Driver) OutputChannelName(index int) (string, error) {
+	ch, err := d.Channel(index)
+	if err != nil {
+		return "", fmt.Errorf("OutputChannelName: %w", err)
+	}
+
+	return ch.Name(), nil
+}
+
+// OutputChannelItem returns the output channel with the given name, as
+// returned by OutputChannelName.
+//
+// OutputChannelItem is the getter for the read-only IviDCPwrBase Attribute
+// Output Channel Item described in Section 4.2.8 of IVI-4.4: IviDCPwr Class
+// Specification.
+func (d *Driver) OutputChannelItem(name string) (dcpwr.BaseChannel, error) {
+	for i := range d.channels {
+		if d.channels[i].Name() == name {
+			return &d.channels[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"OutputChannelItem: %q: %w", name, ivi.ErrChannelNotFound,
+	)
+}
+
 // Name returns the channel's symbolic name (e.g., "P6V", "Output").
 func (ch *Channel) Name() string {
 	return ch.name
@@ -32,20 +66,28 @@ func (ch *Channel) Name() string {
 // CurrentLimit implements the getter for the read-write IviDCPwrBase Attribute
 // Current Limit described in Section 4.2.1 of IVI-4.4: IviDCPwr Class
 // Specification.
-func (ch *Channel) CurrentLimit() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) CurrentLimit(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, ch.getCmd("CURR?"))
 }
 
-// SetCurrentLimit specifies the output current limit in Amperes.
+// SetCurrentLimit specifies the output current limit in Amperes. Under
+// [ivi.WithRangeCheck], a limit outside the output's programming range
+// returns an error wrapping [ivi.ErrValueNotSupported] without being sent.
 //
 // SetCurrentLimit implements the setter for the read-write IviDCPwrBase
 // Attribute Current Limit described in Section 4.2.1 of IVI-4.4: IviDCPwr
 // Class Specification.
-func (ch *Channel) SetCurrentLimit(limit float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetCurrentLimit(ctx context.Context, limit float64) error {
+	if ch.limits != nil {
+		if err := ch.limits.current.Check(limit); err != nil {
+			return fmt.Errorf("SetCurrentLimit: %w", err)
+		}
+	}
+
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, ch.setCmd("CURR %.4f"), limit)
@@ -61,12 +103,14 @@ func (ch *Channel) SetCurrentLimit(limit float64) error {
 // CurrentLimitBehavior implements the getter for the read-write IviDCPwrBase
 // Attribute Current Limit Behavior described in Section 4.2.2 of IVI-4.4:
 // IviDCPwr Class Specification.
-func (ch *Channel) CurrentLimitBehavior() (dcpwr.CurrentLimitBehavior, error) {
+func (ch *Channel) CurrentLimitBehavior(
+	ctx context.Context,
+) (dcpwr.CurrentLimitBehavior, error) {
 	if !ch.protection.ocp {
 		return dcpwr.CurrentRegulate, nil
 	}
 
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	tripping, err := query.Bool(ctx, ch.inst, ch.getCmd("CURR:PROT:STAT?"))
@@ -92,6 +136,7 @@ func (ch *Channel) CurrentLimitBehavior() (dcpwr.CurrentLimitBehavior, error) {
 // IviDCPwrBase Attribute Current Limit Behavior described in Section 4.2.2 of
 // IVI-4.4: IviDCPwr Class Specification.
 func (ch *Channel) SetCurrentLimitBehavior(
+	ctx context.Context,
 	behavior dcpwr.CurrentLimitBehavior,
 ) error {
 	if !ch.protection.ocp {
@@ -105,7 +150,7 @@ func (ch *Channel) SetCurrentLimitBehavior(
 		return nil
 	}
 
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	state := "OFF"
@@ -121,8 +166,8 @@ func (ch *Channel) SetCurrentLimitBehavior(
 //
 // OutputEnabled is the getter for the read-write IviDCPwrBase Attribute Output
 // Enabled described in Section 4.2.3 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) OutputEnabled() (bool, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) OutputEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Bool(ctx, ch.inst, ch.outputGetCmd("OUTP?"))
@@ -133,10 +178,16 @@ func (ch *Channel) OutputEnabled() (bool, error) {
 // SetOutputEnabled is the setter for the read-write IviDCPwrBase Attribute
 // Output Enabled described in Section 4.2.3 of IVI-4.4: IviDCPwr Class
 // Specification.
-func (ch *Channel) SetOutputEnabled(v bool) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetOutputEnabled(ctx context.Context, v bool) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("SetOutputEnabled: %w", err)
+	}
+	defer unlock()
+
 	if v {
 		return ch.inst.Command(ctx, ch.outputSetCmd("OUTP ON"))
 	}
@@ -146,14 +197,14 @@ func (ch *Channel) SetOutputEnabled(v bool) error {
 
 // DisableOutput is a convenience function for setting the Output Enabled
 // attribute to false.
-func (ch *Channel) DisableOutput() error {
-	return ch.SetOutputEnabled(false)
+func (ch *Channel) DisableOutput(ctx context.Context) error {
+	return ch.SetOutputEnabled(ctx, false)
 }
 
 // EnableOutput is a convenience function for setting the Output Enabled
 // attribute to true.
-func (ch *Channel) EnableOutput() error {
-	return ch.SetOutputEnabled(true)
+func (ch *Channel) EnableOutput(ctx context.Context) error {
+	return ch.SetOutputEnabled(ctx, true)
 }
 
 // OVPEnabled determines whether Over-Voltage Protection (OVP) is enabled. It
@@ -161,12 +212,12 @@ func (ch *Channel) EnableOutput() error {
 //
 // OVPEnabled is the getter for the read-write IviFgenBase Attribute OVP
 // Enabled described in Section 4.2.4 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) OVPEnabled() (bool, error) {
+func (ch *Channel) OVPEnabled(ctx context.Context) (bool, error) {
 	if !ch.protection.ovp {
 		return false, nil
 	}
 
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	enabled, err := query.Bool(ctx, ch.inst, ch.getCmd("VOLT:PROT:STAT?"))
@@ -182,12 +233,12 @@ func (ch *Channel) OVPEnabled() (bool, error) {
 //
 // SetOVPEnabled is the setter for the read-write IviFgenBase Attribute OVP
 // Enabled described in Section 4.2.4 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) SetOVPEnabled(v bool) error {
+func (ch *Channel) SetOVPEnabled(ctx context.Context, v bool) error {
 	if !ch.protection.ovp {
 		return fmt.Errorf("SetOVPEnabled: %w", dcpwr.ErrOVPUnsupported)
 	}
 
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	state := "OFF"
@@ -201,19 +252,19 @@ func (ch *Channel) SetOVPEnabled(v bool) error {
 // DisableOVP is a convenience function for setting the OVP Enabled attribute
 // to false. It returns nil on models without Over-Voltage Protection (OVP),
 // such as the E3631A, since their protection is already off.
-func (ch *Channel) DisableOVP() error {
+func (ch *Channel) DisableOVP(ctx context.Context) error {
 	if !ch.protection.ovp {
 		return nil
 	}
 
-	return ch.SetOVPEnabled(false)
+	return ch.SetOVPEnabled(ctx, false)
 }
 
 // EnableOVP is a convenience function for setting the OVP Enabled attribute to
 // true. It returns [dcpwr.ErrOVPUnsupported] on models without Over-Voltage
 // Protection (OVP), such as the E3631A.
-func (ch *Channel) EnableOVP() error {
-	return ch.SetOVPEnabled(true)
+func (ch *Channel) EnableOVP(ctx context.Context) error {
+	return ch.SetOVPEnabled(ctx, true)
 }
 
 // OVPLimit returns the voltage, in Volts, at which Over-Voltage Protection
@@ -222,12 +273,12 @@ func (ch *Channel) EnableOVP() error {
 //
 // OVPLimit is the getter for the read-write IviDWPwrBase Attribute OVP Limit
 // described in Section 4.2.5 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) OVPLimit() (float64, error) {
+func (ch *Channel) OVPLimit(ctx context.Context) (float64, error) {
 	if !ch.protection.ovp {
 		return 0, fmt.Errorf("OVPLimit: %w", dcpwr.ErrOVPUnsupported)
 	}
 
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, ch.getCmd("VOLT:PROT?"))
@@ -239,12 +290,12 @@ func (ch *Channel) OVPLimit() (float64, error) {
 //
 // SetOVPLimit is the setter for the read-write IviDCPwrBase Attribute OVP
 // Limit described in Section 4.2.5 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) SetOVPLimit(limit float64) error {
+func (ch *Channel) SetOVPLimit(ctx context.Context, limit float64) error {
 	if !ch.protection.ovp {
 		return fmt.Errorf("SetOVPLimit: %w", dcpwr.ErrOVPUnsupported)
 	}
 
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, ch.setCmd("VOLT:PROT %.4f"), limit)
@@ -255,21 +306,29 @@ func (ch *Channel) SetOVPLimit(limit float64) error {
 //
 // VoltageLevel is the getter for the read-write IviDCPwrBase Attribute Voltage
 // Level described in Section 4.2.6 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) VoltageLevel() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) VoltageLevel(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, ch.getCmd("VOLT?"))
 }
 
 // SetVoltageLevel specifies the voltage level the DC power supply attempts
-// to generate in Volts.
+// to generate in Volts. Under [ivi.WithRangeCheck], a level outside the
+// output's programming range returns an error wrapping
+// [ivi.ErrValueNotSupported] without being sent.
 //
 // SetVoltageLevel is the setter for the read-write IviDCPwrBase Attribute
 // Voltage Level described in Section 4.2.6 of IVI-4.4: IviDCPwr Class
 // Specification.
-func (ch *Channel) SetVoltageLevel(level float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetVoltageLevel(ctx context.Context, level float64) error {
+	if ch.limits != nil {
+		if err := ch.limits.voltage.Check(level); err != nil {
+			return fmt.Errorf("SetVoltageLevel: %w", err)
+		}
+	}
+
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, ch.setCmd("VOLT %.4f"), level)
@@ -282,14 +341,21 @@ func (ch *Channel) SetVoltageLevel(level float64) error {
 // ConfigureCurrentLimit implements the IviDCPwrBase function described in
 // Section 4.3.1 of IVI-4.4: IviDCPwr Class Specification.
 func (ch *Channel) ConfigureCurrentLimit(
+	ctx context.Context,
 	behavior dcpwr.CurrentLimitBehavior,
 	limit float64,
 ) error {
-	if err := ch.SetCurrentLimitBehavior(behavior); err != nil {
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
 		return fmt.Errorf("ConfigureCurrentLimit: %w", err)
 	}
+	defer unlock()
 
-	if err := ch.SetCurrentLimit(limit); err != nil {
+	if err := ch.SetCurrentLimitBehavior(ctx, behavior); err != nil {
+		return fmt.Errorf("ConfigureCurrentLimit: %w", err)
+	}
+
+	if err := ch.SetCurrentLimit(ctx, limit); err != nil {
 		return fmt.Errorf("ConfigureCurrentLimit: %w", err)
 	}
 
@@ -313,7 +379,11 @@ func (ch *Channel) ConfigureCurrentLimit(
 //
 // ConfigureOutputRange implements the IviDCPwrBase function described in
 // Section 4.3.3 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) ConfigureOutputRange(_ dcpwr.RangeType, _ float64) error {
+func (ch *Channel) ConfigureOutputRange(
+	ctx context.Context,
+	_ dcpwr.RangeType,
+	_ float64,
+) error {
 	return fmt.Errorf("ConfigureOutputRange: %w", ivi.ErrNotImplemented)
 }
 
@@ -325,7 +395,17 @@ func (ch *Channel) ConfigureOutputRange(_ dcpwr.RangeType, _ float64) error {
 //
 // ConfigureOVP implements the IviDCPwrBase function described in Section 4.3.4
 // of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) ConfigureOVP(enabled bool, limit float64) error {
+func (ch *Channel) ConfigureOVP(
+	ctx context.Context,
+	enabled bool,
+	limit float64,
+) error {
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureOVP: %w", err)
+	}
+	defer unlock()
+
 	if !ch.protection.ovp {
 		return fmt.Errorf("ConfigureOVP: %w", dcpwr.ErrOVPUnsupported)
 	}
@@ -333,12 +413,12 @@ func (ch *Channel) ConfigureOVP(enabled bool, limit float64) error {
 	// Per Section 4.3.4, the limit is only applied when OVP is being enabled.
 	// Set it before enabling so the protection never arms at a stale level.
 	if enabled {
-		if err := ch.SetOVPLimit(limit); err != nil {
+		if err := ch.SetOVPLimit(ctx, limit); err != nil {
 			return fmt.Errorf("ConfigureOVP: %w", err)
 		}
 	}
 
-	if err := ch.SetOVPEnabled(enabled); err != nil {
+	if err := ch.SetOVPEnabled(ctx, enabled); err != nil {
 		return fmt.Errorf("ConfigureOVP: %w", err)
 	}
 
@@ -350,7 +430,10 @@ func (ch *Channel) ConfigureOVP(enabled bool, limit float64) error {
 //
 // QueryCurrentLimitMax implements the IviDCPwrBase function described in
 // Section 4.3.7 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) QueryCurrentLimitMax(_ float64) (float64, error) {
+func (ch *Channel) QueryCurrentLimitMax(
+	ctx context.Context,
+	_ float64,
+) (float64, error) {
 	return 0.0, fmt.Errorf("QueryCurrentLimitMax: %w", ivi.ErrNotImplemented)
 }
 
@@ -359,7 +442,10 @@ func (ch *Channel) QueryCurrentLimitMax(_ float64) (float64, error) {
 //
 // QueryVoltageLevelMax implements the IviDCPwrBase function described in
 // Section 4.3.8 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) QueryVoltageLevelMax(_ float64) (float64, error) {
+func (ch *Channel) QueryVoltageLevelMax(
+	ctx context.Context,
+	_ float64,
+) (float64, error) {
 	return 0.0, fmt.Errorf("QueryVoltageLevelMax: %w", ivi.ErrNotImplemented)
 }
 
@@ -407,7 +493,10 @@ func outputStateBit(os dcpwr.OutputState) (register string, mask int, err error)
 //
 // QueryOutputState implements the IviDCPwrBase function described in Section
 // 4.3.9 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) QueryOutputState(os dcpwr.OutputState) (bool, error) {
+func (ch *Channel) QueryOutputState(
+	ctx context.Context,
+	os dcpwr.OutputState,
+) (bool, error) {
 	if !ch.protection.statusRegisters {
 		return false, fmt.Errorf("QueryOutputState: %w", ivi.ErrNotImplemented)
 	}
@@ -417,7 +506,7 @@ func (ch *Channel) QueryOutputState(os dcpwr.OutputState) (bool, error) {
 		return false, fmt.Errorf("QueryOutputState: %w", err)
 	}
 
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	condition, err := query.Int(ctx, ch.inst, ch.getCmd(register))
@@ -434,12 +523,12 @@ func (ch *Channel) QueryOutputState(os dcpwr.OutputState) (bool, error) {
 //
 // ResetOutputProtection implements the IviDCPwrBase function described in
 // Section 4.3.10 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) ResetOutputProtection() error {
+func (ch *Channel) ResetOutputProtection(ctx context.Context) error {
 	if !ch.protection.outputClear {
 		return fmt.Errorf("ResetOutputProtection: %w", ivi.ErrNotImplemented)
 	}
 
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, ch.setCmd("OUTP:PROT:CLE"))
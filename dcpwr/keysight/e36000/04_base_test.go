@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"sync"
 	"testing"
 
 	"github.com/gotmc/ivi"
@@ -78,7 +79,7 @@ func TestChannel_SetOutputEnabled(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			ch := channelForModel(t, mock, "E3631A", 0)
-			err := ch.SetOutputEnabled(tt.enabled)
+			err := ch.SetOutputEnabled(t.Context(), tt.enabled)
 			if err != nil {
 				t.Errorf("SetOutputEnabled() error: %v", err)
 			}
@@ -102,22 +103,22 @@ func TestChannel_SetCommandsPerFamily(t *testing.T) {
 	}{
 		{
 			"E3631A voltage", "E3631A",
-			func(ch *Channel) error { return ch.SetVoltageLevel(5.0) },
+			func(ch *Channel) error { return ch.SetVoltageLevel(t.Context(), 5.0) },
 			"INST P6V; VOLT 5.0000",
 		},
 		{
 			"E36102B voltage", "E36102B",
-			func(ch *Channel) error { return ch.SetVoltageLevel(3.3) },
+			func(ch *Channel) error { return ch.SetVoltageLevel(t.Context(), 3.3) },
 			"VOLT 3.3000",
 		},
 		{
 			"E3631A current", "E3631A",
-			func(ch *Channel) error { return ch.SetCurrentLimit(0.5) },
+			func(ch *Channel) error { return ch.SetCurrentLimit(t.Context(), 0.5) },
 			"INST P6V; CURR 0.5000",
 		},
 		{
 			"E36102B current", "E36102B",
-			func(ch *Channel) error { return ch.SetCurrentLimit(0.5) },
+			func(ch *Channel) error { return ch.SetCurrentLimit(t.Context(), 0.5) },
 			"CURR 0.5000",
 		},
 	}
@@ -147,27 +148,27 @@ func TestChannel_QueriesPerFamily(t *testing.T) {
 	}{
 		{
 			"E3631A voltage", "E3631A",
-			func(ch *Channel) error { _, err := ch.VoltageLevel(); return err },
+			func(ch *Channel) error { _, err := ch.VoltageLevel(t.Context()); return err },
 			"INST P6V; VOLT?",
 		},
 		{
 			"E36102B voltage", "E36102B",
-			func(ch *Channel) error { _, err := ch.VoltageLevel(); return err },
+			func(ch *Channel) error { _, err := ch.VoltageLevel(t.Context()); return err },
 			"VOLT?",
 		},
 		{
 			"E3631A measure voltage", "E3631A",
-			func(ch *Channel) error { _, err := ch.MeasureVoltage(); return err },
+			func(ch *Channel) error { _, err := ch.MeasureVoltage(t.Context()); return err },
 			"MEAS:VOLT? P6V",
 		},
 		{
 			"E36102B measure voltage", "E36102B",
-			func(ch *Channel) error { _, err := ch.MeasureVoltage(); return err },
+			func(ch *Channel) error { _, err := ch.MeasureVoltage(t.Context()); return err },
 			"MEAS:VOLT?",
 		},
 		{
 			"E36102B measure current", "E36102B",
-			func(ch *Channel) error { _, err := ch.MeasureCurrent(); return err },
+			func(ch *Channel) error { _, err := ch.MeasureCurrent(t.Context()); return err },
 			"MEAS:CURR?",
 		},
 	}
@@ -202,7 +203,7 @@ func TestChannel_CurrentLimitBehavior(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{QueryResp: tt.resp}
 			ch := channelForModel(t, mock, tt.model, 0)
-			got, err := ch.CurrentLimitBehavior()
+			got, err := ch.CurrentLimitBehavior(t.Context())
 			if err != nil {
 				t.Fatalf("CurrentLimitBehavior() error: %v", err)
 			}
@@ -237,7 +238,7 @@ func TestChannel_SetCurrentLimitBehavior(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			ch := channelForModel(t, mock, tt.model, 0)
-			err := ch.SetCurrentLimitBehavior(tt.behavior)
+			err := ch.SetCurrentLimitBehavior(t.Context(), tt.behavior)
 			if tt.wantErr {
 				if !errors.Is(err, ivi.ErrValueNotSupported) {
 					t.Fatalf("expected ErrValueNotSupported, got %v", err)
@@ -258,7 +259,7 @@ func TestChannel_SetCurrentLimitBehavior(t *testing.T) {
 func TestChannel_ConfigureCurrentLimit(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := channelForModel(t, mock, "E36102B", 0)
-	if err := ch.ConfigureCurrentLimit(dcpwr.CurrentTrip, 0.25); err != nil {
+	if err := ch.ConfigureCurrentLimit(t.Context(), dcpwr.CurrentTrip, 0.25); err != nil {
 		t.Fatalf("ConfigureCurrentLimit() error: %v", err)
 	}
 	want := []string{"CURR:PROT:STAT ON", "CURR 0.2500"}
@@ -273,7 +274,7 @@ func TestChannel_ConfigureCurrentLimit(t *testing.T) {
 func TestChannel_ConfigureCurrentLimit_Unsupported(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := channelForModel(t, mock, "E3631A", 0)
-	err := ch.ConfigureCurrentLimit(dcpwr.CurrentTrip, 0.25)
+	err := ch.ConfigureCurrentLimit(t.Context(), dcpwr.CurrentTrip, 0.25)
 	if !errors.Is(err, ivi.ErrValueNotSupported) {
 		t.Errorf("ConfigureCurrentLimit() = %v, want ErrValueNotSupported", err)
 	}
@@ -286,7 +287,7 @@ func TestChannel_OVP_Unsupported(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := channelForModel(t, mock, "E3631A", 0)
 
-	enabled, err := ch.OVPEnabled()
+	enabled, err := ch.OVPEnabled(t.Context())
 	if err != nil {
 		t.Errorf("OVPEnabled() error: %v", err)
 	}
@@ -294,15 +295,15 @@ func TestChannel_OVP_Unsupported(t *testing.T) {
 		t.Error("OVPEnabled() = true, want false")
 	}
 
-	if err := ch.DisableOVP(); err != nil {
+	if err := ch.DisableOVP(t.Context()); err != nil {
 		t.Errorf("DisableOVP() = %v, want nil", err)
 	}
 
 	unsupported := map[string]error{
-		"SetOVPEnabled": ch.SetOVPEnabled(true),
-		"EnableOVP":     ch.EnableOVP(),
-		"SetOVPLimit":   ch.SetOVPLimit(10.0),
-		"ConfigureOVP":  ch.ConfigureOVP(true, 10.0),
+		"SetOVPEnabled": ch.SetOVPEnabled(t.Context(), true),
+		"EnableOVP":     ch.EnableOVP(t.Context()),
+		"SetOVPLimit":   ch.SetOVPLimit(t.Context(), 10.0),
+		"ConfigureOVP":  ch.ConfigureOVP(t.Context(), true, 10.0),
 	}
 	for name, err := range unsupported {
 		if !errors.Is(err, dcpwr.ErrOVPUnsupported) {
@@ -310,7 +311,7 @@ func TestChannel_OVP_Unsupported(t *testing.T) {
 		}
 	}
 
-	if _, err := ch.OVPLimit(); !errors.Is(err, dcpwr.ErrOVPUnsupported) {
+	if _, err := ch.OVPLimit(t.Context()); !errors.Is(err, dcpwr.ErrOVPUnsupported) {
 		t.Errorf("OVPLimit() = %v, want ErrOVPUnsupported", err)
 	}
 
@@ -327,29 +328,29 @@ func TestChannel_OVP_Supported(t *testing.T) {
 	}{
 		{
 			"enable",
-			func(ch *Channel) error { return ch.EnableOVP() },
+			func(ch *Channel) error { return ch.EnableOVP(t.Context()) },
 			[]string{"VOLT:PROT:STAT ON"},
 		},
 		{
 			"disable",
-			func(ch *Channel) error { return ch.DisableOVP() },
+			func(ch *Channel) error { return ch.DisableOVP(t.Context()) },
 			[]string{"VOLT:PROT:STAT OFF"},
 		},
 		{
 			"set limit",
-			func(ch *Channel) error { return ch.SetOVPLimit(6.5) },
+			func(ch *Channel) error { return ch.SetOVPLimit(t.Context(), 6.5) },
 			[]string{"VOLT:PROT 6.5000"},
 		},
 		{
 			"configure enabled",
-			func(ch *Channel) error { return ch.ConfigureOVP(true, 6.5) },
+			func(ch *Channel) error { return ch.ConfigureOVP(t.Context(), true, 6.5) },
 			[]string{"VOLT:PROT 6.5000", "VOLT:PROT:STAT ON"},
 		},
 		{
 			// With OVP being turned off the limit is not applied, per
 			// Section 4.3.4 of the IviDCPwr class specification.
 			"configure disabled",
-			func(ch *Channel) error { return ch.ConfigureOVP(false, 6.5) },
+			func(ch *Channel) error { return ch.ConfigureOVP(t.Context(), false, 6.5) },
 			[]string{"VOLT:PROT:STAT OFF"},
 		},
 	}
@@ -371,7 +372,7 @@ func TestChannel_OVP_Supported(t *testing.T) {
 func TestChannel_OVPEnabled_Supported(t *testing.T) {
 	rec := &queryRecorder{Mock: ivitest.Mock{QueryResp: "1"}}
 	ch := channelForModel(t, rec, "E36102B", 0)
-	enabled, err := ch.OVPEnabled()
+	enabled, err := ch.OVPEnabled(t.Context())
 	if err != nil {
 		t.Fatalf("OVPEnabled() error: %v", err)
 	}
@@ -405,7 +406,7 @@ func TestChannel_QueryOutputState(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			rec := &queryRecorder{Mock: ivitest.Mock{QueryResp: tt.resp}}
 			ch := channelForModel(t, rec, "E36102B", 0)
-			got, err := ch.QueryOutputState(tt.state)
+			got, err := ch.QueryOutputState(t.Context(), tt.state)
 			if err != nil {
 				t.Fatalf("QueryOutputState() error: %v", err)
 			}
@@ -422,7 +423,7 @@ func TestChannel_QueryOutputState(t *testing.T) {
 func TestChannel_ResetOutputProtection(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := channelForModel(t, mock, "E36102B", 0)
-	if err := ch.ResetOutputProtection(); err != nil {
+	if err := ch.ResetOutputProtection(t.Context()); err != nil {
 		t.Fatalf("ResetOutputProtection() error: %v", err)
 	}
 	want := []string{"OUTP:PROT:CLE"}
@@ -435,22 +436,22 @@ func TestChannel_NotImplemented_WrapsCorrectError(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := channelForModel(t, mock, "E3631A", 0)
 
-	err := ch.ConfigureOutputRange(dcpwr.CurrentRange, 1.0)
+	err := ch.ConfigureOutputRange(t.Context(), dcpwr.CurrentRange, 1.0)
 	if !errors.Is(err, ivi.ErrNotImplemented) {
 		t.Errorf("ConfigureOutputRange() = %v, want ErrNotImplemented", err)
 	}
 
-	err = ch.ResetOutputProtection()
+	err = ch.ResetOutputProtection(t.Context())
 	if !errors.Is(err, ivi.ErrNotImplemented) {
 		t.Errorf("ResetOutputProtection() = %v, want ErrNotImplemented", err)
 	}
 
-	_, err = ch.QueryCurrentLimitMax(5.0)
+	_, err = ch.QueryCurrentLimitMax(t.Context(), 5.0)
 	if !errors.Is(err, ivi.ErrNotImplemented) {
 		t.Errorf("QueryCurrentLimitMax() = %v, want ErrNotImplemented", err)
 	}
 
-	_, err = ch.QueryOutputState(dcpwr.OverCurrent)
+	_, err = ch.QueryOutputState(t.Context(), dcpwr.OverCurrent)
 	if !errors.Is(err, ivi.ErrNotImplemented) {
 		t.Errorf("QueryOutputState() = %v, want ErrNotImplemented", err)
 	}
@@ -459,7 +460,7 @@ func TestChannel_NotImplemented_WrapsCorrectError(t *testing.T) {
 func TestChannel_DisableOutput(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := channelForModel(t, mock, "E3631A", 0)
-	err := ch.DisableOutput()
+	err := ch.DisableOutput(t.Context())
 	if err != nil {
 		t.Errorf("DisableOutput() error: %v", err)
 	}
@@ -471,7 +472,7 @@ func TestChannel_DisableOutput(t *testing.T) {
 func TestChannel_EnableOutput(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := channelForModel(t, mock, "E3631A", 0)
-	err := ch.EnableOutput()
+	err := ch.EnableOutput(t.Context())
 	if err != nil {
 		t.Errorf("EnableOutput() error: %v", err)
 	}
@@ -531,17 +532,17 @@ func TestE3631A_ConfigureEnableAndReadBack(t *testing.T) {
 	}
 
 	// Configure 4.1 V at a 1.2 A limit on the P6V output.
-	if err := ch.SetVoltageLevel(4.1); err != nil {
+	if err := ch.SetVoltageLevel(t.Context(), 4.1); err != nil {
 		t.Fatalf("SetVoltageLevel() error: %v", err)
 	}
 
-	if err := ch.SetCurrentLimit(1.2); err != nil {
+	if err := ch.SetCurrentLimit(t.Context(), 1.2); err != nil {
 		t.Fatalf("SetCurrentLimit() error: %v", err)
 	}
 
 	// Turn the output on. OUTPut:STATe is global on the E3631A, so this
 	// command carries no INSTrument prefix.
-	if err := ch.EnableOutput(); err != nil {
+	if err := ch.EnableOutput(t.Context()); err != nil {
 		t.Fatalf("EnableOutput() error: %v", err)
 	}
 
@@ -555,7 +556,7 @@ func TestE3631A_ConfigureEnableAndReadBack(t *testing.T) {
 	}
 
 	// Read the voltage back from the P6V output.
-	volts, err := ch.MeasureVoltage()
+	volts, err := ch.MeasureVoltage(t.Context())
 	if err != nil {
 		t.Fatalf("MeasureVoltage() error: %v", err)
 	}
@@ -565,7 +566,7 @@ func TestE3631A_ConfigureEnableAndReadBack(t *testing.T) {
 	}
 
 	// Confirm the output is enabled.
-	enabled, err := ch.OutputEnabled()
+	enabled, err := ch.OutputEnabled(t.Context())
 	if err != nil {
 		t.Fatalf("OutputEnabled() error: %v", err)
 	}
@@ -579,3 +580,210 @@ func TestE3631A_ConfigureEnableAndReadBack(t *testing.T) {
 		t.Errorf("queried %q, want %q", mock.Queries, wantQueries)
 	}
 }
+
+// TestE36312A_RoundTripWithSimulator drives each output of an E36312A through
+// a simulated instrument, which keeps the outputs' settings apart as the
+// INSTrument selection changes and queues an error for anything the supply
+// would reject.
+func TestE36312A_RoundTripWithSimulator(t *testing.T) {
+	ctx := t.Context()
+	sim := ivitest.NewSimulator(ivitest.SimModel{
+		IDN: "Keysight Technologies,E36312A,MY00000001,1.0",
+		Attributes: map[string]ivitest.SimAttribute{
+			"CH1/VOLT":  {Min: 0, Max: 6.18},
+			"CH2/VOLT":  {Min: 0, Max: 25.75},
+			"CH3/VOLT":  {Min: 0, Max: 25.75},
+			"CURR":      {Min: 0, Max: 5.15},
+			"MEAS:VOLT": {Follows: "VOLT"},
+			"OUTP":      {},
+		},
+		Strict: true,
+	})
+
+	d, err := New(sim)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	levels := []float64{5, 12, 24}
+	for i, level := range levels {
+		ch, err := d.Channel(i)
+		if err != nil {
+			t.Fatalf("Channel(%d) error: %v", i, err)
+		}
+
+		if err := ch.SetVoltageLevel(ctx, level); err != nil {
+			t.Fatalf("%s SetVoltageLevel() error: %v", ch.Name(), err)
+		}
+
+		if err := ch.SetCurrentLimit(ctx, 1); err != nil {
+			t.Fatalf("%s SetCurrentLimit() error: %v", ch.Name(), err)
+		}
+	}
+
+	for i, want := range levels {
+		ch, _ := d.Channel(i)
+
+		got, err := ch.MeasureVoltage(ctx)
+		if err != nil || got != want {
+			t.Errorf("%s MeasureVoltage() = %v, %v, want %v",
+				ch.Name(), got, err, want)
+		}
+	}
+
+	sim.CheckErrors(t)
+
+	// CH1 is the 6 V output, so 10 V is out of range and leaves 5 V set.
+	ch1, _ := d.Channel(0)
+	if err := ch1.SetVoltageLevel(ctx, 10); err != nil {
+		t.Fatalf("SetVoltageLevel(10) error: %v", err)
+	}
+
+	if got, _ := ch1.VoltageLevel(ctx); got != 5 {
+		t.Errorf("VoltageLevel() after rejected set = %v, want 5", got)
+	}
+
+	want := []string{`-222,"Data out of range"`}
+	if got := sim.ErrorQueue(); !slices.Equal(got, want) {
+		t.Errorf("ErrorQueue() = %q, want %q", got, want)
+	}
+}
+
+// TestE36312A_ParallelChannels programs and measures the three outputs from
+// separate goroutines, as parallel measurement code sharing one supply does.
+// Run under -race, it also checks the driver holds no unguarded shared state.
+func TestE36312A_ParallelChannels(t *testing.T) {
+	sim := ivitest.NewSimulator(ivitest.SimModel{
+		IDN: "Keysight Technologies,E36312A,MY00000001,1.0",
+		Attributes: map[string]ivitest.SimAttribute{
+			"MEAS:VOLT": {Follows: "VOLT"},
+		},
+	})
+
+	d, err := New(sim)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range d.OutputChannelCount() {
+		wg.Go(func() {
+			ch, _ := d.Channel(i)
+
+			for n := range 20 {
+				level := float64(i*100+n) / 100
+
+				// Hold the lock so no other goroutine changes the output
+				// between programming it and measuring it.
+				ctx, err := d.Lock(t.Context())
+				if err != nil {
+					t.Errorf("Lock() error: %v", err)
+					return
+				}
+
+				err = ch.SetVoltageLevel(ctx, level)
+				got, measErr := ch.MeasureVoltage(ctx)
+
+				if err := d.Unlock(ctx); err != nil {
+					t.Errorf("Unlock() error: %v", err)
+				}
+
+				if err != nil || measErr != nil {
+					t.Errorf("%s: %v, %v", ch.Name(), err, measErr)
+					return
+				}
+
+				if got != level {
+					t.Errorf("%s MeasureVoltage() = %v, want %v",
+						ch.Name(), got, level)
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestChannel_RangeCheck(t *testing.T) {
+	idn := "Keysight Technologies,E36312A,MY12345678,2.1.0-1.0.4-1.12"
+
+	tests := []struct {
+		name    string
+		channel int
+		set     func(ctx context.Context, ch *Channel) error
+		wantErr bool
+	}{
+		{"P6V 6 V", 0, func(ctx context.Context, ch *Channel) error {
+			return ch.SetVoltageLevel(ctx, 6)
+		}, false},
+		{"P6V 7 V", 0, func(ctx context.Context, ch *Channel) error {
+			return ch.SetVoltageLevel(ctx, 7)
+		}, true},
+		{"P25V1 -1 V", 1, func(ctx context.Context, ch *Channel) error {
+			return ch.SetVoltageLevel(ctx, -1)
+		}, true},
+		{"P25V1 1 A", 1, func(ctx context.Context, ch *Channel) error {
+			return ch.SetCurrentLimit(ctx, 1)
+		}, false},
+		{"P25V2 2 A", 2, func(ctx context.Context, ch *Channel) error {
+			return ch.SetCurrentLimit(ctx, 2)
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &ivitest.Mock{QueryResp: idn}
+			d, err := New(mock, ivi.WithRangeCheck())
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+			ch, _ := d.Channel(tt.channel)
+			mock.CommandsSent = nil
+
+			err = tt.set(t.Context(), ch)
+			if !tt.wantErr {
+				if err != nil || len(mock.CommandsSent) != 1 {
+					t.Errorf("error = %v, sent %q, want one command",
+						err, mock.CommandsSent)
+				}
+
+				return
+			}
+			if !errors.Is(err, ivi.ErrValueNotSupported) {
+				t.Errorf("error = %v, want ErrValueNotSupported", err)
+			}
+			if len(mock.CommandsSent) != 0 {
+				t.Errorf("out of range value sent %q", mock.CommandsSent)
+			}
+		})
+	}
+}
+
+func TestChannel_WithoutRangeCheck(t *testing.T) {
+	mock := &ivitest.Mock{
+		QueryResp: "Keysight Technologies,E36312A,MY12345678,2.1.0-1.0.4-1.12",
+	}
+	d, err := New(mock)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	ch, _ := d.Channel(0)
+	mock.CommandsSent = nil
+
+	if err := ch.SetVoltageLevel(t.Context(), 7); err != nil {
+		t.Fatalf("SetVoltageLevel(7) error: %v", err)
+	}
+	if len(mock.CommandsSent) != 1 {
+		t.Errorf("sent %q, want the out of range level sent as given",
+			mock.CommandsSent)
+	}
+}
+
+func TestNew_RangeCheckWithoutRatings(t *testing.T) {
+	mock := &ivitest.Mock{
+		QueryResp: "Keysight Technologies,E36231A,MY12345678,1.0.0",
+	}
+	_, err := New(mock, ivi.WithRangeCheck())
+	if !errors.Is(err, ivi.ErrFunctionNotSupported) {
+		t.Errorf("New() error = %v, want ErrFunctionNotSupported", err)
+	}
+}
@@ -6,6 +6,7 @@
 package e36000
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -34,8 +35,8 @@ var scpiToTriggerSource = map[string]dcpwr.TriggerSource{
 //
 // AbortTrigger implements the IviDCPwrTrigger function described in Section
 // 5.3.1 of IVI-4.4: IviDCPwr Class Specification.
-func (d *Driver) AbortTrigger() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) AbortTrigger(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "ABOR")
@@ -47,8 +48,8 @@ func (d *Driver) AbortTrigger() error {
 //
 // InitiateTrigger implements the IviDCPwrTrigger function described in
 // Section 5.3.5 of IVI-4.4: IviDCPwr Class Specification.
-func (d *Driver) InitiateTrigger() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) InitiateTrigger(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "INIT")
@@ -61,8 +62,10 @@ func (d *Driver) InitiateTrigger() error {
 // TriggerSource is the getter for the read-write IviDCPwrTrigger Attribute
 // Trigger Source described in Section 5.2.1 of IVI-4.4: IviDCPwr Class
 // Specification.
-func (ch *Channel) TriggerSource() (dcpwr.TriggerSource, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) TriggerSource(
+	ctx context.Context,
+) (dcpwr.TriggerSource, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	// On the multi-output models the trigger source is an instrument-wide
@@ -90,8 +93,11 @@ func (ch *Channel) TriggerSource() (dcpwr.TriggerSource, error) {
 // SetTriggerSource is the setter for the read-write IviDCPwrTrigger Attribute
 // Trigger Source described in Section 5.2.1 of IVI-4.4: IviDCPwr Class
 // Specification.
-func (ch *Channel) SetTriggerSource(source dcpwr.TriggerSource) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetTriggerSource(
+	ctx context.Context,
+	source dcpwr.TriggerSource,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	scpi, err := ivi.LookupSCPI(triggerSourceToSCPI, source)
@@ -112,8 +118,8 @@ func (ch *Channel) SetTriggerSource(source dcpwr.TriggerSource) error {
 // TriggeredCurrentLimit is the getter for the read-write IviDCPwrTrigger
 // Attribute Triggered Current Limit described in Section 5.2.2 of IVI-4.4:
 // IviDCPwr Class Specification.
-func (ch *Channel) TriggeredCurrentLimit() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) TriggeredCurrentLimit(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, ch.getCmd("CURR:TRIG?"))
@@ -125,8 +131,11 @@ func (ch *Channel) TriggeredCurrentLimit() (float64, error) {
 // SetTriggeredCurrentLimit is the setter for the read-write IviDCPwrTrigger
 // Attribute Triggered Current Limit described in Section 5.2.2 of IVI-4.4:
 // IviDCPwr Class Specification.
-func (ch *Channel) SetTriggeredCurrentLimit(limit float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetTriggeredCurrentLimit(
+	ctx context.Context,
+	limit float64,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, ch.setCmd("CURR:TRIG %.4f"), limit)
@@ -139,8 +148,8 @@ func (ch *Channel) SetTriggeredCurrentLimit(limit float64) error {
 // TriggeredVoltageLevel is the getter for the read-write IviDCPwrTrigger
 // Attribute Triggered Voltage Level described in Section 5.2.3 of IVI-4.4:
 // IviDCPwr Class Specification.
-func (ch *Channel) TriggeredVoltageLevel() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) TriggeredVoltageLevel(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, ch.getCmd("VOLT:TRIG?"))
@@ -152,8 +161,11 @@ func (ch *Channel) TriggeredVoltageLevel() (float64, error) {
 // SetTriggeredVoltageLevel is the setter for the read-write IviDCPwrTrigger
 // Attribute Triggered Voltage Level described in Section 5.2.3 of IVI-4.4:
 // IviDCPwr Class Specification.
-func (ch *Channel) SetTriggeredVoltageLevel(level float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetTriggeredVoltageLevel(
+	ctx context.Context,
+	level float64,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, ch.setCmd("VOLT:TRIG %.4f"), level)
@@ -6,6 +6,7 @@
 package e36000
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gotmc/ivi/dcpwr"
@@ -19,12 +20,12 @@ import (
 //
 // SendSoftwareTrigger implements the IviDCPwrSoftwareTrigger function
 // described in Section 6.2.1 of IVI-4.4: IviDCPwr Class Specification.
-func (d *Driver) SendSoftwareTrigger() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SendSoftwareTrigger(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	for i := range d.channels {
-		src, err := d.channels[i].TriggerSource()
+		src, err := d.channels[i].TriggerSource(ctx)
 		if err != nil {
 			return fmt.Errorf("SendSoftwareTrigger: %w", err)
 		}
@@ -6,6 +6,7 @@
 package e36000
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gotmc/ivi"
@@ -18,12 +19,15 @@ import (
 //
 // Measure implements the IviDCPwrMeasurement function described in Section
 // 7.3.1 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) Measure(msrType dcpwr.MeasurementType) (float64, error) {
+func (ch *Channel) Measure(
+	ctx context.Context,
+	msrType dcpwr.MeasurementType,
+) (float64, error) {
 	switch msrType {
 	case dcpwr.CurrentMeasurement:
-		return ch.MeasureCurrent()
+		return ch.MeasureCurrent(ctx)
 	case dcpwr.VoltageMeasurement:
-		return ch.MeasureVoltage()
+		return ch.MeasureVoltage(ctx)
 	}
 
 	return 0.0, fmt.Errorf("Measure %v: %w", msrType, ivi.ErrValueNotSupported)
@@ -35,8 +39,8 @@ func (ch *Channel) Measure(msrType dcpwr.MeasurementType) (float64, error) {
 // MeasureVoltage implements the IviDCPwrMeasurement function Measure for the
 // Voltage MeasurementType parameter described in Section 7.2.1 of IVI-4.4:
 // IviDCPwr Class Specification.
-func (ch *Channel) MeasureVoltage() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) MeasureVoltage(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, ch.measCmd("MEAS:VOLT?"))
@@ -48,8 +52,8 @@ func (ch *Channel) MeasureVoltage() (float64, error) {
 // MeasureCurrent implements the IviDCPwrMeasurement function Measure for the
 // Current MeasurementType parameter described in Section 7.2.1 of IVI-4.4:
 // IviDCPwr Class Specification.
-func (ch *Channel) MeasureCurrent() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) MeasureCurrent(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, ch.measCmd("MEAS:CURR?"))
@@ -0,0 +1,67 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package e36000
+
+import "github.com/gotmc/ivi"
+
+// simulation describes the instrument simulated under [ivi.WithSimulate].
+// The output ratings recorded in supportedSupplies bound each model's voltage
+// and current settings; models without recorded ratings are simulated
+// without range checking. Measurements report the programmed voltage of the
+// output measured.
+var simulation = ivi.Simulation{
+	Manufacturer: "Keysight Technologies",
+	Attributes: map[string]ivi.SimulatedAttribute{
+		"MEAS:VOLT": {Follows: "VOLT"},
+		"TRIG:SOUR": {Default: "BUS"},
+		"VOLT:PROT": {Default: "22"},
+	},
+	ModelAttributes: simulatedModels(),
+}
+
+// simulatedResetCurrents holds, per model and in channel order, the current
+// limit of each output after *RST where it is not zero.
+var simulatedResetCurrents = map[string][]string{
+	"E3631A": {"5", "1", "1"},
+}
+
+// simulatedModels returns the simulated attributes of every supported model
+// with recorded output ratings. The channelList family is left unbounded,
+// since the simulator bounds a header, not the channel list that follows it.
+func simulatedModels() map[string]map[string]ivi.SimulatedAttribute {
+	models := make(map[string]map[string]ivi.SimulatedAttribute)
+
+	for _, supply := range supportedSupplies {
+		if len(supply.ratings) == 0 || supply.family == channelList {
+			continue
+		}
+
+		attrs := make(map[string]ivi.SimulatedAttribute)
+
+		for i, rating := range supply.ratings {
+			prefix := ""
+			if supply.family == instSelect {
+				prefix = supply.channels[i] + "/"
+			}
+
+			current := ivi.SimulatedAttribute{
+				Min: rating.current.Min, Max: rating.current.Max,
+			}
+			if resets := simulatedResetCurrents[supply.model]; i < len(resets) {
+				current.Default = resets[i]
+			}
+
+			attrs[prefix+"VOLT"] = ivi.SimulatedAttribute{
+				Min: rating.voltage.Min, Max: rating.voltage.Max,
+			}
+			attrs[prefix+"CURR"] = current
+		}
+
+		models[supply.model] = attrs
+	}
+
+	return models
+}
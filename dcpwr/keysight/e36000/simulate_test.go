@@ -0,0 +1,107 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package e36000
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gotmc/ivi"
+)
+
+func TestSimulate(t *testing.T) {
+	ctx := t.Context()
+
+	d, err := New(nil, ivi.WithSimulate())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	if got := d.OutputChannelCount(); got != 3 {
+		t.Fatalf("OutputChannelCount() = %d, want 3", got)
+	}
+
+	p6v, _ := d.Channel(0)
+	p25v, _ := d.Channel(1)
+	if err := p6v.SetVoltageLevel(ctx, 5.0); err != nil {
+		t.Fatalf("SetVoltageLevel() error: %v", err)
+	}
+	if err := p25v.SetVoltageLevel(ctx, 12.0); err != nil {
+		t.Fatalf("SetVoltageLevel() error: %v", err)
+	}
+
+	if got, err := p6v.VoltageLevel(ctx); err != nil || got != 5.0 {
+		t.Errorf("P6V VoltageLevel() = %v, %v, want 5", got, err)
+	}
+	if got, err := p6v.MeasureVoltage(ctx); err != nil || got != 5.0 {
+		t.Errorf("P6V MeasureVoltage() = %v, %v, want 5", got, err)
+	}
+	if got, err := p25v.MeasureVoltage(ctx); err != nil || got != 12.0 {
+		t.Errorf("P25V MeasureVoltage() = %v, %v, want 12", got, err)
+	}
+
+	err = p6v.SetVoltageLevel(ctx, 10.0)
+	if !errors.Is(err, ivi.ErrValueNotSupported) {
+		t.Errorf("SetVoltageLevel(10) on P6V error = %v, want %v",
+			err, ivi.ErrValueNotSupported)
+	}
+}
+
+func TestSimulate_Model(t *testing.T) {
+	d, err := New(nil, ivi.WithSimulate(), ivi.WithSimulatedModel("E36313A"))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	model, err := d.InstrumentModel(t.Context())
+	if err != nil || model != "E36313A" {
+		t.Errorf("InstrumentModel() = %q, %v, want E36313A", model, err)
+	}
+	if got := d.OutputChannelCount(); got != 3 {
+		t.Errorf("OutputChannelCount() = %d, want 3", got)
+	}
+}
+
+// TestSimulate_Ratings checks that the simulated models other than the
+// E3631A are bounded by their recorded output ratings as well.
+func TestSimulate_Ratings(t *testing.T) {
+	tests := []struct {
+		model   string
+		index   int
+		ok, bad float64
+	}{
+		{"E36102B", 0, 6, 6.5},
+		{"E3634A", 0, 50, 52},
+		{"E3646A", 1, 20, 21},
+		{"EDU36311A", 2, 30, 31},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			ctx := t.Context()
+
+			d, err := New(nil,
+				ivi.WithSimulate(), ivi.WithSimulatedModel(tt.model))
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+
+			ch, err := d.Channel(tt.index)
+			if err != nil {
+				t.Fatalf("Channel(%d) error: %v", tt.index, err)
+			}
+
+			if err := ch.SetVoltageLevel(ctx, tt.ok); err != nil {
+				t.Errorf("SetVoltageLevel(%v) error: %v", tt.ok, err)
+			}
+
+			err = ch.SetVoltageLevel(ctx, tt.bad)
+			if !errors.Is(err, ivi.ErrValueNotSupported) {
+				t.Errorf("SetVoltageLevel(%v) error = %v, want %v",
+					tt.bad, err, ivi.ErrValueNotSupported)
+			}
+		})
+	}
+}
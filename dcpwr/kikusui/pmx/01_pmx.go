@@ -31,6 +31,13 @@ var (
 	_ dcpwr.MeasurementChannel = (*Channel)(nil)
 )
 
+// Register the driver with [dcpwr.Drivers] so that Open can select it by model.
+func init() {
+	dcpwr.Drivers.Register(
+		"pmx", supportedModels(), ivi.Factory[dcpwr.Base](New),
+	)
+}
+
 // Driver provides the IVI driver for the Kikusui PMX series of DC power
 // supplies.
 type Driver struct {
@@ -64,12 +71,10 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		GroupCapabilities: []string{
 			"IviDCPwrBase",
 			"IviDCPwrMeasurement",
-			"IviDCPwrTrigger",
-		},
-		SupportedInstrumentModels: []string{
-			"PMX18-2A", "PMX18-5A", "PMX35-1A", "PMX35-3A", "PMX70-1A",
-			"PMX110-0.6A", "PMX250-0.25A", "PMX350-0.2A", "PMX500-0.2A",
+			// "IviDCPwrTrigger",
 		},
+		SupportedInstrumentModels: supportedModels(),
+		Simulation:                &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
@@ -78,18 +83,18 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	channelNames := []string{"DCOutput"}
 	channels := make([]Channel, len(channelNames))
 	for i, name := range channelNames {
-		channels[i] = Channel{name: name, inst: inst, timeout: s.Timeout}
+		channels[i] = Channel{name: name, inst: s.Transport, timeout: s.Timeout}
 	}
 
 	driver := Driver{
-		inst:     inst,
+		inst:     s.Transport,
 		channels: channels,
 		timeout:  s.Timeout,
 		Inherent: s.Inherent,
 	}
 
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
+		if err := driver.Reset(context.Background()); err != nil {
 			return nil, err
 		}
 	}
@@ -97,6 +102,14 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	return &driver, nil
 }
 
+// supportedModels returns the model numbers the driver supports.
+func supportedModels() []string {
+	return []string{
+		"PMX18-2A", "PMX18-5A", "PMX35-1A", "PMX35-3A", "PMX70-1A",
+		"PMX110-0.6A", "PMX250-0.25A", "PMX350-0.2A", "PMX500-0.2A",
+	}
+}
+
 // Channel returns the Channel at the given index, with bounds checking.
 func (d *Driver) Channel(index int) (*Channel, error) {
 	if index < 0 || index >= len(d.channels) {
@@ -106,9 +119,12 @@ func (d *Driver) Channel(index int) (*Channel, error) {
 	return &d.channels[index], nil
 }
 
-// newContext creates a context with the channel's configured timeout.
-func (ch *Channel) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), ch.timeout)
+// newContext derives a context from ctx that carries the channel's configured
+// timeout, unless ctx already has a deadline of its own.
+func (ch *Channel) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, ch.timeout)
 }
 
 // Close properly shuts down the power supply by returning it to local control.
@@ -6,6 +6,7 @@
 package pmx
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gotmc/ivi"
@@ -24,6 +25,39 @@ func (d *Driver) OutputChannelCount() int {
 	return len(d.channels)
 }
 
+// OutputChannelName returns the name of the output channel at index, which
+// runs from 0 to OutputChannelCount()-1.
+//
+// OutputChannelName is the getter for the read-only IviDCPwrBase Attribute
+// Output Channel Name described in Section 4.2.9 of IVI-4.4: IviDCPwr Class
+// Specification.
+func (d *Driver) OutputChannelName(index int) (string, error) {
+	ch, err := d.Channel(index)
+	if err != nil {
+		return "", fmt.Errorf("OutputChannelName: %w", err)
+	}
+
+	return ch.Name(), nil
+}
+
+// OutputChannelItem returns the output channel with the given name, as
+// returned by OutputChannelName.
+//
+// OutputChannelItem is the getter for the read-only IviDCPwrBase Attribute
+// Output Channel Item described in Section 4.2.8 of IVI-4.4: IviDCPwr Class
+// Specification.
+func (d *Driver) OutputChannelItem(name string) (dcpwr.BaseChannel, error) {
+	for i := range d.channels {
+		if d.channels[i].Name() == name {
+			return &d.channels[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"OutputChannelItem: %q: %w", name, ivi.ErrChannelNotFound,
+	)
+}
+
 // Name returns the channel's symbolic name ("DCOutput").
 func (ch *Channel) Name() string {
 	return ch.name
@@ -34,8 +68,8 @@ func (ch *Channel) Name() string {
 // CurrentLimit implements the getter for the read-write IviDCPwrBase Attribute
 // Current Limit described in Section 4.2.1 of IVI-4.4: IviDCPwr Class
 // Specification.
-func (ch *Channel) CurrentLimit() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) CurrentLimit(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, "CURR?")
@@ -46,10 +80,16 @@ func (ch *Channel) CurrentLimit() (float64, error) {
 // SetCurrentLimit implements the setter for the read-write IviDCPwrBase
 // Attribute Current Limit described in Section 4.2.1 of IVI-4.4: IviDCPwr
 // Class Specification.
-func (ch *Channel) SetCurrentLimit(limit float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetCurrentLimit(ctx context.Context, limit float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("SetCurrentLimit: %w", err)
+	}
+	defer unlock()
+
 	switch ch.currentLimitBehavior {
 	case dcpwr.CurrentRegulate:
 		return ch.inst.Command(ctx, "CURR %f;:CURR:PROT MAX", limit)
@@ -67,7 +107,9 @@ func (ch *Channel) SetCurrentLimit(limit float64) error {
 // CurrentLimitBehavior implements the getter for the read-write IviDCPwrBase
 // Attribute Current Limit Behavior described in Section 4.2.2 of IVI-4.4:
 // IviDCPwr Class Specification.
-func (ch *Channel) CurrentLimitBehavior() (dcpwr.CurrentLimitBehavior, error) {
+func (ch *Channel) CurrentLimitBehavior(
+	ctx context.Context,
+) (dcpwr.CurrentLimitBehavior, error) {
 	return ch.currentLimitBehavior, nil
 }
 
@@ -82,11 +124,18 @@ func (ch *Channel) CurrentLimitBehavior() (dcpwr.CurrentLimitBehavior, error) {
 // Attribute Current Limit Behavior described in Section 4.2.2 of IVI-4.4:
 // IviDCPwr Class Specification.
 func (ch *Channel) SetCurrentLimitBehavior(
+	ctx context.Context,
 	behavior dcpwr.CurrentLimitBehavior,
 ) error {
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("SetCurrentLimitBehavior: %w", err)
+	}
+	defer unlock()
+
 	switch behavior {
 	case dcpwr.CurrentRegulate:
 		ch.currentLimitBehavior = dcpwr.CurrentRegulate
@@ -108,8 +157,8 @@ func (ch *Channel) SetCurrentLimitBehavior(
 //
 // OutputEnabled is the getter for the read-write IviDCPwrBase Attribute Output
 // Enabled described in Section 4.2.3 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) OutputEnabled() (bool, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) OutputEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Bool(ctx, ch.inst, "OUTP?")
@@ -120,10 +169,16 @@ func (ch *Channel) OutputEnabled() (bool, error) {
 // SetOutputEnabled is the setter for the read-write IviDCPwrBase Attribute
 // Output Enabled described in Section 4.2.3 of IVI-4.4: IviDCPwr Class
 // Specification.
-func (ch *Channel) SetOutputEnabled(v bool) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetOutputEnabled(ctx context.Context, v bool) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("SetOutputEnabled: %w", err)
+	}
+	defer unlock()
+
 	if v {
 		return ch.inst.Command(ctx, "OUTP 1")
 	}
@@ -132,14 +187,14 @@ func (ch *Channel) SetOutputEnabled(v bool) error {
 
 // DisableOutput is a convenience function for setting the Output Enabled
 // attribute to false.
-func (ch *Channel) DisableOutput() error {
-	return ch.SetOutputEnabled(false)
+func (ch *Channel) DisableOutput(ctx context.Context) error {
+	return ch.SetOutputEnabled(ctx, false)
 }
 
 // EnableOutput is a convenience function for setting the Output Enabled
 // attribute to true.
-func (ch *Channel) EnableOutput() error {
-	return ch.SetOutputEnabled(true)
+func (ch *Channel) EnableOutput(ctx context.Context) error {
+	return ch.SetOutputEnabled(ctx, true)
 }
 
 // OVPEnabled specifies whether the power supply provides Over-Voltage
@@ -147,10 +202,16 @@ func (ch *Channel) EnableOutput() error {
 //
 // OVPEnabled is the getter for the read-write IviFgenBase Attribute OVP
 // Enabled described in Section 4.2.4 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) OVPEnabled() (bool, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) OVPEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return false, fmt.Errorf("OVPEnabled: %w", err)
+	}
+	defer unlock()
+
 	max, err := query.Float64(ctx, ch.inst, "VOLT:PROT? MAX")
 	if err != nil {
 		return false, err
@@ -172,8 +233,8 @@ func (ch *Channel) OVPEnabled() (bool, error) {
 //
 // SetOVPEnabled is the setter for the read-write IviFgenBase Attribute OVP
 // Enabled described in Section 4.2.4 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) SetOVPEnabled(v bool) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetOVPEnabled(ctx context.Context, v bool) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	if !v {
@@ -184,13 +245,13 @@ func (ch *Channel) SetOVPEnabled(v bool) error {
 
 // DisableOVP is a convenience function for disabling Over-Voltage Protection
 // (OVP).
-func (ch *Channel) DisableOVP() error {
+func (ch *Channel) DisableOVP(ctx context.Context) error {
 	return fmt.Errorf("DisableOVP: %w", ivi.ErrNotImplemented)
 }
 
 // EnableOVP is a convenience function for enabling Over-Voltage Protection
 // (OVP).
-func (ch *Channel) EnableOVP() error {
+func (ch *Channel) EnableOVP(ctx context.Context) error {
 	return fmt.Errorf("EnableOVP: %w", ivi.ErrNotImplemented)
 }
 
@@ -198,8 +259,8 @@ func (ch *Channel) EnableOVP() error {
 //
 // OPVLimit is the getter for the read-write IviDWPwrBase Attribute OVP Limit
 // described in Section 4.2.5 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) OVPLimit() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) OVPLimit(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, "VOLT:PROT?")
@@ -213,8 +274,8 @@ func (ch *Channel) OVPLimit() (float64, error) {
 //
 // SetOVPLimit is the setter for the read-write IviDCPwrBase Attribute OVP
 // Limit described in Section 4.2.5 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) SetOVPLimit(limit float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetOVPLimit(ctx context.Context, limit float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, "VOLT:PROT %f", limit)
@@ -225,8 +286,8 @@ func (ch *Channel) SetOVPLimit(limit float64) error {
 //
 // VoltageLevel is the getter for the read-write IviDCPwrBase Attribute Voltage
 // Level described in Section 4.2.6 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) VoltageLevel() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) VoltageLevel(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, "VOLT?")
@@ -238,8 +299,8 @@ func (ch *Channel) VoltageLevel() (float64, error) {
 // SetVoltageLevel is the setter for the read-write IviDCPwrBase Attribute
 // Voltage Level described in Section 4.2.6 of IVI-4.4: IviDCPwr Class
 // Specification.
-func (ch *Channel) SetVoltageLevel(level float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetVoltageLevel(ctx context.Context, level float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ivi.Set(ctx, ch.inst, "VOLT %f", level)
@@ -253,12 +314,19 @@ func (ch *Channel) SetVoltageLevel(level float64) error {
 // function described in Section 4.3.1 of IVI-4.4: IviDCPwr Class
 // Specification.
 func (ch *Channel) ConfigureCurrentLimit(
+	ctx context.Context,
 	behavior dcpwr.CurrentLimitBehavior,
 	limit float64,
 ) error {
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureCurrentLimit: %w", err)
+	}
+	defer unlock()
+
 	switch behavior {
 	case dcpwr.CurrentRegulate:
 		ch.currentLimitBehavior = dcpwr.CurrentRegulate
@@ -288,6 +356,7 @@ func (ch *Channel) ConfigureCurrentLimit(
 // ConfigureOutputRange implements the IviDCPwrBase function described in
 // Section 4.3.3 of IVI-4.4: IviDCPwr Class Specification.
 func (ch *Channel) ConfigureOutputRange(
+	ctx context.Context,
 	rt dcpwr.RangeType,
 	rng float64,
 ) error {
@@ -299,10 +368,20 @@ func (ch *Channel) ConfigureOutputRange(
 //
 // ConfigureOVP implements the IviDCPwrBase Configure OVP function described in
 // Section 4.3.4 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) ConfigureOVP(enabled bool, limit float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) ConfigureOVP(
+	ctx context.Context,
+	enabled bool,
+	limit float64,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureOVP: %w", err)
+	}
+	defer unlock()
+
 	if !enabled {
 		return ch.inst.Command(ctx, "VOLT:PROT MAX")
 	}
@@ -314,7 +393,10 @@ func (ch *Channel) ConfigureOVP(enabled bool, limit float64) error {
 //
 // QueryCurrentLimitMax implements the IviDCPwrBase function described in
 // Section 4.3.7 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) QueryCurrentLimitMax(voltage float64) (float64, error) {
+func (ch *Channel) QueryCurrentLimitMax(
+	ctx context.Context,
+	voltage float64,
+) (float64, error) {
 	return 0.0, fmt.Errorf("QueryCurrentLimitMax: %w", ivi.ErrNotImplemented)
 }
 
@@ -324,6 +406,7 @@ func (ch *Channel) QueryCurrentLimitMax(voltage float64) (float64, error) {
 // QueryVoltageLevelMax implements the IviDCPwrBase function described in
 // Section 4.3.8 of IVI-4.4: IviDCPwr Class Specification.
 func (ch *Channel) QueryVoltageLevelMax(
+	ctx context.Context,
 	currentLimit float64,
 ) (float64, error) {
 	return 0.0, fmt.Errorf("QueryVoltageLevelMax: %w", ivi.ErrNotImplemented)
@@ -334,7 +417,10 @@ func (ch *Channel) QueryVoltageLevelMax(
 //
 // QueryOutputState implements the IviDCPwrBase function described in Section
 // 4.3.9 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) QueryOutputState(os dcpwr.OutputState) (bool, error) {
+func (ch *Channel) QueryOutputState(
+	ctx context.Context,
+	os dcpwr.OutputState,
+) (bool, error) {
 	return false, fmt.Errorf("QueryOutputState: %w", ivi.ErrNotImplemented)
 }
 
@@ -343,6 +429,6 @@ func (ch *Channel) QueryOutputState(os dcpwr.OutputState) (bool, error) {
 //
 // ResetOutputProtection implements the IviDCPwrBase function described in
 // Section 4.3.10 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) ResetOutputProtection() error {
+func (ch *Channel) ResetOutputProtection(ctx context.Context) error {
 	return fmt.Errorf("ResetOutputProtection: %w", ivi.ErrNotImplemented)
 }
@@ -0,0 +1,65 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package pmx
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gotmc/ivi"
+)
+
+// simulation describes the instrument simulated under [ivi.WithSimulate].
+// Each model's rated output bounds its voltage and current settings, and its
+// protection settings reach 110% of the rating, their power-on value.
+// Measurements report the programmed voltage.
+var simulation = ivi.Simulation{
+	Manufacturer: "KIKUSUI",
+	Attributes: map[string]ivi.SimulatedAttribute{
+		"MEAS:VOLT": {Follows: "VOLT"},
+	},
+	ModelAttributes: map[string]map[string]ivi.SimulatedAttribute{
+		"PMX18-2A":     ratedOutput(18, 2),
+		"PMX18-5A":     ratedOutput(18, 5),
+		"PMX35-1A":     ratedOutput(35, 1),
+		"PMX35-3A":     ratedOutput(35, 3),
+		"PMX70-1A":     ratedOutput(70, 1),
+		"PMX110-0.6A":  ratedOutput(110, 0.6),
+		"PMX250-0.25A": ratedOutput(250, 0.25),
+		"PMX350-0.2A":  ratedOutput(350, 0.2),
+		"PMX500-0.2A":  ratedOutput(500, 0.2),
+	},
+}
+
+// ratedOutput returns the simulated attributes of a model with the given
+// rated output voltage and current.
+func ratedOutput(volts, amps float64) map[string]ivi.SimulatedAttribute {
+	// Round the protection limits as the instrument reports them, to the
+	// nearest millivolt and milliamp.
+	margin := func(v float64) float64 { return math.Round(v*1100) / 1000 }
+
+	ovp := margin(volts)
+	ocp := margin(amps)
+
+	return map[string]ivi.SimulatedAttribute{
+		"VOLT": {Min: 0, Max: volts},
+		"CURR": {
+			Default: strconv.FormatFloat(amps, 'g', -1, 64),
+			Min:     0,
+			Max:     amps,
+		},
+		"VOLT:PROT": {
+			Default: strconv.FormatFloat(ovp, 'g', -1, 64),
+			Min:     0,
+			Max:     ovp,
+		},
+		"CURR:PROT": {
+			Default: strconv.FormatFloat(ocp, 'g', -1, 64),
+			Min:     0,
+			Max:     ocp,
+		},
+	}
+}
@@ -31,6 +31,16 @@ var (
 	_ dcpwr.MeasurementChannel = (*Channel)(nil)
 )
 
+// Register the driver with [dcpwr.Drivers] so that Open can select it by model,
+// and its connection defaults so that a config session can complete its
+// resource.
+func init() {
+	dcpwr.Drivers.Register(
+		"dp800", supportedModels(), ivi.Factory[dcpwr.Base](New),
+	)
+	ivi.RegisterConnectionDefaults("dp800", ConnectionDefaults())
+}
+
 // Driver provides the IVI driver for the Rigol DP800 series of DC power
 // supplies.
 type Driver struct {
@@ -54,22 +64,21 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		ResetDelay:            500 * time.Millisecond,
 		ClearDelay:            500 * time.Millisecond,
 		ReturnToLocal:         true,
+		SupportsOPC:           true,
 		GroupCapabilities: []string{
 			"IviDCPwrBase",
 			"IviDCPwrMeasurement",
-			"IviDCPwrTrigger",
-		},
-		SupportedInstrumentModels: []string{
-			"DP831A", "DP832A", "DP821A", "DP811A",
-			"DP831", "DP832", "DP821", "DP811",
+			// "IviDCPwrTrigger",
 		},
+		SupportedInstrumentModels: supportedModels(),
+		Simulation:                &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	// Channel configuration depends on the queried model.
-	model, err := s.Inherent.InstrumentModel()
+	model, err := s.Inherent.InstrumentModel(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("error determining instrument model: %w", err)
 	}
@@ -129,7 +138,7 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		ch := Channel{
 			name:       genericChannel.name,
 			idx:        i + 1, // 1-based channel index
-			inst:       inst,
+			inst:       s.Transport,
 			timeout:    s.Timeout,
 			minVoltage: genericChannel.minVoltage,
 			maxVoltage: genericChannel.maxVoltage,
@@ -139,14 +148,14 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		channels[i] = ch
 	}
 	driver := Driver{
-		inst:     inst,
+		inst:     s.Transport,
 		channels: channels,
 		timeout:  s.Timeout,
 		Inherent: s.Inherent,
 	}
 
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
+		if err := driver.Reset(context.Background()); err != nil {
 			return nil, err
 		}
 	}
@@ -154,6 +163,14 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	return &driver, nil
 }
 
+// supportedModels returns the model numbers the driver supports.
+func supportedModels() []string {
+	return []string{
+		"DP831A", "DP832A", "DP821A", "DP811A",
+		"DP831", "DP832", "DP821", "DP811",
+	}
+}
+
 // Channel returns the Channel at the given index, with bounds checking.
 func (d *Driver) Channel(index int) (*Channel, error) {
 	if index < 0 || index >= len(d.channels) {
@@ -163,9 +180,12 @@ func (d *Driver) Channel(index int) (*Channel, error) {
 	return &d.channels[index], nil
 }
 
-// newContext creates a context with the channel's configured timeout.
-func (ch *Channel) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), ch.timeout)
+// newContext derives a context from ctx that carries the channel's configured
+// timeout, unless ctx already has a deadline of its own.
+func (ch *Channel) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, ch.timeout)
 }
 
 // Close properly shuts down the power supply by returning it to local control.
@@ -231,3 +251,15 @@ func SerialEndMark() string {
 func DefaultSerialDataFrame() string {
 	return "8N2"
 }
+
+// ConnectionDefaults returns the model's connection defaults, which
+// complete a VISA resource string with [ivi.Resource.Settings].
+func ConnectionDefaults() ivi.ConnectionDefaults {
+	return ivi.ConnectionDefaults{
+		Interfaces:      AvailableCOMPorts(),
+		GPIBAddress:     DefaultGPIBAddress(),
+		SerialBaudRates: SerialBaudRates(),
+		SerialBaudRate:  DefaultSerialBaudRate(),
+		SerialDataFrame: DefaultSerialDataFrame(),
+	}
+}
@@ -6,6 +6,7 @@
 package dp800
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -24,6 +25,39 @@ func (d *Driver) OutputChannelCount() int {
 	return len(d.channels)
 }
 
+// OutputChannelName returns the name of the output channel at index, which
+// runs from 0 to OutputChannelCount()-1.
+//
+// OutputChannelName is the getter for the read-only IviDCPwrBase Attribute
+// Output Channel Name described in Section 4.2.9 of IVI-4.4: IviDCPwr Class
+// Specification.
+func (d *Driver) OutputChannelName(index int) (string, error) {
+	ch, err := d.Channel(index)
+	if err != nil {
+		return "", fmt.Errorf("OutputChannelName: %w", err)
+	}
+
+	return ch.Name(), nil
+}
+
+// OutputChannelItem returns the output channel with the given name, as
+// returned by OutputChannelName.
+//
+// OutputChannelItem is the getter for the read-only IviDCPwrBase Attribute
+// Output Channel Item described in Section 4.2.8 of IVI-4.4: IviDCPwr Class
+// Specification.
+func (d *Driver) OutputChannelItem(name string) (dcpwr.BaseChannel, error) {
+	for i := range d.channels {
+		if d.channels[i].Name() == name {
+			return &d.channels[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"OutputChannelItem: %q: %w", name, ivi.ErrChannelNotFound,
+	)
+}
+
 // Name returns the channel's symbolic name (e.g., "CH1", "Range1").
 func (ch *Channel) Name() string {
 	return ch.name
@@ -34,8 +68,8 @@ func (ch *Channel) Name() string {
 // CurrentLimit implements the getter for the read-write IviDCPwrBase Attribute
 // Current Limit described in Section 4.2.1 of IVI-4.4: IviDCPwr Class
 // Specification.
-func (ch *Channel) CurrentLimit() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) CurrentLimit(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64f(ctx, ch.inst, ":SOUR%d:CURR?", ch.idx)
@@ -46,8 +80,8 @@ func (ch *Channel) CurrentLimit() (float64, error) {
 // SetCurrentLimit implements the setter for the read-write IviDCPwrBase
 // Attribute Current Limit described in Section 4.2.1 of IVI-4.4: IviDCPwr
 // Class Specification.
-func (ch *Channel) SetCurrentLimit(limit float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetCurrentLimit(ctx context.Context, limit float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, ":SOUR%d:CURR %f", ch.idx, limit)
@@ -61,8 +95,10 @@ func (ch *Channel) SetCurrentLimit(limit float64) error {
 // CurrentLimitBehavior implements the getter for the read-write IviDCPwrBase
 // Attribute Current Limit Behavior described in Section 4.2.2 of IVI-4.4:
 // IviDCPwr Class Specification.
-func (ch *Channel) CurrentLimitBehavior() (dcpwr.CurrentLimitBehavior, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) CurrentLimitBehavior(
+	ctx context.Context,
+) (dcpwr.CurrentLimitBehavior, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	ocpEnabled, err := query.Boolf(ctx, ch.inst, ":OUTP:OCP? %s", ch.name)
@@ -86,11 +122,18 @@ func (ch *Channel) CurrentLimitBehavior() (dcpwr.CurrentLimitBehavior, error) {
 // Attribute Current Limit Behavior described in Section 4.2.2 of IVI-4.4:
 // IviDCPwr Class Specification.
 func (ch *Channel) SetCurrentLimitBehavior(
+	ctx context.Context,
 	behavior dcpwr.CurrentLimitBehavior,
 ) error {
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("SetCurrentLimitBehavior: %w", err)
+	}
+	defer unlock()
+
 	switch behavior {
 	case dcpwr.CurrentRegulate:
 		return ch.inst.Command(ctx, ":OUTP:OCP %s,OFF", ch.name)
@@ -107,8 +150,8 @@ func (ch *Channel) SetCurrentLimitBehavior(
 //
 // OutputEnabled is the getter for the read-write IviDCPwrBase Attribute Output
 // Enabled described in Section 4.2.3 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) OutputEnabled() (bool, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) OutputEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Boolf(ctx, ch.inst, ":OUTP? %s", ch.name)
@@ -119,8 +162,8 @@ func (ch *Channel) OutputEnabled() (bool, error) {
 // SetOutputEnabled is the setter for the read-write IviDCPwrBase Attribute
 // Output Enabled described in Section 4.2.3 of IVI-4.4: IviDCPwr Class
 // Specification.
-func (ch *Channel) SetOutputEnabled(v bool) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetOutputEnabled(ctx context.Context, v bool) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	if v {
@@ -132,14 +175,14 @@ func (ch *Channel) SetOutputEnabled(v bool) error {
 
 // DisableOutput is a convenience function for setting the Output Enabled
 // attribute to false.
-func (ch *Channel) DisableOutput() error {
-	return ch.SetOutputEnabled(false)
+func (ch *Channel) DisableOutput(ctx context.Context) error {
+	return ch.SetOutputEnabled(ctx, false)
 }
 
 // EnableOutput is a convenience function for setting the Output Enabled
 // attribute to true.
-func (ch *Channel) EnableOutput() error {
-	return ch.SetOutputEnabled(true)
+func (ch *Channel) EnableOutput(ctx context.Context) error {
+	return ch.SetOutputEnabled(ctx, true)
 }
 
 // OVPEnabled determines whether Over-Voltage Protection (OVP) is enabled on
@@ -147,8 +190,8 @@ func (ch *Channel) EnableOutput() error {
 //
 // OVPEnabled is the getter for the read-write IviDCPwrBase Attribute OVP
 // Enabled described in Section 4.2.4 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) OVPEnabled() (bool, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) OVPEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Boolf(ctx, ch.inst, ":OUTP:OVP? %s", ch.name)
@@ -159,10 +202,16 @@ func (ch *Channel) OVPEnabled() (bool, error) {
 //
 // SetOVPEnabled is the setter for the read-write IviDCPwrBase Attribute OVP
 // Enabled described in Section 4.2.4 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) SetOVPEnabled(v bool) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetOVPEnabled(ctx context.Context, v bool) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("SetOVPEnabled: %w", err)
+	}
+	defer unlock()
+
 	if v {
 		return ch.inst.Command(ctx, ":OUTP:OVP %s,ON", ch.name)
 	}
@@ -172,22 +221,22 @@ func (ch *Channel) SetOVPEnabled(v bool) error {
 
 // DisableOVP is a convenience function for disabling Over-Voltage Protection
 // (OVP).
-func (ch *Channel) DisableOVP() error {
-	return ch.SetOVPEnabled(false)
+func (ch *Channel) DisableOVP(ctx context.Context) error {
+	return ch.SetOVPEnabled(ctx, false)
 }
 
 // EnableOVP is a convenience function for enabling Over-Voltage Protection
 // (OVP).
-func (ch *Channel) EnableOVP() error {
-	return ch.SetOVPEnabled(true)
+func (ch *Channel) EnableOVP(ctx context.Context) error {
+	return ch.SetOVPEnabled(ctx, true)
 }
 
 // OVPLimit returns the Over-Voltage Protection (OVP) value in Volts.
 //
 // OVPLimit is the getter for the read-write IviDCPwrBase Attribute OVP Limit
 // described in Section 4.2.5 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) OVPLimit() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) OVPLimit(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64f(ctx, ch.inst, ":OUTP:OVP:VAL? %s", ch.name)
@@ -197,8 +246,8 @@ func (ch *Channel) OVPLimit() (float64, error) {
 //
 // SetOVPLimit is the setter for the read-write IviDCPwrBase Attribute OVP
 // Limit described in Section 4.2.5 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) SetOVPLimit(limit float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetOVPLimit(ctx context.Context, limit float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, ":OUTP:OVP:VAL %s,%f", ch.name, limit)
@@ -209,8 +258,8 @@ func (ch *Channel) SetOVPLimit(limit float64) error {
 //
 // VoltageLevel is the getter for the read-write IviDCPwrBase Attribute Voltage
 // Level described in Section 4.2.6 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) VoltageLevel() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) VoltageLevel(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64f(ctx, ch.inst, ":SOUR%d:VOLT?", ch.idx)
@@ -222,8 +271,8 @@ func (ch *Channel) VoltageLevel() (float64, error) {
 // SetVoltageLevel is the setter for the read-write IviDCPwrBase Attribute
 // Voltage Level described in Section 4.2.6 of IVI-4.4: IviDCPwr Class
 // Specification.
-func (ch *Channel) SetVoltageLevel(level float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetVoltageLevel(ctx context.Context, level float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, ":SOUR%d:VOLT %f", ch.idx, level)
@@ -236,14 +285,21 @@ func (ch *Channel) SetVoltageLevel(level float64) error {
 // ConfigureCurrentLimit implements the IviDCPwrBase function described in
 // Section 4.3.1 of IVI-4.4: IviDCPwr Class Specification.
 func (ch *Channel) ConfigureCurrentLimit(
+	ctx context.Context,
 	behavior dcpwr.CurrentLimitBehavior,
 	limit float64,
 ) error {
-	if err := ch.SetCurrentLimit(limit); err != nil {
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureCurrentLimit: %w", err)
+	}
+	defer unlock()
+
+	if err := ch.SetCurrentLimit(ctx, limit); err != nil {
 		return err
 	}
 
-	return ch.SetCurrentLimitBehavior(behavior)
+	return ch.SetCurrentLimitBehavior(ctx, behavior)
 }
 
 // ConfigureOutputRange configures either the power supply's output voltage or
@@ -254,6 +310,7 @@ func (ch *Channel) ConfigureCurrentLimit(
 // ConfigureOutputRange implements the IviDCPwrBase function described in
 // Section 4.3.3 of IVI-4.4: IviDCPwr Class Specification.
 func (ch *Channel) ConfigureOutputRange(
+	ctx context.Context,
 	rt dcpwr.RangeType,
 	rng float64,
 ) error {
@@ -268,13 +325,23 @@ func (ch *Channel) ConfigureOutputRange(
 //
 // ConfigureOVP implements the IviDCPwrBase function described in Section 4.3.4
 // of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) ConfigureOVP(enabled bool, limit float64) error {
-	if err := ch.SetOVPEnabled(enabled); err != nil {
+func (ch *Channel) ConfigureOVP(
+	ctx context.Context,
+	enabled bool,
+	limit float64,
+) error {
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureOVP: %w", err)
+	}
+	defer unlock()
+
+	if err := ch.SetOVPEnabled(ctx, enabled); err != nil {
 		return err
 	}
 
 	if enabled {
-		return ch.SetOVPLimit(limit)
+		return ch.SetOVPLimit(ctx, limit)
 	}
 
 	return nil
@@ -286,6 +353,7 @@ func (ch *Channel) ConfigureOVP(enabled bool, limit float64) error {
 // QueryCurrentLimitMax implements the IviDCPwrBase function described in
 // Section 4.3.7 of IVI-4.4: IviDCPwr Class Specification.
 func (ch *Channel) QueryCurrentLimitMax(
+	ctx context.Context,
 	voltage float64,
 ) (float64, error) {
 	return ch.maxCurrent, nil
@@ -297,6 +365,7 @@ func (ch *Channel) QueryCurrentLimitMax(
 // QueryVoltageLevelMax implements the IviDCPwrBase function described in
 // Section 4.3.8 of IVI-4.4: IviDCPwr Class Specification.
 func (ch *Channel) QueryVoltageLevelMax(
+	ctx context.Context,
 	currentLimit float64,
 ) (float64, error) {
 	return ch.maxVoltage, nil
@@ -308,11 +377,18 @@ func (ch *Channel) QueryVoltageLevelMax(
 // QueryOutputState implements the IviDCPwrBase function described in Section
 // 4.3.9 of IVI-4.4: IviDCPwr Class Specification.
 func (ch *Channel) QueryOutputState(
+	ctx context.Context,
 	os dcpwr.OutputState,
 ) (bool, error) {
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return false, fmt.Errorf("QueryOutputState: %w", err)
+	}
+	defer unlock()
+
 	switch os {
 	case dcpwr.ConstantVoltage:
 		mode, err := query.Stringf(ctx, ch.inst, ":OUTP:CVCC? %s", ch.name)
@@ -365,10 +441,16 @@ func (ch *Channel) QueryOutputState(
 //
 // ResetOutputProtection implements the IviDCPwrBase function described in
 // Section 4.3.10 of IVI-4.4: IviDCPwr Class Specification.
-func (ch *Channel) ResetOutputProtection() error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) ResetOutputProtection(ctx context.Context) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("ResetOutputProtection: %w", err)
+	}
+	defer unlock()
+
 	if err := ch.inst.Command(ctx, ":OUTP:OVP:CLEAR %s", ch.name); err != nil {
 		return fmt.Errorf("ResetOutputProtection (OVP): %w", err)
 	}
@@ -52,7 +52,7 @@ func TestChannel_Name(t *testing.T) {
 func TestChannel_SetCurrentLimit(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := Channel{name: "CH1", idx: 1, inst: mock}
-	err := ch.SetCurrentLimit(0.5)
+	err := ch.SetCurrentLimit(t.Context(), 0.5)
 	if err != nil {
 		t.Errorf("SetCurrentLimit() error: %v", err)
 	}
@@ -64,7 +64,7 @@ func TestChannel_SetCurrentLimit(t *testing.T) {
 func TestChannel_SetVoltageLevel(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := Channel{name: "CH1", idx: 1, inst: mock}
-	err := ch.SetVoltageLevel(5.0)
+	err := ch.SetVoltageLevel(t.Context(), 5.0)
 	if err != nil {
 		t.Errorf("SetVoltageLevel() error: %v", err)
 	}
@@ -87,7 +87,7 @@ func TestChannel_SetOutputEnabled(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			ch := Channel{name: "CH1", idx: 1, inst: mock}
-			err := ch.SetOutputEnabled(tt.enabled)
+			err := ch.SetOutputEnabled(t.Context(), tt.enabled)
 			if err != nil {
 				t.Errorf("SetOutputEnabled() error: %v", err)
 			}
@@ -114,7 +114,7 @@ func TestChannel_SetCurrentLimitBehavior(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			ch := Channel{name: "CH1", idx: 1, inst: mock}
-			err := ch.SetCurrentLimitBehavior(tt.behavior)
+			err := ch.SetCurrentLimitBehavior(t.Context(), tt.behavior)
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -145,7 +145,7 @@ func TestChannel_SetOVPEnabled(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			ch := Channel{name: "CH1", idx: 1, inst: mock}
-			err := ch.SetOVPEnabled(tt.enabled)
+			err := ch.SetOVPEnabled(t.Context(), tt.enabled)
 			if err != nil {
 				t.Errorf("SetOVPEnabled() error: %v", err)
 			}
@@ -159,7 +159,7 @@ func TestChannel_SetOVPEnabled(t *testing.T) {
 func TestChannel_SetOVPLimit(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := Channel{name: "CH1", idx: 1, inst: mock}
-	err := ch.SetOVPLimit(10.0)
+	err := ch.SetOVPLimit(t.Context(), 10.0)
 	if err != nil {
 		t.Errorf("SetOVPLimit() error: %v", err)
 	}
@@ -174,7 +174,7 @@ func TestChannel_QueryCurrentLimitMax(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Channel(0) error: %v", err)
 	}
-	got, err := ch.QueryCurrentLimitMax(5.0)
+	got, err := ch.QueryCurrentLimitMax(t.Context(), 5.0)
 	if err != nil {
 		t.Errorf("QueryCurrentLimitMax() error: %v", err)
 	}
@@ -189,7 +189,7 @@ func TestChannel_QueryVoltageLevelMax(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Channel(0) error: %v", err)
 	}
-	got, err := ch.QueryVoltageLevelMax(1.0)
+	got, err := ch.QueryVoltageLevelMax(t.Context(), 1.0)
 	if err != nil {
 		t.Errorf("QueryVoltageLevelMax() error: %v", err)
 	}
@@ -201,7 +201,7 @@ func TestChannel_QueryVoltageLevelMax(t *testing.T) {
 func TestChannel_ConfigureOutputRange_NoOp(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := Channel{name: "CH1", idx: 1, inst: mock}
-	err := ch.ConfigureOutputRange(dcpwr.VoltageRange, 10.0)
+	err := ch.ConfigureOutputRange(t.Context(), dcpwr.VoltageRange, 10.0)
 	if err != nil {
 		t.Errorf("ConfigureOutputRange() error: %v", err)
 	}
@@ -213,7 +213,7 @@ func TestChannel_ConfigureOutputRange_NoOp(t *testing.T) {
 func TestChannel_ResetOutputProtection(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := Channel{name: "CH1", idx: 1, inst: mock}
-	err := ch.ResetOutputProtection()
+	err := ch.ResetOutputProtection(t.Context())
 	if err != nil {
 		t.Errorf("ResetOutputProtection() error: %v", err)
 	}
@@ -243,7 +243,7 @@ func TestChannel_ConfigureOVP(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			ch := Channel{name: "CH1", idx: 1, inst: mock}
-			err := ch.ConfigureOVP(tt.enabled, tt.limit)
+			err := ch.ConfigureOVP(t.Context(), tt.enabled, tt.limit)
 			if err != nil {
 				t.Errorf("ConfigureOVP() error: %v", err)
 			}
@@ -257,7 +257,7 @@ func TestChannel_ConfigureOVP(t *testing.T) {
 func TestChannel_DisableOutput(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := Channel{name: "CH1", idx: 1, inst: mock}
-	err := ch.DisableOutput()
+	err := ch.DisableOutput(t.Context())
 	if err != nil {
 		t.Errorf("DisableOutput() error: %v", err)
 	}
@@ -269,7 +269,7 @@ func TestChannel_DisableOutput(t *testing.T) {
 func TestChannel_EnableOutput(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := Channel{name: "CH1", idx: 1, inst: mock}
-	err := ch.EnableOutput()
+	err := ch.EnableOutput(t.Context())
 	if err != nil {
 		t.Errorf("EnableOutput() error: %v", err)
 	}
@@ -281,7 +281,7 @@ func TestChannel_EnableOutput(t *testing.T) {
 func TestChannel_DisableOVP(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := Channel{name: "CH1", idx: 1, inst: mock}
-	err := ch.DisableOVP()
+	err := ch.DisableOVP(t.Context())
 	if err != nil {
 		t.Errorf("DisableOVP() error: %v", err)
 	}
@@ -293,7 +293,7 @@ func TestChannel_DisableOVP(t *testing.T) {
 func TestChannel_EnableOVP(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := Channel{name: "CH1", idx: 1, inst: mock}
-	err := ch.EnableOVP()
+	err := ch.EnableOVP(t.Context())
 	if err != nil {
 		t.Errorf("EnableOVP() error: %v", err)
 	}
@@ -305,7 +305,7 @@ func TestChannel_EnableOVP(t *testing.T) {
 func TestChannel_ConfigureCurrentLimit(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := Channel{name: "CH1", idx: 1, inst: mock}
-	err := ch.ConfigureCurrentLimit(dcpwr.CurrentRegulate, 0.5)
+	err := ch.ConfigureCurrentLimit(t.Context(), dcpwr.CurrentRegulate, 0.5)
 	if err != nil {
 		t.Errorf("ConfigureCurrentLimit() error: %v", err)
 	}
@@ -0,0 +1,54 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package dp800
+
+import "github.com/gotmc/ivi"
+
+// simulation describes the instrument simulated under [ivi.WithSimulate].
+// The output ratings of the A models bound the voltage and current settings,
+// and measurements report the programmed voltage of the output measured.
+var simulation = ivi.Simulation{
+	Manufacturer: "RIGOL TECHNOLOGIES",
+	Attributes: map[string]ivi.SimulatedAttribute{
+		"MEAS CH1":      {Follows: "SOUR1:VOLT"},
+		"MEAS CH2":      {Follows: "SOUR2:VOLT"},
+		"MEAS CH3":      {Follows: "SOUR3:VOLT"},
+		"OUTP:CVCC":     {Default: "CV"},
+		"OUTP:OVP:VAL":  {Default: "33"},
+		"OUTP:OVP:QUES": {Default: "NO"},
+		"OUTP:OCP:QUES": {Default: "NO"},
+	},
+	ModelAttributes: map[string]map[string]ivi.SimulatedAttribute{
+		"DP831A": {
+			"SOUR1:VOLT": {Min: 0, Max: 8.4},
+			"SOUR1:CURR": {Default: "5", Min: 0, Max: 5.3},
+			"SOUR2:VOLT": {Min: 0, Max: 32},
+			"SOUR2:CURR": {Default: "2", Min: 0, Max: 2.1},
+			"SOUR3:VOLT": {Min: -32, Max: 0},
+			"SOUR3:CURR": {Default: "2", Min: 0, Max: 2.1},
+		},
+		"DP832A": {
+			"SOUR1:VOLT": {Min: 0, Max: 32},
+			"SOUR1:CURR": {Default: "3", Min: 0, Max: 3.2},
+			"SOUR2:VOLT": {Min: 0, Max: 32},
+			"SOUR2:CURR": {Default: "3", Min: 0, Max: 3.2},
+			"SOUR3:VOLT": {Min: 0, Max: 5.3},
+			"SOUR3:CURR": {Default: "3", Min: 0, Max: 3.2},
+		},
+		"DP821A": {
+			"SOUR1:VOLT": {Min: 0, Max: 63},
+			"SOUR1:CURR": {Default: "1", Min: 0, Max: 1.05},
+			"SOUR2:VOLT": {Min: 0, Max: 8.4},
+			"SOUR2:CURR": {Default: "10", Min: 0, Max: 10.5},
+		},
+		"DP811A": {
+			"SOUR1:VOLT": {Min: 0, Max: 21},
+			"SOUR1:CURR": {Default: "10", Min: 0, Max: 10.5},
+			"SOUR2:VOLT": {Min: 0, Max: 42},
+			"SOUR2:CURR": {Default: "5", Min: 0, Max: 5.3},
+		},
+	},
+}
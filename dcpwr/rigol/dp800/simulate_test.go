@@ -0,0 +1,44 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package dp800
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gotmc/ivi"
+)
+
+func TestSimulate(t *testing.T) {
+	ctx := t.Context()
+
+	d, err := New(nil, ivi.WithSimulate(), ivi.WithSimulatedModel("DP832A"))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	ch1, _ := d.Channel(0)
+	ch3, _ := d.Channel(2)
+	if err := ch1.SetVoltageLevel(ctx, 24.0); err != nil {
+		t.Fatalf("SetVoltageLevel() error: %v", err)
+	}
+	if err := ch3.SetVoltageLevel(ctx, 3.3); err != nil {
+		t.Fatalf("SetVoltageLevel() error: %v", err)
+	}
+
+	if got, err := ch1.MeasureVoltage(ctx); err != nil || got != 24.0 {
+		t.Errorf("CH1 MeasureVoltage() = %v, %v, want 24", got, err)
+	}
+	if got, err := ch3.VoltageLevel(ctx); err != nil || got != 3.3 {
+		t.Errorf("CH3 VoltageLevel() = %v, %v, want 3.3", got, err)
+	}
+
+	err = ch3.SetVoltageLevel(ctx, 12.0)
+	if !errors.Is(err, ivi.ErrValueNotSupported) {
+		t.Errorf("SetVoltageLevel(12) on CH3 error = %v, want %v",
+			err, ivi.ErrValueNotSupported)
+	}
+}
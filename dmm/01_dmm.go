@@ -10,3 +10,67 @@
 // Files are split based on the class capability groups listed in Table 2-1
 // IviDmm Group Names in the IVI-4.2 IviDmm Class Specification.
 package dmm
+
+import (
+	"reflect"
+
+	"github.com/gotmc/ivi"
+)
+
+// Groups lists the capability groups of the IviDmm class, with the Go
+// interfaces that implement each, for [ivi.Introspect] and
+// [ivi.CheckGroupCapabilities]. IviDmmAutoRangeValue has no interface yet, so
+// it is never reported as implemented.
+var Groups = []ivi.CapabilityGroup{
+	{Name: "IviDmmBase", Driver: reflect.TypeFor[Base]()},
+	{
+		Name:   "IviDmmACMeasurement",
+		Driver: reflect.TypeFor[ACMeasurementExtension](),
+	},
+	{
+		Name:   "IviDmmFrequencyMeasurement",
+		Driver: reflect.TypeFor[FrequencyMeasurementExtension](),
+	},
+	{
+		Name:   "IviDmmTemperatureMeasurement",
+		Driver: reflect.TypeFor[TemperatureMeasurementExtension](),
+	},
+	{
+		Name:   "IviDmmThermocouple",
+		Driver: reflect.TypeFor[ThermocoupleExtension](),
+	},
+	{
+		Name:   "IviDmmResistanceTemperatureDevice",
+		Driver: reflect.TypeFor[RTDExtension](),
+	},
+	{Name: "IviDmmThermistor", Driver: reflect.TypeFor[ThermistorExtension]()},
+	{Name: "IviDmmMultiPoint", Driver: reflect.TypeFor[MultiPointExtension]()},
+	{
+		Name:   "IviDmmTriggerSlope",
+		Driver: reflect.TypeFor[TriggerSlopeExtension](),
+	},
+	{
+		Name:   "IviDmmSoftwareTrigger",
+		Driver: reflect.TypeFor[SoftwareTriggerExtension](),
+	},
+	{Name: "IviDmmDeviceInfo", Driver: reflect.TypeFor[DeviceInfoExtension]()},
+	{Name: "IviDmmAutoRangeValue"},
+	{Name: "IviDmmAutoZero", Driver: reflect.TypeFor[AutoZeroExtension]()},
+	{
+		Name:   "IviDmmPowerLineFrequency",
+		Driver: reflect.TypeFor[PowerLineFrequencyExtension](),
+	},
+}
+
+// Drivers holds the IviDmm drivers, by the instrument models they support. Each
+// driver registers itself from an init function, so importing a driver package,
+// if only for its side effects, makes it available to Drivers.Open, which picks
+// the driver for the connected instrument's model, and to Drivers.OpenDriver,
+// which creates a driver by name. Both return the driver as a Base; assert it
+// to other IviDmm interfaces, or to the driver's own type, for the capabilities
+// beyond the base group. See [ivi.Registry].
+//
+//	import _ "github.com/gotmc/ivi/dmm/keysight/kt34400"
+//
+//	meter, err := dmm.Drivers.Open(ctx, inst)
+var Drivers ivi.Registry[Base]
@@ -5,6 +5,8 @@
 
 package dmm
 
+import "context"
+
 /*
 
 # Section 6 IviDmmFrequencyMeasurement Extension Group
@@ -36,6 +38,12 @@ None.
 // IviDmmFrequencyMeasurement extension group described in Section 6 of IVI-4.2
 // IviDmm Class Specification.
 type FrequencyMeasurementExtension interface {
-	FrequencyVoltageRange() (autoRange bool, rangeValue float64, err error)
-	SetFrequencyVoltageRange(autoRange bool, rangeValue float64) error
+	FrequencyVoltageRange(
+		ctx context.Context,
+	) (autoRange bool, rangeValue float64, err error)
+	SetFrequencyVoltageRange(
+		ctx context.Context,
+		autoRange bool,
+		rangeValue float64,
+	) error
 }
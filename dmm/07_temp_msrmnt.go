@@ -5,6 +5,8 @@
 
 package dmm
 
+import "context"
+
 /*
 
 # Section 7 IviDmmTemperatureMeasurement Extension Group
@@ -37,6 +39,9 @@ None.
 // IviDmmTemperatureMeasurement extension group described in Section 7 of
 // IVI-4.2 IviDmm Class Specification.
 type TemperatureMeasurementExtension interface {
-	TemperatureTransducerType() (TempTransducerType, error)
-	SetTemperatureTransducerType(t TempTransducerType) error
+	TemperatureTransducerType(ctx context.Context) (TempTransducerType, error)
+	SetTemperatureTransducerType(
+		ctx context.Context,
+		t TempTransducerType,
+	) error
 }
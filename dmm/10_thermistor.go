@@ -5,6 +5,8 @@
 
 package dmm
 
+import "context"
+
 /*
 
 # Section 10 IviDmmThermistor Extension Group
@@ -38,6 +40,6 @@ None.
 // extension group described in Section 10 of IVI-4.2 IviDmm Class
 // Specification.
 type ThermistorExtension interface {
-	ThermistorResistance() (float64, error)
-	SetThermistorResistance(resistance float64) error
+	ThermistorResistance(ctx context.Context) (float64, error)
+	SetThermistorResistance(ctx context.Context, resistance float64) error
 }
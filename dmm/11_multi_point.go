@@ -6,6 +6,7 @@
 package dmm
 
 import (
+	"context"
 	"time"
 )
 
@@ -61,17 +62,23 @@ Below are the .NET functions, since they are the basis for the Go interfaces.
 // extension group described in Section 11 of IVI-4.2 IviDmm Class
 // Specification.
 type MultiPointExtension interface {
-	MeasureCompleteDestination() (MeasurementDestination, error)
-	SetMeasureCompleteDestination(dest MeasurementDestination) error
-	SampleCount() (int, error)
-	SetSampleCount(count int) error
-	SampleInterval() (time.Duration, error)
-	SetSampleInterval(interval time.Duration) error
-	SampleTrigger() (TriggerSource, error)
-	SetSampleTrigger(triggerSource TriggerSource) error
-	TriggerCount() (int, error)
-	SetTriggerCount(count int) error
+	MeasureCompleteDestination(
+		ctx context.Context,
+	) (MeasurementDestination, error)
+	SetMeasureCompleteDestination(
+		ctx context.Context,
+		dest MeasurementDestination,
+	) error
+	SampleCount(ctx context.Context) (int, error)
+	SetSampleCount(ctx context.Context, count int) error
+	SampleInterval(ctx context.Context) (time.Duration, error)
+	SetSampleInterval(ctx context.Context, interval time.Duration) error
+	SampleTrigger(ctx context.Context) (TriggerSource, error)
+	SetSampleTrigger(ctx context.Context, triggerSource TriggerSource) error
+	TriggerCount(ctx context.Context) (int, error)
+	SetTriggerCount(ctx context.Context, count int) error
 	ConfigureMultiPoint(
+		ctx context.Context,
 		triggerCount, sampleCount int,
 		triggerSource TriggerSource,
 		interval time.Duration,
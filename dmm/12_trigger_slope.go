@@ -5,6 +5,8 @@
 
 package dmm
 
+import "context"
+
 /*
 
 # Section 12 IviDmmTriggerSlope Extension Group
@@ -54,6 +56,6 @@ None.
 // extension group described in Section 12 of IVI-4.2 IviDmm Class
 // Specification.
 type TriggerSlopeExtension interface {
-	TriggerSlope() (TriggerSlope, error)
-	SetTriggerSlope(slope TriggerSlope) error
+	TriggerSlope(ctx context.Context) (TriggerSlope, error)
+	SetTriggerSlope(ctx context.Context, slope TriggerSlope) error
 }
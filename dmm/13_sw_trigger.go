@@ -5,6 +5,8 @@
 
 package dmm
 
+import "context"
+
 /*
 
 # Section 13 IviDmmSoftwareTrigger Extension Group
@@ -27,5 +29,5 @@ Below are the .NET functions, since they are the basis for the Go interfaces.
 // IviDmmSoftwareTrigger extension group described in Section 13 of IVI-4.2
 // IviDmm Class Specification.
 type SoftwareTriggerExtension interface {
-	SendSoftwareTrigger() error
+	SendSoftwareTrigger(ctx context.Context) error
 }
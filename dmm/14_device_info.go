@@ -5,6 +5,8 @@
 
 package dmm
 
+import "context"
+
 /*
 
 # Section 14 IviDmmDeviceInfo Extension Group
@@ -36,6 +38,6 @@ None.
 // extension group described in Section 14 of IVI-4.2 IviDmm Class
 // Specification.
 type DeviceInfoExtension interface {
-	ApertureTime() (float64, error)
-	ApertureTimeUnits() (ApertureTimeUnits, error)
+	ApertureTime(ctx context.Context) (float64, error)
+	ApertureTimeUnits(ctx context.Context) (ApertureTimeUnits, error)
 }
@@ -5,6 +5,8 @@
 
 package dmm
 
+import "context"
+
 /*
 
 # Section 16 IviDmmAutoZero Extension Group
@@ -35,6 +37,6 @@ None.
 // extension group described in Section 16 of IVI-4.2 IviDmm Class
 // Specification.
 type AutoZeroExtension interface {
-	AutoZero() (AutoZero, error)
-	SetAutoZero(autoZero AutoZero) error
+	AutoZero(ctx context.Context) (AutoZero, error)
+	SetAutoZero(ctx context.Context, autoZero AutoZero) error
 }
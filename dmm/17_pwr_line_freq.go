@@ -5,6 +5,8 @@
 
 package dmm
 
+import "context"
+
 /*
 
 # Section 17 IviDmmPowerLineFrequency Extension Group
@@ -56,6 +58,6 @@ None.
 // IviDmmPowerLineFrequency extension group described in Section 17 of
 // IVI-4.2 IviDmm Class Specification.
 type PowerLineFrequencyExtension interface {
-	PowerLineFrequency() (float64, error)
-	SetPowerLineFrequency(freq float64) error
+	PowerLineFrequency(ctx context.Context) (float64, error)
+	SetPowerLineFrequency(ctx context.Context, freq float64) error
 }
@@ -25,6 +25,13 @@ const (
 // Confirm the interfaces implemented by the driver.
 var _ dmm.Base = (*Driver)(nil)
 
+// Register the driver with [dmm.Drivers] so that Open can select it by model.
+func init() {
+	dmm.Drivers.Register(
+		"fluke45", supportedModels(), ivi.Factory[dmm.Base](New),
+	)
+}
+
 // Driver provides the IVI driver for the Fluke 45 DMM.
 type Driver struct {
 	inst    ivi.Transport
@@ -43,28 +50,31 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		ClassSpecRevision:     specRevision,
 		ResetDelay:            500 * time.Millisecond,
 		ClearDelay:            500 * time.Millisecond,
+		SelfTestTimeout:       30 * time.Second, // *TST? runs a full self test
 		ReturnToLocal:         true,
+		NoErrorQueue:          true, // not SCPI, so there is no SYST:ERR?
 		GroupCapabilities: []string{
 			"IviDmmBase",
-			"IviDmmACMeasurement",
-			"IviDmmFrequencyMeasurement",
-			"IviDmmDeviceInfo",
+			// "IviDmmACMeasurement",
+			// "IviDmmFrequencyMeasurement",
+			// "IviDmmDeviceInfo",
 		},
-		SupportedInstrumentModels: []string{"45"},
+		SupportedInstrumentModels: supportedModels(),
 		SupportedBusInterfaces:    []string{"GPIB", "Serial"},
+		Simulation:                &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	driver := Driver{
-		inst:     inst,
+		inst:     s.Transport,
 		timeout:  s.Timeout,
 		Inherent: s.Inherent,
 	}
 
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
+		if err := driver.Reset(context.Background()); err != nil {
 			return nil, err
 		}
 	}
@@ -72,9 +82,17 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	return &driver, nil
 }
 
-// newContext creates a context with the driver's configured timeout.
-func (d *Driver) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), d.timeout)
+// supportedModels returns the model numbers the driver supports.
+func supportedModels() []string {
+	return []string{"45"}
+}
+
+// newContext derives a context from ctx that carries the driver's configured
+// timeout, unless ctx already has a deadline of its own.
+func (d *Driver) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, d.timeout)
 }
 
 // Close properly shuts down the DMM by returning it to local control.
@@ -6,6 +6,7 @@
 package fluke45
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -19,8 +20,10 @@ import (
 //
 // MeasurementFunction is the getter for the read-write IviDmmBase Attribute
 // Function described in Section 4.2.1 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) MeasurementFunction() (dmm.MeasurementFunction, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) MeasurementFunction(
+	ctx context.Context,
+) (dmm.MeasurementFunction, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	fcn, err := query.String(ctx, d.inst, "FUNC1?")
@@ -41,9 +44,10 @@ func (d *Driver) MeasurementFunction() (dmm.MeasurementFunction, error) {
 // SetMeasurementFunction is the setter for the read-write IviDmmBase Attribute
 // Function described in Section 4.2.1 of IVI-4.2: IviDmm Class Specification.
 func (d *Driver) SetMeasurementFunction(
+	ctx context.Context,
 	msrFunc dmm.MeasurementFunction,
 ) error {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	scpiCmd, err := ivi.LookupSCPI(msrFuncToCmd, msrFunc)
@@ -90,10 +94,16 @@ func (d *Driver) SetMeasurementFunction(
 //
 // Range is the getter for the read-write IviDmmBase Attribute Range described
 // in Section 4.2.2 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) Range() (dmm.AutoRange, float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) Range(ctx context.Context) (dmm.AutoRange, float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return 0, 0.0, fmt.Errorf("Range: %w", err)
+	}
+	defer unlock()
+
 	isAutoRange, err := query.Bool(ctx, d.inst, "AUTO?")
 	if err != nil {
 		return 0, 0.0, err
@@ -120,10 +130,20 @@ func (d *Driver) Range() (dmm.AutoRange, float64, error) {
 //
 // SetRange is the setter for the read-write IviDmmBase Attribute
 // Range described in Section 4.2.2 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) SetRange(autoRange dmm.AutoRange, rangeValue float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetRange(
+	ctx context.Context,
+	autoRange dmm.AutoRange,
+	rangeValue float64,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetRange: %w", err)
+	}
+	defer unlock()
+
 	// Set the range to auto if appropriate.
 	if autoRange == dmm.AutoOn {
 		return d.inst.Command(ctx, "auto")
@@ -140,7 +160,7 @@ func (d *Driver) SetRange(autoRange dmm.AutoRange, rangeValue float64) error {
 		return err
 	}
 
-	fcn, err := d.MeasurementFunction()
+	fcn, err := d.MeasurementFunction(ctx)
 	if err != nil {
 		return err
 	}
@@ -260,7 +280,7 @@ func determineRangeCommand(
 // ResolutionAbsolute is the getter for the read-write IviDmmBase Attribute
 // Resolution Absolute described in Section 4.2.3 of IVI-4.2: IviDmm Class
 // Specification.
-func (d *Driver) ResolutionAbsolute() (float64, error) {
+func (d *Driver) ResolutionAbsolute(ctx context.Context) (float64, error) {
 	return 0.0, fmt.Errorf(
 		"ResolutionAbsolute: %w", ivi.ErrFunctionNotSupported,
 	)
@@ -269,6 +289,7 @@ func (d *Driver) ResolutionAbsolute() (float64, error) {
 // SetResolutionAbsolute is not directly supported on the Fluke 45. Resolution
 // is controlled by the measurement rate (RATE S/M/F).
 func (d *Driver) SetResolutionAbsolute(
+	ctx context.Context,
 	_ float64,
 ) error {
 	return fmt.Errorf(
@@ -282,8 +303,10 @@ func (d *Driver) SetResolutionAbsolute(
 //
 // TriggerDelay is the getter for the read-write IviDmmBase Attribute Trigger
 // Delay described in Section 4.2.5 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) TriggerDelay() (bool, time.Duration, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TriggerDelay(
+	ctx context.Context,
+) (bool, time.Duration, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	trigType, err := query.Int(ctx, d.inst, "TRIGGER?")
@@ -306,12 +329,19 @@ func (d *Driver) TriggerDelay() (bool, time.Duration, error) {
 // Trigger Delay described in Section 4.2.5 of IVI-4.2: IviDmm Class
 // Specification.
 func (d *Driver) SetTriggerDelay(
+	ctx context.Context,
 	autoDelay bool,
 	_ time.Duration,
 ) error {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetTriggerDelay: %w", err)
+	}
+	defer unlock()
+
 	// Get current trigger type to determine if internal or external.
 	trigType, err := query.Int(ctx, d.inst, "TRIGGER?")
 	if err != nil {
@@ -345,8 +375,8 @@ func (d *Driver) SetTriggerDelay(
 //
 // TriggerSource is the getter for the read-write IviDmmBase Attribute Trigger
 // Source described in Section 4.2.6 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) TriggerSource() (dmm.TriggerSource, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TriggerSource(ctx context.Context) (dmm.TriggerSource, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	trigType, err := query.Int(ctx, d.inst, "TRIGGER?")
@@ -368,11 +398,18 @@ func (d *Driver) TriggerSource() (dmm.TriggerSource, error) {
 // Trigger Source described in Section 4.2.6 of IVI-4.2: IviDmm Class
 // Specification.
 func (d *Driver) SetTriggerSource(
+	ctx context.Context,
 	src dmm.TriggerSource,
 ) error {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetTriggerSource: %w", err)
+	}
+	defer unlock()
+
 	switch src {
 	case dmm.TriggerSourceImmediate:
 		return d.inst.Command(ctx, "TRIGGER 1")
@@ -389,7 +426,7 @@ func (d *Driver) SetTriggerSource(
 }
 
 // Abort is not supported on the Fluke 45.
-func (d *Driver) Abort() error {
+func (d *Driver) Abort(ctx context.Context) error {
 	return fmt.Errorf("Abort: %w", ivi.ErrFunctionNotSupported)
 }
 
@@ -398,16 +435,23 @@ func (d *Driver) Abort() error {
 // ConfigureMeasurement implements the IviDmmBase function described in Section
 // 4.3.2 of IVI-4.2: IviDmm Class Specification.
 func (d *Driver) ConfigureMeasurement(
+	ctx context.Context,
 	msrFunc dmm.MeasurementFunction,
 	autoRange dmm.AutoRange,
 	rangeValue float64,
 	_ float64,
 ) error {
-	if err := d.SetMeasurementFunction(msrFunc); err != nil {
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureMeasurement: %w", err)
+	}
+	defer unlock()
+
+	if err := d.SetMeasurementFunction(ctx, msrFunc); err != nil {
 		return err
 	}
 
-	return d.SetRange(autoRange, rangeValue)
+	return d.SetRange(ctx, autoRange, rangeValue)
 }
 
 // ConfigureTrigger configures the trigger source. The Fluke 45 does not
@@ -416,10 +460,11 @@ func (d *Driver) ConfigureMeasurement(
 // ConfigureTrigger implements the IviDmmBase function described in Section
 // 4.3.3 of IVI-4.2: IviDmm Class Specification.
 func (d *Driver) ConfigureTrigger(
+	ctx context.Context,
 	src dmm.TriggerSource,
 	_ time.Duration,
 ) error {
-	return d.SetTriggerSource(src)
+	return d.SetTriggerSource(ctx, src)
 }
 
 // FetchMeasurement returns the value shown on the primary display without
@@ -428,9 +473,10 @@ func (d *Driver) ConfigureTrigger(
 // FetchMeasurement implements the IviDmmBase function described in Section
 // 4.3.4 of IVI-4.2: IviDmm Class Specification.
 func (d *Driver) FetchMeasurement(
+	ctx context.Context,
 	_ time.Duration,
 ) (float64, error) {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "VAL1?")
@@ -442,33 +488,36 @@ func (d *Driver) FetchMeasurement(
 //
 // InitiateMeasurement implements the IviDmmBase function described in Section
 // 4.3.5 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) InitiateMeasurement() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) InitiateMeasurement(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "*TRG")
 }
 
-func (d *Driver) IsOutOfRange(_ float64) (bool, error) {
+func (d *Driver) IsOutOfRange(ctx context.Context, _ float64) (bool, error) {
 	return false, fmt.Errorf(
 		"IsOutOfRange: %w", ivi.ErrFunctionNotSupported,
 	)
 }
 
-func (d *Driver) IsOverRange(_ float64) (bool, error) {
+func (d *Driver) IsOverRange(ctx context.Context, _ float64) (bool, error) {
 	return false, fmt.Errorf(
 		"IsOverRange: %w", ivi.ErrFunctionNotSupported,
 	)
 }
 
-func (d *Driver) IsUnderRange(_ float64) (bool, error) {
+func (d *Driver) IsUnderRange(ctx context.Context, _ float64) (bool, error) {
 	return false, fmt.Errorf(
 		"IsUnderRange: %w", ivi.ErrFunctionNotSupported,
 	)
 }
 
-func (d *Driver) ReadMeasurement(maxTime time.Duration) (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ReadMeasurement(
+	ctx context.Context,
+	maxTime time.Duration,
+) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "meas1?")
@@ -104,7 +104,7 @@ func TestDriver_MeasurementFunction(t *testing.T) {
 				t.Fatalf("New() error: %v", err)
 			}
 
-			got, err := d.MeasurementFunction()
+			got, err := d.MeasurementFunction(t.Context())
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -142,7 +142,7 @@ func TestDriver_SetMeasurementFunction(t *testing.T) {
 				t.Fatalf("New() error: %v", err)
 			}
 
-			err = d.SetMeasurementFunction(tt.fcn)
+			err = d.SetMeasurementFunction(t.Context(), tt.fcn)
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -179,7 +179,7 @@ func TestDriver_TriggerSource(t *testing.T) {
 				t.Fatalf("New() error: %v", err)
 			}
 
-			got, err := d.TriggerSource()
+			got, err := d.TriggerSource(t.Context())
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -217,7 +217,7 @@ func TestDriver_SetTriggerSource(t *testing.T) {
 				t.Fatalf("New() error: %v", err)
 			}
 
-			err = d.SetTriggerSource(tt.src)
+			err = d.SetTriggerSource(t.Context(), tt.src)
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -241,17 +241,34 @@ func TestDriver_ResolutionAbsolute_NotSupported(t *testing.T) {
 		t.Fatalf("New() error: %v", err)
 	}
 
-	_, err = d.ResolutionAbsolute()
+	_, err = d.ResolutionAbsolute(t.Context())
 	if !errors.Is(err, ivi.ErrFunctionNotSupported) {
 		t.Errorf("ResolutionAbsolute() = %v, want ErrFunctionNotSupported", err)
 	}
 
-	err = d.SetResolutionAbsolute(1.0)
+	err = d.SetResolutionAbsolute(t.Context(), 1.0)
 	if !errors.Is(err, ivi.ErrFunctionNotSupported) {
 		t.Errorf("SetResolutionAbsolute() = %v, want ErrFunctionNotSupported", err)
 	}
 }
 
+func TestDriver_ErrorQuery_NotSupported(t *testing.T) {
+	mock := &ivitest.Mock{}
+	d, err := New(mock, ivi.WithoutIDQuery())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	mock.CommandsSent = nil
+
+	_, err = d.ErrorQuery(t.Context())
+	if !errors.Is(err, ivi.ErrFunctionNotSupported) {
+		t.Errorf("ErrorQuery() = %v, want ErrFunctionNotSupported", err)
+	}
+	if len(mock.CommandsSent) != 0 {
+		t.Errorf("sent %q, want no I/O", mock.CommandsSent)
+	}
+}
+
 func TestDriver_Abort_NotSupported(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, err := New(mock, ivi.WithoutIDQuery())
@@ -259,7 +276,7 @@ func TestDriver_Abort_NotSupported(t *testing.T) {
 		t.Fatalf("New() error: %v", err)
 	}
 
-	err = d.Abort()
+	err = d.Abort(t.Context())
 	if !errors.Is(err, ivi.ErrFunctionNotSupported) {
 		t.Errorf("Abort() = %v, want ErrFunctionNotSupported", err)
 	}
@@ -272,7 +289,7 @@ func TestDriver_InitiateMeasurement(t *testing.T) {
 		t.Fatalf("New() error: %v", err)
 	}
 
-	err = d.InitiateMeasurement()
+	err = d.InitiateMeasurement(t.Context())
 	if err != nil {
 		t.Errorf("InitiateMeasurement() error: %v", err)
 	}
@@ -288,17 +305,17 @@ func TestDriver_IsOutOfRange_NotSupported(t *testing.T) {
 		t.Fatalf("New() error: %v", err)
 	}
 
-	_, err = d.IsOutOfRange(1.0)
+	_, err = d.IsOutOfRange(t.Context(), 1.0)
 	if !errors.Is(err, ivi.ErrFunctionNotSupported) {
 		t.Errorf("IsOutOfRange() = %v, want ErrFunctionNotSupported", err)
 	}
 
-	_, err = d.IsOverRange(1.0)
+	_, err = d.IsOverRange(t.Context(), 1.0)
 	if !errors.Is(err, ivi.ErrFunctionNotSupported) {
 		t.Errorf("IsOverRange() = %v, want ErrFunctionNotSupported", err)
 	}
 
-	_, err = d.IsUnderRange(1.0)
+	_, err = d.IsUnderRange(t.Context(), 1.0)
 	if !errors.Is(err, ivi.ErrFunctionNotSupported) {
 		t.Errorf("IsUnderRange() = %v, want ErrFunctionNotSupported", err)
 	}
@@ -326,7 +343,7 @@ func TestDriver_TriggerDelay(t *testing.T) {
 				t.Fatalf("New() error: %v", err)
 			}
 
-			hasDelay, dur, err := d.TriggerDelay()
+			hasDelay, dur, err := d.TriggerDelay(t.Context())
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -353,7 +370,7 @@ func TestDriver_FetchMeasurement(t *testing.T) {
 		t.Fatalf("New() error: %v", err)
 	}
 
-	got, err := d.FetchMeasurement(0)
+	got, err := d.FetchMeasurement(t.Context(), 0)
 	if err != nil {
 		t.Errorf("FetchMeasurement() error: %v", err)
 	}
@@ -369,7 +386,7 @@ func TestDriver_ReadMeasurement(t *testing.T) {
 		t.Fatalf("New() error: %v", err)
 	}
 
-	got, err := d.ReadMeasurement(0)
+	got, err := d.ReadMeasurement(t.Context(), 0)
 	if err != nil {
 		t.Errorf("ReadMeasurement() error: %v", err)
 	}
@@ -0,0 +1,32 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package fluke45
+
+import "github.com/gotmc/ivi"
+
+// simulation describes the instrument simulated under [ivi.WithSimulate]. The
+// Fluke 45 selects its function with a command named for it, which the
+// simulated instrument reports as the primary display function, and starts in
+// DC volts, auto range, slow rate, with internal triggering.
+var simulation = ivi.Simulation{
+	Manufacturer: "FLUKE",
+	Attributes: map[string]ivi.SimulatedAttribute{
+		"FUNC1":   {Default: "VDC"},
+		"VDC":     {Sets: "FUNC1 VDC"},
+		"VAC":     {Sets: "FUNC1 VAC"},
+		"ADC":     {Sets: "FUNC1 ADC"},
+		"AAC":     {Sets: "FUNC1 AAC"},
+		"OHMS":    {Sets: "FUNC1 OHMS"},
+		"FREQ":    {Sets: "FUNC1 FREQ"},
+		"VACDC":   {Sets: "FUNC1 VACDC"},
+		"AACDC":   {Sets: "FUNC1 AACDC"},
+		"AUTO":    {Default: "1", Sets: "AUTO 1"},
+		"RANG":    {Default: "1", Min: 1, Max: 7, Sets: "AUTO 0"},
+		"RANG1":   {Follows: "RANG"},
+		"RATE":    {Default: "S"},
+		"TRIGGER": {Default: "1", Min: 1, Max: 5},
+	},
+}
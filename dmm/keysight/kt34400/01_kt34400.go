@@ -36,6 +36,13 @@ var (
 	_ dmm.TemperatureMeasurementExtension = (*Driver)(nil)
 )
 
+// Register the driver with [dmm.Drivers] so that Open can select it by model.
+func init() {
+	dmm.Drivers.Register(
+		"kt34400", supportedModels(), ivi.Factory[dmm.Base](New),
+	)
+}
+
 // Driver provides the IVI driver for the Keysight 3446x family of DMMs.
 type Driver struct {
 	inst    ivi.Transport
@@ -56,6 +63,7 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		ResetDelay:            500 * time.Millisecond,
 		ClearDelay:            500 * time.Millisecond,
 		ReturnToLocal:         true,
+		SupportsOPC:           true,
 		GroupCapabilities: []string{
 			"IviDmmBase",
 			"IviDmmACMeasurement",
@@ -71,29 +79,23 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 			// "IviDmmAutoZero",
 			// "IviDmmPowerLineFrequency",
 		},
-		SupportedInstrumentModels: []string{
-			"34450A",
-			"EDU34450A",
-			"34460A",
-			"34461A",
-			"34465A",
-			"34470A",
-		},
+		SupportedInstrumentModels: supportedModels(),
 
 		SupportedBusInterfaces: []string{"USB", "GPIB", "LAN"},
+		Simulation:             &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	driver := Driver{
-		inst:     inst,
+		inst:     s.Transport,
 		timeout:  s.Timeout,
 		Inherent: s.Inherent,
 	}
 
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
+		if err := driver.Reset(context.Background()); err != nil {
 			return nil, err
 		}
 	}
@@ -101,9 +103,24 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	return &driver, nil
 }
 
-// newContext creates a context with the driver's configured timeout.
-func (d *Driver) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), d.timeout)
+// supportedModels returns the model numbers the driver supports.
+func supportedModels() []string {
+	return []string{
+		"34450A",
+		"EDU34450A",
+		"34460A",
+		"34461A",
+		"34465A",
+		"34470A",
+	}
+}
+
+// newContext derives a context from ctx that carries the driver's configured
+// timeout, unless ctx already has a deadline of its own.
+func (d *Driver) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, d.timeout)
 }
 
 // Close properly shuts down the DMM by returning it to local control.
@@ -6,6 +6,7 @@
 package kt34400
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -19,8 +20,10 @@ import (
 //
 // MeasurementFunction is the getter for the read-write IviDmmBase Attribute
 // Function described in Section 4.2.1 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) MeasurementFunction() (dmm.MeasurementFunction, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) MeasurementFunction(
+	ctx context.Context,
+) (dmm.MeasurementFunction, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	response, err := query.String(ctx, d.inst, "FUNC?")
@@ -43,9 +46,10 @@ func (d *Driver) MeasurementFunction() (dmm.MeasurementFunction, error) {
 // SetMeasurementFunction is the setter for the read-write IviDmmBase Attribute
 // Function described in Section 4.2.1 of IVI-4.2: IviDmm Class Specification.
 func (d *Driver) SetMeasurementFunction(
+	ctx context.Context,
 	msrFunc dmm.MeasurementFunction,
 ) error {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	scpiCmd, err := ivi.LookupSCPI(msrFuncToCmd, msrFunc)
@@ -92,11 +96,17 @@ func (d *Driver) SetMeasurementFunction(
 //
 // Range is the getter for the read-write IviDmmBase Attribute Range described
 // in Section 4.2.2 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) Range() (dmm.AutoRange, float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) Range(ctx context.Context) (dmm.AutoRange, float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	fcn, err := d.MeasurementFunction()
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return 0, 0.0, fmt.Errorf("Range: %w", err)
+	}
+	defer unlock()
+
+	fcn, err := d.MeasurementFunction(ctx)
 	if err != nil {
 		return 0, 0.0, err
 	}
@@ -134,11 +144,21 @@ func (d *Driver) Range() (dmm.AutoRange, float64, error) {
 //
 // SetRange is the setter for the read-write IviDmmBase Attribute
 // Range described in Section 4.2.2 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) SetRange(autoRange dmm.AutoRange, rangeValue float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetRange(
+	ctx context.Context,
+	autoRange dmm.AutoRange,
+	rangeValue float64,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	fcn, err := d.MeasurementFunction()
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetRange: %w", err)
+	}
+	defer unlock()
+
+	fcn, err := d.MeasurementFunction(ctx)
 	if err != nil {
 		return err
 	}
@@ -205,11 +225,11 @@ func (d *Driver) SetRange(autoRange dmm.AutoRange, rangeValue float64) error {
 // ResolutionAbsolute is the getter for the read-write IviDmmBase Attribute
 // Resolution Absolute described in Section 4.2.3 of IVI-4.2: IviDmm Class
 // Specification.
-func (d *Driver) ResolutionAbsolute() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ResolutionAbsolute(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	fcn, err := d.MeasurementFunction()
+	fcn, err := d.MeasurementFunction(ctx)
 	if err != nil {
 		return 0.0, err
 	}
@@ -228,12 +248,13 @@ func (d *Driver) ResolutionAbsolute() (float64, error) {
 // Resolution Absolute described in Section 4.2.3 of IVI-4.2: IviDmm Class
 // Specification.
 func (d *Driver) SetResolutionAbsolute(
+	ctx context.Context,
 	resolution float64,
 ) error {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	fcn, err := d.MeasurementFunction()
+	fcn, err := d.MeasurementFunction(ctx)
 	if err != nil {
 		return err
 	}
@@ -251,10 +272,18 @@ func (d *Driver) SetResolutionAbsolute(
 //
 // TriggerDelay is the getter for the read-write IviDmmBase Attribute Trigger
 // Delay described in Section 4.2.5 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) TriggerDelay() (bool, time.Duration, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TriggerDelay(
+	ctx context.Context,
+) (bool, time.Duration, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return false, 0, fmt.Errorf("TriggerDelay: %w", err)
+	}
+	defer unlock()
+
 	autoDelay, err := query.Bool(ctx, d.inst, "TRIG:DEL:AUTO?")
 	if err != nil {
 		return false, 0, fmt.Errorf("TriggerDelay: %w", err)
@@ -277,12 +306,19 @@ func (d *Driver) TriggerDelay() (bool, time.Duration, error) {
 // Trigger Delay described in Section 4.2.5 of IVI-4.2: IviDmm Class
 // Specification.
 func (d *Driver) SetTriggerDelay(
+	ctx context.Context,
 	autoDelay bool,
 	delay time.Duration,
 ) error {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetTriggerDelay: %w", err)
+	}
+	defer unlock()
+
 	if autoDelay {
 		return d.inst.Command(ctx, "TRIG:DEL:AUTO ON")
 	}
@@ -296,8 +332,8 @@ func (d *Driver) SetTriggerDelay(
 //
 // TriggerSource is the getter for the read-write IviDmmBase Attribute Trigger
 // Source described in Section 4.2.6 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) TriggerSource() (dmm.TriggerSource, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TriggerSource(ctx context.Context) (dmm.TriggerSource, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, d.inst, "TRIG:SOUR?")
@@ -318,8 +354,11 @@ func (d *Driver) TriggerSource() (dmm.TriggerSource, error) {
 // SetTriggerSource is the setter for the read-write IviDmmBase Attribute
 // Trigger Source described in Section 4.2.6 of IVI-4.2: IviDmm Class
 // Specification.
-func (d *Driver) SetTriggerSource(src dmm.TriggerSource) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetTriggerSource(
+	ctx context.Context,
+	src dmm.TriggerSource,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(triggerSourceToSCPI, src)
@@ -335,8 +374,8 @@ func (d *Driver) SetTriggerSource(src dmm.TriggerSource) error {
 //
 // Abort implements the IviDmmBase function described in Section 4.3.1 of
 // IVI-4.2: IviDmm Class Specification.
-func (d *Driver) Abort() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) Abort(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "ABOR")
@@ -349,12 +388,13 @@ func (d *Driver) Abort() error {
 // ConfigureMeasurement implements the IviDmmBase function described in
 // Section 4.3.2 of IVI-4.2: IviDmm Class Specification.
 func (d *Driver) ConfigureMeasurement(
+	ctx context.Context,
 	msrFunc dmm.MeasurementFunction,
 	autoRange dmm.AutoRange,
 	rangeValue float64,
 	resolution float64,
 ) error {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := createConfigureMeasurementCommand(msrFunc, autoRange, rangeValue, resolution)
@@ -371,14 +411,21 @@ func (d *Driver) ConfigureMeasurement(
 // ConfigureTrigger implements the IviDmmBase function described in Section
 // 4.3.3 of IVI-4.2: IviDmm Class Specification.
 func (d *Driver) ConfigureTrigger(
+	ctx context.Context,
 	src dmm.TriggerSource,
 	delay time.Duration,
 ) error {
-	if err := d.SetTriggerSource(src); err != nil {
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureTrigger: %w", err)
+	}
+	defer unlock()
+
+	if err := d.SetTriggerSource(ctx, src); err != nil {
 		return err
 	}
 
-	return d.SetTriggerDelay(false, delay)
+	return d.SetTriggerDelay(ctx, false, delay)
 }
 
 // FetchMeasurement returns the measured value from a measurement that the
@@ -390,8 +437,11 @@ func (d *Driver) ConfigureTrigger(
 //
 // FetchMeasurement implements the IviDmmBase function described in Section
 // 4.3.4 of the IVI-4.2 IviDmm Class Specification.
-func (d *Driver) FetchMeasurement(_ time.Duration) (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) FetchMeasurement(
+	ctx context.Context,
+	_ time.Duration,
+) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "FETC?")
@@ -409,8 +459,8 @@ func (d *Driver) FetchMeasurement(_ time.Duration) (float64, error) {
 //
 // InitiateMeasurement implements the IviDmmBase function described in Section
 // 4.3.5 of the IVI-4.2 IviDmm Class Specification.
-func (d *Driver) InitiateMeasurement() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) InitiateMeasurement(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "init")
@@ -426,7 +476,10 @@ const overRangeValue = 9.9e37
 //
 // IsOutOfRange implements the IviDmmBase function described in Section 4.3.6
 // of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) IsOutOfRange(value float64) (bool, error) {
+func (d *Driver) IsOutOfRange(
+	ctx context.Context,
+	value float64,
+) (bool, error) {
 	return value >= overRangeValue || value <= -overRangeValue, nil
 }
 
@@ -435,7 +488,7 @@ func (d *Driver) IsOutOfRange(value float64) (bool, error) {
 //
 // IsOverRange implements the IviDmmBase function described in Section 4.3.7
 // of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) IsOverRange(value float64) (bool, error) {
+func (d *Driver) IsOverRange(ctx context.Context, value float64) (bool, error) {
 	return value >= overRangeValue, nil
 }
 
@@ -444,7 +497,10 @@ func (d *Driver) IsOverRange(value float64) (bool, error) {
 //
 // IsUnderRange implements the IviDmmBase function described in Section 4.3.8
 // of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) IsUnderRange(value float64) (bool, error) {
+func (d *Driver) IsUnderRange(
+	ctx context.Context,
+	value float64,
+) (bool, error) {
 	return value <= -overRangeValue, nil
 }
 
@@ -453,8 +509,11 @@ func (d *Driver) IsUnderRange(value float64) (bool, error) {
 //
 // ReadMeasurement implements the IviDmmBase function described in Section
 // 4.3.9 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) ReadMeasurement(_ time.Duration) (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ReadMeasurement(
+	ctx context.Context,
+	_ time.Duration,
+) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "read?")
@@ -213,7 +213,7 @@ func TestMeasurementFunction(t *testing.T) {
 			}
 			d := newTestDriver(t, mock)
 
-			got, err := d.MeasurementFunction()
+			got, err := d.MeasurementFunction(t.Context())
 
 			if tc.expectErr && err == nil {
 				t.Fatal("expected error, got nil")
@@ -254,7 +254,7 @@ func TestSetMeasurementFunction(t *testing.T) {
 			mock := &ivitest.Mock{ShouldError: tc.shouldError}
 			d := newTestDriver(t, mock)
 
-			err := d.SetMeasurementFunction(tc.msrFunc)
+			err := d.SetMeasurementFunction(t.Context(), tc.msrFunc)
 
 			if tc.expectErr && err == nil {
 				t.Fatal("expected error, got nil")
@@ -299,7 +299,7 @@ func TestTriggerSource(t *testing.T) {
 			}
 			d := newTestDriver(t, mock)
 
-			got, err := d.TriggerSource()
+			got, err := d.TriggerSource(t.Context())
 
 			if tc.expectErr && err == nil {
 				t.Fatal("expected error, got nil")
@@ -334,7 +334,7 @@ func TestSetTriggerSource(t *testing.T) {
 			mock := &ivitest.Mock{ShouldError: tc.shouldError}
 			d := newTestDriver(t, mock)
 
-			err := d.SetTriggerSource(tc.src)
+			err := d.SetTriggerSource(t.Context(), tc.src)
 
 			if tc.expectErr && err == nil {
 				t.Fatal("expected error, got nil")
@@ -362,7 +362,7 @@ func TestAbort(t *testing.T) {
 		mock := &ivitest.Mock{}
 		d := newTestDriver(t, mock)
 
-		err := d.Abort()
+		err := d.Abort(t.Context())
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -380,7 +380,7 @@ func TestAbort(t *testing.T) {
 		mock := &ivitest.Mock{ShouldError: true}
 		d := newTestDriver(t, mock)
 
-		err := d.Abort()
+		err := d.Abort(t.Context())
 		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
@@ -392,7 +392,7 @@ func TestInitiateMeasurement(t *testing.T) {
 		mock := &ivitest.Mock{}
 		d := newTestDriver(t, mock)
 
-		err := d.InitiateMeasurement()
+		err := d.InitiateMeasurement(t.Context())
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -410,7 +410,7 @@ func TestInitiateMeasurement(t *testing.T) {
 		mock := &ivitest.Mock{ShouldError: true}
 		d := newTestDriver(t, mock)
 
-		err := d.InitiateMeasurement()
+		err := d.InitiateMeasurement(t.Context())
 		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
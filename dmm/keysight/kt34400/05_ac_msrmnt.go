@@ -6,6 +6,7 @@
 package kt34400
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gotmc/ivi"
@@ -28,7 +29,7 @@ const (
 // MaxACFrequency is the getter for the read-write IviDmmACMeasurement
 // Attribute AC Max Freq described in Section 5.2.1 of IVI-4.2: IviDmm Class
 // Specification.
-func (d *Driver) MaxACFrequency() (float64, error) {
+func (d *Driver) MaxACFrequency(ctx context.Context) (float64, error) {
 	return maxACInputFrequency, nil
 }
 
@@ -39,7 +40,7 @@ func (d *Driver) MaxACFrequency() (float64, error) {
 // SetMaxACFrequency is the setter for the read-write IviDmmACMeasurement
 // Attribute AC Max Freq described in Section 5.2.1 of IVI-4.2: IviDmm Class
 // Specification.
-func (d *Driver) SetMaxACFrequency(maxFreq float64) error {
+func (d *Driver) SetMaxACFrequency(ctx context.Context, maxFreq float64) error {
 	if maxFreq < maxACInputFrequency {
 		return fmt.Errorf(
 			"SetMaxACFrequency: %g Hz below fixed instrument bandwidth %g Hz: %w",
@@ -57,11 +58,11 @@ func (d *Driver) SetMaxACFrequency(maxFreq float64) error {
 // MinACFrequency is the getter for the read-write IviDmmACMeasurement
 // Attribute AC Min Freq described in Section 5.2.2 of IVI-4.2: IviDmm Class
 // Specification.
-func (d *Driver) MinACFrequency() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) MinACFrequency(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	scpiFunc, err := d.acFilterFunction()
+	scpiFunc, err := d.acFilterFunction(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -76,7 +77,7 @@ func (d *Driver) MinACFrequency() (float64, error) {
 // SetMinACFrequency is the setter for the read-write IviDmmACMeasurement
 // Attribute AC Min Freq described in Section 5.2.2 of IVI-4.2: IviDmm Class
 // Specification.
-func (d *Driver) SetMinACFrequency(minFreq float64) error {
+func (d *Driver) SetMinACFrequency(ctx context.Context, minFreq float64) error {
 	if minFreq < minACInputFrequency {
 		return fmt.Errorf(
 			"SetMinACFrequency: %g Hz below minimum filter cutoff %g Hz: %w",
@@ -84,10 +85,10 @@ func (d *Driver) SetMinACFrequency(minFreq float64) error {
 		)
 	}
 
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	scpiFunc, err := d.acFilterFunction()
+	scpiFunc, err := d.acFilterFunction(ctx)
 	if err != nil {
 		return err
 	}
@@ -101,12 +102,21 @@ func (d *Driver) SetMinACFrequency(minFreq float64) error {
 //
 // ConfigureACBandwidth implements the IviDmmACMeasurement function described
 // in Section 5.3.1 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) ConfigureACBandwidth(minFreq, maxFreq float64) error {
-	if err := d.SetMaxACFrequency(maxFreq); err != nil {
+func (d *Driver) ConfigureACBandwidth(
+	ctx context.Context,
+	minFreq, maxFreq float64,
+) error {
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureACBandwidth: %w", err)
+	}
+	defer unlock()
+
+	if err := d.SetMaxACFrequency(ctx, maxFreq); err != nil {
 		return err
 	}
 
-	return d.SetMinACFrequency(minFreq)
+	return d.SetMinACFrequency(ctx, minFreq)
 }
 
 // acFilterFunction returns the SCPI branch (VOLT:AC or CURR:AC) that the AC
@@ -114,8 +124,8 @@ func (d *Driver) ConfigureACBandwidth(minFreq, maxFreq float64) error {
 // function. Non-AC measurement functions default to VOLT:AC because that is
 // where the attribute is most commonly read or programmed before switching the
 // function.
-func (d *Driver) acFilterFunction() (string, error) {
-	fcn, err := d.MeasurementFunction()
+func (d *Driver) acFilterFunction(ctx context.Context) (string, error) {
+	fcn, err := d.MeasurementFunction(ctx)
 	if err != nil {
 		return "", err
 	}
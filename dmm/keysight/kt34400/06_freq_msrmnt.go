@@ -6,8 +6,10 @@
 package kt34400
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/gotmc/ivi"
 	"github.com/gotmc/query"
 )
 
@@ -18,10 +20,18 @@ import (
 // FrequencyVoltageRange is the getter for the read-write
 // IviDmmFrequencyMeasurement Attribute Frequency Voltage Range described in
 // Section 6.2.1 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) FrequencyVoltageRange() (bool, float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) FrequencyVoltageRange(
+	ctx context.Context,
+) (bool, float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return false, 0, fmt.Errorf("FrequencyVoltageRange: %w", err)
+	}
+	defer unlock()
+
 	autoRange, err := query.Bool(ctx, d.inst, "FREQ:VOLT:RANG:AUTO?")
 	if err != nil {
 		return false, 0, fmt.Errorf("FrequencyVoltageRange: %w", err)
@@ -44,10 +54,20 @@ func (d *Driver) FrequencyVoltageRange() (bool, float64, error) {
 // SetFrequencyVoltageRange is the setter for the read-write
 // IviDmmFrequencyMeasurement Attribute Frequency Voltage Range described in
 // Section 6.2.1 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) SetFrequencyVoltageRange(autoRange bool, rangeValue float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetFrequencyVoltageRange(
+	ctx context.Context,
+	autoRange bool,
+	rangeValue float64,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetFrequencyVoltageRange: %w", err)
+	}
+	defer unlock()
+
 	if autoRange {
 		return d.inst.Command(ctx, "FREQ:VOLT:RANG:AUTO ON")
 	}
@@ -6,6 +6,7 @@
 package kt34400
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"strings"
@@ -21,8 +22,10 @@ import (
 // TemperatureTransducerType is the getter for the read-write
 // IviDmmTemperatureMeasurement Attribute Temp Transducer Type described in
 // Section 7.2.1 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) TemperatureTransducerType() (dmm.TempTransducerType, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TemperatureTransducerType(
+	ctx context.Context,
+) (dmm.TempTransducerType, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	response, err := query.String(ctx, d.inst, "TEMP:TRAN:TYPE?")
@@ -58,9 +61,12 @@ func (d *Driver) TemperatureTransducerType() (dmm.TempTransducerType, error) {
 // SetTemperatureTransducerType is the setter for the read-write
 // IviDmmTemperatureMeasurement Attribute Temp Transducer Type described in
 // Section 7.2.1 of IVI-4.2: IviDmm Class Specification.
-func (d *Driver) SetTemperatureTransducerType(t dmm.TempTransducerType) error {
+func (d *Driver) SetTemperatureTransducerType(
+	ctx context.Context,
+	t dmm.TempTransducerType,
+) error {
 	if t == dmm.Thermocouple {
-		if err := d.requireThermocoupleCapableModel(); err != nil {
+		if err := d.requireThermocoupleCapableModel(ctx); err != nil {
 			return err
 		}
 	}
@@ -73,7 +79,7 @@ func (d *Driver) SetTemperatureTransducerType(t dmm.TempTransducerType) error {
 		)
 	}
 
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "TEMP:TRAN:TYPE %s", scpi)
@@ -90,8 +96,8 @@ var thermocoupleCapableModels = []string{"34465A", "34470A"}
 // populated by New, so this check typically does not issue any SCPI; it falls
 // back to a live *IDN? query when the cache is empty (e.g., the caller
 // passed [ivi.WithoutIDQuery] and construction-time *IDN? failed).
-func (d *Driver) requireThermocoupleCapableModel() error {
-	model, err := d.InstrumentModel()
+func (d *Driver) requireThermocoupleCapableModel(ctx context.Context) error {
+	model, err := d.InstrumentModel(ctx)
 	if err != nil {
 		return fmt.Errorf(
 			"SetTemperatureTransducerType: cannot determine model: %w", err,
@@ -6,6 +6,7 @@
 package kt34400
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -15,8 +16,8 @@ import (
 // SelectedTerminals queries if the front or rear terminals are selected on the
 // 34461A front panel Front/Rear switch. This switch is not programmable; this
 // query reports the position of the switch, but cannot change it.
-func (d *Driver) SelectedTerminals() (Terminal, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) SelectedTerminals(ctx context.Context) (Terminal, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	term, err := query.String(ctx, d.inst, "rout:term?")
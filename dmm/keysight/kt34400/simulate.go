@@ -0,0 +1,43 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package kt34400
+
+import "github.com/gotmc/ivi"
+
+// simulation describes the instrument simulated under [ivi.WithSimulate],
+// which starts as a Truevolt DMM does after *RST: DC volts, auto range,
+// immediate triggering, and the front terminals selected. Configuring a
+// measurement selects its function.
+var simulation = ivi.Simulation{
+	Manufacturer: "Keysight Technologies",
+	Attributes: map[string]ivi.SimulatedAttribute{
+		"FUNC":                {Default: `"VOLT"`},
+		"CONF:VOLT:DC":        {Sets: "FUNC VOLT"},
+		"CONF:VOLT:AC":        {Sets: "FUNC VOLT:AC"},
+		"VOLT:RANG:AUTO":      {Default: "1"},
+		"VOLT:RANG":           {Default: "10"},
+		"VOLT:AC:RANG:AUTO":   {Default: "1"},
+		"VOLT:AC:RANG":        {Default: "10"},
+		"CURR:RANG:AUTO":      {Default: "1"},
+		"CURR:RANG":           {Default: "0.1"},
+		"CURR:AC:RANG:AUTO":   {Default: "1"},
+		"CURR:AC:RANG":        {Default: "0.1"},
+		"RES:RANG:AUTO":       {Default: "1"},
+		"RES:RANG":            {Default: "10000"},
+		"FRES:RANG:AUTO":      {Default: "1"},
+		"FRES:RANG":           {Default: "10000"},
+		"FREQ:VOLT:RANG:AUTO": {Default: "1"},
+		"FREQ:VOLT:RANG":      {Default: "10"},
+		"VOLT:RES":            {Default: "0.000003"},
+		"VOLT:AC:BAND":        {Default: "20", Min: 3, Max: 200},
+		"CURR:AC:BAND":        {Default: "20", Min: 3, Max: 200},
+		"TRIG:DEL:AUTO":       {Default: "1"},
+		"TRIG:DEL":            {Min: 0, Max: 3600},
+		"TRIG:SOUR":           {Default: "IMM"},
+		"TEMP:TRAN:TYPE":      {Default: "FRTD"},
+		"ROUT:TERM":           {Default: "FRON"},
+	},
+}
@@ -15,7 +15,22 @@
 // 33220A and the Stanford Research Systems DS345 function generators can be
 // programmed using one API.
 //
-// Currently, ivi doesn't cache state. Every time an attribute is read directly
-// from the instrument. Development focus is currently on fleshing out the APIs
-// and creating a few IVI drivers for each instrument type.
+// Every class interface and driver method that communicates with an
+// instrument takes a [context.Context] as its first parameter, so callers can
+// cancel an operation or bound it with a deadline. A context without a
+// deadline falls back to the driver's configured timeout (see [WithTimeout]
+// and [ContextWithTimeout]).
+//
+// Drivers are safe for use by multiple goroutines. Each exchange with the
+// instrument, such as a query and its response, runs alone on the driver's
+// [SessionTransport], so goroutines never read each other's responses. A
+// goroutine that needs several calls to run without others between them,
+// such as programming an output and then measuring it, holds the session
+// lock with the driver's Lock and Unlock methods.
+//
+// By default ivi doesn't cache state, so every attribute is read directly from
+// the instrument. Drivers that implement state caching serve repeated reads
+// from a cache when constructed with [WithCache]. Development focus is
+// currently on fleshing out the APIs and creating a few IVI drivers for each
+// instrument type.
 package ivi
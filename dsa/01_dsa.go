@@ -10,6 +10,33 @@ included in one of the IVI specifications.
 */
 package dsa
 
+import (
+	"reflect"
+
+	"github.com/gotmc/ivi"
+)
+
+// Groups lists the capability groups of the dynamic signal analyzer class, with
+// the Go interfaces that implement each, for [ivi.Introspect] and
+// [ivi.CheckGroupCapabilities].
+var Groups = []ivi.CapabilityGroup{
+	{Name: "IviDSABase", Driver: reflect.TypeFor[Base]()},
+	{Name: "IviDSASource", Driver: reflect.TypeFor[Source]()},
+}
+
+// Drivers holds the IviDSA drivers, by the instrument models they support. Each
+// driver registers itself from an init function, so importing a driver package,
+// if only for its side effects, makes it available to Drivers.Open, which picks
+// the driver for the connected instrument's model, and to Drivers.OpenDriver,
+// which creates a driver by name. Both return the driver as a Base; assert it
+// to other IviDSA interfaces, or to the driver's own type, for the capabilities
+// beyond the base group. See [ivi.Registry].
+//
+//	import _ "github.com/gotmc/ivi/dsa/keysight/kt35670"
+//
+//	analyzer, err := dsa.Drivers.Open(ctx, inst)
+var Drivers ivi.Registry[Base]
+
 // AmpUnits models the defined values for amplitude units.
 type AmpUnits int
 
@@ -5,7 +5,12 @@
 
 package dsa
 
-import "time"
+import (
+	"context"
+	"time"
+
+	"github.com/gotmc/ivi"
+)
 
 /*
 
@@ -28,68 +33,93 @@ Key differences from spectrum analyzers:
 // and trace data retrieval.
 type Base interface {
 	// Frequency
-	FrequencyStart() (float64, error)
-	SetFrequencyStart(freq float64) error
-	FrequencyStop() (float64, error)
-	SetFrequencyStop(freq float64) error
-	FrequencySpan() (float64, error)
-	SetFrequencySpan(span float64) error
-	FrequencyCenter() (float64, error)
-	SetFrequencyCenter(freq float64) error
-	ConfigureFrequencyStartStop(startFreq, stopFreq float64) error
-	ConfigureFrequencyCenterSpan(centerFreq, span float64) error
+	FrequencyStart(ctx context.Context) (float64, error)
+	SetFrequencyStart(ctx context.Context, freq float64) error
+	FrequencyStop(ctx context.Context) (float64, error)
+	SetFrequencyStop(ctx context.Context, freq float64) error
+	FrequencySpan(ctx context.Context) (float64, error)
+	SetFrequencySpan(ctx context.Context, span float64) error
+	FrequencyCenter(ctx context.Context) (float64, error)
+	SetFrequencyCenter(ctx context.Context, freq float64) error
+	ConfigureFrequencyStartStop(
+		ctx context.Context,
+		startFreq, stopFreq float64,
+	) error
+	ConfigureFrequencyCenterSpan(
+		ctx context.Context,
+		centerFreq, span float64,
+	) error
 
 	// Resolution (spectral lines)
-	SpectralLines() (int, error)
-	SetSpectralLines(lines int) error
+	SpectralLines(ctx context.Context) (int, error)
+	SetSpectralLines(ctx context.Context, lines int) error
 
 	// Window function
-	WindowType() (WindowType, error)
-	SetWindowType(window WindowType) error
+	WindowType(ctx context.Context) (WindowType, error)
+	SetWindowType(ctx context.Context, window WindowType) error
 
 	// Averaging
-	AveragingEnabled() (bool, error)
-	SetAveragingEnabled(enabled bool) error
-	AveragingCount() (int, error)
-	SetAveragingCount(count int) error
-	AveragingType() (AveragingType, error)
-	SetAveragingType(avgType AveragingType) error
+	AveragingEnabled(ctx context.Context) (bool, error)
+	SetAveragingEnabled(ctx context.Context, enabled bool) error
+	AveragingCount(ctx context.Context) (int, error)
+	SetAveragingCount(ctx context.Context, count int) error
+	AveragingType(ctx context.Context) (AveragingType, error)
+	SetAveragingType(ctx context.Context, avgType AveragingType) error
 
 	// Input range
-	InputRange(channel int) (float64, error)
-	SetInputRange(channel int, rangeDBVrms float64) error
-	InputAutoRange(channel int) (bool, error)
-	SetInputAutoRange(channel int, auto bool) error
-	InputCoupling(channel int) (InputCoupling, error)
-	SetInputCoupling(channel int, coupling InputCoupling) error
+	InputRange(ctx context.Context, channel int) (float64, error)
+	SetInputRange(ctx context.Context, channel int, rangeDBVrms float64) error
+	InputAutoRange(ctx context.Context, channel int) (bool, error)
+	SetInputAutoRange(ctx context.Context, channel int, auto bool) error
+	InputCoupling(ctx context.Context, channel int) (InputCoupling, error)
+	SetInputCoupling(
+		ctx context.Context,
+		channel int,
+		coupling InputCoupling,
+	) error
 
 	// Measurement control
-	MeasurementMode() (MeasurementMode, error)
-	SetMeasurementMode(mode MeasurementMode) error
-	ChannelCount() (int, error)
-	SetChannelCount(count int) error
+	MeasurementMode(ctx context.Context) (MeasurementMode, error)
+	SetMeasurementMode(ctx context.Context, mode MeasurementMode) error
+	ChannelCount(ctx context.Context) (int, error)
+	SetChannelCount(ctx context.Context, count int) error
 
 	// Acquisition control
-	Abort() error
-	Initiate() error
-	SweepModeContinuous() (bool, error)
-	SetSweepModeContinuous(continuous bool) error
+	Abort(ctx context.Context) error
+	Initiate(ctx context.Context) error
+	SweepModeContinuous(ctx context.Context) (bool, error)
+	SetSweepModeContinuous(ctx context.Context, continuous bool) error
 
 	// Trace data
-	FetchYTrace(traceName string) ([]float64, error)
-	ReadYTrace(traceName string, maxTime time.Duration) ([]float64, error)
+	FetchYTrace(ctx context.Context, traceName string) ([]float64, error)
+	ReadYTrace(
+		ctx context.Context,
+		traceName string,
+		maxTime time.Duration,
+	) ([]float64, error)
+	FetchWaveform(
+		ctx context.Context,
+		traceName string,
+		waveform *ivi.Waveform,
+	) error
+	ReadWaveform(
+		ctx context.Context,
+		traceName string,
+		maxTime time.Duration,
+		waveform *ivi.Waveform,
+	) error
 }
 
 // Source provides the interface for DSA source output control. Not all DSAs
 // have a built-in source; drivers for instruments without a source should
 // return [ivi.ErrFunctionNotSupported].
 type Source interface {
-	SourceEnabled() (bool, error)
-	SetSourceEnabled(enabled bool) error
-	SourceShape() (SourceShape, error)
-	SetSourceShape(shape SourceShape) error
-	SourceFrequency() (float64, error)
-	SetSourceFrequency(freq float64) error
-	SourceOutputLevel() (float64, error)
-	SetSourceOutputLevel(level float64) error
+	SourceEnabled(ctx context.Context) (bool, error)
+	SetSourceEnabled(ctx context.Context, enabled bool) error
+	SourceShape(ctx context.Context) (SourceShape, error)
+	SetSourceShape(ctx context.Context, shape SourceShape) error
+	SourceFrequency(ctx context.Context) (float64, error)
+	SetSourceFrequency(ctx context.Context, freq float64) error
+	SourceOutputLevel(ctx context.Context) (float64, error)
+	SetSourceOutputLevel(ctx context.Context, level float64) error
 }
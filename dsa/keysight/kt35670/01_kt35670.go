@@ -35,6 +35,13 @@ const (
 var _ dsa.Base = (*Driver)(nil)
 var _ dsa.Source = (*Driver)(nil)
 
+// Register the driver with [dsa.Drivers] so that Open can select it by model.
+func init() {
+	dsa.Drivers.Register(
+		"kt35670", supportedModels(), ivi.Factory[dsa.Base](New),
+	)
+}
+
 // Driver provides the IVI driver for a Keysight 35670A Dynamic Signal
 // Analyzer.
 type Driver struct {
@@ -50,14 +57,19 @@ type Driver struct {
 // creation and [ivi.WithTimeout] to override the default I/O timeout.
 func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	s, err := ivi.NewDriverSetup(inst, ivi.InherentBase{
-		ClassSpecMajorVersion:     specMajorVersion,
-		ClassSpecMinorVersion:     specMinorVersion,
-		ClassSpecRevision:         specRevision,
-		ResetDelay:                500 * time.Millisecond,
-		ClearDelay:                500 * time.Millisecond,
-		ReturnToLocal:             true,
-		GroupCapabilities:         []string{"IviDSABase"},
-		SupportedInstrumentModels: []string{"35670A"},
+		ClassSpecMajorVersion: specMajorVersion,
+		ClassSpecMinorVersion: specMinorVersion,
+		ClassSpecRevision:     specRevision,
+		ResetDelay:            500 * time.Millisecond,
+		ClearDelay:            500 * time.Millisecond,
+		ReturnToLocal:         true,
+		SupportsOPC:           true,
+		GroupCapabilities: []string{
+			"IviDSABase",
+			"IviDSASource",
+		},
+		SupportedInstrumentModels: supportedModels(),
+		Simulation:                &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
@@ -66,18 +78,18 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	channelNames := []string{"CH1", "CH2", "CH3", "CH4"}
 	channels := make([]Channel, len(channelNames))
 	for i, ch := range channelNames {
-		channels[i] = Channel{dsa.NewChannel(i, ch, inst)}
+		channels[i] = Channel{dsa.NewChannel(i, ch, s.Transport)}
 	}
 
 	driver := Driver{
-		inst:     inst,
+		inst:     s.Transport,
 		channels: channels,
 		timeout:  s.Timeout,
 		Inherent: s.Inherent,
 	}
 
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
+		if err := driver.Reset(context.Background()); err != nil {
 			return &driver, err
 		}
 	}
@@ -85,9 +97,17 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	return &driver, nil
 }
 
-// newContext creates a context with the driver's configured timeout.
-func (d *Driver) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), d.timeout)
+// supportedModels returns the model numbers the driver supports.
+func supportedModels() []string {
+	return []string{"35670A"}
+}
+
+// newContext derives a context from ctx that carries the driver's configured
+// timeout, unless ctx already has a deadline of its own.
+func (d *Driver) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, d.timeout)
 }
 
 // Channel returns the Channel at the given index, with bounds checking.
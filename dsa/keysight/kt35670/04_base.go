@@ -19,93 +19,107 @@ import (
 
 // --- Frequency ---
 
-func (d *Driver) FrequencyStart() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) FrequencyStart(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "SENS:FREQ:STAR?")
 }
 
-func (d *Driver) SetFrequencyStart(freq float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetFrequencyStart(ctx context.Context, freq float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "SENS:FREQ:STAR %f", freq)
 }
 
-func (d *Driver) FrequencyStop() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) FrequencyStop(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "SENS:FREQ:STOP?")
 }
 
-func (d *Driver) SetFrequencyStop(freq float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetFrequencyStop(ctx context.Context, freq float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "SENS:FREQ:STOP %f", freq)
 }
 
-func (d *Driver) FrequencySpan() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) FrequencySpan(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "SENS:FREQ:SPAN?")
 }
 
-func (d *Driver) SetFrequencySpan(span float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetFrequencySpan(ctx context.Context, span float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "SENS:FREQ:SPAN %f", span)
 }
 
-func (d *Driver) FrequencyCenter() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) FrequencyCenter(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "SENS:FREQ:CENT?")
 }
 
-func (d *Driver) SetFrequencyCenter(freq float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetFrequencyCenter(ctx context.Context, freq float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "SENS:FREQ:CENT %f", freq)
 }
 
 func (d *Driver) ConfigureFrequencyStartStop(
+	ctx context.Context,
 	startFreq, stopFreq float64,
 ) error {
-	if err := d.SetFrequencyStart(startFreq); err != nil {
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureFrequencyStartStop: %w", err)
+	}
+	defer unlock()
+
+	if err := d.SetFrequencyStart(ctx, startFreq); err != nil {
 		return err
 	}
 
-	return d.SetFrequencyStop(stopFreq)
+	return d.SetFrequencyStop(ctx, stopFreq)
 }
 
 func (d *Driver) ConfigureFrequencyCenterSpan(
+	ctx context.Context,
 	centerFreq, span float64,
 ) error {
-	if err := d.SetFrequencyCenter(centerFreq); err != nil {
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureFrequencyCenterSpan: %w", err)
+	}
+	defer unlock()
+
+	if err := d.SetFrequencyCenter(ctx, centerFreq); err != nil {
 		return err
 	}
 
-	return d.SetFrequencySpan(span)
+	return d.SetFrequencySpan(ctx, span)
 }
 
 // --- Resolution (spectral lines) ---
 
-func (d *Driver) SpectralLines() (int, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) SpectralLines(ctx context.Context) (int, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Int(ctx, d.inst, "SENS:FREQ:RES?")
 }
 
-func (d *Driver) SetSpectralLines(lines int) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetSpectralLines(ctx context.Context, lines int) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "SENS:FREQ:RES %d", lines)
@@ -129,8 +143,8 @@ var scpiToWindowType = map[string]dsa.WindowType{
 	"EXP":  dsa.WindowExponential,
 }
 
-func (d *Driver) WindowType() (dsa.WindowType, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) WindowType(ctx context.Context) (dsa.WindowType, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, d.inst, "SENS:WIND:TYPE?")
@@ -146,8 +160,11 @@ func (d *Driver) WindowType() (dsa.WindowType, error) {
 	return wt, nil
 }
 
-func (d *Driver) SetWindowType(window dsa.WindowType) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetWindowType(
+	ctx context.Context,
+	window dsa.WindowType,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(windowTypeToSCPI, window)
@@ -160,17 +177,23 @@ func (d *Driver) SetWindowType(window dsa.WindowType) error {
 
 // --- Averaging ---
 
-func (d *Driver) AveragingEnabled() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AveragingEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Bool(ctx, d.inst, "SENS:AVER?")
 }
 
-func (d *Driver) SetAveragingEnabled(enabled bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetAveragingEnabled(ctx context.Context, enabled bool) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetAveragingEnabled: %w", err)
+	}
+	defer unlock()
+
 	if enabled {
 		return d.inst.Command(ctx, "SENS:AVER ON")
 	}
@@ -178,15 +201,15 @@ func (d *Driver) SetAveragingEnabled(enabled bool) error {
 	return d.inst.Command(ctx, "SENS:AVER OFF")
 }
 
-func (d *Driver) AveragingCount() (int, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AveragingCount(ctx context.Context) (int, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Int(ctx, d.inst, "SENS:AVER:COUN?")
 }
 
-func (d *Driver) SetAveragingCount(count int) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetAveragingCount(ctx context.Context, count int) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "SENS:AVER:COUN %d", count)
@@ -208,8 +231,8 @@ var scpiToAveragingType = map[string]dsa.AveragingType{
 	"PEAK":  dsa.AveragingPeakHold,
 }
 
-func (d *Driver) AveragingType() (dsa.AveragingType, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AveragingType(ctx context.Context) (dsa.AveragingType, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, d.inst, "SENS:AVER:TYPE?")
@@ -225,8 +248,11 @@ func (d *Driver) AveragingType() (dsa.AveragingType, error) {
 	return at, nil
 }
 
-func (d *Driver) SetAveragingType(avgType dsa.AveragingType) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetAveragingType(
+	ctx context.Context,
+	avgType dsa.AveragingType,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(averagingTypeToSCPI, avgType)
@@ -239,31 +265,48 @@ func (d *Driver) SetAveragingType(avgType dsa.AveragingType) error {
 
 // --- Input range ---
 
-func (d *Driver) InputRange(channel int) (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) InputRange(ctx context.Context, channel int) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64f(ctx, d.inst, "INP%d:RANG?", channel+1)
 }
 
-func (d *Driver) SetInputRange(channel int, rangeDBVrms float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetInputRange(
+	ctx context.Context,
+	channel int,
+	rangeDBVrms float64,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "INP%d:RANG %f", channel+1, rangeDBVrms)
 }
 
-func (d *Driver) InputAutoRange(channel int) (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) InputAutoRange(
+	ctx context.Context,
+	channel int,
+) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Boolf(ctx, d.inst, "INP%d:RANG:AUTO?", channel+1)
 }
 
-func (d *Driver) SetInputAutoRange(channel int, auto bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetInputAutoRange(
+	ctx context.Context,
+	channel int,
+	auto bool,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetInputAutoRange: %w", err)
+	}
+	defer unlock()
+
 	if auto {
 		return d.inst.Command(ctx, "INP%d:RANG:AUTO ON", channel+1)
 	}
@@ -281,8 +324,11 @@ var scpiToInputCoupling = map[string]dsa.InputCoupling{
 	"DC": dsa.InputCouplingDC,
 }
 
-func (d *Driver) InputCoupling(channel int) (dsa.InputCoupling, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) InputCoupling(
+	ctx context.Context,
+	channel int,
+) (dsa.InputCoupling, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.Stringf(ctx, d.inst, "INP%d:COUP?", channel+1)
@@ -301,9 +347,10 @@ func (d *Driver) InputCoupling(channel int) (dsa.InputCoupling, error) {
 }
 
 func (d *Driver) SetInputCoupling(
+	ctx context.Context,
 	channel int, coupling dsa.InputCoupling,
 ) error {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(inputCouplingToSCPI, coupling)
@@ -334,8 +381,10 @@ var scpiToMeasurementMode = map[string]dsa.MeasurementMode{
 	"SWEP": dsa.MeasurementModeSweptSine,
 }
 
-func (d *Driver) MeasurementMode() (dsa.MeasurementMode, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) MeasurementMode(
+	ctx context.Context,
+) (dsa.MeasurementMode, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, d.inst, "INST:SEL?")
@@ -353,8 +402,11 @@ func (d *Driver) MeasurementMode() (dsa.MeasurementMode, error) {
 	return mode, nil
 }
 
-func (d *Driver) SetMeasurementMode(mode dsa.MeasurementMode) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetMeasurementMode(
+	ctx context.Context,
+	mode dsa.MeasurementMode,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(measurementModeToSCPI, mode)
@@ -365,15 +417,15 @@ func (d *Driver) SetMeasurementMode(mode dsa.MeasurementMode) error {
 	return d.inst.Command(ctx, "INST:SEL %s", cmd)
 }
 
-func (d *Driver) ChannelCount() (int, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ChannelCount(ctx context.Context) (int, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Int(ctx, d.inst, "INST:NCHA?")
 }
 
-func (d *Driver) SetChannelCount(count int) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetChannelCount(ctx context.Context, count int) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "INST:NCHA %d", count)
@@ -381,31 +433,40 @@ func (d *Driver) SetChannelCount(count int) error {
 
 // --- Acquisition control ---
 
-func (d *Driver) Abort() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) Abort(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "ABOR")
 }
 
-func (d *Driver) Initiate() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) Initiate(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "INIT")
 }
 
-func (d *Driver) SweepModeContinuous() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) SweepModeContinuous(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Bool(ctx, d.inst, "INIT:CONT?")
 }
 
-func (d *Driver) SetSweepModeContinuous(continuous bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetSweepModeContinuous(
+	ctx context.Context,
+	continuous bool,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetSweepModeContinuous: %w", err)
+	}
+	defer unlock()
+
 	if continuous {
 		return d.inst.Command(ctx, "INIT:CONT ON")
 	}
@@ -416,8 +477,11 @@ func (d *Driver) SetSweepModeContinuous(continuous bool) error {
 // --- Trace data ---
 
 // FetchYTrace returns the trace data as a slice of float64 values.
-func (d *Driver) FetchYTrace(traceName string) ([]float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) FetchYTrace(
+	ctx context.Context,
+	traceName string,
+) ([]float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.Stringf(ctx, d.inst, "CALC%s:DATA?", traceName)
@@ -431,34 +495,166 @@ func (d *Driver) FetchYTrace(traceName string) ([]float64, error) {
 // ReadYTrace initiates a measurement, waits for completion, and returns the
 // trace data.
 func (d *Driver) ReadYTrace(
+	ctx context.Context,
 	traceName string, maxTime time.Duration,
 ) ([]float64, error) {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.measure(ctx, maxTime)
+	defer cancel()
+
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("ReadYTrace: %w", err)
+	}
+	defer unlock()
+
+	if err := d.initiateAndWait(ctx); err != nil {
+		return nil, fmt.Errorf("ReadYTrace: %w", err)
+	}
+
+	return d.FetchYTrace(ctx, traceName)
+}
+
+// FetchWaveform fills waveform with the trace data, on a frequency axis in Hz
+// from the start to the stop frequency. Only frequency domain traces of an FFT
+// measurement have such an axis; for any other trace, such as a time record or
+// an octave, order or swept sine measurement, FetchWaveform returns an error
+// wrapping [ivi.ErrValueNotSupported].
+func (d *Driver) FetchWaveform(
+	ctx context.Context,
+	traceName string,
+	waveform *ivi.Waveform,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	// Use a longer timeout for the measurement if needed.
-	if maxTime > d.timeout {
-		var longCancel context.CancelFunc
-		ctx, longCancel = context.WithTimeout(context.Background(), maxTime)
-		defer longCancel()
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("FetchWaveform: %w", err)
+	}
+	defer unlock()
+
+	if err := d.checkLinearFrequencyAxis(ctx, traceName); err != nil {
+		return fmt.Errorf("FetchWaveform: %w", err)
+	}
+
+	trace, err := d.FetchYTrace(ctx, traceName)
+	if err != nil {
+		return fmt.Errorf("FetchWaveform: %w", err)
+	}
+
+	start, err := d.FrequencyStart(ctx)
+	if err != nil {
+		return fmt.Errorf("FetchWaveform: %w", err)
 	}
 
+	stop, err := d.FrequencyStop(ctx)
+	if err != nil {
+		return fmt.Errorf("FetchWaveform: %w", err)
+	}
+
+	var increment float64
+	if len(trace) > 1 {
+		increment = (stop - start) / float64(len(trace)-1)
+	}
+
+	waveform.Configure(trace, ivi.WaveformInfo{
+		InitialX:   start,
+		XIncrement: increment,
+		XUnits:     "Hz",
+		Timestamp:  time.Now(),
+		Source:     "CALC" + traceName,
+	})
+
+	return nil
+}
+
+// ReadWaveform initiates a measurement, waits for completion, and fills
+// waveform with the trace data as [Driver.FetchWaveform] does.
+func (d *Driver) ReadWaveform(
+	ctx context.Context,
+	traceName string,
+	maxTime time.Duration,
+	waveform *ivi.Waveform,
+) error {
+	ctx, cancel := d.measure(ctx, maxTime)
+	defer cancel()
+
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("ReadWaveform: %w", err)
+	}
+	defer unlock()
+
+	if err := d.initiateAndWait(ctx); err != nil {
+		return fmt.Errorf("ReadWaveform: %w", err)
+	}
+
+	return d.FetchWaveform(ctx, traceName, waveform)
+}
+
+// checkLinearFrequencyAxis returns an error wrapping
+// [ivi.ErrValueNotSupported] unless the trace holds frequency domain data
+// spaced linearly from the start to the stop frequency, which is only the case
+// in FFT mode for a trace fed from frequency domain ("XFR") data.
+func (d *Driver) checkLinearFrequencyAxis(
+	ctx context.Context,
+	traceName string,
+) error {
+	mode, err := d.MeasurementMode(ctx)
+	if err != nil {
+		return err
+	}
+
+	if mode != dsa.MeasurementModeFFT {
+		return fmt.Errorf(
+			"%s mode traces are not linear in frequency: %w",
+			measurementModeToSCPI[mode], ivi.ErrValueNotSupported)
+	}
+
+	feed, err := query.Stringf(ctx, d.inst, "CALC%s:FEED?", traceName)
+	if err != nil {
+		return err
+	}
+
+	feed = strings.Trim(strings.TrimSpace(feed), `"'`)
+	if !strings.HasPrefix(feed, "XFR") {
+		return fmt.Errorf("trace %s is fed %q, not frequency domain data: %w",
+			traceName, feed, ivi.ErrValueNotSupported)
+	}
+
+	return nil
+}
+
+// measure returns a context for a measurement lasting up to maxTime, or the
+// I/O timeout when that is longer.
+func (d *Driver) measure(
+	ctx context.Context,
+	maxTime time.Duration,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, max(maxTime, d.timeout))
+}
+
+// initiateAndWait starts a single measurement and waits for it to complete.
+func (d *Driver) initiateAndWait(ctx context.Context) error {
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("InitiateAndWait: %w", err)
+	}
+	defer unlock()
+
 	if err := d.inst.Command(ctx, "INIT:CONT OFF"); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := d.inst.Command(ctx, "INIT"); err != nil {
-		return nil, err
+		return err
 	}
 
-	// Wait for operation complete.
-	if _, err := query.String(ctx, d.inst, "*OPC?"); err != nil {
-		return nil, fmt.Errorf(
-			"ReadYTrace: waiting for measurement complete: %w", err,
-		)
+	if err := d.WaitForOperationComplete(ctx); err != nil {
+		return fmt.Errorf("waiting for measurement complete: %w", err)
 	}
 
-	return d.FetchYTrace(traceName)
+	return nil
 }
 
 func parseCSVFloat64(s string) ([]float64, error) {
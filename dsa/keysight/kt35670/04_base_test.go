@@ -0,0 +1,75 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package kt35670
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gotmc/ivi"
+)
+
+func TestDriver_FetchWaveform(t *testing.T) {
+	d, err := New(nil, ivi.WithSimulate())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	var w ivi.Waveform
+	if err := d.FetchWaveform(t.Context(), "1", &w); err != nil {
+		t.Fatalf("FetchWaveform() error: %v", err)
+	}
+
+	if w.Len() != 401 {
+		t.Errorf("Len() = %d, want 401", w.Len())
+	}
+	if w.InitialX() != 0 || w.XIncrement() != 128 || w.XUnits() != "Hz" {
+		t.Errorf("X axis = %g + n*%g %s, want 0 + n*128 Hz",
+			w.InitialX(), w.XIncrement(), w.XUnits())
+	}
+	if x, y := w.At(400); x != 51200 || y != -100 {
+		t.Errorf("At(400) = %g, %g, want 51200, -100", x, y)
+	}
+	if w.Source() != "CALC1" {
+		t.Errorf("Source() = %q, want CALC1", w.Source())
+	}
+	if w.Timestamp().IsZero() {
+		t.Error("Timestamp() is zero")
+	}
+}
+
+func TestDriver_FetchWaveform_NotLinearInFrequency(t *testing.T) {
+	tests := []struct {
+		name string
+		mode string
+		feed string
+	}{
+		{"octave", "OCT", `"XFR:POW 1"`},
+		{"swept sine", "SWEP", `"XFR:POW:RAT 2,1"`},
+		{"time record", "FFT", `"XTIM:VOLT 1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := New(nil, ivi.WithSimulate())
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+			ctx := t.Context()
+			err = d.inst.Command(ctx, "INST:SEL %s;:CALC1:FEED %s",
+				tt.mode, tt.feed)
+			if err != nil {
+				t.Fatalf("Command() error: %v", err)
+			}
+
+			var w ivi.Waveform
+			err = d.FetchWaveform(ctx, "1", &w)
+			if !errors.Is(err, ivi.ErrValueNotSupported) {
+				t.Errorf("FetchWaveform() error = %v, "+
+					"want ErrValueNotSupported", err)
+			}
+		})
+	}
+}
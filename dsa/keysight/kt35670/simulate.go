@@ -0,0 +1,52 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package kt35670
+
+import (
+	"strings"
+
+	"github.com/gotmc/ivi"
+)
+
+// simulatedTrace is the trace data returned under [ivi.WithSimulate]: the 401
+// points of a 400 line measurement, all at a -100 dBVrms noise floor.
+var simulatedTrace = strings.TrimSuffix(strings.Repeat("-100,", 401), ",")
+
+// simulation describes the instrument simulated under [ivi.WithSimulate],
+// which starts in two channel FFT mode over a 51.2 kHz span.
+var simulation = ivi.Simulation{
+	Manufacturer: "HEWLETT-PACKARD",
+	Attributes: map[string]ivi.SimulatedAttribute{
+		"INST:SEL":              {Default: "FFT"},
+		"INST:NCHA":             {Default: "2", Min: 1, Max: 4},
+		"SENS:FREQ:STAR":        {Min: 0, Max: 102400},
+		"SENS:FREQ:STOP":        {Default: "51200", Min: 0, Max: 102400},
+		"SENS:FREQ:SPAN":        {Default: "51200", Min: 0, Max: 102400},
+		"SENS:FREQ:CENT":        {Default: "25600", Min: 0, Max: 102400},
+		"SENS:FREQ:RES":         {Default: "400", Min: 100, Max: 1600},
+		"SENS:WIND:TYPE":        {Default: "HANN"},
+		"SENS:AVER:COUN":        {Default: "10", Min: 1, Max: 9999999},
+		"SENS:AVER:TYPE":        {Default: "RMS"},
+		"INP1:RANG":             {Default: "-51", Min: -51, Max: 27},
+		"INP2:RANG":             {Default: "-51", Min: -51, Max: 27},
+		"INP1:RANG:AUTO":        {Default: "1"},
+		"INP2:RANG:AUTO":        {Default: "1"},
+		"INP1:COUP":             {Default: "DC"},
+		"INP2:COUP":             {Default: "DC"},
+		"INIT:CONT":             {Default: "1"},
+		"CALC1:DATA":            {Default: simulatedTrace},
+		"CALC2:DATA":            {Default: simulatedTrace},
+		"CALC3:DATA":            {Default: simulatedTrace},
+		"CALC4:DATA":            {Default: simulatedTrace},
+		"CALC1:FEED":            {Default: `"XFR:POW 1"`},
+		"CALC2:FEED":            {Default: `"XFR:POW 2"`},
+		"CALC3:FEED":            {Default: `"XFR:POW 1"`},
+		"CALC4:FEED":            {Default: `"XFR:POW 2"`},
+		"SOUR:FUNC:SHAP":        {Default: "SIN"},
+		"SOUR:FREQ":             {Default: "1000", Min: 0, Max: 102400},
+		"SOUR:VOLT:LEV:IMM:AMP": {Min: 0, Max: 5},
+	},
+}
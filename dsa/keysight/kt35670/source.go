@@ -6,26 +6,34 @@
 package kt35670
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/gotmc/ivi"
 	"github.com/gotmc/ivi/dsa"
 	"github.com/gotmc/query"
 )
 
 // SourceEnabled determines if the source output is enabled or disabled.
-func (d *Driver) SourceEnabled() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) SourceEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Bool(ctx, d.inst, "OUTP?")
 }
 
 // SetSourceEnabled sets the source output to enabled or disabled.
-func (d *Driver) SetSourceEnabled(v bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetSourceEnabled(ctx context.Context, v bool) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetSourceEnabled: %w", err)
+	}
+	defer unlock()
+
 	if v {
 		return d.inst.Command(ctx, "OUTP ON")
 	}
@@ -34,18 +42,18 @@ func (d *Driver) SetSourceEnabled(v bool) error {
 }
 
 // DisableSource is a convenience function for disabling the source output.
-func (d *Driver) DisableSource() error {
-	return d.SetSourceEnabled(false)
+func (d *Driver) DisableSource(ctx context.Context) error {
+	return d.SetSourceEnabled(ctx, false)
 }
 
 // EnableSource is a convenience function for enabling the source output.
-func (d *Driver) EnableSource() error {
-	return d.SetSourceEnabled(true)
+func (d *Driver) EnableSource(ctx context.Context) error {
+	return d.SetSourceEnabled(ctx, true)
 }
 
 // SourceShape queries the source output waveform shape.
-func (d *Driver) SourceShape() (dsa.SourceShape, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) SourceShape(ctx context.Context) (dsa.SourceShape, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, d.inst, "SOUR:FUNC:SHAP?")
@@ -62,16 +70,19 @@ func (d *Driver) SourceShape() (dsa.SourceShape, error) {
 }
 
 // SetSourceShape sets the source output waveform shape.
-func (d *Driver) SetSourceShape(shape dsa.SourceShape) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetSourceShape(
+	ctx context.Context,
+	shape dsa.SourceShape,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "SOUR:FUNC:SHAP %s", shape)
 }
 
 // SourceFrequency queries the source output frequency in Hz.
-func (d *Driver) SourceFrequency() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) SourceFrequency(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "SOUR:FREQ?")
@@ -79,8 +90,8 @@ func (d *Driver) SourceFrequency() (float64, error) {
 
 // SetSourceFrequency sets the source output frequency in Hz. Allowable range
 // is 0 to 115 kHz in 15.625 mHz increments.
-func (d *Driver) SetSourceFrequency(freq float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetSourceFrequency(ctx context.Context, freq float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	if freq < 0 || freq > 115000 {
@@ -91,16 +102,19 @@ func (d *Driver) SetSourceFrequency(freq float64) error {
 }
 
 // SourceOutputLevel queries the source output level.
-func (d *Driver) SourceOutputLevel() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) SourceOutputLevel(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "SOUR:VOLT:LEV:IMM:AMP?")
 }
 
 // SetSourceOutputLevel sets the source output level.
-func (d *Driver) SetSourceOutputLevel(level float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetSourceOutputLevel(
+	ctx context.Context,
+	level float64,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "SOUR:VOLT:LEV:IMM:AMP %f", level)
@@ -0,0 +1,243 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultErrorQueryCommand is the SCPI query that reads and removes the oldest
+// entry from the instrument's error queue.
+const defaultErrorQueryCommand = "SYST:ERR?"
+
+// maxErrorQueueReads bounds how many entries are read while draining the
+// error queue. SCPI instruments report 0,"No error" once the queue is empty,
+// but an instrument that never does would otherwise loop forever.
+const maxErrorQueueReads = 64
+
+// ErrorQuery reads the instrument's error queue until it reports no error and
+// returns the entries in the order the instrument reported them. An empty
+// queue returns a nil slice. The returned error is non-nil only when the
+// queue could not be read or an entry could not be parsed; instrument errors
+// themselves are returned in the slice, not as the error. Command is empty on
+// the returned entries, since the queue does not record what caused them.
+//
+// The query defaults to SYST:ERR? and can be overridden for instruments with
+// a different dialect through [InherentBase].ErrorQueryCommand. For an
+// instrument without an error queue, as flagged by
+// [InherentBase].NoErrorQueue, ErrorQuery returns an error wrapping
+// [ErrFunctionNotSupported] without any I/O.
+//
+// ErrorQuery implements the Error Query function described in IVI-3.2:
+// Inherent Capabilities Specification.
+func (inherent *Inherent) ErrorQuery(
+	ctx context.Context,
+) ([]InstrumentError, error) {
+	if inherent.NoErrorQueue {
+		return nil, fmt.Errorf("ErrorQuery: %w", ErrFunctionNotSupported)
+	}
+
+	ctx, cancel := inherent.newContext(ctx)
+	defer cancel()
+
+	return drainErrorQueue(ctx, inherent.inst, inherent.errorQueryCommand())
+}
+
+// errorQueryCommand returns the configured error queue query, falling back
+// to SYST:ERR?.
+func (inherent *Inherent) errorQueryCommand() string {
+	if inherent.ErrorQueryCommand == "" {
+		return defaultErrorQueryCommand
+	}
+
+	return inherent.ErrorQueryCommand
+}
+
+// drainErrorQueue reads error queue entries with the given query until the
+// instrument reports error code 0.
+func drainErrorQueue(
+	ctx context.Context,
+	q Querier,
+	cmd string,
+) ([]InstrumentError, error) {
+	var errs []InstrumentError
+
+	for range maxErrorQueueReads {
+		resp, err := q.Query(ctx, cmd)
+		if err != nil {
+			return errs, fmt.Errorf("error querying error queue: %w", err)
+		}
+
+		instErr, err := parseInstrumentError(resp)
+		if err != nil {
+			return errs, err
+		}
+
+		if instErr.Code == 0 {
+			return errs, nil
+		}
+
+		errs = append(errs, instErr)
+	}
+
+	return errs, fmt.Errorf(
+		"%w: error queue not empty after %d reads",
+		ErrUnexpectedResponse, maxErrorQueueReads,
+	)
+}
+
+// parseInstrumentError parses one SCPI error queue entry of the form
+// <code>,"<message>", such as -113,"Undefined header". A bare code without a
+// message is also accepted.
+func parseInstrumentError(s string) (InstrumentError, error) {
+	s = strings.TrimSpace(s)
+	codeStr, msg, _ := strings.Cut(s, ",")
+
+	code, err := strconv.Atoi(strings.TrimSpace(codeStr))
+	if err != nil {
+		return InstrumentError{}, fmt.Errorf(
+			"%w: error queue entry %q", ErrUnexpectedResponse, s,
+		)
+	}
+
+	return InstrumentError{
+		Code:    code,
+		Message: strings.Trim(strings.TrimSpace(msg), `"`),
+	}, nil
+}
+
+// instrumentErrors returns nil for no entries, the single entry as an
+// *InstrumentError, or several entries joined with [errors.Join], so that
+// [errors.As] finds the first entry in every case.
+func instrumentErrors(errs []InstrumentError) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return &errs[0]
+	}
+
+	joined := make([]error, len(errs))
+	for i := range errs {
+		joined[i] = &errs[i]
+	}
+
+	return errors.Join(joined...)
+}
+
+// statusCheckingTransport wraps a Transport and drains the instrument's error
+// queue after every Command, so that a command the instrument rejects fails
+// at the call that sent it rather than as a confusing timeout on a later
+// query. It is installed by [NewDriverSetup] when the caller passes
+// [WithQueryInstrumentStatus].
+//
+// A query sent with Command, such as :WAV:DATA? whose block response is read
+// with ReadBinary, leaves a response pending. Querying the error queue then
+// would interrupt that query and read its response as the error queue entry,
+// so the queue is not read until [CheckInstrumentStatus] is called once the
+// response has been read, or the next command that is not a query is sent.
+type statusCheckingTransport struct {
+	Transport
+	errorQuery string
+	// pending is the last query sent with Command whose errors have not yet
+	// been checked.
+	pending string
+}
+
+// Command sends the command and then reads the error queue, returning any
+// entries as [InstrumentError] values that record the command that was sent.
+// A query is sent without reading the error queue.
+func (t *statusCheckingTransport) Command(
+	ctx context.Context,
+	cmd string,
+	a ...any,
+) error {
+	if err := t.Transport.Command(ctx, cmd, a...); err != nil {
+		return err
+	}
+
+	sent := FormatCommand(cmd, a)
+	if isQuery(sent) {
+		t.pending = sent
+		return nil
+	}
+
+	return t.check(ctx, sent)
+}
+
+// checkStatus reads the error queue, attributing any entries to the query
+// last sent with Command.
+func (t *statusCheckingTransport) checkStatus(ctx context.Context) error {
+	return t.check(ctx, t.pending)
+}
+
+// check reads the error queue, attributing any entries to sent.
+func (t *statusCheckingTransport) check(ctx context.Context, sent string) error {
+	t.pending = ""
+
+	errs, err := drainErrorQueue(ctx, t.Transport, t.errorQuery)
+	if err != nil {
+		return fmt.Errorf("checking error queue after %q: %w", sent, err)
+	}
+
+	for i := range errs {
+		errs[i].Command = sent
+	}
+
+	return instrumentErrors(errs)
+}
+
+// statusChecker is implemented by the transports [NewDriverSetup] installs,
+// which check the instrument status when [WithQueryInstrumentStatus] is set.
+type statusChecker interface {
+	checkStatus(ctx context.Context) error
+}
+
+// CheckInstrumentStatus reads the error queue of an instrument whose driver
+// was created with [WithQueryInstrumentStatus], returning any entries as
+// [InstrumentError] values. Drivers call it after reading the response to a
+// query sent with Command, such as a binary block read with [ReadBlock], for
+// which the error queue is not read when the query is sent. For any other
+// transport CheckInstrumentStatus does nothing and returns nil.
+func CheckInstrumentStatus(ctx context.Context, inst Transport) error {
+	if c, ok := inst.(statusChecker); ok {
+		return c.checkStatus(ctx)
+	}
+
+	return nil
+}
+
+// isQuery reports whether a program message includes a query, whose header
+// ends in a question mark, so that a response is pending once it is sent.
+func isQuery(msg string) bool {
+	for unit := range strings.SplitSeq(msg, ";") {
+		fields := strings.Fields(unit)
+		if len(fields) > 0 && strings.HasSuffix(fields[0], "?") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatCommand returns the command as sent to the instrument, with any
+// arguments applied and the trailing terminator trimmed. Format verbs are
+// only interpreted when there are arguments, so a literal "%" in a command
+// without them is kept as is. Recording, tracing, and replay all use it, so
+// a replayed command matches the recorded one. It takes the arguments as a
+// slice rather than variadically so that vet does not treat every
+// Transport.Command call as a printf wrapper.
+func FormatCommand(cmd string, a []any) string {
+	if len(a) > 0 {
+		cmd = fmt.Sprintf(cmd, a...)
+	}
+
+	return strings.TrimSpace(cmd)
+}
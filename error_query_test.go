@@ -0,0 +1,272 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// mockErrorQueueInst answers *IDN? with a fixed identity and every other
+// query by popping the next entry from errorQueue, reporting 0,"No error" once
+// the queue is empty, as a SCPI instrument does for SYST:ERR?.
+type mockErrorQueueInst struct {
+	commandsSent []string
+	queriesSent  []string
+	errorQueue   []string
+}
+
+func (m *mockErrorQueueInst) ReadBinary(_ context.Context, _ []byte) (int, error) {
+	return 0, nil
+}
+
+func (m *mockErrorQueueInst) WriteBinary(_ context.Context, p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (m *mockErrorQueueInst) Command(_ context.Context, format string, _ ...any) error {
+	m.commandsSent = append(m.commandsSent, format)
+	return nil
+}
+
+func (m *mockErrorQueueInst) Query(_ context.Context, s string) (string, error) {
+	m.queriesSent = append(m.queriesSent, s)
+	if s == "*IDN?" {
+		return "KEYSIGHT TECHNOLOGIES,34465A,MY54505281,A.03.01", nil
+	}
+	if len(m.errorQueue) == 0 {
+		return `+0,"No error"`, nil
+	}
+	resp := m.errorQueue[0]
+	m.errorQueue = m.errorQueue[1:]
+	return resp, nil
+}
+
+func TestParseInstrumentError(t *testing.T) {
+	tests := map[string]struct {
+		given    string
+		wantCode int
+		wantMsg  string
+	}{
+		"no error":        {`+0,"No error"`, 0, "No error"},
+		"undefined":       {`-113,"Undefined header"` + "\n", -113, "Undefined header"},
+		"comma in detail": {`-222,"Data out of range;VOLT 99,(@1)"`, -222, "Data out of range;VOLT 99,(@1)"},
+		"bare code":       {"0", 0, ""},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseInstrumentError(tt.given)
+			if err != nil {
+				t.Fatalf("parseInstrumentError(%q) error: %v", tt.given, err)
+			}
+			if got.Code != tt.wantCode || got.Message != tt.wantMsg {
+				t.Errorf("parseInstrumentError(%q) = %d, %q; want %d, %q",
+					tt.given, got.Code, got.Message, tt.wantCode, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestParseInstrumentError_Malformed(t *testing.T) {
+	_, err := parseInstrumentError(`"No error"`)
+	if !errors.Is(err, ErrUnexpectedResponse) {
+		t.Errorf("error = %v, want ErrUnexpectedResponse", err)
+	}
+}
+
+func TestInherent_ErrorQuery(t *testing.T) {
+	mock := &mockErrorQueueInst{
+		errorQueue: []string{
+			`-113,"Undefined header"`,
+			`-222,"Data out of range"`,
+		},
+	}
+	inherent := NewInherent(mock, InherentBase{}, 0)
+
+	errs, err := inherent.ErrorQuery(t.Context())
+	if err != nil {
+		t.Fatalf("ErrorQuery() error: %v", err)
+	}
+	if len(errs) != 2 || errs[0].Code != -113 || errs[1].Code != -222 {
+		t.Errorf("ErrorQuery() = %+v, want codes -113 and -222", errs)
+	}
+	if len(mock.queriesSent) != 3 || mock.queriesSent[0] != "SYST:ERR?" {
+		t.Errorf("queries sent = %v, want three SYST:ERR?", mock.queriesSent)
+	}
+}
+
+func TestInherent_ErrorQuery_Empty(t *testing.T) {
+	inherent := NewInherent(&mockErrorQueueInst{}, InherentBase{}, 0)
+
+	errs, err := inherent.ErrorQuery(t.Context())
+	if err != nil {
+		t.Fatalf("ErrorQuery() error: %v", err)
+	}
+	if errs != nil {
+		t.Errorf("ErrorQuery() = %+v, want nil", errs)
+	}
+}
+
+func TestInherent_ErrorQuery_CustomCommand(t *testing.T) {
+	mock := &mockErrorQueueInst{}
+	inherent := NewInherent(mock, InherentBase{ErrorQueryCommand: "ERR?"}, 0)
+
+	if _, err := inherent.ErrorQuery(t.Context()); err != nil {
+		t.Fatalf("ErrorQuery() error: %v", err)
+	}
+	if len(mock.queriesSent) != 1 || mock.queriesSent[0] != "ERR?" {
+		t.Errorf("queries sent = %v, want [ERR?]", mock.queriesSent)
+	}
+}
+
+func TestInherent_ErrorQuery_NoErrorQueue(t *testing.T) {
+	mock := &mockErrorQueueInst{}
+	inherent := NewInherent(mock, InherentBase{NoErrorQueue: true}, 0)
+
+	_, err := inherent.ErrorQuery(t.Context())
+	if !errors.Is(err, ErrFunctionNotSupported) {
+		t.Errorf("ErrorQuery() error = %v, want ErrFunctionNotSupported", err)
+	}
+	if len(mock.queriesSent) != 0 {
+		t.Errorf("queries sent = %v, want none", mock.queriesSent)
+	}
+}
+
+func TestWithQueryInstrumentStatus_NoErrorQueue(t *testing.T) {
+	_, err := NewDriverSetup(
+		&mockErrorQueueInst{},
+		InherentBase{NoErrorQueue: true},
+		[]DriverOption{WithoutIDQuery(), WithQueryInstrumentStatus()},
+	)
+	if !errors.Is(err, ErrFunctionNotSupported) {
+		t.Errorf("NewDriverSetup() error = %v, want ErrFunctionNotSupported",
+			err)
+	}
+}
+
+func TestWithQueryInstrumentStatus(t *testing.T) {
+	mock := &mockErrorQueueInst{}
+	s, err := NewDriverSetup(
+		mock,
+		InherentBase{SupportedInstrumentModels: []string{"34465A"}},
+		[]DriverOption{WithQueryInstrumentStatus()},
+	)
+	if err != nil {
+		t.Fatalf("NewDriverSetup() error: %v", err)
+	}
+
+	if err := s.Transport.Command(t.Context(), "VOLT %.1f", 5.0); err != nil {
+		t.Fatalf("Command() with empty error queue returned %v", err)
+	}
+
+	mock.errorQueue = []string{`-113,"Undefined header"`}
+	err = s.Transport.Command(t.Context(), "VOLTS %.1f", 5.0)
+
+	var instErr *InstrumentError
+	if !errors.As(err, &instErr) {
+		t.Fatalf("Command() error = %v, want an *InstrumentError", err)
+	}
+	if instErr.Code != -113 || instErr.Command != "VOLTS 5.0" {
+		t.Errorf("InstrumentError = %+v, want code -113 for \"VOLTS 5.0\"", instErr)
+	}
+
+	// Reset goes through the same transport, so it is checked as well.
+	mock.errorQueue = []string{`-100,"Command error"`}
+	if err := s.Inherent.Reset(t.Context()); !errors.As(err, &instErr) {
+		t.Errorf("Reset() error = %v, want an *InstrumentError", err)
+	}
+}
+
+func TestWithQueryInstrumentStatus_Off(t *testing.T) {
+	mock := &mockErrorQueueInst{errorQueue: []string{`-113,"Undefined header"`}}
+	s, err := NewDriverSetup(
+		mock,
+		InherentBase{SupportedInstrumentModels: []string{"34465A"}},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewDriverSetup() error: %v", err)
+	}
+
+	if err := s.Transport.Command(t.Context(), "VOLTS 5"); err != nil {
+		t.Errorf("Command() error = %v, want nil without the option", err)
+	}
+	if len(mock.errorQueue) != 1 {
+		t.Error("the error queue was read without WithQueryInstrumentStatus")
+	}
+}
+
+func TestWithQueryInstrumentStatus_QueryViaCommand(t *testing.T) {
+	mock := &mockErrorQueueInst{}
+	s, err := NewDriverSetup(
+		mock,
+		InherentBase{SupportedInstrumentModels: []string{"34465A"}},
+		[]DriverOption{WithQueryInstrumentStatus()},
+	)
+	if err != nil {
+		t.Fatalf("NewDriverSetup() error: %v", err)
+	}
+
+	mock.queriesSent = nil
+	mock.errorQueue = []string{`-222,"Data out of range"`}
+
+	// Reading the error queue now would interrupt the pending block
+	// response.
+	if err := s.Transport.Command(t.Context(), ":WAV:SOUR CHAN1;DATA?"); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+	if len(mock.queriesSent) != 0 {
+		t.Fatalf("queried %q with a response pending", mock.queriesSent)
+	}
+
+	err = CheckInstrumentStatus(t.Context(), s.Transport)
+
+	var instErr *InstrumentError
+	if !errors.As(err, &instErr) {
+		t.Fatalf("CheckInstrumentStatus() error = %v, want an *InstrumentError", err)
+	}
+	if instErr.Code != -222 || instErr.Command != ":WAV:SOUR CHAN1;DATA?" {
+		t.Errorf("InstrumentError = %+v, want code -222 for the query", instErr)
+	}
+
+	if err := CheckInstrumentStatus(t.Context(), mock); err != nil {
+		t.Errorf("CheckInstrumentStatus() of a bare transport = %v, want nil", err)
+	}
+}
+
+func TestIsQuery(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want bool
+	}{
+		{":WAV:DATA?", true},
+		{"*OPC?", true},
+		{":WAV:SOUR CHAN1;DATA?", true},
+		{"TRAC:DATA? TRACE1", true},
+		{"VOLT 5", false},
+		{`DISP:TEXT "Ready?"`, false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isQuery(tt.msg); got != tt.want {
+			t.Errorf("isQuery(%q) = %t, want %t", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestInstrumentErrors_JoinsSeveral(t *testing.T) {
+	err := instrumentErrors([]InstrumentError{
+		{Code: -113, Message: "Undefined header"},
+		{Code: -222, Message: "Data out of range"},
+	})
+
+	var instErr *InstrumentError
+	if !errors.As(err, &instErr) || instErr.Code != -113 {
+		t.Errorf("errors.As found %+v, want the first entry", instErr)
+	}
+}
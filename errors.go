@@ -5,7 +5,12 @@
 
 package ivi
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gotmc/ivi/internal/block"
+)
 
 // Sentinel errors returned by the ivi package and by driver implementations.
 // Callers should use [errors.Is] to check for these rather than comparing
@@ -30,4 +35,49 @@ var (
 	// ErrUnsupportedModel indicates the connected instrument's model is not in
 	// the driver's SupportedInstrumentModels list.
 	ErrUnsupportedModel = errors.New("unsupported instrument model")
+	// ErrInvalidRecording indicates a recording read by [ReadRecording] is
+	// not one written by a [RecordingTransport].
+	ErrInvalidRecording = errors.New("invalid recording")
+	// ErrNotLocked indicates a session lock was released with a context that
+	// does not hold it.
+	ErrNotLocked = errors.New("session lock not held")
+	// ErrDisconnected indicates a [ReconnectingTransport] lost its connection
+	// and could not open a new one.
+	ErrDisconnected = errors.New("instrument connection lost")
+	// ErrUnknownDriver indicates no driver is registered under the requested
+	// name.
+	ErrUnknownDriver = errors.New("unknown driver")
+	// ErrInvalidResource indicates a VISA resource string passed to
+	// [ParseResource] is malformed.
+	ErrInvalidResource = errors.New("invalid resource string")
+	// ErrSafetyLimit indicates a setting was rejected because it exceeds a
+	// user-defined safety limit of the device under test.
+	ErrSafetyLimit = errors.New("safety limit exceeded")
+	// ErrInvalidBlock indicates data read as IEEE 488.2 arbitrary block data
+	// does not have a valid block header, or ends before the length it
+	// declares.
+	ErrInvalidBlock = block.ErrInvalid
 )
+
+// InstrumentError is one entry read from an instrument's error queue, such as
+// the -113,"Undefined header" returned by SYST:ERR? after a command the
+// instrument did not recognize. Code and Message are as reported by the
+// instrument. Command is the command that caused the error when it is known,
+// which is the case for errors surfaced by [WithQueryInstrumentStatus], and
+// empty otherwise.
+type InstrumentError struct {
+	Code    int
+	Message string
+	Command string
+}
+
+// Error implements the error interface for InstrumentError.
+func (e *InstrumentError) Error() string {
+	if e.Command == "" {
+		return fmt.Sprintf("instrument error %d: %s", e.Code, e.Message)
+	}
+
+	return fmt.Sprintf(
+		"instrument error %d: %s (after %q)", e.Code, e.Message, e.Command,
+	)
+}
@@ -10,3 +10,97 @@
 // Files are split based on the class capability groups listed in Table 2-1
 // IviFgen Group Names in the IVI-4.3 IviFgen Class Specification.
 package fgen
+
+import (
+	"reflect"
+
+	"github.com/gotmc/ivi"
+)
+
+// Groups lists the capability groups of the IviFgen class, with the Go
+// interfaces that implement each, for [ivi.Introspect] and
+// [ivi.CheckGroupCapabilities].
+var Groups = []ivi.CapabilityGroup{
+	{
+		Name:    "IviFgenBase",
+		Driver:  reflect.TypeFor[Base](),
+		Channel: reflect.TypeFor[BaseChannel](),
+	},
+	{Name: "IviFgenStdFunc", Channel: reflect.TypeFor[StdFuncChannel]()},
+	{
+		Name:    "IviFgenArbWfm",
+		Driver:  reflect.TypeFor[ArbWfm](),
+		Channel: reflect.TypeFor[ArbWfmChannel](),
+	},
+	{
+		Name:    "IviFgenArbFrequency",
+		Channel: reflect.TypeFor[ArbFrequencyChannel](),
+	},
+	{
+		Name:    "IviFgenArbSeq",
+		Driver:  reflect.TypeFor[ArbSeq](),
+		Channel: reflect.TypeFor[ArbSeqChannel](),
+	},
+	{Name: "IviFgenTrigger", Channel: reflect.TypeFor[TriggerChannel]()},
+	{
+		Name:    "IviFgenStartTrigger",
+		Channel: reflect.TypeFor[StartTriggerChannel](),
+	},
+	{
+		Name:    "IviFgenStopTrigger",
+		Driver:  reflect.TypeFor[StopTrigger](),
+		Channel: reflect.TypeFor[StopTriggerChannel](),
+	},
+	{
+		Name:    "IviFgenHoldTrigger",
+		Driver:  reflect.TypeFor[HoldTrigger](),
+		Channel: reflect.TypeFor[HoldTriggerChannel](),
+	},
+	{
+		Name:    "IviFgenResumeTrigger",
+		Driver:  reflect.TypeFor[ResumeTrigger](),
+		Channel: reflect.TypeFor[ResumeTriggerChannel](),
+	},
+	{
+		Name:    "IviFgenAdvanceTrigger",
+		Driver:  reflect.TypeFor[AdvanceTrigger](),
+		Channel: reflect.TypeFor[AdvanceTriggerChannel](),
+	},
+	{
+		Name:    "IviFgenInternalTrigger",
+		Channel: reflect.TypeFor[IntTriggerChannel](),
+	},
+	{
+		Name:   "IviFgenSoftwareTrigger",
+		Driver: reflect.TypeFor[SoftwareTrigger](),
+	},
+	{Name: "IviFgenBurst", Channel: reflect.TypeFor[BurstChannel]()},
+	{
+		Name:    "IviFgenModulateAM",
+		Driver:  reflect.TypeFor[ModulateAM](),
+		Channel: reflect.TypeFor[ModulateAMChannel](),
+	},
+	{
+		Name:    "IviFgenModulateFM",
+		Driver:  reflect.TypeFor[ModulateFM](),
+		Channel: reflect.TypeFor[ModulateFMChannel](),
+	},
+	{Name: "IviFgenSampleClock", Driver: reflect.TypeFor[SampleClock]()},
+	{
+		Name:   "IviFgenTerminalConfiguration",
+		Driver: reflect.TypeFor[TerminalConfigurator](),
+	},
+}
+
+// Drivers holds the IviFgen drivers, by the instrument models they support.
+// Each driver registers itself from an init function, so importing a driver
+// package, if only for its side effects, makes it available to Drivers.Open,
+// which picks the driver for the connected instrument's model, and to
+// Drivers.OpenDriver, which creates a driver by name. Both return the driver as
+// a Base; assert it to other IviFgen interfaces, or to the driver's own type,
+// for the capabilities beyond the base group. See [ivi.Registry].
+//
+//	import _ "github.com/gotmc/ivi/fgen/keysight/kt33000"
+//
+//	gen, err := fgen.Drivers.Open(ctx, inst)
+var Drivers ivi.Registry[Base]
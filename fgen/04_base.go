@@ -5,6 +5,8 @@
 
 package fgen
 
+import "context"
+
 /*
 
 # Section 4 IviFgenBase Capability Group
@@ -41,22 +43,24 @@ Below are the .NET functions, since they are the basis for the Go interfaces.
 // Base provides the interface required for the IviFgenBase capability group.
 type Base interface {
 	OutputCount() int
-	OutputMode() (OutputMode, error)
-	SetOutputMode(mode OutputMode) error
-	ReferenceClockSource() (ClockSource, error)
-	SetReferenceClockSource(src ClockSource) error
-	AbortGeneration() error
-	InitiateGeneration() error
+	OutputName(index int) (string, error)
+	OutputItem(name string) (BaseChannel, error)
+	OutputMode(ctx context.Context) (OutputMode, error)
+	SetOutputMode(ctx context.Context, mode OutputMode) error
+	ReferenceClockSource(ctx context.Context) (ClockSource, error)
+	SetReferenceClockSource(ctx context.Context, src ClockSource) error
+	AbortGeneration(ctx context.Context) error
+	InitiateGeneration(ctx context.Context) error
 }
 
 // BaseChannel provides the interface required for the channel repeated
 // capability for the IviFgenBase capability group.
 type BaseChannel interface {
 	Name() string
-	OperationMode() (OperationMode, error)
-	SetOperationMode(mode OperationMode) error
-	OutputEnabled() (bool, error)
-	SetOutputEnabled(b bool) error
-	OutputImpedance() (float64, error)
-	SetOutputImpedance(impedance float64) error
+	OperationMode(ctx context.Context) (OperationMode, error)
+	SetOperationMode(ctx context.Context, mode OperationMode) error
+	OutputEnabled(ctx context.Context) (bool, error)
+	SetOutputEnabled(ctx context.Context, b bool) error
+	OutputImpedance(ctx context.Context) (float64, error)
+	SetOutputImpedance(ctx context.Context, impedance float64) error
 }
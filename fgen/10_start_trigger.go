@@ -5,7 +5,11 @@
 
 package fgen
 
-import "time"
+import (
+	"context"
+
+	"time"
+)
 
 /*
 
@@ -54,13 +58,17 @@ Below are the .NET functions, since they are the basis for the Go interfaces.
 // StartTriggerChannel provides the interface for the channel repeated
 // capability for the IviFgenStartTrigger extension group.
 type StartTriggerChannel interface {
-	StartTriggerDelay() (time.Duration, error)
-	SetStartTriggerDelay(delay time.Duration) error
-	StartTriggerSlope() (TriggerSlope, error)
-	SetStartTriggerSlope(slope TriggerSlope) error
-	StartTriggerSource() (TriggerSource, error)
-	SetStartTriggerSource(source TriggerSource) error
-	StartTriggerThreshold() (float64, error)
-	SetStartTriggerThreshold(threshold float64) error
-	StartTriggerConfigure(source TriggerSource, slope TriggerSlope) error
+	StartTriggerDelay(ctx context.Context) (time.Duration, error)
+	SetStartTriggerDelay(ctx context.Context, delay time.Duration) error
+	StartTriggerSlope(ctx context.Context) (TriggerSlope, error)
+	SetStartTriggerSlope(ctx context.Context, slope TriggerSlope) error
+	StartTriggerSource(ctx context.Context) (TriggerSource, error)
+	SetStartTriggerSource(ctx context.Context, source TriggerSource) error
+	StartTriggerThreshold(ctx context.Context) (float64, error)
+	SetStartTriggerThreshold(ctx context.Context, threshold float64) error
+	StartTriggerConfigure(
+		ctx context.Context,
+		source TriggerSource,
+		slope TriggerSlope,
+	) error
 }
@@ -5,6 +5,8 @@
 
 package fgen
 
+import "context"
+
 /*
 
 The following information is from the IVI-4.3: IviFgen Class Specification date
@@ -48,6 +50,6 @@ None.
 // burst periods per channel. Single-channel instruments are unaffected since
 // they have only one channel.
 type IntTriggerChannel interface {
-	InternalTriggerRate() (float64, error)
-	SetInternalTriggerRate(rate float64) error
+	InternalTriggerRate(ctx context.Context) (float64, error)
+	SetInternalTriggerRate(ctx context.Context, rate float64) error
 }
@@ -5,6 +5,8 @@
 
 package fgen
 
+import "context"
+
 /*
 
 The following information is from the IVI-4.3: IviFgen Class Specification date
@@ -48,6 +50,6 @@ None.
 // BurstChannel provides the interface for the channel repeated capability for
 // the IviFgenBurst extension group.
 type BurstChannel interface {
-	BurstCount() (int, error)
-	SetBurstCount(count int) error
+	BurstCount(ctx context.Context) (int, error)
+	SetBurstCount(ctx context.Context, count int) error
 }
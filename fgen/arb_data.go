@@ -0,0 +1,50 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package fgen
+
+import (
+	"math"
+
+	"github.com/gotmc/ivi"
+)
+
+// ArbitraryData converts a recorded waveform into arbitrary waveform data,
+// which the IviFgenArbWfm extension group requires to lie between -1 and 1,
+// together with the Arbitrary Gain and Arbitrary Offset that reproduce the
+// recorded voltages, since the generator outputs data×gain + offset. Samples
+// that are not valid are set to the offset level. Play the data back at the
+// recorded rate by setting the Arbitrary Sample Rate to 1/wf.XIncrement().
+func ArbitraryData(wf *ivi.Waveform) (data []float64, gain, offset float64) {
+	items, _ := wf.AllElements()
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, v := range items {
+		if wf.Valid(i) && !math.IsNaN(v) && !math.IsInf(v, 0) {
+			lo = min(lo, v)
+			hi = max(hi, v)
+		}
+	}
+
+	data = make([]float64, len(items))
+	if lo > hi {
+		return data, 0, 0
+	}
+
+	gain = (hi - lo) / 2
+	offset = (hi + lo) / 2
+
+	if gain == 0 {
+		return data, 0, offset
+	}
+
+	for i, v := range items {
+		if wf.Valid(i) && !math.IsNaN(v) && !math.IsInf(v, 0) {
+			data[i] = max(-1, min(1, (v-offset)/gain))
+		}
+	}
+
+	return data, gain, offset
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package fgen_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/fgen"
+)
+
+func TestArbitraryData(t *testing.T) {
+	wf := ivi.NewWaveform([]float64{1, 3, 5, 99, 2}, ivi.WaveformInfo{})
+	wf.SetStatus(3, ivi.SampleOverRange)
+
+	data, gain, offset := fgen.ArbitraryData(wf)
+
+	if gain != 2 || offset != 3 {
+		t.Errorf("gain, offset = %g, %g, want 2, 3", gain, offset)
+	}
+	if want := []float64{-1, 0, 1, 0, -0.5}; !slices.Equal(data, want) {
+		t.Errorf("data = %v, want %v", data, want)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package fgen
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/gotmc/ivi"
+)
+
+// SafetyLimits are the most one output may apply to a device under test. They
+// belong to the device, so they are set by the user and are independent of
+// the function generator's ratings. A zero field is not checked.
+type SafetyLimits struct {
+	// MaxAmplitude is the largest peak-to-peak amplitude in volts.
+	MaxAmplitude float64
+	// MaxVoltage is the largest instantaneous voltage magnitude in volts,
+	// which is the DC offset magnitude plus half the amplitude.
+	MaxVoltage float64
+}
+
+// StdFuncOutput is the channel of a function generator that generates
+// standard waveforms, which is what a [GuardedChannel] wraps.
+type StdFuncOutput interface {
+	BaseChannel
+	StdFuncChannel
+}
+
+// GuardedChannel is a [StdFuncOutput] that rejects settings exceeding its
+// [SafetyLimits] before they reach the instrument, returning an error
+// wrapping [ivi.ErrSafetyLimit]. Setting the amplitude or the DC offset,
+// configuring a standard waveform, and enabling the output are checked. A
+// check that needs the setting not being changed, such as the peak voltage
+// check when setting the amplitude, reads it back from the instrument, so
+// enabling the output also catches settings made before the channel was
+// guarded. The checks treat every waveform as swinging half the amplitude
+// either side of the offset, which is conservative for a DC waveform set
+// other than through ConfigureStandardWaveform. All other methods pass
+// through to the wrapped channel.
+type GuardedChannel struct {
+	StdFuncOutput
+	limits SafetyLimits
+}
+
+// Guard wraps ch so that its settings are checked against limits.
+func Guard(ch StdFuncOutput, limits SafetyLimits) *GuardedChannel {
+	return &GuardedChannel{StdFuncOutput: ch, limits: limits}
+}
+
+// Limits returns the safety limits the channel is guarded by.
+func (g *GuardedChannel) Limits() SafetyLimits {
+	return g.limits
+}
+
+// SetAmplitude sets the peak-to-peak amplitude after checking it, and the
+// peak voltage it gives with the DC offset, against the safety limits.
+func (g *GuardedChannel) SetAmplitude(ctx context.Context, amp float64) error {
+	if err := g.check(ctx, &amp, nil); err != nil {
+		return fmt.Errorf("SetAmplitude: %w", err)
+	}
+
+	return g.StdFuncOutput.SetAmplitude(ctx, amp)
+}
+
+// SetDCOffset sets the DC offset after checking the peak voltage it gives
+// with the amplitude against the safety limits.
+func (g *GuardedChannel) SetDCOffset(
+	ctx context.Context,
+	offset float64,
+) error {
+	if err := g.check(ctx, nil, &offset); err != nil {
+		return fmt.Errorf("SetDCOffset: %w", err)
+	}
+
+	return g.StdFuncOutput.SetDCOffset(ctx, offset)
+}
+
+// ConfigureStandardWaveform configures the standard waveform after checking
+// the amplitude and the peak voltage against the safety limits. The
+// amplitude of a DC waveform is ignored, as the instrument ignores it.
+func (g *GuardedChannel) ConfigureStandardWaveform(
+	ctx context.Context,
+	wave StandardWaveform,
+	amp, offset, freq, phase float64,
+) error {
+	checked := amp
+	if wave == DC {
+		checked = 0
+	}
+
+	if err := g.check(ctx, &checked, &offset); err != nil {
+		return fmt.Errorf("ConfigureStandardWaveform: %w", err)
+	}
+
+	return g.StdFuncOutput.ConfigureStandardWaveform(
+		ctx, wave, amp, offset, freq, phase,
+	)
+}
+
+// SetOutputEnabled enables or disables the output. Before enabling, it reads
+// the amplitude and DC offset back and checks them against the safety
+// limits. Disabling is never checked.
+func (g *GuardedChannel) SetOutputEnabled(ctx context.Context, b bool) error {
+	if b {
+		if err := g.check(ctx, nil, nil); err != nil {
+			return fmt.Errorf("SetOutputEnabled: %w", err)
+		}
+	}
+
+	return g.StdFuncOutput.SetOutputEnabled(ctx, b)
+}
+
+// check checks an amplitude and DC offset against the safety limits. A nil
+// setting is one not being changed; it is read from the instrument when the
+// peak voltage check needs it, or when enabling, which passes neither.
+func (g *GuardedChannel) check(
+	ctx context.Context,
+	amp, offset *float64,
+) error {
+	enabling := amp == nil && offset == nil
+
+	if amp == nil &&
+		(g.limits.MaxVoltage != 0 || enabling && g.limits.MaxAmplitude != 0) {
+		a, err := g.Amplitude(ctx)
+		if err != nil {
+			return err
+		}
+		amp = &a
+	}
+
+	if offset == nil && g.limits.MaxVoltage != 0 {
+		o, err := g.DCOffset(ctx)
+		if err != nil {
+			return err
+		}
+		offset = &o
+	}
+
+	if amp != nil {
+		err := ivi.CheckSafetyLimit("amplitude", "Vpp", *amp, g.limits.MaxAmplitude)
+		if err != nil {
+			return err
+		}
+	}
+
+	if amp != nil && offset != nil {
+		peak := math.Abs(*offset) + math.Abs(*amp)/2
+
+		return ivi.CheckSafetyLimit("peak voltage", "V", peak, g.limits.MaxVoltage)
+	}
+
+	return nil
+}
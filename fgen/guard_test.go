@@ -0,0 +1,72 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package fgen_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/fgen"
+	"github.com/gotmc/ivi/fgen/keysight/kt33000"
+)
+
+func TestGuardedChannel(t *testing.T) {
+	ctx := t.Context()
+
+	d, err := kt33000.New(
+		nil, ivi.WithSimulate(), ivi.WithSimulatedModel("33522B"),
+	)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	ch, err := d.Channel(0)
+	if err != nil {
+		t.Fatalf("Channel(0) error: %v", err)
+	}
+	g := fgen.Guard(ch, fgen.SafetyLimits{MaxAmplitude: 4, MaxVoltage: 3})
+
+	if err := g.SetAmplitude(ctx, 2); err != nil {
+		t.Fatalf("SetAmplitude(2) error: %v", err)
+	}
+	if err := g.SetDCOffset(ctx, 1.5); err != nil {
+		t.Fatalf("SetDCOffset(1.5) error: %v", err)
+	}
+	if err := g.SetOutputEnabled(ctx, true); err != nil {
+		t.Fatalf("SetOutputEnabled(true) error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		set  func() error
+	}{
+		{"amplitude", func() error { return g.SetAmplitude(ctx, 5) }},
+		{"amplitude plus offset", func() error { return g.SetAmplitude(ctx, 4) }},
+		{"offset plus amplitude", func() error { return g.SetDCOffset(ctx, -2.5) }},
+		{"configure", func() error {
+			return g.ConfigureStandardWaveform(ctx, fgen.Sine, 3, 2, 1e3, 0)
+		}},
+		{"configure DC", func() error {
+			return g.ConfigureStandardWaveform(ctx, fgen.DC, 0, 3.5, 1e3, 0)
+		}},
+	}
+
+	for _, tt := range tests {
+		if err := tt.set(); !errors.Is(err, ivi.ErrSafetyLimit) {
+			t.Errorf("%s: error = %v, want ErrSafetyLimit", tt.name, err)
+		}
+	}
+
+	if got, _ := g.Amplitude(ctx); got != 2 {
+		t.Errorf("Amplitude() = %v after rejected settings, want 2", got)
+	}
+
+	// A DC waveform's amplitude is ignored, so only the offset counts.
+	err = g.ConfigureStandardWaveform(ctx, fgen.DC, 10, 3, 1e3, 0)
+	if err != nil {
+		t.Errorf("ConfigureStandardWaveform(DC) error: %v", err)
+	}
+}
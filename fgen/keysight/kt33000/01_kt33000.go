@@ -18,7 +18,13 @@
 // The 33500B and 33600A models use LAN port 5025 for SCPI Socket sessions.
 // The default GPIB address is 10.
 //
-// State Caching: Not implemented
+// State Caching: Implemented for the IviFgenStdFunc attributes when the
+// driver is created with [ivi.WithCache].
+//
+// Range Checking: Frequency, against the model's maximum sine wave frequency,
+// when the driver is created with [ivi.WithRangeCheck]. For a model whose
+// maximum frequency is not recorded, such as the 33502A, New returns an error
+// wrapping [ivi.ErrFunctionNotSupported].
 package kt33000
 
 import (
@@ -38,6 +44,9 @@ const (
 	defaultGPIBAddress = 10
 	telnetPort         = 5024
 	socketPort         = 5025
+	// minFrequency is the lowest output frequency in hertz of every
+	// supported model.
+	minFrequency = 1e-6
 )
 
 // Confirm the interfaces implemented by the driver.
@@ -51,6 +60,16 @@ var _ fgen.BurstChannel = (*Channel)(nil)
 var _ fgen.ArbWfm = (*Driver)(nil)
 var _ fgen.ArbWfmChannel = (*Channel)(nil)
 
+// Register the driver with [fgen.Drivers] so that Open can select it by model,
+// and its connection defaults so that a config session can complete its
+// resource.
+func init() {
+	fgen.Drivers.Register(
+		"kt33000", supportedModels(), ivi.Factory[fgen.Base](New),
+	)
+	ivi.RegisterConnectionDefaults("kt33000", ConnectionDefaults())
+}
+
 // Driver provides the IVI driver for the Keysight 33000 series
 // function/arbitrary waveform generators.
 type Driver struct {
@@ -74,22 +93,26 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		ResetDelay:            500 * time.Millisecond,
 		ClearDelay:            500 * time.Millisecond,
 		ReturnToLocal:         true,
+		SupportsOPC:           true,
 		GroupCapabilities: []string{
 			"IviFgenBase",
+			"IviFgenArbWfm",
 			"IviFgenBurst",
 			"IviFgenInternalTrigger",
-			"IviFgenStdfunc",
+			"IviFgenStartTrigger",
+			"IviFgenStdFunc",
 			"IviFgenTrigger",
 		},
 		SupportedInstrumentModels: supportedModels(),
 		SupportedBusInterfaces:    []string{"TCPIP", "GPIB", "USB"},
+		Simulation:                &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	// Channel configuration depends on the instrument model.
-	model, err := s.Inherent.InstrumentModel()
+	model, err := s.Inherent.InstrumentModel(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("error determining instrument model: %w", err)
 	}
@@ -103,16 +126,26 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	// specific, and the family is not known until the model is queried.
 	s.Inherent.LocalControlCommand = gen.family.localControlCommand()
 
+	if s.Config.RangeCheck && gen.bandwidthHz == 0 {
+		return nil, ivi.RangeLimitsNotRecorded(gen.model)
+	}
+
 	channels := make([]Channel, len(gen.channels))
 	for i, name := range gen.channels {
 		channels[i] = Channel{
-			name: name, inst: inst, num: i,
+			name: name, inst: s.Transport, cache: s.Cache, num: i,
 			family: gen.family, timeout: s.Timeout,
 		}
+
+		if s.Config.RangeCheck {
+			channels[i].frequency = &ivi.Range{
+				Min: minFrequency, Max: float64(gen.bandwidthHz),
+			}
+		}
 	}
 
 	driver := Driver{
-		inst:     inst,
+		inst:     s.Transport,
 		gen:      gen,
 		channels: channels,
 		timeout:  s.Timeout,
@@ -120,7 +153,7 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	}
 
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
+		if err := driver.Reset(context.Background()); err != nil {
 			return nil, err
 		}
 	}
@@ -278,9 +311,12 @@ func (d *Driver) SupportsArbWaveform() bool {
 	return d.gen.arbWaveforms
 }
 
-// newContext creates a context with the driver's configured timeout.
-func (d *Driver) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), d.timeout)
+// newContext derives a context from ctx that carries the driver's configured
+// timeout, unless ctx already has a deadline of its own.
+func (d *Driver) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, d.timeout)
 }
 
 // Channel returns the Channel at the given index, with bounds checking.
@@ -317,19 +353,40 @@ func LANPorts() map[string]int {
 	}
 }
 
+// ConnectionDefaults returns the model's connection defaults, which
+// complete a VISA resource string with [ivi.Resource.Settings].
+func ConnectionDefaults() ivi.ConnectionDefaults {
+	return ivi.ConnectionDefaults{
+		Interfaces:  AvailableCOMPorts(),
+		GPIBAddress: DefaultGPIBAddress(),
+		LANPorts:    LANPorts(),
+	}
+}
+
 // Channel models the output channel repeated capability for the function
 // generator output channel.
 type Channel struct {
 	inst    ivi.Transport
+	cache   *ivi.AttributeCache // nil unless ivi.WithCache is given
 	name    string
 	num     int // 0-based channel index
 	family  scpiFamily
 	timeout time.Duration
+	// frequency is nil unless range checking the frequency.
+	frequency *ivi.Range
+}
+
+// cacheKey returns the attribute cache key for the channel's attribute.
+func (ch *Channel) cacheKey(attribute string) string {
+	return ch.name + "/" + attribute
 }
 
-// newContext creates a context with the channel's configured timeout.
-func (ch *Channel) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), ch.timeout)
+// newContext derives a context from ctx that carries the channel's configured
+// timeout, unless ctx already has a deadline of its own.
+func (ch *Channel) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, ch.timeout)
 }
 
 // srcPrefix returns the SCPI source subsystem prefix for this channel, such
@@ -6,6 +6,7 @@
 package kt33000
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -169,49 +170,51 @@ func TestDriver_MaxFrequency(t *testing.T) {
 // exerciseChannel calls every Channel method that reaches the transport.
 // Errors are ignored: only the strings that reach the wire are under test.
 func exerciseChannel(ch *Channel) {
-	_, _ = ch.OperationMode()
-	_ = ch.SetOperationMode(fgen.BurstMode)
-	_ = ch.SetOperationMode(fgen.ContinuousMode)
-	_, _ = ch.OutputEnabled()
-	_ = ch.SetOutputEnabled(true)
-	_ = ch.DisableOutput()
-	_ = ch.EnableOutput()
-	_, _ = ch.OutputImpedance()
-	_ = ch.SetOutputImpedance(50.0)
-	_ = ch.AbortGeneration()
-	_, _ = ch.Amplitude()
-	_ = ch.SetAmplitude(2.5)
-	_, _ = ch.DCOffset()
-	_ = ch.SetDCOffset(0.5)
-	_, _ = ch.DutyCycleHigh()
-	_ = ch.SetDutyCycleHigh(50.0)
-	_, _ = ch.Frequency()
-	_ = ch.SetFrequency(1000.0)
-	_, _ = ch.StartPhase()
-	_ = ch.SetStartPhase(0.0)
-	_, _ = ch.StandardWaveform()
-	_ = ch.ConfigureStandardWaveform(fgen.Sine, 0.5, 0.0, 100.0, 0.0)
-	_, _ = ch.StartTriggerDelay()
-	_ = ch.SetStartTriggerDelay(10 * time.Millisecond)
-	_, _ = ch.StartTriggerSlope()
-	_ = ch.SetStartTriggerSlope(fgen.TriggerSlopePositive)
-	_, _ = ch.StartTriggerSource()
-	_ = ch.SetStartTriggerSource(fgen.TriggerSourceExternal)
-	_, _ = ch.TriggerSource()
-	_, _ = ch.InternalTriggerRate()
-	_ = ch.SetInternalTriggerRate(1000.0)
-	_, _ = ch.BurstCount()
-	_ = ch.SetBurstCount(10)
-	_, _ = ch.ArbitraryGain()
-	_ = ch.SetArbitraryGain(1.0)
-	_, _ = ch.ArbitraryOffset()
-	_ = ch.SetArbitraryOffset(0.0)
+	ctx := context.Background()
+
+	_, _ = ch.OperationMode(ctx)
+	_ = ch.SetOperationMode(ctx, fgen.BurstMode)
+	_ = ch.SetOperationMode(ctx, fgen.ContinuousMode)
+	_, _ = ch.OutputEnabled(ctx)
+	_ = ch.SetOutputEnabled(ctx, true)
+	_ = ch.DisableOutput(ctx)
+	_ = ch.EnableOutput(ctx)
+	_, _ = ch.OutputImpedance(ctx)
+	_ = ch.SetOutputImpedance(ctx, 50.0)
+	_ = ch.AbortGeneration(ctx)
+	_, _ = ch.Amplitude(ctx)
+	_ = ch.SetAmplitude(ctx, 2.5)
+	_, _ = ch.DCOffset(ctx)
+	_ = ch.SetDCOffset(ctx, 0.5)
+	_, _ = ch.DutyCycleHigh(ctx)
+	_ = ch.SetDutyCycleHigh(ctx, 50.0)
+	_, _ = ch.Frequency(ctx)
+	_ = ch.SetFrequency(ctx, 1000.0)
+	_, _ = ch.StartPhase(ctx)
+	_ = ch.SetStartPhase(ctx, 0.0)
+	_, _ = ch.StandardWaveform(ctx)
+	_ = ch.ConfigureStandardWaveform(ctx, fgen.Sine, 0.5, 0.0, 100.0, 0.0)
+	_, _ = ch.StartTriggerDelay(ctx)
+	_ = ch.SetStartTriggerDelay(ctx, 10*time.Millisecond)
+	_, _ = ch.StartTriggerSlope(ctx)
+	_ = ch.SetStartTriggerSlope(ctx, fgen.TriggerSlopePositive)
+	_, _ = ch.StartTriggerSource(ctx)
+	_ = ch.SetStartTriggerSource(ctx, fgen.TriggerSourceExternal)
+	_, _ = ch.TriggerSource(ctx)
+	_, _ = ch.InternalTriggerRate(ctx)
+	_ = ch.SetInternalTriggerRate(ctx, 1000.0)
+	_, _ = ch.BurstCount(ctx)
+	_ = ch.SetBurstCount(ctx, 10)
+	_, _ = ch.ArbitraryGain(ctx)
+	_ = ch.SetArbitraryGain(ctx, 1.0)
+	_, _ = ch.ArbitraryOffset(ctx)
+	_ = ch.SetArbitraryOffset(ctx, 0.0)
 
 	for _, wave := range []fgen.StandardWaveform{
 		fgen.Sine, fgen.Square, fgen.Triangle, fgen.RampUp, fgen.RampDown,
 		fgen.DC,
 	} {
-		_ = ch.SetStandardWaveform(wave)
+		_ = ch.SetStandardWaveform(ctx, wave)
 	}
 }
 
@@ -251,3 +254,28 @@ func TestAllModels_EmitValidSCPI(t *testing.T) {
 		})
 	}
 }
+
+func TestFgenOpen(t *testing.T) {
+	gen, err := fgen.Drivers.Open(t.Context(), nil,
+		ivi.WithSimulate(), ivi.WithSimulatedModel("33522B"))
+	if err != nil {
+		t.Fatalf("fgen.Drivers.Open() error: %v", err)
+	}
+	if _, ok := gen.(*Driver); !ok {
+		t.Fatalf("fgen.Drivers.Open() returned %T, want *Driver", gen)
+	}
+
+	name, err := gen.OutputName(1)
+	if err != nil {
+		t.Fatalf("OutputName(1) error: %v", err)
+	}
+	ch, err := gen.OutputItem(name)
+	if err != nil || ch.Name() != name {
+		t.Fatalf("OutputItem(%q) = %v, %v", name, ch, err)
+	}
+	_, err = gen.OutputItem("Output9")
+	if !errors.Is(err, ivi.ErrChannelNotFound) {
+		t.Errorf("OutputItem(Output9) error = %v, want %v",
+			err, ivi.ErrChannelNotFound)
+	}
+}
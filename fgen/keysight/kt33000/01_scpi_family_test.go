@@ -27,40 +27,40 @@ type setter struct {
 
 var channelSetters = []setter{
 	{"SetOutputEnabled", func(ch *Channel) error {
-		return ch.SetOutputEnabled(true)
+		return ch.SetOutputEnabled(context.Background(), true)
 	}},
 	{"SetOutputImpedance", func(ch *Channel) error {
-		return ch.SetOutputImpedance(50.0)
+		return ch.SetOutputImpedance(context.Background(), 50.0)
 	}},
 	{"SetFrequency", func(ch *Channel) error {
-		return ch.SetFrequency(1000.0)
+		return ch.SetFrequency(context.Background(), 1000.0)
 	}},
 	{"SetAmplitude", func(ch *Channel) error {
-		return ch.SetAmplitude(2.5)
+		return ch.SetAmplitude(context.Background(), 2.5)
 	}},
 	{"SetDCOffset", func(ch *Channel) error {
-		return ch.SetDCOffset(0.5)
+		return ch.SetDCOffset(context.Background(), 0.5)
 	}},
 	{"SetStandardWaveform", func(ch *Channel) error {
-		return ch.SetStandardWaveform(fgen.Sine)
+		return ch.SetStandardWaveform(context.Background(), fgen.Sine)
 	}},
 	{"SetStartTriggerDelay", func(ch *Channel) error {
-		return ch.SetStartTriggerDelay(10 * time.Millisecond)
+		return ch.SetStartTriggerDelay(context.Background(), 10*time.Millisecond)
 	}},
 	{"SetStartTriggerSlope", func(ch *Channel) error {
-		return ch.SetStartTriggerSlope(fgen.TriggerSlopePositive)
+		return ch.SetStartTriggerSlope(context.Background(), fgen.TriggerSlopePositive)
 	}},
 	{"SetStartTriggerSource", func(ch *Channel) error {
-		return ch.SetStartTriggerSource(fgen.TriggerSourceExternal)
+		return ch.SetStartTriggerSource(context.Background(), fgen.TriggerSourceExternal)
 	}},
 	{"SetBurstCount", func(ch *Channel) error {
-		return ch.SetBurstCount(10)
+		return ch.SetBurstCount(context.Background(), 10)
 	}},
 	{"SetInternalTriggerRate", func(ch *Channel) error {
-		return ch.SetInternalTriggerRate(1000.0)
+		return ch.SetInternalTriggerRate(context.Background(), 1000.0)
 	}},
 	{"SetOperationMode", func(ch *Channel) error {
-		return ch.SetOperationMode(fgen.ContinuousMode)
+		return ch.SetOperationMode(context.Background(), fgen.ContinuousMode)
 	}},
 }
 
@@ -174,35 +174,35 @@ func TestSCPIFamily_QueryCommands(t *testing.T) {
 		call  func(ch *Channel) error
 	}{
 		{"33220A OutputEnabled", "33220A", "OUTP?", func(ch *Channel) error {
-			_, err := ch.OutputEnabled()
+			_, err := ch.OutputEnabled(t.Context())
 			return err
 		}},
 		{"33522B OutputEnabled", "33522B", "OUTP1?", func(ch *Channel) error {
-			_, err := ch.OutputEnabled()
+			_, err := ch.OutputEnabled(t.Context())
 			return err
 		}},
 		{"33220A OutputImpedance", "33220A", "OUTP:LOAD?", func(ch *Channel) error {
-			_, err := ch.OutputImpedance()
+			_, err := ch.OutputImpedance(t.Context())
 			return err
 		}},
 		{"33522B OutputImpedance", "33522B", "OUTP1:LOAD?", func(ch *Channel) error {
-			_, err := ch.OutputImpedance()
+			_, err := ch.OutputImpedance(t.Context())
 			return err
 		}},
 		{"33220A Frequency", "33220A", "FREQ?", func(ch *Channel) error {
-			_, err := ch.Frequency()
+			_, err := ch.Frequency(t.Context())
 			return err
 		}},
 		{"33522B Frequency", "33522B", "SOUR1:FREQ?", func(ch *Channel) error {
-			_, err := ch.Frequency()
+			_, err := ch.Frequency(t.Context())
 			return err
 		}},
 		{"33220A StartTriggerSlope", "33220A", "TRIG:SLOP?", func(ch *Channel) error {
-			_, err := ch.StartTriggerSlope()
+			_, err := ch.StartTriggerSlope(t.Context())
 			return err
 		}},
 		{"33522B StartTriggerSlope", "33522B", "TRIG1:SLOP?", func(ch *Channel) error {
-			_, err := ch.StartTriggerSlope()
+			_, err := ch.StartTriggerSlope(t.Context())
 			return err
 		}},
 	}
@@ -6,6 +6,7 @@
 package kt33000
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -45,35 +46,78 @@ func (d *Driver) OutputCount() int {
 	return len(d.channels)
 }
 
+// OutputName returns the name of the output channel at index, which runs
+// from 0 to OutputCount()-1.
+//
+// OutputName implements the IviFgenBase function Get Channel Name described
+// in Section 4.3.7 of IVI-4.3: IviFgen Class Specification.
+func (d *Driver) OutputName(index int) (string, error) {
+	ch, err := d.Channel(index)
+	if err != nil {
+		return "", fmt.Errorf("OutputName: %w", err)
+	}
+
+	return ch.Name(), nil
+}
+
+// OutputItem returns the output channel with the given name, as returned by
+// OutputName, for the Outputs repeated capability of IVI-4.3: IviFgen Class
+// Specification.
+func (d *Driver) OutputItem(name string) (fgen.BaseChannel, error) {
+	for i := range d.channels {
+		if d.channels[i].Name() == name {
+			return &d.channels[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("OutputItem: %q: %w", name, ivi.ErrChannelNotFound)
+}
+
 // OutputMode returns how the function generator produces waveforms. This
 // attribute determines which extension group's functions and attributes are
 // used to configure the waveform the function generator produces.
 //
 // OutputMode is the getter for the read-write IviFgenBase Attribute Output
 // Mode described in Section 4.2.5 of IVI-4.3: IviFgen Class Specification.
-func (d *Driver) OutputMode() (fgen.OutputMode, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) OutputMode(ctx context.Context) (fgen.OutputMode, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	s, err := query.String(ctx, d.inst, d.channels[0].srcPrefix()+"FUNC?")
-	if err != nil {
-		return 0, fmt.Errorf("OutputMode: %w", err)
-	}
-
-	mode, err := ivi.ReverseLookup(scpiToOutputMode, strings.TrimSpace(s))
-	if err != nil {
-		return 0, fmt.Errorf("OutputMode: %w", err)
-	}
-
-	return mode, nil
+	ch := &d.channels[0]
+
+	return ivi.CachedGet(
+		ch.cache, ch.cacheKey("OutputMode"),
+		func() (fgen.OutputMode, error) {
+			s, err := query.String(ctx, d.inst, ch.srcPrefix()+"FUNC?")
+			if err != nil {
+				return 0, fmt.Errorf("OutputMode: %w", err)
+			}
+
+			mode, err := ivi.ReverseLookup(
+				scpiToOutputMode, strings.TrimSpace(s),
+			)
+			if err != nil {
+				return 0, fmt.Errorf("OutputMode: %w", err)
+			}
+
+			return mode, nil
+		},
+	)
 }
 
 // SetOutputMode sets how the function generator produces waveforms.
 //
 // SetOutputMode is the setter for the read-write IviFgenBase Attribute Output
 // Mode described in Section 4.2.5 of IVI-4.3: IviFgen Class Specification.
-func (d *Driver) SetOutputMode(outputMode fgen.OutputMode) error {
-	ctx, cancel := d.newContext()
+//
+// Changing the mode selects a new function, which can coerce the frequency,
+// amplitude, offset, and duty cycle to its limits, so their cached values are
+// discarded along with the standard waveform's.
+func (d *Driver) SetOutputMode(
+	ctx context.Context,
+	outputMode fgen.OutputMode,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(outputModeToSCPI, outputMode)
@@ -81,16 +125,26 @@ func (d *Driver) SetOutputMode(outputMode fgen.OutputMode) error {
 		return fmt.Errorf("SetOutputMode: %w", err)
 	}
 
-	return d.inst.Command(ctx, d.channels[0].srcPrefix()+cmd)
+	ch := &d.channels[0]
+
+	return ivi.CachedSet(
+		ch.cache, ch.cacheKey("OutputMode"), outputMode,
+		func() error { return d.inst.Command(ctx, ch.srcPrefix()+cmd) },
+		ch.cacheKey("StandardWaveform"),
+		ch.cacheKey("Frequency"),
+		ch.cacheKey("Amplitude"),
+		ch.cacheKey("DCOffset"),
+		ch.cacheKey("DutyCycleHigh"),
+	)
 }
 
 // InitiateGeneration initiates signal generation by enabling all outputs.
 //
 // InitiateGeneration implements the IviFgenBase function described in Section
 // 4.3.8 of IVI-4.3: IviFgen Class Specification.
-func (d *Driver) InitiateGeneration() error {
+func (d *Driver) InitiateGeneration(ctx context.Context) error {
 	for _, channel := range d.channels {
-		if err := channel.EnableOutput(); err != nil {
+		if err := channel.EnableOutput(ctx); err != nil {
 			return err
 		}
 	}
@@ -103,9 +157,9 @@ func (d *Driver) InitiateGeneration() error {
 //
 // AbortGeneration implements the IviFgenBase function described in Section
 // 4.3.1 of IVI-4.3: IviFgen Class Specification.
-func (d *Driver) AbortGeneration() error {
+func (d *Driver) AbortGeneration(ctx context.Context) error {
 	for _, channel := range d.channels {
-		if err := channel.DisableOutput(); err != nil {
+		if err := channel.DisableOutput(ctx); err != nil {
 			return err
 		}
 	}
@@ -113,11 +167,16 @@ func (d *Driver) AbortGeneration() error {
 	return nil
 }
 
-func (d *Driver) ReferenceClockSource() (fgen.ClockSource, error) {
+func (d *Driver) ReferenceClockSource(
+	ctx context.Context,
+) (fgen.ClockSource, error) {
 	return fgen.RefClockInternal, nil
 }
 
-func (d *Driver) SetReferenceClockSource(_ fgen.ClockSource) error {
+func (d *Driver) SetReferenceClockSource(
+	ctx context.Context,
+	_ fgen.ClockSource,
+) error {
 	return nil
 }
 
@@ -131,8 +190,10 @@ func (ch *Channel) Name() string {
 // OperationMode implements the getter for the read-write IviFgenBase Attribute
 // Operation Mode described in Section 4.2.2 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) OperationMode() (fgen.OperationMode, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) OperationMode(
+	ctx context.Context,
+) (fgen.OperationMode, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, ch.inst, ch.srcPrefix()+"BURS:STAT?")
@@ -154,10 +215,19 @@ func (ch *Channel) OperationMode() (fgen.OperationMode, error) {
 // SetOperationMode implements the setter for the read-write IviFgenBase
 // Attribute Operation Mode described in Section 4.2.2 of IVI-4.3: IviFgen
 // Class Specification.
-func (ch *Channel) SetOperationMode(mode fgen.OperationMode) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetOperationMode(
+	ctx context.Context,
+	mode fgen.OperationMode,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("SetOperationMode: %w", err)
+	}
+	defer unlock()
+
 	switch mode {
 	case fgen.BurstMode:
 		if err := ch.inst.Command(ctx, ch.srcPrefix()+"BURS:MODE TRIG"); err != nil {
@@ -177,8 +247,8 @@ func (ch *Channel) SetOperationMode(mode fgen.OperationMode) error {
 // OutputEnabled is the getter for the read-write IviFgenBase Attribute
 // Output Enabled described in Section 4.2.3 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) OutputEnabled() (bool, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) OutputEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Bool(ctx, ch.inst, "OUTP"+ch.chanSuffix()+"?")
@@ -189,10 +259,16 @@ func (ch *Channel) OutputEnabled() (bool, error) {
 // SetOutputEnabled is the setter for the read-write IviFgenBase Attribute
 // Output Enabled described in Section 4.2.3 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetOutputEnabled(b bool) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetOutputEnabled(ctx context.Context, b bool) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("SetOutputEnabled: %w", err)
+	}
+	defer unlock()
+
 	if b {
 		return ch.inst.Command(ctx, "OUTP"+ch.chanSuffix()+" ON")
 	}
@@ -202,14 +278,14 @@ func (ch *Channel) SetOutputEnabled(b bool) error {
 
 // DisableOutput is a convenience function for setting the Output Enabled
 // attribute to false.
-func (ch *Channel) DisableOutput() error {
-	return ch.SetOutputEnabled(false)
+func (ch *Channel) DisableOutput(ctx context.Context) error {
+	return ch.SetOutputEnabled(ctx, false)
 }
 
 // EnableOutput is a convenience function for setting the Output Enabled
 // attribute to true.
-func (ch *Channel) EnableOutput() error {
-	return ch.SetOutputEnabled(true)
+func (ch *Channel) EnableOutput(ctx context.Context) error {
+	return ch.SetOutputEnabled(ctx, true)
 }
 
 // OutputImpedance return the output channel's impedance in ohms.
@@ -217,8 +293,8 @@ func (ch *Channel) EnableOutput() error {
 // OutputImpedance is the getter for the read-write IviFgenBase Attribute
 // Output Impedance described in Section 4.2.4 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) OutputImpedance() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) OutputImpedance(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, "OUTP"+ch.chanSuffix()+":LOAD?")
@@ -229,10 +305,16 @@ func (ch *Channel) OutputImpedance() (float64, error) {
 // SetOutputImpedance is the setter for the read-write IviFgenBase Attribute
 // Output Impedance described in Section 4.2.4 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetOutputImpedance(impedance float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetOutputImpedance(
+	ctx context.Context,
+	impedance float64,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	// The displayed amplitude and offset are scaled to the load setting.
+	ch.cache.Invalidate(ch.cacheKey("Amplitude"), ch.cacheKey("DCOffset"))
+
 	return ch.inst.Command(ctx, "OUTP"+ch.chanSuffix()+":LOAD %f", impedance)
 }
 
@@ -240,6 +322,6 @@ func (ch *Channel) SetOutputImpedance(impedance float64) error {
 //
 // AbortGeneration implements the IviFgenBase function described in Section
 // 4.3.1 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) AbortGeneration() error {
-	return ch.DisableOutput()
+func (ch *Channel) AbortGeneration(ctx context.Context) error {
+	return ch.DisableOutput(ctx)
 }
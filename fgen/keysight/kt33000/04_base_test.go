@@ -6,6 +6,7 @@
 package kt33000
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -110,7 +111,7 @@ func TestChannel_SetOutputEnabled(t *testing.T) {
 			mock := &ivitest.Mock{}
 			d := newTestDriver(mock)
 			ch, _ := d.Channel(0)
-			err := ch.SetOutputEnabled(tt.enabled)
+			err := ch.SetOutputEnabled(t.Context(), tt.enabled)
 			if err != nil {
 				t.Fatalf("SetOutputEnabled() error: %v", err)
 			}
@@ -125,7 +126,7 @@ func TestChannel_SetOutputEnabled_Ch2(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
 	ch, _ := d.Channel(1)
-	if err := ch.SetOutputEnabled(true); err != nil {
+	if err := ch.SetOutputEnabled(t.Context(), true); err != nil {
 		t.Fatalf("SetOutputEnabled() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != "OUTP2 ON" {
@@ -137,7 +138,7 @@ func TestChannel_SetFrequency(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
 	ch, _ := d.Channel(0)
-	if err := ch.SetFrequency(1000.0); err != nil {
+	if err := ch.SetFrequency(t.Context(), 1000.0); err != nil {
 		t.Fatalf("SetFrequency() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -146,11 +147,50 @@ func TestChannel_SetFrequency(t *testing.T) {
 	}
 }
 
+func TestChannel_SetFrequency_RangeCheck(t *testing.T) {
+	mock := &ivitest.Mock{QueryResp: idn("33210A")}
+	d, err := New(mock, ivi.WithRangeCheck())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	ch, _ := d.Channel(0)
+	mock.CommandsSent = nil
+
+	ctx := t.Context()
+	for _, freq := range []float64{0, 11e6} {
+		err := ch.SetFrequency(ctx, freq)
+		if !errors.Is(err, ivi.ErrValueNotSupported) {
+			t.Errorf("SetFrequency(%g) error = %v, want ErrValueNotSupported",
+				freq, err)
+		}
+	}
+	err = ch.ConfigureStandardWaveform(ctx, fgen.Sine, 1, 0, 11e6, 0)
+	if !errors.Is(err, ivi.ErrValueNotSupported) {
+		t.Errorf("ConfigureStandardWaveform() error = %v, "+
+			"want ErrValueNotSupported", err)
+	}
+	if len(mock.CommandsSent) != 0 {
+		t.Errorf("out of range frequency sent %q", mock.CommandsSent)
+	}
+
+	if err := ch.SetFrequency(ctx, 10e6); err != nil {
+		t.Errorf("SetFrequency(10 MHz) error: %v", err)
+	}
+}
+
+func TestNew_RangeCheckWithoutBandwidth(t *testing.T) {
+	mock := &ivitest.Mock{QueryResp: idn("33502A")}
+	_, err := New(mock, ivi.WithRangeCheck())
+	if !errors.Is(err, ivi.ErrFunctionNotSupported) {
+		t.Errorf("New() error = %v, want ErrFunctionNotSupported", err)
+	}
+}
+
 func TestChannel_SetAmplitude(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
 	ch, _ := d.Channel(1)
-	if err := ch.SetAmplitude(2.5); err != nil {
+	if err := ch.SetAmplitude(t.Context(), 2.5); err != nil {
 		t.Fatalf("SetAmplitude() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -175,7 +215,7 @@ func TestChannel_SetStandardWaveform(t *testing.T) {
 			mock := &ivitest.Mock{}
 			d := newTestDriver(mock)
 			ch, _ := d.Channel(0)
-			if err := ch.SetStandardWaveform(tt.wave); err != nil {
+			if err := ch.SetStandardWaveform(t.Context(), tt.wave); err != nil {
 				t.Fatalf("SetStandardWaveform() error: %v", err)
 			}
 			if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != tt.want {
@@ -189,7 +229,7 @@ func TestChannel_SetStandardWaveform_Triangle(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
 	ch, _ := d.Channel(0)
-	if err := ch.SetStandardWaveform(fgen.Triangle); err != nil {
+	if err := ch.SetStandardWaveform(t.Context(), fgen.Triangle); err != nil {
 		t.Fatalf("SetStandardWaveform(Triangle) error: %v", err)
 	}
 	// Triangle sends two separate commands (no semicolons).
@@ -211,7 +251,7 @@ func TestChannel_SetOperationMode_Burst(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
 	ch, _ := d.Channel(0)
-	if err := ch.SetOperationMode(fgen.BurstMode); err != nil {
+	if err := ch.SetOperationMode(t.Context(), fgen.BurstMode); err != nil {
 		t.Fatalf("SetOperationMode(BurstMode) error: %v", err)
 	}
 	// Burst mode sends two separate commands (no semicolons).
@@ -236,7 +276,7 @@ func TestChannel_SetOperationMode_Continuous(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
 	ch, _ := d.Channel(0)
-	if err := ch.SetOperationMode(fgen.ContinuousMode); err != nil {
+	if err := ch.SetOperationMode(t.Context(), fgen.ContinuousMode); err != nil {
 		t.Fatalf("SetOperationMode(ContinuousMode) error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -251,7 +291,7 @@ func TestChannel_SetBurstCount(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
 	ch, _ := d.Channel(0)
-	if err := ch.SetBurstCount(10); err != nil {
+	if err := ch.SetBurstCount(t.Context(), 10); err != nil {
 		t.Fatalf("SetBurstCount() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -266,7 +306,7 @@ func TestChannel_SetOutputImpedance(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
 	ch, _ := d.Channel(1)
-	if err := ch.SetOutputImpedance(50.0); err != nil {
+	if err := ch.SetOutputImpedance(t.Context(), 50.0); err != nil {
 		t.Fatalf("SetOutputImpedance() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -290,7 +330,7 @@ func TestChannel_InternalTriggerRate(t *testing.T) {
 			mock := &ivitest.Mock{QueryResp: tt.response}
 			d := newTestDriver(mock)
 			ch, _ := d.Channel(0)
-			got, err := ch.InternalTriggerRate()
+			got, err := ch.InternalTriggerRate(t.Context())
 			if err != nil {
 				t.Fatalf("InternalTriggerRate() error: %v", err)
 			}
@@ -305,7 +345,7 @@ func TestChannel_SetInternalTriggerRate(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
 	ch, _ := d.Channel(0)
-	if err := ch.SetInternalTriggerRate(1000.0); err != nil {
+	if err := ch.SetInternalTriggerRate(t.Context(), 1000.0); err != nil {
 		t.Fatalf("SetInternalTriggerRate() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -321,7 +361,7 @@ func TestChannel_SetDCOffset(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
 	ch, _ := d.Channel(0)
-	if err := ch.SetDCOffset(0.5); err != nil {
+	if err := ch.SetDCOffset(t.Context(), 0.5); err != nil {
 		t.Fatalf("SetDCOffset() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -346,7 +386,7 @@ func TestChannel_StandardWaveform(t *testing.T) {
 			mock := &ivitest.Mock{QueryResp: tt.resp}
 			d := newTestDriver(mock)
 			ch, _ := d.Channel(0)
-			got, err := ch.StandardWaveform()
+			got, err := ch.StandardWaveform(t.Context())
 			if err != nil {
 				t.Fatalf("StandardWaveform() error: %v", err)
 			}
@@ -373,7 +413,7 @@ func TestChannel_OutputMode(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{QueryResp: tt.resp}
 			d := newTestDriver(mock)
-			got, err := d.OutputMode()
+			got, err := d.OutputMode(t.Context())
 			if err != nil {
 				t.Fatalf("OutputMode() error: %v", err)
 			}
@@ -388,7 +428,85 @@ func TestChannel_CommandError(t *testing.T) {
 	mock := &ivitest.Mock{ShouldError: true}
 	d := newTestDriver(mock)
 	ch, _ := d.Channel(0)
-	if err := ch.SetFrequency(1000); err == nil {
+	if err := ch.SetFrequency(t.Context(), 1000); err == nil {
 		t.Error("expected error, got nil")
 	}
 }
+
+func TestChannel_CachedStdFunc(t *testing.T) {
+	strict := &ivitest.Strict{Mock: ivitest.Mock{QueryResp: "2.5"}}
+	ch := &Channel{
+		name: "Output 1", inst: strict, cache: ivi.NewAttributeCache(),
+		timeout: ivi.DefaultTimeout,
+	}
+	ctx := t.Context()
+
+	if err := ch.SetFrequency(ctx, 1e3); err != nil {
+		t.Fatalf("SetFrequency() error: %v", err)
+	}
+	if got, _ := ch.Frequency(ctx); got != 1e3 {
+		t.Errorf("Frequency() = %g, want 1000 from cache", got)
+	}
+	if len(strict.QueriesSent) != 0 {
+		t.Errorf("queries = %v, want none", strict.QueriesSent)
+	}
+
+	// Changing the waveform can coerce the frequency.
+	if err := ch.SetStandardWaveform(ctx, fgen.Square); err != nil {
+		t.Fatalf("SetStandardWaveform() error: %v", err)
+	}
+	if got, _ := ch.Frequency(ctx); got != 2.5 {
+		t.Errorf("Frequency() = %g, want 2.5 from the instrument", got)
+	}
+
+	// The amplitude is scaled to the load setting.
+	if err := ch.SetAmplitude(ctx, 1); err != nil {
+		t.Fatalf("SetAmplitude() error: %v", err)
+	}
+	if err := ch.SetOutputImpedance(ctx, 50); err != nil {
+		t.Fatalf("SetOutputImpedance() error: %v", err)
+	}
+	if got, _ := ch.Amplitude(ctx); got != 2.5 {
+		t.Errorf("Amplitude() = %g, want 2.5 from the instrument", got)
+	}
+	strict.Check(t)
+}
+
+func TestDriver_CachedOutputMode(t *testing.T) {
+	mock := &ivitest.Mock{QueryResp: "2.5"}
+	d := newTestDriver(mock)
+	ch := &d.channels[0]
+	ch.cache = ivi.NewAttributeCache()
+	ctx := t.Context()
+
+	if err := ch.SetFrequency(ctx, 1e3); err != nil {
+		t.Fatalf("SetFrequency() error: %v", err)
+	}
+	if err := ch.SetAmplitude(ctx, 1); err != nil {
+		t.Fatalf("SetAmplitude() error: %v", err)
+	}
+
+	// Selecting the arbitrary waveform function can coerce the frequency
+	// and amplitude.
+	if err := d.SetOutputMode(ctx, fgen.OutputModeArbitrary); err != nil {
+		t.Fatalf("SetOutputMode() error: %v", err)
+	}
+	if got, _ := ch.Frequency(ctx); got != 2.5 {
+		t.Errorf("Frequency() = %g, want 2.5 from the instrument", got)
+	}
+	if got, _ := ch.Amplitude(ctx); got != 2.5 {
+		t.Errorf("Amplitude() = %g, want 2.5 from the instrument", got)
+	}
+
+	// Selecting a standard waveform changes the output mode.
+	mock.QueryResp = "SIN"
+	if got, _ := d.OutputMode(ctx); got != fgen.OutputModeArbitrary {
+		t.Errorf("OutputMode() = %v, want arbitrary from cache", got)
+	}
+	if err := ch.SetStandardWaveform(ctx, fgen.Sine); err != nil {
+		t.Fatalf("SetStandardWaveform() error: %v", err)
+	}
+	if got, _ := d.OutputMode(ctx); got != fgen.OutputModeFunction {
+		t.Errorf("OutputMode() = %v, want function from the instrument", got)
+	}
+}
@@ -6,6 +6,7 @@
 package kt33000
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -20,11 +21,16 @@ import (
 // Amplitude is the getter for the read-write IviFgenStdFunc Attribute
 // Amplitude described in Section 5.2.1 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) Amplitude() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) Amplitude(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, ch.inst, ch.srcPrefix()+"VOLT?")
+	return ivi.CachedGet(
+		ch.cache, ch.cacheKey("Amplitude"),
+		func() (float64, error) {
+			return query.Float64(ctx, ch.inst, ch.srcPrefix()+"VOLT?")
+		},
+	)
 }
 
 // SetAmplitude specifies the difference between the maximum and minimum
@@ -33,11 +39,17 @@ func (ch *Channel) Amplitude() (float64, error) {
 // SetAmplitude is the setter for the read-write IviFgenStdFunc Attribute
 // Amplitude described in Section 5.2.1 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetAmplitude(amp float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetAmplitude(ctx context.Context, amp float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
-	return ch.inst.Command(ctx, ch.srcPrefix()+"VOLT %f VPP", amp)
+	return ivi.CachedSet(
+		ch.cache, ch.cacheKey("Amplitude"), amp,
+		func() error {
+			return ch.inst.Command(ctx, ch.srcPrefix()+"VOLT %f VPP", amp)
+		},
+		ch.cacheKey("DCOffset"),
+	)
 }
 
 // DCOffset reads the difference between the average of the maximum and minimum
@@ -45,11 +57,16 @@ func (ch *Channel) SetAmplitude(amp float64) error {
 //
 // DCOffset is the getter for the read-write IviFgenStdFunc Attribute DC Offset
 // described in Section 5.2.2 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) DCOffset() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) DCOffset(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, ch.inst, ch.srcPrefix()+"VOLT:OFFS?")
+	return ivi.CachedGet(
+		ch.cache, ch.cacheKey("DCOffset"),
+		func() (float64, error) {
+			return query.Float64(ctx, ch.inst, ch.srcPrefix()+"VOLT:OFFS?")
+		},
+	)
 }
 
 // SetDCOffset sets the difference between the average of the maximum and
@@ -57,11 +74,17 @@ func (ch *Channel) DCOffset() (float64, error) {
 //
 // SetDCOffset is the setter for the read-write IviFgenStdFunc Attribute DC
 // Offset described in Section 5.2.2 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) SetDCOffset(offset float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetDCOffset(ctx context.Context, offset float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
-	return ch.inst.Command(ctx, ch.srcPrefix()+"VOLT:OFFS %f", offset)
+	return ivi.CachedSet(
+		ch.cache, ch.cacheKey("DCOffset"), offset,
+		func() error {
+			return ch.inst.Command(ctx, ch.srcPrefix()+"VOLT:OFFS %f", offset)
+		},
+		ch.cacheKey("Amplitude"),
+	)
 }
 
 // DutyCycleHigh reads the percentage of time, specified as 0-100, during one
@@ -70,11 +93,16 @@ func (ch *Channel) SetDCOffset(offset float64) error {
 // DutyCycleHigh is the getter for the read-write IviFgenStdFunc Attribute Duty
 // Cycle High described in Section 5.2.3 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) DutyCycleHigh() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) DutyCycleHigh(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, ch.inst, ch.srcPrefix()+"FUNC:SQU:DCYC?")
+	return ivi.CachedGet(
+		ch.cache, ch.cacheKey("DutyCycleHigh"),
+		func() (float64, error) {
+			return query.Float64(ctx, ch.inst, ch.srcPrefix()+"FUNC:SQU:DCYC?")
+		},
+	)
 }
 
 // SetDutyCycleHigh sets the percentage of time, specified as 0-100, during one
@@ -83,11 +111,16 @@ func (ch *Channel) DutyCycleHigh() (float64, error) {
 // SetDutyCycleHigh is the setter for the read-write IviFgenStdFunc Attribute
 // Duty Cycle High described in Section 5.2.3 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetDutyCycleHigh(duty float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetDutyCycleHigh(ctx context.Context, duty float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
-	return ch.inst.Command(ctx, ch.srcPrefix()+"FUNC:SQU:DCYC %f", duty)
+	return ivi.CachedSet(
+		ch.cache, ch.cacheKey("DutyCycleHigh"), duty,
+		func() error {
+			return ch.inst.Command(ctx, ch.srcPrefix()+"FUNC:SQU:DCYC %f", duty)
+		},
+	)
 }
 
 // Frequency reads the number of waveform cycles generated in one second (i.e.,
@@ -96,24 +129,52 @@ func (ch *Channel) SetDutyCycleHigh(duty float64) error {
 // Frequency is the getter for the read-write IviFgenStdFunc Attribute
 // Frequency described in Section 5.2.4 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) Frequency() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) Frequency(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, ch.inst, ch.srcPrefix()+"FREQ?")
+	return ivi.CachedGet(
+		ch.cache, ch.cacheKey("Frequency"),
+		func() (float64, error) {
+			return query.Float64(ctx, ch.inst, ch.srcPrefix()+"FREQ?")
+		},
+	)
 }
 
 // SetFrequency sets the number of waveform cycles generated in one second
-// (i.e., Hz). Frequency is not applicable for a DC waveform.
+// (i.e., Hz). Frequency is not applicable for a DC waveform. Under
+// [ivi.WithRangeCheck], a frequency above the model's maximum sine wave
+// frequency returns an error wrapping [ivi.ErrValueNotSupported] without
+// being sent.
 //
 // SetFrequency is the setter for the read-write IviFgenStdFunc Attribute
 // Frequency described in Section 5.2.4 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetFrequency(freq float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetFrequency(ctx context.Context, freq float64) error {
+	if err := ch.checkFrequency(freq); err != nil {
+		return fmt.Errorf("SetFrequency: %w", err)
+	}
+
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
-	return ch.inst.Command(ctx, ch.srcPrefix()+"FREQ %f", freq)
+	return ivi.CachedSet(
+		ch.cache, ch.cacheKey("Frequency"), freq,
+		func() error {
+			return ch.inst.Command(ctx, ch.srcPrefix()+"FREQ %f", freq)
+		},
+		ch.cacheKey("DutyCycleHigh"),
+	)
+}
+
+// checkFrequency checks freq against the model's frequency range when range
+// checking.
+func (ch *Channel) checkFrequency(freq float64) error {
+	if ch.frequency == nil {
+		return nil
+	}
+
+	return ch.frequency.Check(freq)
 }
 
 // StartPhase reads the start phase of the standard waveform the function
@@ -121,11 +182,16 @@ func (ch *Channel) SetFrequency(freq float64) error {
 //
 // StartPhase is the getter for the read-write IviFgenStdFunc Attribute Start
 // Phase described in Section 5.2.5 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) StartPhase() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) StartPhase(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, ch.inst, ch.srcPrefix()+"PHAS?")
+	return ivi.CachedGet(
+		ch.cache, ch.cacheKey("StartPhase"),
+		func() (float64, error) {
+			return query.Float64(ctx, ch.inst, ch.srcPrefix()+"PHAS?")
+		},
+	)
 }
 
 // SetStartPhase writes the start phase of the standard waveform the function
@@ -134,11 +200,16 @@ func (ch *Channel) StartPhase() (float64, error) {
 // SetStartPhase is the setter for the read-write IviFgenStdFunc Attribute
 // Start Phase described in Section 5.2.5 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetStartPhase(phase float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetStartPhase(ctx context.Context, phase float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
-	return ch.inst.Command(ctx, ch.srcPrefix()+"PHAS %f", phase)
+	return ivi.CachedSet(
+		ch.cache, ch.cacheKey("StartPhase"), phase,
+		func() error {
+			return ch.inst.Command(ctx, ch.srcPrefix()+"PHAS %f", phase)
+		},
+	)
 }
 
 // StandardWaveform determines which standard waveform is being output by the
@@ -146,10 +217,31 @@ func (ch *Channel) SetStartPhase(phase float64) error {
 //
 // StandardWaveform is the getter for the read-write IviFgenStdFunc Attribute
 // Waveform described in Section 5.2.6 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) StandardWaveform() (fgen.StandardWaveform, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) StandardWaveform(
+	ctx context.Context,
+) (fgen.StandardWaveform, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	return ivi.CachedGet(
+		ch.cache, ch.cacheKey("StandardWaveform"),
+		func() (fgen.StandardWaveform, error) {
+			return ch.queryStandardWaveform(ctx)
+		},
+	)
+}
+
+// queryStandardWaveform reads the standard waveform from the instrument,
+// using the ramp symmetry to tell the ramp-based waveforms apart.
+func (ch *Channel) queryStandardWaveform(
+	ctx context.Context,
+) (fgen.StandardWaveform, error) {
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return 0, fmt.Errorf("QueryStandardWaveform: %w", err)
+	}
+	defer unlock()
+
 	var wave fgen.StandardWaveform
 
 	s, err := query.String(ctx, ch.inst, ch.srcPrefix()+"FUNC?")
@@ -195,10 +287,40 @@ func (ch *Channel) StandardWaveform() (fgen.StandardWaveform, error) {
 // SetStandardWaveform is the setter for the read-write IviFgenStdFunc
 // Attribute Waveform described in Section 5.2.6 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetStandardWaveform(wave fgen.StandardWaveform) error {
-	ctx, cancel := ch.newContext()
+//
+// Changing the waveform can coerce the frequency, amplitude, offset, and duty
+// cycle to the limits of the new waveform, and selects the standard function
+// output mode, so their cached values are discarded.
+func (ch *Channel) SetStandardWaveform(
+	ctx context.Context,
+	wave fgen.StandardWaveform,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	return ivi.CachedSet(
+		ch.cache, ch.cacheKey("StandardWaveform"), wave,
+		func() error { return ch.setStandardWaveform(ctx, wave) },
+		ch.cacheKey("OutputMode"),
+		ch.cacheKey("Frequency"),
+		ch.cacheKey("Amplitude"),
+		ch.cacheKey("DCOffset"),
+		ch.cacheKey("DutyCycleHigh"),
+	)
+}
+
+// setStandardWaveform sends the commands that select the given standard
+// waveform.
+func (ch *Channel) setStandardWaveform(
+	ctx context.Context,
+	wave fgen.StandardWaveform,
+) error {
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("SetStandardWaveform: %w", err)
+	}
+	defer unlock()
+
 	// Triangle, RampUp, and RampDown require two commands: set the function to
 	// RAMP, then set the symmetry. These must be separate commands because the
 	// SOURce channel prefix must appear on each.
@@ -238,20 +360,45 @@ func (ch *Channel) SetStandardWaveform(wave fgen.StandardWaveform) error {
 // Standard Waveform function described in Section 5.3.1 of IVI-4.3: IviFgen
 // Class Specification.
 func (ch *Channel) ConfigureStandardWaveform(
+	ctx context.Context,
 	wave fgen.StandardWaveform,
 	amp float64,
 	offset float64,
 	freq float64,
 	phase float64,
 ) error {
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureStandardWaveform: %w", err)
+	}
+	defer unlock()
+
 	format, err := ivi.LookupSCPI(waveformApplyCommand, wave)
 	if err != nil {
 		return fmt.Errorf("ConfigureStandardWaveform: %w", err)
 	}
 
+	if wave != fgen.DC {
+		if err := ch.checkFrequency(freq); err != nil {
+			return fmt.Errorf("ConfigureStandardWaveform: %w", err)
+		}
+	}
+
+	// The instrument coerces the values APPLy sets to the limits of the
+	// waveform, so read them back rather than caching the requested values.
+	ch.cache.Invalidate(
+		ch.cacheKey("OutputMode"),
+		ch.cacheKey("StandardWaveform"),
+		ch.cacheKey("Frequency"),
+		ch.cacheKey("Amplitude"),
+		ch.cacheKey("DCOffset"),
+		ch.cacheKey("DutyCycleHigh"),
+		ch.cacheKey("StartPhase"),
+	)
+
 	if err := ch.inst.Command(
 		ctx, ch.srcPrefix()+format, freq, amp, offset,
 	); err != nil {
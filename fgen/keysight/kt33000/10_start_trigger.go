@@ -6,6 +6,7 @@
 package kt33000
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -44,8 +45,10 @@ var scpiToTriggerSource = map[string]fgen.TriggerSource{
 // StartTriggerDelay is the getter for the read-write IviFgenTrigger
 // Attribute Start Trigger Delay described in Section 10.2.1 of IVI-4.3:
 // IviFgen Class Specification.
-func (ch *Channel) StartTriggerDelay() (time.Duration, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) StartTriggerDelay(
+	ctx context.Context,
+) (time.Duration, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	sec, err := query.Float64(ctx, ch.inst, ch.trigPrefix()+"DEL?")
@@ -62,8 +65,11 @@ func (ch *Channel) StartTriggerDelay() (time.Duration, error) {
 // SetStartTriggerDelay is the setter for the read-write IviFgenTrigger
 // Attribute Start Trigger Delay described in Section 10.2.1 of IVI-4.3:
 // IviFgen Class Specification.
-func (ch *Channel) SetStartTriggerDelay(delay time.Duration) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetStartTriggerDelay(
+	ctx context.Context,
+	delay time.Duration,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, ch.trigPrefix()+"DEL %f", delay.Seconds())
@@ -75,8 +81,10 @@ func (ch *Channel) SetStartTriggerDelay(delay time.Duration) error {
 // StartTriggerSlope is the getter for the read-write IviFgenTrigger
 // Attribute Start Trigger Slope described in Section 10.2.2 of IVI-4.3:
 // IviFgen Class Specification.
-func (ch *Channel) StartTriggerSlope() (fgen.TriggerSlope, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) StartTriggerSlope(
+	ctx context.Context,
+) (fgen.TriggerSlope, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	var slope fgen.TriggerSlope
@@ -102,8 +110,11 @@ func (ch *Channel) StartTriggerSlope() (fgen.TriggerSlope, error) {
 // SetStartTriggerSlope is the setter for the read-write IviFgenTrigger
 // Attribute Start Trigger Slope described in Section 10.2.2 of IVI-4.3:
 // IviFgen Class Specification.
-func (ch *Channel) SetStartTriggerSlope(slope fgen.TriggerSlope) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetStartTriggerSlope(
+	ctx context.Context,
+	slope fgen.TriggerSlope,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	triggerSlope, err := ivi.LookupSCPI(triggerSlopeToSCPI, slope)
@@ -119,8 +130,10 @@ func (ch *Channel) SetStartTriggerSlope(slope fgen.TriggerSlope) error {
 // StartTriggerSource is the getter for the read-write IviFgenTrigger
 // Attribute Start Trigger Source described in Section 10.2.3 of IVI-4.3:
 // IviFgen Class Specification.
-func (ch *Channel) StartTriggerSource() (fgen.TriggerSource, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) StartTriggerSource(
+	ctx context.Context,
+) (fgen.TriggerSource, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	var src fgen.TriggerSource
@@ -145,8 +158,11 @@ func (ch *Channel) StartTriggerSource() (fgen.TriggerSource, error) {
 // SetStartTriggerSource is the setter for the read-write IviFgenTrigger
 // Attribute Start Trigger Source described in Section 10.2.3 of IVI-4.3:
 // IviFgen Class Specification.
-func (ch *Channel) SetStartTriggerSource(src fgen.TriggerSource) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetStartTriggerSource(
+	ctx context.Context,
+	src fgen.TriggerSource,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	triggerSource, err := ivi.LookupSCPI(triggerSourceToSCPI, src)
@@ -157,15 +173,19 @@ func (ch *Channel) SetStartTriggerSource(src fgen.TriggerSource) error {
 	return ch.inst.Command(ctx, ch.trigPrefix()+"SOUR %s", triggerSource)
 }
 
-func (ch *Channel) StartTriggerThreshold() (float64, error) {
+func (ch *Channel) StartTriggerThreshold(ctx context.Context) (float64, error) {
 	return 0.0, ivi.ErrFunctionNotSupported
 }
 
-func (ch *Channel) SetStartTriggerThreshold(_ float64) error {
+func (ch *Channel) SetStartTriggerThreshold(
+	ctx context.Context,
+	_ float64,
+) error {
 	return ivi.ErrFunctionNotSupported
 }
 
 func (ch *Channel) StartTriggerConfigure(
+	ctx context.Context,
 	_ fgen.TriggerSource,
 	_ fgen.TriggerSlope,
 ) error {
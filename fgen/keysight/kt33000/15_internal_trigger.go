@@ -6,6 +6,8 @@
 package kt33000
 
 import (
+	"context"
+
 	"github.com/gotmc/query"
 )
 
@@ -16,8 +18,8 @@ import (
 // InternalTriggerRate as a driver-level attribute. We implement it on the
 // channel because the 33500B/33600A series have independent burst periods per
 // channel via the SOURce[1|2]:BURSt:INTernal:PERiod command.
-func (ch *Channel) InternalTriggerRate() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) InternalTriggerRate(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	// The 33500B stores the burst internal period in seconds; the IVI API
@@ -34,8 +36,11 @@ func (ch *Channel) InternalTriggerRate() (float64, error) {
 // internal trigger source produces a trigger in triggers per second (Hz).
 //
 // Deviation from IVI-4.3: See InternalTriggerRate.
-func (ch *Channel) SetInternalTriggerRate(rate float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetInternalTriggerRate(
+	ctx context.Context,
+	rate float64,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	// Convert rate (Hz) to period (seconds).
@@ -6,6 +6,8 @@
 package kt33000
 
 import (
+	"context"
+
 	"github.com/gotmc/query"
 )
 
@@ -14,8 +16,8 @@ import (
 //
 // BurstCount is the getter for the read-write IviFgenBurst Attribute Burst
 // Count described in Section 17.2.1 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) BurstCount() (int, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) BurstCount(ctx context.Context) (int, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Int(ctx, ch.inst, ch.srcPrefix()+"BURS:NCYC?")
@@ -26,8 +28,8 @@ func (ch *Channel) BurstCount() (int, error) {
 //
 // SetBurstCount is the setter for the read-write IviFgenBurst Attribute Burst
 // Count described in Section 17.2.1 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) SetBurstCount(count int) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetBurstCount(ctx context.Context, count int) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, ch.srcPrefix()+"BURS:NCYC %d", count)
@@ -0,0 +1,89 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package kt33000
+
+import (
+	"fmt"
+
+	"github.com/gotmc/ivi"
+)
+
+// simulation describes the instrument simulated under [ivi.WithSimulate].
+// Each output starts as after *RST, with a 1 kHz, 100 mVpp sine wave, and
+// each model's bandwidth bounds its frequency settings.
+var simulation = ivi.Simulation{
+	Manufacturer:    "Keysight Technologies",
+	ModelAttributes: simulatedModels(),
+}
+
+// simulatedModels returns the simulated attributes of every supported model.
+func simulatedModels() map[string]map[string]ivi.SimulatedAttribute {
+	models := make(map[string]map[string]ivi.SimulatedAttribute)
+
+	for _, gen := range supportedGenerators {
+		attrs := make(map[string]ivi.SimulatedAttribute)
+
+		for i := range gen.channels {
+			ch := Channel{num: i, family: gen.family}
+			addSimulatedChannel(attrs, &ch, float64(gen.bandwidthHz))
+		}
+
+		models[gen.model] = attrs
+	}
+
+	return models
+}
+
+// addSimulatedChannel adds the simulated attributes of one output channel,
+// spelled as the channel spells its commands, to attrs. A zero bandwidth
+// leaves the frequency unbounded.
+func addSimulatedChannel(
+	attrs map[string]ivi.SimulatedAttribute,
+	ch *Channel,
+	bandwidth float64,
+) {
+	src := ch.srcPrefix()
+	trig := ch.trigPrefix()
+	outp := "OUTP" + ch.chanSuffix()
+
+	frequency := ivi.SimulatedAttribute{Default: "1000"}
+	if bandwidth > 0 {
+		frequency.Min, frequency.Max = 1e-6, bandwidth
+	}
+
+	attrs[src+"FUNC"] = ivi.SimulatedAttribute{Default: "SIN"}
+	attrs[src+"FREQ"] = frequency
+	attrs[src+"VOLT"] = ivi.SimulatedAttribute{
+		Default: "0.1", Min: 0.001, Max: 20,
+	}
+	attrs[src+"VOLT:OFFS"] = ivi.SimulatedAttribute{Min: -10, Max: 10}
+	attrs[src+"FUNC:SQU:DCYC"] = ivi.SimulatedAttribute{
+		Default: "50", Min: 0.01, Max: 99.99,
+	}
+	attrs[src+"FUNC:RAMP:SYMM"] = ivi.SimulatedAttribute{
+		Default: "100", Min: 0, Max: 100,
+	}
+	attrs[src+"PHAS"] = ivi.SimulatedAttribute{Min: -360, Max: 360}
+	attrs[src+"FUNC:ARB:SRAT"] = ivi.SimulatedAttribute{Default: "40000"}
+	attrs[src+"BURS:NCYC"] = ivi.SimulatedAttribute{
+		Default: "1", Min: 1, Max: 100_000_000,
+	}
+	attrs[src+"BURS:INT:PER"] = ivi.SimulatedAttribute{Default: "0.01"}
+	attrs[outp+":LOAD"] = ivi.SimulatedAttribute{Default: "50"}
+	attrs[trig+"SLOP"] = ivi.SimulatedAttribute{Default: "POS"}
+	attrs[trig+"SOUR"] = ivi.SimulatedAttribute{Default: "IMM"}
+	attrs[trig+"DEL"] = ivi.SimulatedAttribute{Min: 0, Max: 1000}
+
+	// APPLy sets the function, frequency, amplitude, and offset at once.
+	for _, wave := range []string{"SIN", "SQU", "RAMP", "DC"} {
+		attrs[src+"APPL:"+wave] = ivi.SimulatedAttribute{
+			Sets: fmt.Sprintf(
+				"%[1]sFUNC %[2]s;%[1]sFREQ $1;%[1]sVOLT $2;%[1]sVOLT:OFFS $3",
+				src, wave,
+			),
+		}
+	}
+}
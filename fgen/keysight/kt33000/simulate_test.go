@@ -0,0 +1,61 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package kt33000
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/fgen"
+)
+
+func TestSimulate(t *testing.T) {
+	ctx := t.Context()
+
+	d, err := New(nil, ivi.WithSimulate(), ivi.WithSimulatedModel("33512B"))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	ch1, err := d.Channel(0)
+	if err != nil {
+		t.Fatalf("Channel(0) error: %v", err)
+	}
+	ch2, err := d.Channel(1)
+	if err != nil {
+		t.Fatalf("Channel(1) error: %v", err)
+	}
+
+	if got, err := ch2.Frequency(ctx); err != nil || got != 1000 {
+		t.Errorf("CH2 Frequency() = %v, %v, want 1000", got, err)
+	}
+
+	err = ch1.ConfigureStandardWaveform(ctx, fgen.Square, 2.5, 0.5, 5000, 0)
+	if err != nil {
+		t.Fatalf("ConfigureStandardWaveform() error: %v", err)
+	}
+
+	if got, err := ch1.StandardWaveform(ctx); err != nil || got != fgen.Square {
+		t.Errorf("StandardWaveform() = %v, %v, want %v", got, err, fgen.Square)
+	}
+	if got, err := ch1.Frequency(ctx); err != nil || got != 5000 {
+		t.Errorf("Frequency() = %v, %v, want 5000", got, err)
+	}
+	if got, err := ch1.Amplitude(ctx); err != nil || got != 2.5 {
+		t.Errorf("Amplitude() = %v, %v, want 2.5", got, err)
+	}
+	if got, err := ch2.Frequency(ctx); err != nil || got != 1000 {
+		t.Errorf("CH2 Frequency() after CH1 change = %v, %v, want 1000",
+			got, err)
+	}
+
+	err = ch1.SetFrequency(ctx, 1e9)
+	if !errors.Is(err, ivi.ErrValueNotSupported) {
+		t.Errorf("SetFrequency(1e9) error = %v, want %v",
+			err, ivi.ErrValueNotSupported)
+	}
+}
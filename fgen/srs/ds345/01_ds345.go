@@ -39,6 +39,16 @@ var _ fgen.IntTriggerChannel = (*Channel)(nil)
 var _ fgen.StdFuncChannel = (*Channel)(nil)
 var _ fgen.TriggerChannel = (*Channel)(nil)
 
+// Register the driver with [fgen.Drivers] so that Open can select it by model,
+// and its connection defaults so that a config session can complete its
+// resource.
+func init() {
+	fgen.Drivers.Register(
+		"ds345", supportedModels(), ivi.Factory[fgen.Base](New),
+	)
+	ivi.RegisterConnectionDefaults("ds345", ConnectionDefaults())
+}
+
 // Driver provides the IVI driver for a SRS DS345 function generator.
 type Driver struct {
 	inst     ivi.Transport
@@ -74,15 +84,17 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 			// "IviFgenArbWaveform",
 			"IviFgenBase",
 			"IviFgenBurst",
-			// "IviFgenInternalTrigger",
+			"IviFgenInternalTrigger",
 			// "IviFgenModulateFM",
 			// "IviFgenModulateAM",
 			// "IviFgenSoftwareTrigger",
-			"IviFgenStdfunc",
+			"IviFgenStartTrigger",
+			"IviFgenStdFunc",
 			"IviFgenTrigger",
 		},
-		SupportedInstrumentModels: []string{"DS345"},
+		SupportedInstrumentModels: supportedModels(),
 		SupportedBusInterfaces:    []string{"GPIB", "RS232"},
+		Simulation:                &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
@@ -91,22 +103,22 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	channelNames := []string{"Output"}
 	channels := make([]Channel, len(channelNames))
 	for i, name := range channelNames {
-		channels[i] = Channel{name: name, inst: inst, timeout: s.Timeout}
+		channels[i] = Channel{name: name, inst: s.Transport, timeout: s.Timeout}
 	}
 
 	driver := Driver{
-		inst:     inst,
+		inst:     s.Transport,
 		channels: channels,
 		timeout:  s.Timeout,
 		Inherent: s.Inherent,
 	}
 
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
+		if err := driver.Reset(context.Background()); err != nil {
 			return &driver, err
 		}
 		// Default to internal trigger instead of single trigger when reset.
-		ctx, cancel := driver.newContext()
+		ctx, cancel := driver.newContext(context.Background())
 		defer cancel()
 
 		if err := driver.inst.Command(ctx, "TSRC1"); err != nil {
@@ -117,14 +129,25 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	return &driver, nil
 }
 
-// newContext creates a context with the driver's configured timeout.
-func (d *Driver) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), d.timeout)
+// supportedModels returns the model numbers the driver supports.
+func supportedModels() []string {
+	return []string{"DS345"}
+}
+
+// newContext derives a context from ctx that carries the driver's configured
+// timeout, unless ctx already has a deadline of its own.
+func (d *Driver) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, d.timeout)
 }
 
-// newContext creates a context with the channel's configured timeout.
-func (ch *Channel) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), ch.timeout)
+// newContext derives a context from ctx that carries the channel's configured
+// timeout, unless ctx already has a deadline of its own.
+func (ch *Channel) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, ch.timeout)
 }
 
 // Channel returns the Channel at the given index, with bounds checking.
@@ -178,3 +201,14 @@ func SerialDataFrames() []string {
 func DefaultSerialDataFrame() string {
 	return "8N2"
 }
+
+// ConnectionDefaults returns the model's connection defaults, which
+// complete a VISA resource string with [ivi.Resource.Settings].
+func ConnectionDefaults() ivi.ConnectionDefaults {
+	return ivi.ConnectionDefaults{
+		GPIBAddress:     DefaultGPIBAddress(),
+		SerialBaudRates: SerialBaudRates(),
+		SerialBaudRate:  DefaultSerialBaudRate(),
+		SerialDataFrame: DefaultSerialDataFrame(),
+	}
+}
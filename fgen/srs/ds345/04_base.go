@@ -6,6 +6,7 @@
 package ds345
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gotmc/ivi"
@@ -36,6 +37,33 @@ func (d *Driver) OutputCount() int {
 	return len(d.channels)
 }
 
+// OutputName returns the name of the output channel at index, which runs
+// from 0 to OutputCount()-1.
+//
+// OutputName implements the IviFgenBase function Get Channel Name described
+// in Section 4.3.7 of IVI-4.3: IviFgen Class Specification.
+func (d *Driver) OutputName(index int) (string, error) {
+	ch, err := d.Channel(index)
+	if err != nil {
+		return "", fmt.Errorf("OutputName: %w", err)
+	}
+
+	return ch.Name(), nil
+}
+
+// OutputItem returns the output channel with the given name, as returned by
+// OutputName, for the Outputs repeated capability of IVI-4.3: IviFgen Class
+// Specification.
+func (d *Driver) OutputItem(name string) (fgen.BaseChannel, error) {
+	for i := range d.channels {
+		if d.channels[i].Name() == name {
+			return &d.channels[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("OutputItem: %q: %w", name, ivi.ErrChannelNotFound)
+}
+
 // OutputMode returns the determines how the function generator produces
 // waveforms. This attribute determines which extension group's functions and
 // attributes are used to configure the waveform the function generator
@@ -43,8 +71,8 @@ func (d *Driver) OutputCount() int {
 //
 // OutputMode is the getter for the read-only IviFgenBase Attribute Output
 // Mode described in Section 4.2.5 of IVI-4.3: IviFgen Class Specification.
-func (d *Driver) OutputMode() (fgen.OutputMode, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) OutputMode(ctx context.Context) (fgen.OutputMode, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	var outputMode fgen.OutputMode
@@ -72,8 +100,11 @@ func (d *Driver) OutputMode() (fgen.OutputMode, error) {
 //
 // OutputMode is the setter for the read-only IviFgenBase Attribute Output
 // Mode described in Section 4.2.5 of IVI-4.3: IviFgen Class Specification.
-func (d *Driver) SetOutputMode(outputMode fgen.OutputMode) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetOutputMode(
+	ctx context.Context,
+	outputMode fgen.OutputMode,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(outputModeToSCPI, outputMode)
@@ -88,10 +119,18 @@ func (d *Driver) SetOutputMode(outputMode fgen.OutputMode) error {
 // continuous or burst output on the channel. OperationMode implements the
 // getter for the read-write IviFgenBase Attribute Operation Mode described in
 // Section 4.2.2 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) OperationMode() (fgen.OperationMode, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) OperationMode(
+	ctx context.Context,
+) (fgen.OperationMode, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return 0, fmt.Errorf("OperationMode: %w", err)
+	}
+	defer unlock()
+
 	var mode fgen.OperationMode
 
 	isModulationEnabled, err := query.Bool(ctx, ch.inst, "MENA?")
@@ -122,8 +161,11 @@ func (ch *Channel) OperationMode() (fgen.OperationMode, error) {
 // SetOperationMode implements the setter for the read-write IviFgenBase
 // Attribute Operation Mode described in Section 4.2.2 of IVI-4.3: IviFgen
 // Class Specification.
-func (ch *Channel) SetOperationMode(mode fgen.OperationMode) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetOperationMode(
+	ctx context.Context,
+	mode fgen.OperationMode,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(operationModeToSCPI, mode)
@@ -138,7 +180,7 @@ func (ch *Channel) SetOperationMode(mode fgen.OperationMode) error {
 //
 // OutputEnabled is the getter for the read-write IviFgenBase Attribute Output
 // Enabled described in Section 4.2.3 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) OutputEnabled() (bool, error) {
+func (ch *Channel) OutputEnabled(ctx context.Context) (bool, error) {
 	return false, ivi.ErrFunctionNotSupported
 }
 
@@ -147,20 +189,20 @@ func (ch *Channel) OutputEnabled() (bool, error) {
 // SetOutputEnabled is the setter for the read-write IviFgenBase Attribute
 // Output Enabled described in Section 4.2.3 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetOutputEnabled(_ bool) error {
+func (ch *Channel) SetOutputEnabled(ctx context.Context, _ bool) error {
 	return ivi.ErrFunctionNotSupported
 }
 
 // DisableOutput is a convenience function for setting the Output Enabled
 // attribute to false.
-func (ch *Channel) DisableOutput() error {
-	return ch.SetOutputEnabled(false)
+func (ch *Channel) DisableOutput(ctx context.Context) error {
+	return ch.SetOutputEnabled(ctx, false)
 }
 
 // EnableOutput is a convenience function for setting the Output Enabled
 // attribute to true.
-func (ch *Channel) EnableOutput() error {
-	return ch.SetOutputEnabled(true)
+func (ch *Channel) EnableOutput(ctx context.Context) error {
+	return ch.SetOutputEnabled(ctx, true)
 }
 
 // OutputImpedance return the output channel's impedance in ohms.
@@ -168,7 +210,7 @@ func (ch *Channel) EnableOutput() error {
 // OutputImpedance is the getter for the read-write IviFgenBase Attribute
 // Output Impedance described in Section 4.2.4 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) OutputImpedance() (float64, error) {
+func (ch *Channel) OutputImpedance(ctx context.Context) (float64, error) {
 	return outputImpedance, nil
 }
 
@@ -177,7 +219,10 @@ func (ch *Channel) OutputImpedance() (float64, error) {
 // SetOutputImpedance is the setter for the read-write IviFgenBase Attribute
 // Output Impedance described in Section 4.2.4 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetOutputImpedance(impedance float64) error {
+func (ch *Channel) SetOutputImpedance(
+	ctx context.Context,
+	impedance float64,
+) error {
 	if impedance != outputImpedance {
 		return ivi.ErrFunctionNotSupported
 	}
@@ -191,7 +236,7 @@ func (ch *Channel) SetOutputImpedance(impedance float64) error {
 //
 // AbortGeneration implements the IviFgenBase function described in Section 4.3.1
 // of IVI-4.3: IviFgen Class Specification.
-func (d *Driver) AbortGeneration() error {
+func (d *Driver) AbortGeneration(ctx context.Context) error {
 	return nil
 }
 
@@ -201,15 +246,20 @@ func (d *Driver) AbortGeneration() error {
 //
 // InitiateGeneration implements the IviFgenBase function described in Section
 // 4.3.8 of IVI-4.3: IviFgen Class Specification.
-func (d *Driver) InitiateGeneration() error {
+func (d *Driver) InitiateGeneration(ctx context.Context) error {
 	return nil
 }
 
-func (d *Driver) ReferenceClockSource() (fgen.ClockSource, error) {
+func (d *Driver) ReferenceClockSource(
+	ctx context.Context,
+) (fgen.ClockSource, error) {
 	return fgen.RefClockInternal, nil
 }
 
-func (d *Driver) SetReferenceClockSource(_ fgen.ClockSource) error {
+func (d *Driver) SetReferenceClockSource(
+	ctx context.Context,
+	_ fgen.ClockSource,
+) error {
 	return nil
 }
 
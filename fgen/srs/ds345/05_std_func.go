@@ -6,6 +6,7 @@
 package ds345
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -21,8 +22,8 @@ import (
 // Amplitude is the getter for the read-write IviFgenStdFunc Attribute
 // Amplitude described in Section 5.2.1 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) Amplitude() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) Amplitude(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, ch.inst, "AMPL? VP")
@@ -41,8 +42,8 @@ func (ch *Channel) Amplitude() (float64, error) {
 // SetAmplitude is the setter for the read-write IviFgenStdFunc Attribute
 // Amplitude described in Section 5.2.1 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetAmplitude(amp float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetAmplitude(ctx context.Context, amp float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, "AMPL %fVP", amp)
@@ -53,8 +54,8 @@ func (ch *Channel) SetAmplitude(amp float64) error {
 //
 // DCOffset is the getter for the read-write IviFgenStdFunc Attribute DC Offset
 // described in Section 5.2.2 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) DCOffset() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) DCOffset(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, "OFFS?")
@@ -65,8 +66,8 @@ func (ch *Channel) DCOffset() (float64, error) {
 //
 // SetDCOffset is the setter for the read-write IviFgenStdFunc Attribute DC
 // Offset described in Section 5.2.2 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) SetDCOffset(amp float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetDCOffset(ctx context.Context, amp float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, "OFFS %f", amp)
@@ -78,7 +79,7 @@ func (ch *Channel) SetDCOffset(amp float64) error {
 // DutyCycle is the getter for the read-write IviFgenStdFunc Attribute Duty
 // Cycle High described in Section 5.2.3 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) DutyCycleHigh() (float64, error) {
+func (ch *Channel) DutyCycleHigh(ctx context.Context) (float64, error) {
 	return 0.0, ivi.ErrNotImplemented
 }
 
@@ -88,7 +89,7 @@ func (ch *Channel) DutyCycleHigh() (float64, error) {
 // SetDutyCycle is the setter for the read-write IviFgenStdFunc Attribute Duty
 // Cycle High described in Section 5.2.3 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetDutyCycleHigh(duty float64) error {
+func (ch *Channel) SetDutyCycleHigh(ctx context.Context, duty float64) error {
 	return ivi.ErrNotImplemented
 }
 
@@ -98,8 +99,8 @@ func (ch *Channel) SetDutyCycleHigh(duty float64) error {
 // Frequency is the getter for the read-write IviFgenStdFunc Attribute
 // Frequency described in Section 5.2.4 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) Frequency() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) Frequency(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, "FREQ?")
@@ -111,8 +112,8 @@ func (ch *Channel) Frequency() (float64, error) {
 // SetFrequency is the setter for the read-write IviFgenStdFunc Attribute
 // Frequency described in Section 5.2.4 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetFrequency(freq float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetFrequency(ctx context.Context, freq float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, "FREQ %f", freq)
@@ -124,8 +125,8 @@ func (ch *Channel) SetFrequency(freq float64) error {
 //
 // StartPhase is the getter for the read-write IviFgenStdFunc Attribute Start
 // Phase described in Section 5.2.5 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) StartPhase() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) StartPhase(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, "PHSE?")
@@ -138,8 +139,8 @@ func (ch *Channel) StartPhase() (float64, error) {
 // SetStartPhase is the setter for the read-write IviFgenStdFunc Attribute
 // Start Phase described in Section 5.2.5 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetStartPhase(freq float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetStartPhase(ctx context.Context, freq float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, "PHSE %f", freq)
@@ -150,10 +151,18 @@ func (ch *Channel) SetStartPhase(freq float64) error {
 //
 // StandardWaveform is the getter for the read-write IviFgenStdFunc Attribute
 // Waveform described in Section 5.2.6 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) StandardWaveform() (fgen.StandardWaveform, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) StandardWaveform(
+	ctx context.Context,
+) (fgen.StandardWaveform, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return 0, fmt.Errorf("StandardWaveform: %w", err)
+	}
+	defer unlock()
+
 	var wave fgen.StandardWaveform
 
 	s, err := query.String(ctx, ch.inst, "FUNC?")
@@ -193,8 +202,11 @@ func (ch *Channel) StandardWaveform() (fgen.StandardWaveform, error) {
 // SetStandardWaveform is the setter for the read-write IviFgenStdFunc
 // Attribute Waveform described in Section 5.2.6 of IVI-4.3: IviFgen Class
 // Specification.
-func (ch *Channel) SetStandardWaveform(wave fgen.StandardWaveform) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetStandardWaveform(
+	ctx context.Context,
+	wave fgen.StandardWaveform,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	// FIXME(mdr): May need to change the phase offset in order to match the
@@ -222,13 +234,14 @@ var waveformCommand = map[fgen.StandardWaveform]string{
 // Standard Waveform function described in Section 5.3.1 of IVI-4.3: IviFgen
 // Class Specification.
 func (ch *Channel) ConfigureStandardWaveform(
+	ctx context.Context,
 	wave fgen.StandardWaveform,
 	amp float64,
 	offset float64,
 	freq float64,
 	phase float64,
 ) error {
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	format, err := ivi.LookupSCPI(waveformApplyCommand, wave)
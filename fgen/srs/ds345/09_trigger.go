@@ -6,6 +6,7 @@
 package ds345
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gotmc/ivi/fgen"
@@ -18,8 +19,10 @@ import (
 // Specification.
 //
 // Deprecated: Use StartTriggerSource instead (Section 10).
-func (ch *Channel) TriggerSource() (fgen.OldTriggerSource, error) {
-	src, err := ch.StartTriggerSource()
+func (ch *Channel) TriggerSource(
+	ctx context.Context,
+) (fgen.OldTriggerSource, error) {
+	src, err := ch.StartTriggerSource(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -39,11 +42,14 @@ func (ch *Channel) TriggerSource() (fgen.OldTriggerSource, error) {
 // Specification.
 //
 // Deprecated: Use SetStartTriggerSource instead (Section 10).
-func (ch *Channel) SetTriggerSource(src fgen.OldTriggerSource) error {
+func (ch *Channel) SetTriggerSource(
+	ctx context.Context,
+	src fgen.OldTriggerSource,
+) error {
 	ts, ok := fgen.OldToNewTriggerSource(src)
 	if !ok {
 		return fmt.Errorf("trigger source %s not supported", src)
 	}
 
-	return ch.SetStartTriggerSource(ts)
+	return ch.SetStartTriggerSource(ctx, ts)
 }
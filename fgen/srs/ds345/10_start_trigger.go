@@ -6,6 +6,7 @@
 package ds345
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -27,24 +28,36 @@ var scpiToTriggerSource = map[string]fgen.TriggerSource{
 	"3": fgen.TriggerSourceExternal,
 }
 
-func (ch *Channel) StartTriggerDelay() (time.Duration, error) {
+func (ch *Channel) StartTriggerDelay(
+	ctx context.Context,
+) (time.Duration, error) {
 	return time.Duration(0), ivi.ErrFunctionNotSupported
 }
 
-func (ch *Channel) SetStartTriggerDelay(_ time.Duration) error {
+func (ch *Channel) SetStartTriggerDelay(
+	ctx context.Context,
+	_ time.Duration,
+) error {
 	return ivi.ErrFunctionNotSupported
 }
 
-func (ch *Channel) StartTriggerSlope() (fgen.TriggerSlope, error) {
+func (ch *Channel) StartTriggerSlope(
+	ctx context.Context,
+) (fgen.TriggerSlope, error) {
 	return 0, ivi.ErrFunctionNotSupported
 }
 
-func (ch *Channel) SetStartTriggerSlope(slope fgen.TriggerSlope) error {
+func (ch *Channel) SetStartTriggerSlope(
+	ctx context.Context,
+	slope fgen.TriggerSlope,
+) error {
 	return ivi.ErrFunctionNotSupported
 }
 
-func (ch *Channel) StartTriggerSource() (fgen.TriggerSource, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) StartTriggerSource(
+	ctx context.Context,
+) (fgen.TriggerSource, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	var src fgen.TriggerSource
@@ -64,8 +77,11 @@ func (ch *Channel) StartTriggerSource() (fgen.TriggerSource, error) {
 	return src, nil
 }
 
-func (ch *Channel) SetStartTriggerSource(src fgen.TriggerSource) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetStartTriggerSource(
+	ctx context.Context,
+	src fgen.TriggerSource,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	triggerSource, err := ivi.LookupSCPI(triggerSourceToSCPI, src)
@@ -76,15 +92,19 @@ func (ch *Channel) SetStartTriggerSource(src fgen.TriggerSource) error {
 	return ch.inst.Command(ctx, "TSRC%s", triggerSource)
 }
 
-func (ch *Channel) StartTriggerThreshold() (float64, error) {
+func (ch *Channel) StartTriggerThreshold(ctx context.Context) (float64, error) {
 	return 0.0, ivi.ErrFunctionNotSupported
 }
 
-func (ch *Channel) SetStartTriggerThreshold(threshold float64) error {
+func (ch *Channel) SetStartTriggerThreshold(
+	ctx context.Context,
+	threshold float64,
+) error {
 	return ivi.ErrFunctionNotSupported
 }
 
 func (ch *Channel) StartTriggerConfigure(
+	ctx context.Context,
 	source fgen.TriggerSource,
 	slope fgen.TriggerSlope,
 ) error {
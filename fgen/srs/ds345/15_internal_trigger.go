@@ -6,6 +6,8 @@
 package ds345
 
 import (
+	"context"
+
 	"github.com/gotmc/ivi"
 	"github.com/gotmc/query"
 )
@@ -19,8 +21,8 @@ import (
 // InternalTriggerRate as a driver-level attribute. We implement it on the
 // channel to support multi-channel function generators. The DS345 is
 // single-channel, so the behavior is unchanged.
-func (ch *Channel) InternalTriggerRate() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) InternalTriggerRate(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, ch.inst, "TRAT?")
@@ -32,8 +34,11 @@ func (ch *Channel) InternalTriggerRate() (float64, error) {
 // Hz to 10 kHz.
 //
 // Deviation from IVI-4.3: See InternalTriggerRate.
-func (ch *Channel) SetInternalTriggerRate(rate float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetInternalTriggerRate(
+	ctx context.Context,
+	rate float64,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	// The DS345 supports internal trigger rates from 0.001 Hz to 10 kHz.
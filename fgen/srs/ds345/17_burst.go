@@ -6,6 +6,8 @@
 package ds345
 
 import (
+	"context"
+
 	"github.com/gotmc/query"
 )
 
@@ -14,8 +16,8 @@ import (
 //
 // BurstCount is the getter for the read-write IviFgenBurst Attribute Burst
 // Count described in Section 17.2.1 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) BurstCount() (int, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) BurstCount(ctx context.Context) (int, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Int(ctx, ch.inst, "BCNT?")
@@ -26,8 +28,8 @@ func (ch *Channel) BurstCount() (int, error) {
 //
 // SetBurstCount is the setter for the read-write IviFgenBurst Attribute Burst
 // Count described in Section 17.2.1 of IVI-4.3: IviFgen Class Specification.
-func (ch *Channel) SetBurstCount(count int) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetBurstCount(ctx context.Context, count int) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, "BCNT %d", count)
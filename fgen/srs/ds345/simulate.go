@@ -0,0 +1,48 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ds345
+
+import (
+	"strconv"
+
+	"github.com/gotmc/ivi"
+)
+
+// simulation describes the instrument simulated under [ivi.WithSimulate],
+// which starts with a 1 kHz, 1 Vpp sine wave.
+var simulation = ivi.Simulation{
+	Manufacturer: "StanfordResearchSystems",
+	Attributes:   simulatedAttributes(),
+}
+
+// simulatedAttributes returns the attributes of the simulated DS345.
+func simulatedAttributes() map[string]ivi.SimulatedAttribute {
+	attrs := map[string]ivi.SimulatedAttribute{
+		"FREQ":    {Default: "1000", Min: 1e-6, Max: 30.2e6},
+		"AMPL":    {Default: "1.00VP"},
+		"AMPL VP": {Follows: "AMPL"},
+		"OFFS":    {Min: -5, Max: 5},
+		"PHSE":    {Min: 0, Max: 7199.999},
+		"TRAT":    {Default: "1", Min: 0.001, Max: 10000},
+		"BCNT":    {Default: "1", Min: 1, Max: 30000},
+		"TSRC":    {Default: "1"},
+	}
+
+	// Settings such as the function are set by a command with the value
+	// appended to it, as in FUNC0, but read with FUNC?.
+	for header, count := range map[string]int{
+		"FUNC": 6, "INVT": 2, "MENA": 2, "MTYP": 6, "TSRC": 6,
+	} {
+		for i := range count {
+			value := strconv.Itoa(i)
+			attrs[header+value] = ivi.SimulatedAttribute{
+				Sets: header + " " + value,
+			}
+		}
+	}
+
+	return attrs
+}
@@ -8,13 +8,19 @@ package ivi
 import (
 	"context"
 	"fmt"
+	"runtime/debug"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gotmc/query"
 )
 
+// modulePath is the import path of the ivi module, used to look up its
+// version in the program's build information.
+const modulePath = "github.com/gotmc/ivi"
+
 type idPart int
 
 const (
@@ -27,7 +33,12 @@ const (
 // Inherent provides the inherent capabilities for all IVI instruments.
 type Inherent struct {
 	inst             Transport
+	session          *SessionTransport
 	timeout          time.Duration
+	defaultSetup     []string
+	cache            *AttributeCache
+	simulate         bool
+	rangeCheck       bool
 	manufacturer     string
 	model            string
 	serialNumber     string
@@ -41,14 +52,21 @@ type InherentBase struct {
 	ClassSpecRevision         string
 	IDNString                 string
 	LocalControlCommand       string // SCPI command to return to local control (default: "SYST:LOC")
+	ErrorQueryCommand         string // SCPI query for one error queue entry (default: "SYST:ERR?")
+	SelfTestCommand           string // Query that runs the self test (default: "*TST?")
+	DriverRevision            string // Reported by RevisionQuery (default: ivi module version)
 	GroupCapabilities         []string
 	SupportedInstrumentModels []string
 	SupportedBusInterfaces    []string
+	Simulation                *Simulation // Instrument simulated under WithSimulate
 	ClassSpecMajorVersion     int
 	ClassSpecMinorVersion     int
 	ResetDelay                time.Duration
 	ClearDelay                time.Duration
-	ReturnToLocal             bool // Whether to return to local control on Close/Disable
+	SelfTestTimeout           time.Duration // Minimum time allowed for the self test
+	ReturnToLocal             bool          // Whether to return to local control on Close/Disable
+	SupportsOPC               bool          // Whether to wait on *OPC? rather than ResetDelay/ClearDelay
+	NoErrorQueue              bool          // Whether the instrument lacks an error queue to query
 }
 
 // NewInherent creates a new Inherent struct using the given Transport
@@ -60,8 +78,11 @@ func NewInherent(inst Transport, base InherentBase, timeout time.Duration) Inher
 		timeout = DefaultTimeout
 	}
 
+	session, _ := inst.(*SessionTransport)
+
 	return Inherent{
 		inst:         inst,
+		session:      session,
 		timeout:      timeout,
 		InherentBase: base,
 	}
@@ -72,9 +93,12 @@ func (inherent *Inherent) Timeout() time.Duration {
 	return inherent.timeout
 }
 
-// newContext creates a context with the driver's configured timeout.
-func (inherent *Inherent) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), inherent.timeout)
+// newContext derives a context from ctx that carries the driver's configured
+// timeout, unless ctx already has a deadline of its own.
+func (inherent *Inherent) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ContextWithTimeout(ctx, inherent.timeout)
 }
 
 // CheckID queries the instrument for its identification string (*IDN?) and
@@ -89,8 +113,8 @@ func (inherent *Inherent) newContext() (context.Context, context.CancelFunc) {
 // ([Inherent.FirmwareRevision], [Inherent.InstrumentManufacturer],
 // [Inherent.InstrumentModel], [Inherent.InstrumentSerialNumber]) return the
 // cached values without issuing additional SCPI.
-func (inherent *Inherent) CheckID() (string, error) {
-	ctx, cancel := inherent.newContext()
+func (inherent *Inherent) CheckID(ctx context.Context) (string, error) {
+	ctx, cancel := inherent.newContext(ctx)
 	defer cancel()
 
 	idn, err := query.String(ctx, inherent.inst, "*IDN?")
@@ -98,6 +122,12 @@ func (inherent *Inherent) CheckID() (string, error) {
 		return "", fmt.Errorf("error querying instrument identity: %w", err)
 	}
 
+	return inherent.checkIdentification(idn)
+}
+
+// checkIdentification does the checking and caching of [Inherent.CheckID]
+// for an *IDN? response that has already been read.
+func (inherent *Inherent) checkIdentification(idn string) (string, error) {
 	inherent.IDNString = strings.TrimSpace(idn)
 
 	if err := inherent.cacheIdentification(inherent.IDNString); err != nil {
@@ -121,8 +151,10 @@ func (inherent *Inherent) CheckID() (string, error) {
 // live *IDN? query and caches the result. FirmwareRevision is the getter for
 // the read-only inherent attribute Instrument Firmware Revision described in
 // Section 5.18 of IVI-3.2: Inherent Capabilities Specification.
-func (inherent *Inherent) FirmwareRevision() (string, error) {
-	return inherent.lookupIdentification(&inherent.firmwareRevision, fwrID)
+func (inherent *Inherent) FirmwareRevision(
+	ctx context.Context,
+) (string, error) {
+	return inherent.lookupIdentification(ctx, &inherent.firmwareRevision, fwrID)
 }
 
 // InstrumentManufacturer returns the instrument manufacturer. It returns the
@@ -130,8 +162,10 @@ func (inherent *Inherent) FirmwareRevision() (string, error) {
 // *IDN? query and caches the result. InstrumentManufacturer is the getter for
 // the read-only inherent attribute Instrument Manufacturer described in
 // Section 5.19 of IVI-3.2: Inherent Capabilities Specification.
-func (inherent *Inherent) InstrumentManufacturer() (string, error) {
-	return inherent.lookupIdentification(&inherent.manufacturer, mfrID)
+func (inherent *Inherent) InstrumentManufacturer(
+	ctx context.Context,
+) (string, error) {
+	return inherent.lookupIdentification(ctx, &inherent.manufacturer, mfrID)
 }
 
 // InstrumentModel returns the instrument model. It returns the value cached
@@ -139,25 +173,31 @@ func (inherent *Inherent) InstrumentManufacturer() (string, error) {
 // and caches the result. InstrumentModel is the getter for the read-only
 // inherent attribute Instrument Model described in Section 5.20 of IVI-3.2:
 // Inherent Capabilities Specification.
-func (inherent *Inherent) InstrumentModel() (string, error) {
-	return inherent.lookupIdentification(&inherent.model, modelID)
+func (inherent *Inherent) InstrumentModel(ctx context.Context) (string, error) {
+	return inherent.lookupIdentification(ctx, &inherent.model, modelID)
 }
 
 // InstrumentSerialNumber returns the instrument serial number. It returns the
 // value cached by [Inherent.CheckID]; if the cache is empty it issues a live
 // *IDN? query and caches the result.
-func (inherent *Inherent) InstrumentSerialNumber() (string, error) {
-	return inherent.lookupIdentification(&inherent.serialNumber, snID)
+func (inherent *Inherent) InstrumentSerialNumber(
+	ctx context.Context,
+) (string, error) {
+	return inherent.lookupIdentification(ctx, &inherent.serialNumber, snID)
 }
 
 // lookupIdentification returns the cached identification field when
 // populated, otherwise issues a live *IDN? query and caches the result.
-func (inherent *Inherent) lookupIdentification(field *string, part idPart) (string, error) {
+func (inherent *Inherent) lookupIdentification(
+	ctx context.Context,
+	field *string,
+	part idPart,
+) (string, error) {
 	if *field != "" {
 		return *field, nil
 	}
 
-	value, err := inherent.queryIdentification(part)
+	value, err := inherent.queryIdentification(ctx, part)
 	if err != nil {
 		return "", err
 	}
@@ -186,34 +226,153 @@ func (inherent *Inherent) cacheIdentification(idn string) error {
 	return nil
 }
 
-// Reset resets the instrument.
-func (inherent *Inherent) Reset() error {
-	ctx, cancel := inherent.newContext()
+// Reset resets the instrument and waits for the reset to finish, using *OPC?
+// when SupportsOPC is set and otherwise waiting ResetDelay. Reset discards
+// every cached attribute value.
+func (inherent *Inherent) Reset(ctx context.Context) error {
+	ctx, cancel := inherent.newContext(ctx)
 	defer cancel()
 
+	inherent.cache.InvalidateAll()
+
 	if err := inherent.inst.Command(ctx, "*rst"); err != nil {
 		return err
 	}
-	// Wait for the device to finish resetting.
-	time.Sleep(inherent.ResetDelay)
 
-	return nil
+	// Wait for the device to finish resetting.
+	return inherent.AwaitOperation(ctx, inherent.ResetDelay)
 }
 
-// Clear clears the instrument.
-func (inherent *Inherent) Clear() error {
-	ctx, cancel := inherent.newContext()
+// Clear clears the instrument and waits for the clear to finish, using *OPC?
+// when SupportsOPC is set and otherwise waiting ClearDelay.
+func (inherent *Inherent) Clear(ctx context.Context) error {
+	ctx, cancel := inherent.newContext(ctx)
 	defer cancel()
 
 	if err := inherent.inst.Command(ctx, "*cls"); err != nil {
 		return err
 	}
+
 	// Wait for the device to finish clearing.
-	time.Sleep(inherent.ClearDelay)
+	return inherent.AwaitOperation(ctx, inherent.ClearDelay)
+}
+
+// ResetWithDefaults resets the instrument and then sends the default setup
+// commands given to the driver with [WithDefaultSetup], so that the
+// instrument is left in the user's preferred initial state rather than its
+// factory state. Without a default setup it behaves exactly like Reset.
+// ResetWithDefaults provides the method described in IVI-3.2: Inherent
+// Capabilities Specification.
+func (inherent *Inherent) ResetWithDefaults(ctx context.Context) error {
+	if err := inherent.Reset(ctx); err != nil {
+		return err
+	}
+
+	ctx, cancel := inherent.newContext(ctx)
+	defer cancel()
+
+	for _, cmd := range inherent.defaultSetup {
+		if err := inherent.inst.Command(ctx, cmd); err != nil {
+			return fmt.Errorf("error applying default setup %q: %w", cmd, err)
+		}
+	}
 
 	return nil
 }
 
+// SelfTest runs the instrument's self test using SelfTestCommand, *TST? by
+// default, and returns the numeric result code together with a message
+// describing it. A result code of zero means the self test passed; any other
+// code is instrument-specific. The returned error reports only a failure to
+// run the test, so callers must check the code. Because self tests commonly
+// take longer than an ordinary query, the driver timeout is extended to
+// SelfTestTimeout when that is longer. SelfTest provides the method described
+// in IVI-3.2: Inherent Capabilities Specification.
+func (inherent *Inherent) SelfTest(ctx context.Context) (int, string, error) {
+	ctx, cancel := ContextWithTimeout(
+		ctx, max(inherent.timeout, inherent.SelfTestTimeout),
+	)
+	defer cancel()
+
+	cmd := inherent.SelfTestCommand
+	if cmd == "" {
+		cmd = "*TST?"
+	}
+
+	resp, err := query.String(ctx, inherent.inst, cmd)
+	if err != nil {
+		return 0, "", fmt.Errorf("error running self test: %w", err)
+	}
+
+	code, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(resp), "+"))
+	if err != nil {
+		return 0, "", fmt.Errorf(
+			"%w: %s returned %q", ErrUnexpectedResponse, cmd, resp,
+		)
+	}
+
+	if code != 0 {
+		return code, fmt.Sprintf("self test failed with code %d", code), nil
+	}
+
+	return 0, "self test passed", nil
+}
+
+// RevisionQuery returns the revision of the IVI driver and the firmware
+// revision of the instrument. The driver revision is DriverRevision when the
+// driver sets it and otherwise the version of the ivi module the program was
+// built with. The firmware revision comes from [Inherent.FirmwareRevision].
+// RevisionQuery provides the method described in IVI-3.2: Inherent
+// Capabilities Specification.
+func (inherent *Inherent) RevisionQuery(
+	ctx context.Context,
+) (driverRev, firmwareRev string, err error) {
+	firmwareRev, err = inherent.FirmwareRevision(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	driverRev = inherent.DriverRevision
+	if driverRev == "" {
+		driverRev = moduleVersion()
+	}
+
+	return driverRev, firmwareRev, nil
+}
+
+// InvalidateAllAttributes discards every attribute value the driver has
+// cached, so that the next read of each attribute queries the instrument.
+// Call it after changing instrument settings outside the driver, such as from
+// the front panel or with raw SCPI. Without [WithCache] there is nothing to
+// discard. InvalidateAllAttributes provides the method described in IVI-3.2:
+// Inherent Capabilities Specification.
+func (inherent *Inherent) InvalidateAllAttributes() {
+	inherent.cache.InvalidateAll()
+}
+
+// moduleVersion returns the version of the ivi module recorded in the
+// running program's build information, or "(devel)" when it is unknown.
+func moduleVersion() string {
+	const unknown = "(devel)"
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return unknown
+	}
+
+	if info.Main.Path == modulePath && info.Main.Version != "" {
+		return info.Main.Version
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			return dep.Version
+		}
+	}
+
+	return unknown
+}
+
 // Disable places the instrument in a quiescent state as quickly as possible.
 // If ReturnToLocal is true, this method also returns the instrument to local
 // control by sending the SYST:LOC command, allowing the front panel to regain
@@ -223,12 +382,12 @@ func (inherent *Inherent) Clear() error {
 // Errors from the local control command are intentionally ignored because not
 // all instruments support SYST:LOC, and a failure to return to local should
 // not prevent the instrument from being closed.
-func (inherent *Inherent) Disable() error {
+func (inherent *Inherent) Disable(ctx context.Context) error {
 	if !inherent.ReturnToLocal {
 		return nil
 	}
 
-	ctx, cancel := inherent.newContext()
+	ctx, cancel := inherent.newContext(ctx)
 	defer cancel()
 
 	// Best-effort return to local control so the front panel regains control.
@@ -244,8 +403,11 @@ func (inherent *Inherent) Disable() error {
 	return nil
 }
 
-func (inherent *Inherent) queryIdentification(part idPart) (string, error) {
-	ctx, cancel := inherent.newContext()
+func (inherent *Inherent) queryIdentification(
+	ctx context.Context,
+	part idPart,
+) (string, error) {
+	ctx, cancel := inherent.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, inherent.inst, "*IDN?")
@@ -284,5 +446,5 @@ func (inherent *Inherent) IsReturnToLocal() bool {
 // Disable. The underlying transport connection is not closed — that is the
 // caller's responsibility since they created it.
 func (inherent *Inherent) Close() error {
-	return inherent.Disable()
+	return inherent.Disable(context.Background())
 }
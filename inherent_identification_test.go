@@ -48,7 +48,7 @@ func TestInherent_InstrumentManufacturer(t *testing.T) {
 		idnResponse: "KEYSIGHT TECHNOLOGIES,34465A,MY54505281,A.03.01-03.01-03.01-00.52-04-02",
 	}
 	inherent := NewInherent(mock, InherentBase{ReturnToLocal: true}, 0)
-	mfr, err := inherent.InstrumentManufacturer()
+	mfr, err := inherent.InstrumentManufacturer(t.Context())
 	if err != nil {
 		t.Fatalf("InstrumentManufacturer() error: %v", err)
 	}
@@ -62,7 +62,7 @@ func TestInherent_InstrumentModel(t *testing.T) {
 		idnResponse: "KEYSIGHT TECHNOLOGIES,34465A,MY54505281,A.03.01-03.01-03.01-00.52-04-02",
 	}
 	inherent := NewInherent(mock, InherentBase{ReturnToLocal: true}, 0)
-	model, err := inherent.InstrumentModel()
+	model, err := inherent.InstrumentModel(t.Context())
 	if err != nil {
 		t.Fatalf("InstrumentModel() error: %v", err)
 	}
@@ -76,7 +76,7 @@ func TestInherent_InstrumentSerialNumber(t *testing.T) {
 		idnResponse: "KEYSIGHT TECHNOLOGIES,34465A,MY54505281,A.03.01",
 	}
 	inherent := NewInherent(mock, InherentBase{ReturnToLocal: true}, 0)
-	sn, err := inherent.InstrumentSerialNumber()
+	sn, err := inherent.InstrumentSerialNumber(t.Context())
 	if err != nil {
 		t.Fatalf("InstrumentSerialNumber() error: %v", err)
 	}
@@ -90,7 +90,7 @@ func TestInherent_FirmwareRevision(t *testing.T) {
 		idnResponse: "KEYSIGHT TECHNOLOGIES,34465A,MY54505281,A.03.01",
 	}
 	inherent := NewInherent(mock, InherentBase{ReturnToLocal: true}, 0)
-	fw, err := inherent.FirmwareRevision()
+	fw, err := inherent.FirmwareRevision(t.Context())
 	if err != nil {
 		t.Fatalf("FirmwareRevision() error: %v", err)
 	}
@@ -102,7 +102,7 @@ func TestInherent_FirmwareRevision(t *testing.T) {
 func TestInherent_Identification_QueryError(t *testing.T) {
 	mock := &mockIDNInstrument{shouldError: true}
 	inherent := NewInherent(mock, InherentBase{ReturnToLocal: true}, 0)
-	_, err := inherent.InstrumentManufacturer()
+	_, err := inherent.InstrumentManufacturer(t.Context())
 	if err == nil {
 		t.Error("expected error from query failure, got nil")
 	}
@@ -115,7 +115,7 @@ func TestInherent_CheckID(t *testing.T) {
 	inherent := NewInherent(mock, InherentBase{
 		SupportedInstrumentModels: []string{"34460A", "34461A", "34465A", "34470A"},
 	}, 0)
-	model, err := inherent.CheckID()
+	model, err := inherent.CheckID(t.Context())
 	if err != nil {
 		t.Fatalf("CheckID() error: %v", err)
 	}
@@ -134,7 +134,7 @@ func TestInherent_CheckID_UnsupportedModel(t *testing.T) {
 	inherent := NewInherent(mock, InherentBase{
 		SupportedInstrumentModels: []string{"33220A"},
 	}, 0)
-	_, err := inherent.CheckID()
+	_, err := inherent.CheckID(t.Context())
 	if err == nil {
 		t.Fatal("CheckID() expected error for unsupported model, got nil")
 	}
@@ -148,7 +148,7 @@ func TestInherent_CheckID_QueryError(t *testing.T) {
 	inherent := NewInherent(mock, InherentBase{
 		SupportedInstrumentModels: []string{"34465A"},
 	}, 0)
-	_, err := inherent.CheckID()
+	_, err := inherent.CheckID(t.Context())
 	if err == nil {
 		t.Error("CheckID() expected error from query failure, got nil")
 	}
@@ -161,7 +161,7 @@ func TestInherent_Reset(t *testing.T) {
 		ResetDelay:    1 * time.Millisecond,
 	}, 0)
 
-	err := inherent.Reset()
+	err := inherent.Reset(t.Context())
 	if err != nil {
 		t.Errorf("Reset() error: %v", err)
 	}
@@ -177,7 +177,7 @@ func TestInherent_Clear(t *testing.T) {
 		ClearDelay:    1 * time.Millisecond,
 	}, 0)
 
-	err := inherent.Clear()
+	err := inherent.Clear(t.Context())
 	if err != nil {
 		t.Errorf("Clear() error: %v", err)
 	}
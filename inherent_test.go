@@ -6,6 +6,8 @@
 package ivi
 
 import (
+	"errors"
+	"slices"
 	"testing"
 )
 
@@ -74,3 +76,107 @@ func TestParseIdentificationErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestSelfTest(t *testing.T) {
+	tests := map[string]struct {
+		resp     string
+		wantCode int
+		wantMsg  string
+	}{
+		"passed":        {"+0\n", 0, "self test passed"},
+		"failed":        {"1", 1, "self test failed with code 1"},
+		"failed signed": {"+64", 64, "self test failed with code 64"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mock := &mockScriptedInst{
+				responses: map[string][]string{"*TST?": {tt.resp}},
+			}
+			inherent := NewInherent(mock, InherentBase{}, 0)
+			code, msg, err := inherent.SelfTest(t.Context())
+			if err != nil {
+				t.Fatalf("SelfTest() error: %v", err)
+			}
+			if code != tt.wantCode || msg != tt.wantMsg {
+				t.Errorf("SelfTest() = %d, %q; want %d, %q",
+					code, msg, tt.wantCode, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestSelfTest_CustomCommand(t *testing.T) {
+	mock := &mockScriptedInst{
+		responses: map[string][]string{"TEST?": {"0"}},
+	}
+	inherent := NewInherent(mock, InherentBase{SelfTestCommand: "TEST?"}, 0)
+	if _, _, err := inherent.SelfTest(t.Context()); err != nil {
+		t.Fatalf("SelfTest() error: %v", err)
+	}
+	if !slices.Equal(mock.queriesSent, []string{"TEST?"}) {
+		t.Errorf("queries = %v, want [TEST?]", mock.queriesSent)
+	}
+}
+
+func TestSelfTest_UnexpectedResponse(t *testing.T) {
+	mock := &mockScriptedInst{
+		responses: map[string][]string{"*TST?": {"PASS"}},
+	}
+	inherent := NewInherent(mock, InherentBase{}, 0)
+	_, _, err := inherent.SelfTest(t.Context())
+	if !errors.Is(err, ErrUnexpectedResponse) {
+		t.Errorf("error = %v, want ErrUnexpectedResponse", err)
+	}
+}
+
+func TestRevisionQuery(t *testing.T) {
+	mock := &mockScriptedInst{
+		responses: map[string][]string{
+			"*IDN?": {"Agilent Technologies,E3631A,0,2.1-5.0-1.0"},
+		},
+	}
+	inherent := NewInherent(mock, InherentBase{DriverRevision: "1.2.3"}, 0)
+	driverRev, firmwareRev, err := inherent.RevisionQuery(t.Context())
+	if err != nil {
+		t.Fatalf("RevisionQuery() error: %v", err)
+	}
+	if driverRev != "1.2.3" || firmwareRev != "2.1-5.0-1.0" {
+		t.Errorf("RevisionQuery() = %q, %q; want %q, %q",
+			driverRev, firmwareRev, "1.2.3", "2.1-5.0-1.0")
+	}
+}
+
+func TestRevisionQuery_ModuleVersion(t *testing.T) {
+	mock := &mockScriptedInst{
+		responses: map[string][]string{
+			"*IDN?": {"Agilent Technologies,E3631A,0,2.1-5.0-1.0"},
+		},
+	}
+	inherent := NewInherent(mock, InherentBase{}, 0)
+	driverRev, _, err := inherent.RevisionQuery(t.Context())
+	if err != nil {
+		t.Fatalf("RevisionQuery() error: %v", err)
+	}
+	if driverRev == "" {
+		t.Error("RevisionQuery() driver revision is empty")
+	}
+}
+
+func TestResetWithDefaults(t *testing.T) {
+	mock := &mockScriptedInst{}
+	setup, err := NewDriverSetup(mock, InherentBase{}, []DriverOption{
+		WithoutIDQuery(),
+		WithDefaultSetup("VOLT 5", "CURR 0.1"),
+	})
+	if err != nil {
+		t.Fatalf("NewDriverSetup() error: %v", err)
+	}
+	mock.commandsSent = nil
+	if err := setup.Inherent.ResetWithDefaults(t.Context()); err != nil {
+		t.Fatalf("ResetWithDefaults() error: %v", err)
+	}
+	want := []string{"*rst", "VOLT 5", "CURR 0.1"}
+	if !slices.Equal(mock.commandsSent, want) {
+		t.Errorf("commands = %v, want %v", mock.commandsSent, want)
+	}
+}
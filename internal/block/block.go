@@ -0,0 +1,83 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+// Package block parses and formats IEEE 488.2 arbitrary block data. It is
+// shared by the ivi package and the instrument simulator, which the ivi
+// package imports, and is not part of the public ivi API; the ivi package
+// exports it as [github.com/gotmc/ivi.ParseBlock] and
+// [github.com/gotmc/ivi.ErrInvalidBlock].
+package block
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrInvalid indicates data is not valid IEEE 488.2 arbitrary block data.
+var ErrInvalid = errors.New("invalid block data")
+
+// Parse parses IEEE 488.2 arbitrary block data at the start of p, returning
+// the block's data and the bytes that follow it. A definite length block,
+// #<n><length><data>, ends after length bytes of data. An indefinite length
+// block, #0<data>, extends to the end of p, less a trailing newline. The data
+// is a subslice of p.
+func Parse(p []byte) (data, rest []byte, err error) {
+	if len(p) < 2 || p[0] != '#' || p[1] < '0' || p[1] > '9' {
+		return nil, nil, fmt.Errorf("%w: no #<digit> block header", ErrInvalid)
+	}
+
+	digits := int(p[1] - '0')
+	if digits == 0 {
+		return bytes.TrimSuffix(p[2:], []byte("\n")), nil, nil
+	}
+
+	if len(p) < 2+digits {
+		return nil, nil, fmt.Errorf("%w: truncated length", ErrInvalid)
+	}
+
+	length, err := ParseLength(p[2 : 2+digits])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p = p[2+digits:]
+	if len(p) < length {
+		return nil, nil, fmt.Errorf(
+			"%w: %d bytes of data, header says %d",
+			ErrInvalid, len(p), length,
+		)
+	}
+
+	return p[:length], p[length:], nil
+}
+
+// ParseLength parses the decimal length of a definite length block.
+func ParseLength(p []byte) (int, error) {
+	for _, c := range p {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("%w: length %q", ErrInvalid, p)
+		}
+	}
+
+	// At most nine digits, so the length always fits in an int.
+	length, err := strconv.Atoi(string(p))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	return length, nil
+}
+
+// Append appends data to dst as a definite length block,
+// #<n><length><data>, and returns the extended slice.
+func Append(dst, data []byte) []byte {
+	length := strconv.Itoa(len(data))
+	dst = append(dst, '#', byte('0'+len(length)))
+	dst = append(dst, length...)
+
+	return append(dst, data...)
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivitest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/gotmc/ivi"
+)
+
+// ErrUnexpectedCall indicates a driver made a Transport call that does not
+// match the next call in a [Replay]'s recording.
+var ErrUnexpectedCall = errors.New("unexpected call")
+
+// Replay serves a session recorded by [ivi.RecordingTransport], so that a
+// session with a real instrument becomes a deterministic test. Each call must
+// match the next recorded call: a command or query must be spelled the same,
+// and binary writes must carry the same bytes. A matching call returns what
+// the instrument returned, including any error, without the recorded delay.
+// Consecutive binary reads are replayed as one byte stream, so a driver may
+// read the data in chunks of any size, not only those recorded.
+//
+// A call that does not match fails with [ErrUnexpectedCall] and is collected
+// in Violations, so a test can drive the whole sequence and then report with
+// Check.
+type Replay struct {
+	mu    sync.Mutex
+	calls []ivi.RecordedCall
+	next  int
+	// unread holds the recorded read data not yet returned by ReadBinary,
+	// and unreadErr the error recorded with its last read.
+	unread    []byte
+	unreadErr error
+	// Violations holds one message per call that did not match the
+	// recording.
+	Violations []string
+}
+
+// NewReplay returns a Replay serving the given recorded calls.
+func NewReplay(calls []ivi.RecordedCall) *Replay {
+	return &Replay{calls: calls}
+}
+
+// LoadReplay returns a Replay serving the recording in the named file.
+func LoadReplay(name string) (*Replay, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	calls, err := ivi.ReadRecording(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return NewReplay(calls), nil
+}
+
+// Check reports every collected violation to t, along with any recorded
+// calls the driver never made.
+func (r *Replay) Check(t errorf) {
+	t.Helper()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, v := range r.Violations {
+		t.Errorf("%s", v)
+	}
+
+	if left := len(r.calls) - r.next; left > 0 {
+		t.Errorf(
+			"replay: %d recorded calls not made, starting with %s",
+			left, describeCall(r.calls[r.next]),
+		)
+	}
+}
+
+// Command returns the recorded result of the matching command.
+func (r *Replay) Command(_ context.Context, format string, a ...any) error {
+	call, err := r.match(ivi.RecordedCall{
+		Op:      ivi.OpCommand,
+		Command: ivi.FormatCommand(format, a),
+	})
+	if err != nil {
+		return err
+	}
+
+	return recordedErr(call)
+}
+
+// Query returns the recorded response to the matching query.
+func (r *Replay) Query(_ context.Context, cmd string) (string, error) {
+	call, err := r.match(ivi.RecordedCall{Op: ivi.OpQuery, Command: cmd})
+	if err != nil {
+		return "", err
+	}
+
+	return call.Response, recordedErr(call)
+}
+
+// ReadBinary copies the next recorded read data into p. The data of
+// consecutive recorded reads is served as one stream: a short p leaves the
+// rest for the next ReadBinary, and a long p takes data from as many
+// recorded reads as it holds. The error recorded with a read is returned by
+// the ReadBinary after the one that returns the last of its data. Data still
+// unread when the driver makes another call is discarded.
+func (r *Replay) ReadBinary(_ context.Context, p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for len(r.unread) < len(p) && r.unreadErr == nil {
+		if len(r.unread) > 0 && !r.nextIsLocked(ivi.OpReadBinary) {
+			break
+		}
+
+		call, err := r.matchLocked(ivi.RecordedCall{Op: ivi.OpReadBinary})
+		if err != nil {
+			return 0, err
+		}
+
+		r.unread = append(r.unread, call.Data...)
+		r.unreadErr = recordedErr(call)
+	}
+
+	n := copy(p, r.unread)
+	r.unread = r.unread[n:]
+
+	if n > 0 {
+		return n, nil
+	}
+
+	err := r.unreadErr
+	r.unread, r.unreadErr = nil, nil
+
+	return 0, err
+}
+
+// WriteBinary returns the recorded result of the matching write.
+func (r *Replay) WriteBinary(_ context.Context, p []byte) (int, error) {
+	call, err := r.match(ivi.RecordedCall{Op: ivi.OpWriteBinary, Data: p})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(call.Data), recordedErr(call)
+}
+
+// Close returns nil.
+func (r *Replay) Close() error { return nil }
+
+// match consumes and returns the next recorded call when got matches it.
+func (r *Replay) match(got ivi.RecordedCall) (ivi.RecordedCall, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Another call discards read data the driver left unread, as an
+	// instrument clears its output queue.
+	r.unread, r.unreadErr = nil, nil
+
+	return r.matchLocked(got)
+}
+
+// matchLocked is match for a caller holding r.mu.
+func (r *Replay) matchLocked(
+	got ivi.RecordedCall,
+) (ivi.RecordedCall, error) {
+	if r.next >= len(r.calls) {
+		return ivi.RecordedCall{}, r.violationLocked(fmt.Sprintf(
+			"%s after the end of the recording", describeCall(got),
+		))
+	}
+
+	want := r.calls[r.next]
+	if got.Op != want.Op || got.Command != want.Command ||
+		got.Op == ivi.OpWriteBinary && !bytes.Equal(got.Data, want.Data) {
+		return ivi.RecordedCall{}, r.violationLocked(fmt.Sprintf(
+			"call %d is %s, want %s",
+			r.next+1, describeCall(got), describeCall(want),
+		))
+	}
+
+	r.next++
+
+	return want, nil
+}
+
+// nextIsLocked reports whether the next recorded call is an op call.
+func (r *Replay) nextIsLocked(op ivi.CallOp) bool {
+	return r.next < len(r.calls) && r.calls[r.next].Op == op
+}
+
+// violationLocked records msg and returns it as an ErrUnexpectedCall error.
+// The caller holds r.mu.
+func (r *Replay) violationLocked(msg string) error {
+	r.Violations = append(r.Violations, "replay: "+msg)
+
+	return fmt.Errorf("%w: %s", ErrUnexpectedCall, msg)
+}
+
+// describeCall names a call for a violation message.
+func describeCall(call ivi.RecordedCall) string {
+	switch call.Op {
+	case ivi.OpCommand, ivi.OpQuery:
+		return fmt.Sprintf("%s %q", call.Op, call.Command)
+	case ivi.OpWriteBinary:
+		return fmt.Sprintf("%s of %d bytes", call.Op, len(call.Data))
+	default:
+		return string(call.Op)
+	}
+}
+
+// recordedErr recreates the error a recorded call returned. Only io.EOF keeps
+// its identity; other errors keep their text.
+func recordedErr(call ivi.RecordedCall) error {
+	switch call.Err {
+	case "":
+		return nil
+	case io.EOF.Error():
+		return io.EOF
+	default:
+		return errors.New(call.Err)
+	}
+}
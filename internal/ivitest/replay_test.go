@@ -0,0 +1,162 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivitest
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/gotmc/ivi"
+)
+
+func TestReplay_Matching(t *testing.T) {
+	ctx := t.Context()
+	replay := NewReplay([]ivi.RecordedCall{
+		{Op: ivi.OpCommand, Command: "VOLT 5.0"},
+		{Op: ivi.OpQuery, Command: "VOLT?", Response: "+5.0E+00"},
+		{Op: ivi.OpCommand, Command: ":WAV:DATA?"},
+		{Op: ivi.OpReadBinary, Data: []byte("#13abc")},
+		{Op: ivi.OpReadBinary, Err: "EOF"},
+		{Op: ivi.OpQuery, Command: "MEAS?", Err: "i/o timeout"},
+	})
+
+	if err := replay.Command(ctx, "VOLT %.1f", 5.0); err != nil {
+		t.Errorf("Command() error: %v", err)
+	}
+	if got, err := replay.Query(ctx, "VOLT?"); err != nil || got != "+5.0E+00" {
+		t.Errorf("Query() = %q, %v, want +5.0E+00", got, err)
+	}
+	if err := replay.Command(ctx, ":WAV:DATA?"); err != nil {
+		t.Errorf("Command() error: %v", err)
+	}
+
+	buf := make([]byte, 16)
+	if n, err := replay.ReadBinary(ctx, buf); err != nil ||
+		string(buf[:n]) != "#13abc" {
+		t.Errorf("ReadBinary() = %q, %v, want #13abc", buf[:n], err)
+	}
+	if _, err := replay.ReadBinary(ctx, buf); !errors.Is(err, io.EOF) {
+		t.Errorf("ReadBinary() error = %v, want EOF", err)
+	}
+	if _, err := replay.Query(ctx, "MEAS?"); err == nil ||
+		err.Error() != "i/o timeout" {
+		t.Errorf("Query(MEAS?) error = %v, want i/o timeout", err)
+	}
+
+	var r recorder
+	replay.Check(&r)
+	if len(r.errors) != 0 {
+		t.Errorf("Check() reported %d errors, want 0", len(r.errors))
+	}
+}
+
+func TestReplay_CommandNormalized(t *testing.T) {
+	// Commands are matched as the recorder formats them: verbs are only
+	// applied when there are arguments, and the terminator is trimmed.
+	ctx := t.Context()
+	replay := NewReplay([]ivi.RecordedCall{
+		{Op: ivi.OpCommand, Command: "DISP:TEXT '50%'"},
+		{Op: ivi.OpCommand, Command: "*CLS"},
+	})
+
+	if err := replay.Command(ctx, "DISP:TEXT '50%'"); err != nil {
+		t.Errorf("Command() error: %v", err)
+	}
+	if err := replay.Command(ctx, "*CLS\n"); err != nil {
+		t.Errorf("Command() error: %v", err)
+	}
+
+	var r recorder
+	replay.Check(&r)
+	if len(r.errors) != 0 {
+		t.Errorf("Check() reported %v, want none", r.errors)
+	}
+}
+
+func TestReplay_Unexpected(t *testing.T) {
+	ctx := t.Context()
+	replay := NewReplay([]ivi.RecordedCall{
+		{Op: ivi.OpCommand, Command: "VOLT 5.0"},
+		{Op: ivi.OpWriteBinary, Data: []byte{1, 2, 3}},
+	})
+
+	err := replay.Command(ctx, "VOLT %.2f", 5.0)
+	if !errors.Is(err, ErrUnexpectedCall) {
+		t.Errorf("Command(VOLT 5.00) error = %v, want %v", err, ErrUnexpectedCall)
+	}
+	if err := replay.Command(ctx, "VOLT 5.0"); err != nil {
+		t.Errorf("Command(VOLT 5.0) error = %v, want nil", err)
+	}
+	_, err = replay.WriteBinary(ctx, []byte{1, 2, 4})
+	if !errors.Is(err, ErrUnexpectedCall) {
+		t.Errorf("WriteBinary() error = %v, want %v", err, ErrUnexpectedCall)
+	}
+
+	// The write is still pending, so Check reports it along with the two
+	// mismatches.
+	var r recorder
+	replay.Check(&r)
+	if len(r.errors) != 3 {
+		t.Errorf("Check() reported %d errors, want 3", len(r.errors))
+	}
+
+	if _, err := replay.WriteBinary(ctx, []byte{1, 2, 3}); err != nil {
+		t.Errorf("WriteBinary() error = %v, want nil", err)
+	}
+	if _, err := replay.Query(ctx, "*IDN?"); !errors.Is(err, ErrUnexpectedCall) {
+		t.Errorf("Query() past the end error = %v, want %v",
+			err, ErrUnexpectedCall)
+	}
+}
+
+func TestLoadReplay_Missing(t *testing.T) {
+	if _, err := LoadReplay("testdata/missing.jsonl"); err == nil {
+		t.Error("LoadReplay() error = nil, want error")
+	}
+}
+
+func TestReplay_ReadStream(t *testing.T) {
+	// The recorded reads are one stream, so the driver may read it in
+	// chunks other than those recorded.
+	ctx := t.Context()
+	replay := NewReplay([]ivi.RecordedCall{
+		{Op: ivi.OpCommand, Command: ":WAV:DATA?"},
+		{Op: ivi.OpReadBinary, Data: []byte("#16ab")},
+		{Op: ivi.OpReadBinary, Data: []byte("cdef\n"), Err: "EOF"},
+		{Op: ivi.OpQuery, Command: "*OPC?", Response: "1"},
+	})
+
+	if err := replay.Command(ctx, ":WAV:DATA?"); err != nil {
+		t.Errorf("Command() error: %v", err)
+	}
+
+	var got []byte
+	for _, size := range []int{3, 6, 16} {
+		buf := make([]byte, size)
+		n, err := replay.ReadBinary(ctx, buf)
+		if err != nil {
+			t.Fatalf("ReadBinary(%d bytes) error: %v", size, err)
+		}
+		got = append(got, buf[:n]...)
+	}
+	if string(got) != "#16abcdef\n" {
+		t.Errorf("read %q, want %q", got, "#16abcdef\n")
+	}
+	_, err := replay.ReadBinary(ctx, make([]byte, 4))
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("ReadBinary() at the end error = %v, want EOF", err)
+	}
+	if got, err := replay.Query(ctx, "*OPC?"); err != nil || got != "1" {
+		t.Errorf("Query(*OPC?) = %q, %v, want 1", got, err)
+	}
+
+	var r recorder
+	replay.Check(&r)
+	if len(r.errors) != 0 {
+		t.Errorf("Check() reported %v, want none", r.errors)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivitest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gotmc/ivi/internal/scpisim"
+)
+
+// SimAttribute describes one setting of a simulated instrument: its default,
+// its valid range, the header whose value it reports, and the settings it
+// changes along with its own.
+type SimAttribute = scpisim.Attribute
+
+// SimModel describes the instrument model a [Simulator] simulates.
+type SimModel struct {
+	// IDN is the response to *IDN?, which drivers use to pick the model.
+	IDN string
+	// Attributes describes the instrument's settings, keyed by command
+	// header as the driver spells it (e.g., "SOUR1:FREQ"). A key may be
+	// prefixed with an INSTrument selection and a slash, as in "P6V/VOLT".
+	Attributes map[string]SimAttribute
+	// Blocks holds the data returned as IEEE 488.2 definite length blocks,
+	// keyed by query header (e.g., "WAV:DATA").
+	Blocks map[string][]byte
+	// Strict limits the command tree to the headers in Attributes and
+	// Blocks. Any other header queues -113,"Undefined header".
+	Strict bool
+}
+
+// Simulator is a stateful SCPI instrument for driver tests. Unlike [Mock],
+// which answers every query with the same string, a Simulator remembers what
+// was set, so a test can set a value through a driver and read it back.
+//
+// Like an instrument, it reports errors through the SYST:ERR? queue rather
+// than failing the command: a value outside an attribute's range queues
+// -222,"Data out of range" and leaves the setting unchanged. Headers match in
+// their long or short form. A query sent with Command leaves its response to
+// be read with ReadBinary, which is how block data such as a waveform is
+// read.
+//
+// Simulator records commands in CommandsSent and queries in QueriesSent, and
+// is safe for concurrent use.
+type Simulator struct {
+	*scpisim.Instrument
+
+	mu sync.Mutex
+	// CommandsSent captures every formatted SCPI command passed to Command,
+	// in call order.
+	CommandsSent []string
+	// QueriesSent captures every query string, in call order.
+	QueriesSent []string
+}
+
+// NewSimulator returns a Simulator for the given instrument model.
+func NewSimulator(model SimModel) *Simulator {
+	return &Simulator{
+		Instrument: scpisim.New(scpisim.Config{
+			IDN:         model.IDN,
+			Attributes:  model.Attributes,
+			Blocks:      model.Blocks,
+			Strict:      model.Strict,
+			QueueErrors: true,
+		}),
+	}
+}
+
+// Command records the formatted command and applies it to the simulated
+// instrument.
+func (s *Simulator) Command(ctx context.Context, format string, a ...any) error {
+	cmd := fmt.Sprintf(format, a...)
+
+	s.mu.Lock()
+	s.CommandsSent = append(s.CommandsSent, cmd)
+	s.mu.Unlock()
+
+	return s.Instrument.Command(ctx, cmd)
+}
+
+// Query records the query and returns the simulated instrument's response.
+func (s *Simulator) Query(ctx context.Context, cmd string) (string, error) {
+	s.mu.Lock()
+	s.QueriesSent = append(s.QueriesSent, cmd)
+	s.mu.Unlock()
+
+	return s.Instrument.Query(ctx, cmd)
+}
+
+// CheckErrors reports every entry in the simulated error queue to t, so a
+// test can confirm the driver sent nothing the instrument would reject.
+func (s *Simulator) CheckErrors(t errorf) {
+	t.Helper()
+
+	for _, entry := range s.ErrorQueue() {
+		t.Errorf("instrument error queue: %s", entry)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivitest
+
+import (
+	"io"
+	"slices"
+	"testing"
+)
+
+// recorder collects the errors reported by CheckErrors.
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, format)
+}
+
+func (r *recorder) Helper() {}
+
+func TestSimulator_RoundTrip(t *testing.T) {
+	ctx := t.Context()
+	sim := NewSimulator(SimModel{
+		IDN: "Acme,PS1,SN0,1.0",
+		Attributes: map[string]SimAttribute{
+			"VOLT":      {Min: 0, Max: 30},
+			"MEAS:VOLT": {Follows: "VOLT"},
+		},
+		Strict: true,
+	})
+
+	if err := sim.Command(ctx, "VOLT %.2f", 12.5); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+	got, err := sim.Query(ctx, "MEAS:VOLT?")
+	if err != nil || got != "12.50" {
+		t.Errorf("MEAS:VOLT? = %q, %v, want 12.50", got, err)
+	}
+	if idn, _ := sim.Query(ctx, "*IDN?"); idn != "Acme,PS1,SN0,1.0" {
+		t.Errorf("*IDN? = %q", idn)
+	}
+
+	wantCommands := []string{"VOLT 12.50"}
+	if !slices.Equal(sim.CommandsSent, wantCommands) {
+		t.Errorf("CommandsSent = %q, want %q", sim.CommandsSent, wantCommands)
+	}
+	wantQueries := []string{"MEAS:VOLT?", "*IDN?"}
+	if !slices.Equal(sim.QueriesSent, wantQueries) {
+		t.Errorf("QueriesSent = %q, want %q", sim.QueriesSent, wantQueries)
+	}
+
+	var r recorder
+	sim.CheckErrors(&r)
+	if len(r.errors) != 0 {
+		t.Errorf("CheckErrors() reported %d errors, want 0", len(r.errors))
+	}
+}
+
+func TestSimulator_QueuesErrors(t *testing.T) {
+	ctx := t.Context()
+	sim := NewSimulator(SimModel{
+		Attributes: map[string]SimAttribute{"VOLT": {Min: 0, Max: 30}},
+		Strict:     true,
+	})
+
+	for _, cmd := range []string{"VOLT 31", "CURR 1"} {
+		if err := sim.Command(ctx, "%s", cmd); err != nil {
+			t.Errorf("Command(%q) error = %v, want nil", cmd, err)
+		}
+	}
+
+	var r recorder
+	sim.CheckErrors(&r)
+	if len(r.errors) != 2 {
+		t.Errorf("CheckErrors() reported %d errors, want 2", len(r.errors))
+	}
+
+	want := `-222,"Data out of range"`
+	if got, _ := sim.Query(ctx, "SYST:ERR?"); got != want {
+		t.Errorf("SYST:ERR? = %q, want %q", got, want)
+	}
+}
+
+func TestSimulator_BinaryBlock(t *testing.T) {
+	ctx := t.Context()
+	sim := NewSimulator(SimModel{
+		Blocks: map[string][]byte{"WAV:DATA": {0x10, 0x20}},
+	})
+
+	if err := sim.Command(ctx, ":WAV:DATA?"); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+
+	got, err := io.ReadAll(readerFunc(func(p []byte) (int, error) {
+		return sim.ReadBinary(ctx, p)
+	}))
+	if err != nil {
+		t.Fatalf("ReadBinary() error: %v", err)
+	}
+	if want := "#12\x10\x20\n"; string(got) != want {
+		t.Errorf("ReadBinary() = %q, want %q", got, want)
+	}
+}
+
+// readerFunc adapts a function to io.Reader.
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) { return f(p) }
@@ -0,0 +1,722 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+// Package scpisim models the state of a SCPI instrument well enough for IVI
+// drivers to run against it without hardware. It backs the ivi package's
+// simulation mode and the test fixtures in ivitest, and is not part of the
+// public ivi API.
+//
+// The model is a map from command header to the value last set with it.
+// Headers match in either their long or short form (VOLTage or VOLT).
+// Queries return the last set value, falling back to the attribute's
+// configured default and then to "0". Common commands (*IDN?, *OPC?, *RST,
+// and so on) and the SCPI error queue are answered directly, and queries
+// configured with a block respond with IEEE 488.2 definite length block data.
+package scpisim
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gotmc/ivi/internal/block"
+)
+
+// ErrDataOutOfRange is returned by [Instrument.Command] when a value is
+// outside the range configured for its attribute.
+var ErrDataOutOfRange = errors.New("data out of range")
+
+// ErrUndefinedHeader is returned by [Instrument.Command] and
+// [Instrument.Query] when a strict instrument receives a header it does not
+// define.
+var ErrUndefinedHeader = errors.New("undefined header")
+
+// Error queue entries for the errors an instrument reports through SYST:ERR?
+// when Config.QueueErrors is set.
+const (
+	undefinedHeaderEntry = `-113,"Undefined header"`
+	invalidBlockEntry    = `-161,"Invalid block data"`
+	dataOutOfRangeEntry  = `-222,"Data out of range"`
+)
+
+// Config describes the instrument model an Instrument simulates.
+type Config struct {
+	// IDN is the response to *IDN?.
+	IDN string
+	// Attributes describes the instrument's settings, keyed by command
+	// header.
+	Attributes map[string]Attribute
+	// Blocks holds the initial data of queries answered with definite length
+	// block data (e.g., a waveform or trace), keyed by command header. Data
+	// written with [Instrument.WriteBinary] replaces it.
+	Blocks map[string][]byte
+	// Strict limits the command tree to the headers in Attributes and
+	// Blocks, along with common commands, INSTrument selection, and
+	// SYSTem:ERRor. Any other header is an undefined header error.
+	Strict bool
+	// QueueErrors reports errors as an instrument does, by queueing them for
+	// SYST:ERR? and carrying on, rather than returning them. A rejected
+	// setting keeps its previous value either way.
+	QueueErrors bool
+}
+
+// Attribute describes one simulated instrument setting, keyed in the
+// instrument's attribute map by its command header as drivers spell it,
+// without a leading colon or trailing question mark (e.g., "SOUR1:FREQ").
+// A key may be prefixed with an INSTrument selection and a slash (e.g.,
+// "P6V/VOLT"), or followed by a space and a query parameter (e.g.,
+// "MEAS CH1"), to describe the setting for one output only.
+type Attribute struct {
+	// Default is the response to a query before the attribute is set. It may
+	// include the current value of another header as {HEADER}, for queries
+	// whose response combines several settings.
+	Default string
+	// Min and Max bound numeric values when Min < Max. They are also the
+	// responses to a query with a MIN or MAX parameter.
+	Min, Max float64
+	// Follows names another header, optionally followed by a parameter
+	// selector, whose value this query reports, so that a measurement query
+	// such as MEAS:VOLT can return the VOLT setting.
+	Follows string
+	// Sets is a command applied along with every command with this header,
+	// for commands that change other settings. Its units are separated by
+	// semicolons, and $1, $2, and so on stand for the parameters of the
+	// triggering command. For example, the Fluke 45's VDC command sets FUNC1
+	// to VDC, and APPL:SIN sets FUNC, FREQ, and VOLT from its parameters with
+	// "FUNC SIN;FREQ $1;VOLT $2".
+	Sets string
+}
+
+// Instrument is a simulated SCPI instrument. It implements the ivi.Transport
+// interface and is safe for concurrent use.
+type Instrument struct {
+	mu          sync.Mutex
+	idn         string
+	attributes  map[string]Attribute
+	initBlocks  map[string][]byte
+	blocks      map[string][]byte
+	defined     map[string]bool
+	strict      bool
+	queueErrors bool
+	values      map[string]string
+	selected    string
+	errorQueue  []string
+	output      bytes.Buffer
+}
+
+// New returns an Instrument simulating the model described by cfg. Unless
+// cfg.Strict is set, headers missing from cfg.Attributes are still accepted;
+// querying one before it is set returns "0".
+func New(cfg Config) *Instrument {
+	in := &Instrument{
+		idn:         cfg.IDN,
+		attributes:  make(map[string]Attribute, len(cfg.Attributes)),
+		initBlocks:  make(map[string][]byte, len(cfg.Blocks)),
+		blocks:      make(map[string][]byte, len(cfg.Blocks)),
+		defined:     make(map[string]bool),
+		strict:      cfg.Strict,
+		queueErrors: cfg.QueueErrors,
+		values:      make(map[string]string),
+	}
+
+	for key, attr := range cfg.Attributes {
+		key = normalizeKey(key)
+		in.attributes[key] = attr
+		in.defined[bareHeader(key)] = true
+	}
+
+	for header, data := range cfg.Blocks {
+		header = normalizeHeader(header)
+		in.initBlocks[header] = bytes.Clone(data)
+		in.defined[header] = true
+	}
+
+	in.resetBlocks()
+
+	return in
+}
+
+// Command formats the command and applies each of its program message units
+// to the simulated state.
+func (in *Instrument) Command(
+	_ context.Context,
+	format string,
+	a ...any,
+) error {
+	return in.command(formatCommand(format, a))
+}
+
+func (in *Instrument) command(msg string) error {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	for unit := range strings.SplitSeq(msg, ";") {
+		if err := in.apply(unit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Query applies every program message unit of cmd and returns the response
+// to the last, which must be a query.
+func (in *Instrument) Query(_ context.Context, cmd string) (string, error) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	units := strings.Split(cmd, ";")
+	for _, unit := range units[:len(units)-1] {
+		if err := in.apply(unit); err != nil {
+			return "", err
+		}
+	}
+
+	return in.get(units[len(units)-1])
+}
+
+// apply applies one program message unit whose response, if it is a query,
+// is left to be read with ReadBinary, as block data such as a waveform is
+// read.
+func (in *Instrument) apply(unit string) error {
+	if header, _ := splitUnit(unit); !strings.HasSuffix(header, "?") {
+		return in.set(unit)
+	}
+
+	resp, err := in.get(unit)
+	if err != nil {
+		return err
+	}
+
+	in.output.WriteString(resp)
+	in.output.WriteByte('\n')
+
+	return nil
+}
+
+// ReadBinary reads the responses to queries sent with Command. It returns
+// io.EOF when no response is pending.
+func (in *Instrument) ReadBinary(_ context.Context, p []byte) (int, error) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	return in.output.Read(p)
+}
+
+// WriteBinary applies a program message whose parameter is IEEE 488.2 block
+// data, such as "DATA:ARB #3100<100 bytes>", storing the block's data as the
+// response to a query of the header. A trailing newline is ignored.
+func (in *Instrument) WriteBinary(_ context.Context, p []byte) (int, error) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	header, rest, ok := bytes.Cut(p, []byte(" "))
+	if !ok {
+		return 0, in.fail(invalidBlockEntry, fmt.Errorf(
+			"%w: no header before the block", block.ErrInvalid,
+		))
+	}
+
+	data, extra, err := block.Parse(bytes.TrimSuffix(rest, []byte("\n")))
+	if err == nil && len(extra) > 0 {
+		err = fmt.Errorf("%w: %d bytes after the block", block.ErrInvalid,
+			len(extra))
+	}
+
+	if err != nil {
+		return 0, in.fail(invalidBlockEntry, err)
+	}
+
+	key := normalizeHeader(string(header))
+	if !in.isDefined(key) {
+		return 0, in.undefined(key)
+	}
+
+	in.blocks[in.scope(key)] = bytes.Clone(data)
+
+	return len(p), nil
+}
+
+// Close returns nil.
+func (in *Instrument) Close() error { return nil }
+
+// Value returns the value last set with the given header and parameter
+// selector (e.g., "(@1)" or "CH1"), and whether one was set.
+func (in *Instrument) Value(header, selector string) (string, bool) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	v, ok := in.values[valueKey(in.scope(normalizeHeader(header)), selector)]
+
+	return v, ok
+}
+
+// PushError appends an entry such as `-113,"Undefined header"` to the error
+// queue read by SYST:ERR?.
+func (in *Instrument) PushError(entry string) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.errorQueue = append(in.errorQueue, entry)
+}
+
+// ErrorQueue returns the entries waiting to be read with SYST:ERR?, oldest
+// first, without removing them.
+func (in *Instrument) ErrorQueue() []string {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	return append([]string(nil), in.errorQueue...)
+}
+
+// fail reports err, or, when errors are queued, queues entry for SYST:ERR?
+// and returns nil.
+func (in *Instrument) fail(entry string, err error) error {
+	if !in.queueErrors {
+		return err
+	}
+
+	in.errorQueue = append(in.errorQueue, entry)
+
+	return nil
+}
+
+// undefined reports header as an undefined header unless it is in the
+// instrument's command tree.
+func (in *Instrument) undefined(header string) error {
+	return in.fail(undefinedHeaderEntry, fmt.Errorf(
+		"%w: %s", ErrUndefinedHeader, header,
+	))
+}
+
+// isDefined reports whether header is in the instrument's command tree, which
+// holds every header unless the instrument is strict.
+func (in *Instrument) isDefined(header string) bool {
+	if !in.strict || in.defined[header] || strings.HasPrefix(header, "*") {
+		return true
+	}
+
+	switch header {
+	case "INST", "INST:SEL", "INST:NSEL", "SYST:ERR", "SYST:ERR:NEXT":
+		return true
+	}
+
+	return false
+}
+
+// resetBlocks restores the configured block data.
+func (in *Instrument) resetBlocks() {
+	clear(in.blocks)
+
+	for header, data := range in.initBlocks {
+		in.blocks[header] = data
+	}
+}
+
+// set applies one command message unit.
+func (in *Instrument) set(unit string) error {
+	header, params := splitUnit(unit)
+
+	switch header {
+	case "":
+		return nil
+	case "*RST":
+		clear(in.values)
+		in.resetBlocks()
+		in.selected = ""
+
+		return nil
+	case "*CLS":
+		in.errorQueue = nil
+
+		return nil
+	case "INST", "INST:SEL", "INST:NSEL":
+		in.selected = strings.ToUpper(strings.Join(params, ","))
+	}
+
+	if strings.HasPrefix(header, "*") {
+		return nil
+	}
+
+	if !in.isDefined(header) {
+		return in.undefined(header)
+	}
+
+	if attr, ok := in.attribute(header); ok && attr.Min < attr.Max {
+		for i, p := range params {
+			switch strings.ToUpper(p) {
+			case "MIN", "MINIMUM":
+				params[i] = strconv.FormatFloat(attr.Min, 'g', -1, 64)
+			case "MAX", "MAXIMUM":
+				params[i] = strconv.FormatFloat(attr.Max, 'g', -1, 64)
+			}
+		}
+	}
+
+	if err := in.checkRange(header, params); err != nil {
+		return in.fail(dataOutOfRangeEntry, err)
+	}
+
+	in.store(header, params)
+
+	if attr, ok := in.attribute(header); ok && attr.Sets != "" {
+		return in.applySets(attr.Sets, params)
+	}
+
+	return nil
+}
+
+// applySets applies the units of an Attribute's Sets command, with each $n
+// replaced by the nth parameter of the command that triggered it. The units
+// are range checked, but their own Sets commands are not applied.
+func (in *Instrument) applySets(sets string, params []string) error {
+	for i := len(params); i > 0; i-- {
+		sets = strings.ReplaceAll(sets, "$"+strconv.Itoa(i), params[i-1])
+	}
+
+	for unit := range strings.SplitSeq(sets, ";") {
+		header, params := splitUnit(unit)
+
+		if err := in.checkRange(header, params); err != nil {
+			return in.fail(dataOutOfRangeEntry, err)
+		}
+
+		in.store(header, params)
+	}
+
+	return nil
+}
+
+// store records params as the value of header.
+func (in *Instrument) store(header string, params []string) {
+	key := in.scope(header)
+	in.values[key] = strings.Join(params, ",")
+
+	// Store the value under the first and last parameters as well, so that a
+	// query naming the channel, as in "VOLT? (@1)" or ":OUTP? CH1", finds
+	// the value set with "VOLT 5,(@1)" or ":OUTP CH1,ON".
+	if n := len(params); n > 1 {
+		in.values[valueKey(key, params[0])] = strings.Join(params[1:], ",")
+		in.values[valueKey(key, params[n-1])] = strings.Join(params[:n-1], ",")
+	}
+}
+
+// get answers one query message unit.
+func (in *Instrument) get(unit string) (string, error) {
+	header, params := splitUnit(unit)
+
+	if !strings.HasSuffix(header, "?") {
+		return "", fmt.Errorf("scpisim: %q is not a query", unit)
+	}
+
+	header = strings.TrimSuffix(header, "?")
+
+	switch header {
+	case "*IDN":
+		return in.idn, nil
+	case "*OPC":
+		return "1", nil
+	case "*TST", "*ESR", "*STB", "*ESE", "*SRE":
+		return "0", nil
+	case "SYST:ERR", "SYST:ERR:NEXT":
+		if len(in.errorQueue) == 0 {
+			return `+0,"No error"`, nil
+		}
+
+		entry := in.errorQueue[0]
+		in.errorQueue = in.errorQueue[1:]
+
+		return entry, nil
+	}
+
+	if !in.isDefined(header) {
+		return "", in.undefined(header)
+	}
+
+	if data, ok := in.blocks[in.scope(header)]; ok {
+		return string(block.Append(nil, data)), nil
+	}
+
+	if data, ok := in.blocks[header]; ok {
+		return string(block.Append(nil, data)), nil
+	}
+
+	return in.lookup(header, strings.Join(params, ","), 0), nil
+}
+
+// maxDepth limits how many Follows links and {HEADER} references lookup
+// resolves for one query, to guard against cycles.
+const maxDepth = 4
+
+// lookup returns the value for header and selector, following Attribute
+// Follows links and expanding {HEADER} references in defaults.
+func (in *Instrument) lookup(header, selector string, depth int) string {
+	attr, hasAttr := in.attribute(header)
+	hasRange := hasAttr && attr.Min < attr.Max
+
+	switch strings.ToUpper(selector) {
+	case "MIN", "MINIMUM":
+		if hasRange {
+			return strconv.FormatFloat(attr.Min, 'g', -1, 64)
+		}
+	case "MAX", "MAXIMUM":
+		if hasRange {
+			return strconv.FormatFloat(attr.Max, 'g', -1, 64)
+		}
+	}
+
+	if v, ok := in.values[valueKey(in.scope(header), selector)]; ok {
+		return v
+	}
+
+	// A query such as "MEAS:VOLT? P6V" names the output as its parameter
+	// rather than selecting it first.
+	if v, ok := in.values[strings.ToUpper(selector)+"/"+header]; ok {
+		return v
+	}
+
+	// An attribute keyed by header and selector, such as "MEAS CH1", takes
+	// precedence over one keyed by header alone, and follows the header it
+	// names without the selector.
+	followSelector := selector
+	if a, ok := in.attributes[valueKey(header, selector)]; ok && selector != "" {
+		attr, hasAttr = a, true
+		followSelector = ""
+	}
+
+	if hasAttr && attr.Follows != "" && depth < maxDepth {
+		follows, sel, ok := strings.Cut(normalizeHeader(attr.Follows), " ")
+		if ok {
+			followSelector = sel
+		}
+
+		return in.lookup(follows, followSelector, depth+1)
+	}
+
+	if hasAttr && attr.Default != "" {
+		return in.expand(attr.Default, depth)
+	}
+
+	return "0"
+}
+
+// expand replaces each {HEADER} in s with the current value of that header.
+func (in *Instrument) expand(s string, depth int) string {
+	if depth >= maxDepth {
+		return s
+	}
+
+	var b strings.Builder
+
+	for {
+		before, rest, ok := strings.Cut(s, "{")
+		if !ok {
+			break
+		}
+
+		header, after, ok := strings.Cut(rest, "}")
+		if !ok {
+			break
+		}
+
+		b.WriteString(before)
+		b.WriteString(in.lookup(normalizeHeader(header), "", depth+1))
+		s = after
+	}
+
+	b.WriteString(s)
+
+	return b.String()
+}
+
+// checkRange returns ErrDataOutOfRange when the header's attribute has a
+// range and the single numeric parameter is outside it.
+func (in *Instrument) checkRange(header string, params []string) error {
+	attr, ok := in.attribute(header)
+	if !ok || attr.Min >= attr.Max {
+		return nil
+	}
+
+	var numeric []float64
+
+	for _, p := range params {
+		if v, err := strconv.ParseFloat(p, 64); err == nil {
+			numeric = append(numeric, v)
+		}
+	}
+
+	if len(numeric) != 1 {
+		return nil
+	}
+
+	if v := numeric[0]; v < attr.Min || v > attr.Max {
+		return fmt.Errorf(
+			"%w: %s %g outside [%g, %g]",
+			ErrDataOutOfRange, header, v, attr.Min, attr.Max,
+		)
+	}
+
+	return nil
+}
+
+// attribute returns the attribute for header under the current INSTrument
+// selection, falling back to the attribute for header alone.
+func (in *Instrument) attribute(header string) (Attribute, bool) {
+	if attr, ok := in.attributes[in.scope(header)]; ok {
+		return attr, true
+	}
+
+	attr, ok := in.attributes[header]
+
+	return attr, ok
+}
+
+// scope prefixes header with the current INSTrument selection, so that the
+// outputs of a multi-output instrument keep separate settings.
+func (in *Instrument) scope(header string) string {
+	if in.selected == "" || strings.HasPrefix(header, "INST") {
+		return header
+	}
+
+	return in.selected + "/" + header
+}
+
+// valueKey joins a scoped header and a parameter selector into a key of the
+// values map.
+func valueKey(key, selector string) string {
+	if selector == "" {
+		return key
+	}
+
+	return key + " " + strings.ToUpper(selector)
+}
+
+// splitUnit splits a program message unit into its normalized header and its
+// trimmed, comma separated parameters, with units dropped from numbers and ON
+// and OFF normalized to 1 and 0.
+func splitUnit(unit string) (string, []string) {
+	header, rest, _ := strings.Cut(strings.TrimSpace(unit), " ")
+	header = normalizeHeader(header)
+
+	rest = strings.TrimSpace(rest)
+	if rest == "" {
+		return header, nil
+	}
+
+	params := strings.Split(rest, ",")
+	for i, p := range params {
+		params[i] = strings.TrimSpace(p)
+
+		// A number may carry a unit, as in "VOLT 1.0 VPP", which the
+		// instrument does not repeat when queried.
+		if num, _, ok := strings.Cut(params[i], " "); ok {
+			if _, err := strconv.ParseFloat(num, 64); err == nil {
+				params[i] = num
+			}
+		}
+
+		// Boolean settings read back as 1 or 0 however they were set.
+		switch strings.ToUpper(params[i]) {
+		case "ON":
+			params[i] = "1"
+		case "OFF":
+			params[i] = "0"
+		}
+	}
+
+	return header, params
+}
+
+// normalizeHeader upper-cases a header, removes its leading colon and any
+// quotes around it, and shortens each long form mnemonic, so that VOLTage,
+// VOLT, and volt are the same header.
+func normalizeHeader(header string) string {
+	header = strings.Trim(strings.TrimSpace(header), `"`)
+	header = strings.ToUpper(strings.TrimPrefix(header, ":"))
+
+	if strings.HasPrefix(header, "*") {
+		return header
+	}
+
+	query := strings.HasSuffix(header, "?")
+	header = strings.TrimSuffix(header, "?")
+
+	segments := strings.Split(header, ":")
+	for i, segment := range segments {
+		segments[i] = shortForm(segment)
+	}
+
+	header = strings.Join(segments, ":")
+	if query {
+		header += "?"
+	}
+
+	return header
+}
+
+// shortForm returns the short form of an upper-case SCPI mnemonic, keeping
+// any numeric suffix. By the SCPI rule, the short form of a mnemonic longer
+// than four characters is its first four, or its first three when the fourth
+// is a vowel (FREQuency, SWEep).
+func shortForm(mnemonic string) string {
+	word := strings.TrimRight(mnemonic, "0123456789")
+	suffix := mnemonic[len(word):]
+
+	if len(word) <= 4 {
+		return mnemonic
+	}
+
+	if strings.ContainsRune("AEIOU", rune(word[3])) {
+		return word[:3] + suffix
+	}
+
+	return word[:4] + suffix
+}
+
+// normalizeKey normalizes an attribute key, which is a header optionally
+// prefixed with an INSTrument selection and a slash and optionally followed
+// by a space and a query parameter (e.g., "P6V/VOLT" or "MEAS CH1").
+func normalizeKey(key string) string {
+	selection, header, ok := strings.Cut(strings.TrimSpace(key), "/")
+	if !ok {
+		header, selection = selection, ""
+	}
+
+	header, selector, _ := strings.Cut(header, " ")
+
+	key = valueKey(normalizeHeader(header), selector)
+	if selection != "" {
+		key = strings.ToUpper(selection) + "/" + key
+	}
+
+	return key
+}
+
+// bareHeader returns the header of a normalized attribute key, without its
+// INSTrument selection or query parameter.
+func bareHeader(key string) string {
+	if _, header, ok := strings.Cut(key, "/"); ok {
+		key = header
+	}
+
+	header, _, _ := strings.Cut(key, " ")
+
+	return header
+}
+
+// formatCommand applies the format arguments, if any, to cmd. It takes the
+// arguments as a slice rather than variadically so that vet does not treat
+// Instrument.Command as a printf wrapper.
+func formatCommand(cmd string, a []any) string {
+	if len(a) > 0 {
+		return fmt.Sprintf(cmd, a...)
+	}
+
+	return cmd
+}
@@ -0,0 +1,360 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package scpisim
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/gotmc/ivi/internal/block"
+)
+
+const testIDN = "Acme,Model1,SN0,1.0"
+
+func mustQuery(t *testing.T, in *Instrument, cmd string) string {
+	t.Helper()
+
+	got, err := in.Query(t.Context(), cmd)
+	if err != nil {
+		t.Fatalf("Query(%q) error: %v", cmd, err)
+	}
+
+	return got
+}
+
+func mustCommand(t *testing.T, in *Instrument, format string, a ...any) {
+	t.Helper()
+
+	if err := in.Command(t.Context(), format, a...); err != nil {
+		t.Fatalf("Command(%q) error: %v", format, err)
+	}
+}
+
+func TestQuery_CommonCommands(t *testing.T) {
+	in := New(Config{IDN: testIDN})
+	tests := map[string]string{
+		"*IDN?":     testIDN,
+		"*OPC?":     "1",
+		"*TST?":     "0",
+		"*ESR?":     "0",
+		"SYST:ERR?": `+0,"No error"`,
+	}
+	for cmd, want := range tests {
+		if got := mustQuery(t, in, cmd); got != want {
+			t.Errorf("Query(%q) = %q, want %q", cmd, got, want)
+		}
+	}
+}
+
+func TestQuery_ReturnsLastSetValue(t *testing.T) {
+	in := New(Config{IDN: testIDN, Attributes: map[string]Attribute{
+		"FREQ": {Default: "1000"},
+	}})
+	if got := mustQuery(t, in, ":FREQ?"); got != "1000" {
+		t.Errorf("FREQ? before set = %q, want 1000", got)
+	}
+	mustCommand(t, in, ":freq %.1f", 2500.0)
+	if got := mustQuery(t, in, "FREQ?"); got != "2500.0" {
+		t.Errorf("FREQ? after set = %q, want 2500.0", got)
+	}
+	if got := mustQuery(t, in, "UNKNOWN?"); got != "0" {
+		t.Errorf("UNKNOWN? = %q, want 0", got)
+	}
+}
+
+func TestQuery_NormalizesParameters(t *testing.T) {
+	in := New(Config{IDN: testIDN})
+	mustCommand(t, in, "OUTP ON;VOLT 1.5 VPP")
+	if got := mustQuery(t, in, "OUTP?"); got != "1" {
+		t.Errorf("OUTP? = %q, want 1", got)
+	}
+	if got := mustQuery(t, in, "VOLT?"); got != "1.5" {
+		t.Errorf("VOLT? = %q, want 1.5", got)
+	}
+}
+
+func TestQuery_ChannelSelectors(t *testing.T) {
+	in := New(Config{IDN: testIDN})
+	mustCommand(t, in, "VOLT 5,(@1)")
+	mustCommand(t, in, "VOLT 7,(@2)")
+	mustCommand(t, in, ":OUTP CH1,ON")
+
+	tests := map[string]string{
+		"VOLT? (@1)": "5",
+		"VOLT? (@2)": "7",
+		":OUTP? CH1": "1",
+	}
+	for cmd, want := range tests {
+		if got := mustQuery(t, in, cmd); got != want {
+			t.Errorf("Query(%q) = %q, want %q", cmd, got, want)
+		}
+	}
+}
+
+func TestQuery_InstrumentSelect(t *testing.T) {
+	in := New(Config{IDN: testIDN})
+	mustCommand(t, in, "INST P6V; VOLT 3")
+	mustCommand(t, in, "INST P25V; VOLT 20")
+
+	tests := map[string]string{
+		"INST P6V;VOLT?":   "3",
+		"INST P25V; VOLT?": "20",
+		"INST?":            "P25V",
+	}
+	for cmd, want := range tests {
+		if got := mustQuery(t, in, cmd); got != want {
+			t.Errorf("Query(%q) = %q, want %q", cmd, got, want)
+		}
+	}
+}
+
+func TestQuery_Follows(t *testing.T) {
+	in := New(Config{IDN: testIDN, Attributes: map[string]Attribute{
+		"MEAS:VOLT": {Follows: "VOLT"},
+		"MEAS CH2":  {Follows: "SOUR2:VOLT"},
+	}})
+	mustCommand(t, in, "INST P6V; VOLT 4.5")
+	mustCommand(t, in, "SOUR2:VOLT 12")
+	if got := mustQuery(t, in, "MEAS:VOLT? P6V"); got != "4.5" {
+		t.Errorf("MEAS:VOLT? P6V = %q, want 4.5", got)
+	}
+	if got := mustQuery(t, in, ":MEAS? CH2"); got != "12" {
+		t.Errorf(":MEAS? CH2 = %q, want 12", got)
+	}
+}
+
+func TestQuery_DefaultReferences(t *testing.T) {
+	in := New(Config{IDN: testIDN, Attributes: map[string]Attribute{
+		"TIM":      {Default: "MAIN:RANG {TIM:RANG};POS 0"},
+		"TIM:RANG": {Default: "1E-3"},
+		"LOOP":     {Default: "{LOOP}"},
+	}})
+	if got := mustQuery(t, in, ":TIM?"); got != "MAIN:RANG 1E-3;POS 0" {
+		t.Errorf(":TIM? = %q", got)
+	}
+	mustCommand(t, in, ":TIM:RANG 2E-3")
+	if got := mustQuery(t, in, ":TIM?"); got != "MAIN:RANG 2E-3;POS 0" {
+		t.Errorf(":TIM? after set = %q", got)
+	}
+	if got := mustQuery(t, in, "LOOP?"); got != "{LOOP}" {
+		t.Errorf("LOOP? = %q, want {LOOP}", got)
+	}
+}
+
+func TestCommand_Sets(t *testing.T) {
+	in := New(Config{IDN: testIDN, Attributes: map[string]Attribute{
+		"VDC":      {Sets: "FUNC1 VDC"},
+		"APPL:SIN": {Sets: "FUNC SIN;FREQ $1;VOLT $2"},
+		"FREQ":     {Min: 1, Max: 1e6},
+	}})
+	mustCommand(t, in, `"VDC"`)
+	if got := mustQuery(t, in, "FUNC1?"); got != "VDC" {
+		t.Errorf("FUNC1? = %q, want VDC", got)
+	}
+	mustCommand(t, in, "APPL:SIN 1000, 2.5")
+	for cmd, want := range map[string]string{
+		"FUNC?": "SIN", "FREQ?": "1000", "VOLT?": "2.5",
+	} {
+		if got := mustQuery(t, in, cmd); got != want {
+			t.Errorf("Query(%q) = %q, want %q", cmd, got, want)
+		}
+	}
+	err := in.Command(t.Context(), "APPL:SIN 2e6, 1")
+	if !errors.Is(err, ErrDataOutOfRange) {
+		t.Errorf("APPL:SIN out of range error = %v, want ErrDataOutOfRange", err)
+	}
+}
+
+func TestCommand_Range(t *testing.T) {
+	in := New(Config{IDN: testIDN, Attributes: map[string]Attribute{
+		"VOLT":     {Min: 0, Max: 30},
+		"P6V/VOLT": {Min: 0, Max: 6},
+	}})
+	mustCommand(t, in, "VOLT 30")
+	err := in.Command(t.Context(), "VOLT 30.5")
+	if !errors.Is(err, ErrDataOutOfRange) {
+		t.Fatalf("VOLT 30.5 error = %v, want ErrDataOutOfRange", err)
+	}
+	if got := mustQuery(t, in, "VOLT?"); got != "30" {
+		t.Errorf("VOLT? after rejected set = %q, want 30", got)
+	}
+	err = in.Command(t.Context(), "INST P6V;VOLT 10")
+	if !errors.Is(err, ErrDataOutOfRange) {
+		t.Errorf("P6V VOLT 10 error = %v, want ErrDataOutOfRange", err)
+	}
+	mustCommand(t, in, "VOLT MAX")
+	if got := mustQuery(t, in, "VOLT?"); got != "6" {
+		t.Errorf("VOLT? after VOLT MAX = %q, want 6", got)
+	}
+	if got := mustQuery(t, in, "VOLT? MIN"); got != "0" {
+		t.Errorf("VOLT? MIN = %q, want 0", got)
+	}
+}
+
+func TestReset(t *testing.T) {
+	in := New(Config{IDN: testIDN, Attributes: map[string]Attribute{"FREQ": {Default: "1000"}}})
+	mustCommand(t, in, "INST P6V;FREQ 5")
+	mustCommand(t, in, "*RST")
+	if got := mustQuery(t, in, "FREQ?"); got != "1000" {
+		t.Errorf("FREQ? after *RST = %q, want 1000", got)
+	}
+}
+
+func TestErrorQueue(t *testing.T) {
+	in := New(Config{IDN: testIDN})
+	in.PushError(`-113,"Undefined header"`)
+	in.PushError(`-222,"Data out of range"`)
+	if got := mustQuery(t, in, "SYST:ERR?"); got != `-113,"Undefined header"` {
+		t.Errorf("first SYST:ERR? = %q", got)
+	}
+	mustCommand(t, in, "*CLS")
+	if got := mustQuery(t, in, "SYST:ERR?"); got != `+0,"No error"` {
+		t.Errorf("SYST:ERR? after *CLS = %q", got)
+	}
+}
+
+func TestQuery_NotAQuery(t *testing.T) {
+	in := New(Config{IDN: testIDN})
+	if _, err := in.Query(t.Context(), "VOLT 5"); err == nil {
+		t.Error("Query(\"VOLT 5\") error = nil, want error")
+	}
+}
+
+func TestValue(t *testing.T) {
+	in := New(Config{IDN: testIDN})
+	mustCommand(t, in, "VOLT 5,(@2)")
+	if v, ok := in.Value("volt", "(@2)"); !ok || v != "5" {
+		t.Errorf("Value(volt, (@2)) = %q, %v, want 5, true", v, ok)
+	}
+	if _, ok := in.Value("CURR", ""); ok {
+		t.Error("Value(CURR) ok = true, want false")
+	}
+}
+
+func TestQuery_LongForm(t *testing.T) {
+	in := New(Config{IDN: testIDN, Attributes: map[string]Attribute{
+		"SOURce1:FREQuency": {Default: "1000"},
+	}})
+	if got := mustQuery(t, in, "SOUR1:FREQ?"); got != "1000" {
+		t.Errorf("SOUR1:FREQ? = %q, want 1000", got)
+	}
+	mustCommand(t, in, ":SOURCE1:FREQUENCY 2000")
+	if got := mustQuery(t, in, "sour1:freq?"); got != "2000" {
+		t.Errorf("sour1:freq? = %q, want 2000", got)
+	}
+	mustCommand(t, in, "INSTrument:SELect P6V;VOLTage 3")
+	if got := mustQuery(t, in, "INST P6V;VOLT?"); got != "3" {
+		t.Errorf("INST P6V;VOLT? = %q, want 3", got)
+	}
+	if got := mustQuery(t, in, "SYSTem:ERRor:NEXT?"); got != `+0,"No error"` {
+		t.Errorf("SYSTem:ERRor:NEXT? = %q", got)
+	}
+}
+
+func TestShortForm(t *testing.T) {
+	tests := map[string]string{
+		"VOLTAGE":    "VOLT",
+		"FREQUENCY":  "FREQ",
+		"SWEEP":      "SWE",
+		"SOURCE2":    "SOUR2",
+		"IMMEDIATE":  "IMM",
+		"VOLT":       "VOLT",
+		"SEL":        "SEL",
+		"CHANNEL12":  "CHAN12",
+		"PROTECTION": "PROT",
+	}
+	for mnemonic, want := range tests {
+		if got := shortForm(mnemonic); got != want {
+			t.Errorf("shortForm(%q) = %q, want %q", mnemonic, got, want)
+		}
+	}
+}
+
+func TestStrict(t *testing.T) {
+	in := New(Config{
+		IDN:        testIDN,
+		Attributes: map[string]Attribute{"VOLT": {}, "P6V/CURR": {}},
+		Strict:     true,
+	})
+	mustCommand(t, in, "*RST;INST P6V;VOLT 5;CURR 1")
+	err := in.Command(t.Context(), "VOLT:PROT 6")
+	if !errors.Is(err, ErrUndefinedHeader) {
+		t.Errorf("VOLT:PROT error = %v, want ErrUndefinedHeader", err)
+	}
+	if _, err := in.Query(t.Context(), "OUTP?"); !errors.Is(err, ErrUndefinedHeader) {
+		t.Errorf("OUTP? error = %v, want ErrUndefinedHeader", err)
+	}
+}
+
+func TestQueueErrors(t *testing.T) {
+	in := New(Config{
+		IDN:         testIDN,
+		Attributes:  map[string]Attribute{"VOLT": {Min: 0, Max: 10}},
+		Strict:      true,
+		QueueErrors: true,
+	})
+	mustCommand(t, in, "VOLT 5")
+	mustCommand(t, in, "VOLT 50")
+	mustCommand(t, in, "CURR 1")
+
+	want := []string{`-222,"Data out of range"`, `-113,"Undefined header"`}
+	if got := in.ErrorQueue(); !slices.Equal(got, want) {
+		t.Errorf("ErrorQueue() = %q, want %q", got, want)
+	}
+	if got := mustQuery(t, in, "VOLT?"); got != "5" {
+		t.Errorf("VOLT? after rejected set = %q, want 5", got)
+	}
+	for _, entry := range want {
+		if got := mustQuery(t, in, "SYST:ERR?"); got != entry {
+			t.Errorf("SYST:ERR? = %q, want %q", got, entry)
+		}
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	ctx := t.Context()
+	in := New(Config{
+		IDN:    testIDN,
+		Blocks: map[string][]byte{"WAV:DATA": []byte("\x01\x02\x03")},
+	})
+
+	if got := mustQuery(t, in, ":WAVeform:DATA?"); got != "#13\x01\x02\x03" {
+		t.Errorf(":WAV:DATA? = %q", got)
+	}
+
+	mustCommand(t, in, ":WAV:DATA?")
+	buf := make([]byte, 64)
+	n, err := in.ReadBinary(ctx, buf)
+	if err != nil || string(buf[:n]) != "#13\x01\x02\x03\n" {
+		t.Errorf("ReadBinary() = %q, %v", buf[:n], err)
+	}
+	if _, err := in.ReadBinary(ctx, buf); !errors.Is(err, io.EOF) {
+		t.Errorf("ReadBinary() with nothing pending error = %v, want EOF", err)
+	}
+
+	msg := []byte("WAV:DATA #212hello, world\n")
+	if n, err := in.WriteBinary(ctx, msg); err != nil || n != len(msg) {
+		t.Fatalf("WriteBinary() = %d, %v", n, err)
+	}
+	if got := mustQuery(t, in, "WAV:DATA?"); got != "#212hello, world" {
+		t.Errorf("WAV:DATA? after write = %q", got)
+	}
+
+	mustCommand(t, in, "*RST")
+	if got := mustQuery(t, in, "WAV:DATA?"); got != "#13\x01\x02\x03" {
+		t.Errorf("WAV:DATA? after *RST = %q", got)
+	}
+
+	for _, bad := range []string{"WAV:DATA", "WAV:DATA #3", "WAV:DATA #15abc"} {
+		_, err := in.WriteBinary(ctx, []byte(bad))
+		if !errors.Is(err, block.ErrInvalid) {
+			t.Errorf("WriteBinary(%q) error = %v, want block.ErrInvalid",
+				bad, err)
+		}
+	}
+}
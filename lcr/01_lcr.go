@@ -10,3 +10,31 @@ in one of the IVI specifications. This interface is designed based on the
 capabilities common to precision LCR meters such as the Keysight E4980A.
 */
 package lcr
+
+import (
+	"reflect"
+
+	"github.com/gotmc/ivi"
+)
+
+// Groups lists the capability groups of the LCR meter class, with the Go
+// interfaces that implement each, for [ivi.Introspect] and
+// [ivi.CheckGroupCapabilities].
+var Groups = []ivi.CapabilityGroup{
+	{Name: "IviLCRBase", Driver: reflect.TypeFor[Base]()},
+	{Name: "IviLCRDCBias", Driver: reflect.TypeFor[DCBias]()},
+	{Name: "IviLCRCompensation", Driver: reflect.TypeFor[Compensation]()},
+}
+
+// Drivers holds the IviLCR drivers, by the instrument models they support. Each
+// driver registers itself from an init function, so importing a driver package,
+// if only for its side effects, makes it available to Drivers.Open, which picks
+// the driver for the connected instrument's model, and to Drivers.OpenDriver,
+// which creates a driver by name. Both return the driver as a Base; assert it
+// to other IviLCR interfaces, or to the driver's own type, for the capabilities
+// beyond the base group. See [ivi.Registry].
+//
+//	import _ "github.com/gotmc/ivi/lcr/keysight/kte4980"
+//
+//	meter, err := lcr.Drivers.Open(ctx, inst)
+var Drivers ivi.Registry[Base]
@@ -5,7 +5,11 @@
 
 package lcr
 
-import "time"
+import (
+	"context"
+
+	"time"
+)
 
 /*
 
@@ -26,62 +30,64 @@ for series inductance and series resistance).
 // retrieval.
 type Base interface {
 	// Measurement function
-	MeasurementFunction() (MeasurementFunction, error)
-	SetMeasurementFunction(fcn MeasurementFunction) error
+	MeasurementFunction(ctx context.Context) (MeasurementFunction, error)
+	SetMeasurementFunction(ctx context.Context, fcn MeasurementFunction) error
 
 	// Test signal
-	Frequency() (float64, error)
-	SetFrequency(freq float64) error
-	TestVoltageLevel() (float64, error)
-	SetTestVoltageLevel(volts float64) error
-	TestCurrentLevel() (float64, error)
-	SetTestCurrentLevel(amps float64) error
+	Frequency(ctx context.Context) (float64, error)
+	SetFrequency(ctx context.Context, freq float64) error
+	TestVoltageLevel(ctx context.Context) (float64, error)
+	SetTestVoltageLevel(ctx context.Context, volts float64) error
+	TestCurrentLevel(ctx context.Context) (float64, error)
+	SetTestCurrentLevel(ctx context.Context, amps float64) error
 
 	// Impedance range
-	ImpedanceAutoRange() (bool, error)
-	SetImpedanceAutoRange(auto bool) error
-	ImpedanceRange() (float64, error)
-	SetImpedanceRange(ohms float64) error
+	ImpedanceAutoRange(ctx context.Context) (bool, error)
+	SetImpedanceAutoRange(ctx context.Context, auto bool) error
+	ImpedanceRange(ctx context.Context) (float64, error)
+	SetImpedanceRange(ctx context.Context, ohms float64) error
 
 	// Measurement speed and averaging
-	MeasurementSpeed() (MeasurementSpeed, error)
-	SetMeasurementSpeed(speed MeasurementSpeed) error
-	AveragingCount() (int, error)
-	SetAveragingCount(count int) error
+	MeasurementSpeed(ctx context.Context) (MeasurementSpeed, error)
+	SetMeasurementSpeed(ctx context.Context, speed MeasurementSpeed) error
+	AveragingCount(ctx context.Context) (int, error)
+	SetAveragingCount(ctx context.Context, count int) error
 
 	// Trigger
-	TriggerSource() (TriggerSource, error)
-	SetTriggerSource(src TriggerSource) error
-	TriggerDelay() (time.Duration, error)
-	SetTriggerDelay(delay time.Duration) error
+	TriggerSource(ctx context.Context) (TriggerSource, error)
+	SetTriggerSource(ctx context.Context, src TriggerSource) error
+	TriggerDelay(ctx context.Context) (time.Duration, error)
+	SetTriggerDelay(ctx context.Context, delay time.Duration) error
 
 	// Measurement control
-	Initiate() error
-	Trigger() error
-	Abort() error
+	Initiate(ctx context.Context) error
+	Trigger(ctx context.Context) error
+	Abort(ctx context.Context) error
 
 	// Results — returns primary value, secondary value, and status
-	FetchMeasurement() (float64, float64, MeasurementStatus, error)
+	FetchMeasurement(
+		ctx context.Context,
+	) (float64, float64, MeasurementStatus, error)
 }
 
 // DCBias provides the interface for DC bias control on LCR meters that
 // support it. Not all LCR meters have DC bias capability.
 type DCBias interface {
-	DCBiasEnabled() (bool, error)
-	SetDCBiasEnabled(enabled bool) error
-	DCBiasVoltageLevel() (float64, error)
-	SetDCBiasVoltageLevel(volts float64) error
+	DCBiasEnabled(ctx context.Context) (bool, error)
+	SetDCBiasEnabled(ctx context.Context, enabled bool) error
+	DCBiasVoltageLevel(ctx context.Context) (float64, error)
+	SetDCBiasVoltageLevel(ctx context.Context, volts float64) error
 }
 
 // Compensation provides the interface for open, short, and load correction
 // on LCR meters.
 type Compensation interface {
-	OpenCorrectionEnabled() (bool, error)
-	SetOpenCorrectionEnabled(enabled bool) error
-	ExecuteOpenCorrection() error
-	ShortCorrectionEnabled() (bool, error)
-	SetShortCorrectionEnabled(enabled bool) error
-	ExecuteShortCorrection() error
-	LoadCorrectionEnabled() (bool, error)
-	SetLoadCorrectionEnabled(enabled bool) error
+	OpenCorrectionEnabled(ctx context.Context) (bool, error)
+	SetOpenCorrectionEnabled(ctx context.Context, enabled bool) error
+	ExecuteOpenCorrection(ctx context.Context) error
+	ShortCorrectionEnabled(ctx context.Context) (bool, error)
+	SetShortCorrectionEnabled(ctx context.Context, enabled bool) error
+	ExecuteShortCorrection(ctx context.Context) error
+	LoadCorrectionEnabled(ctx context.Context) (bool, error)
+	SetLoadCorrectionEnabled(ctx context.Context, enabled bool) error
 }
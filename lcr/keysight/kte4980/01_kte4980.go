@@ -23,6 +23,16 @@ var _ lcr.Base = (*Driver)(nil)
 var _ lcr.DCBias = (*Driver)(nil)
 var _ lcr.Compensation = (*Driver)(nil)
 
+// Register the driver with [lcr.Drivers] so that Open can select it by model,
+// and its connection defaults so that a config session can complete its
+// resource.
+func init() {
+	lcr.Drivers.Register(
+		"kte4980", supportedModels(), ivi.Factory[lcr.Base](New),
+	)
+	ivi.RegisterConnectionDefaults("kte4980", ConnectionDefaults())
+}
+
 // Driver provides the IVI driver for the Keysight E4980A and E4980AL
 // precision LCR meters.
 type Driver struct {
@@ -44,26 +54,28 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		ResetDelay:            500 * time.Millisecond,
 		ClearDelay:            500 * time.Millisecond,
 		ReturnToLocal:         true,
+		SupportsOPC:           true,
 		GroupCapabilities: []string{
 			"IviLCRBase",
 			"IviLCRDCBias",
 			"IviLCRCompensation",
 		},
-		SupportedInstrumentModels: []string{"E4980A", "E4980AL"},
+		SupportedInstrumentModels: supportedModels(),
 		SupportedBusInterfaces:    []string{"GPIB", "USB", "TCPIP"},
+		Simulation:                &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	driver := Driver{
-		inst:     inst,
+		inst:     s.Transport,
 		timeout:  s.Timeout,
 		Inherent: s.Inherent,
 	}
 
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
+		if err := driver.Reset(context.Background()); err != nil {
 			return &driver, err
 		}
 	}
@@ -71,9 +83,17 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	return &driver, nil
 }
 
-// newContext creates a context with the driver's configured timeout.
-func (d *Driver) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), d.timeout)
+// supportedModels returns the model numbers the driver supports.
+func supportedModels() []string {
+	return []string{"E4980A", "E4980AL"}
+}
+
+// newContext derives a context from ctx that carries the driver's configured
+// timeout, unless ctx already has a deadline of its own.
+func (d *Driver) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, d.timeout)
 }
 
 // Close properly shuts down the LCR meter by returning it to local control.
@@ -91,6 +111,15 @@ func LANPort() int {
 	return 5025
 }
 
+// ConnectionDefaults returns the model's connection defaults, which
+// complete a VISA resource string with [ivi.Resource.Settings].
+func ConnectionDefaults() ivi.ConnectionDefaults {
+	return ivi.ConnectionDefaults{
+		GPIBAddress: DefaultGPIBAddress(),
+		LANPorts:    map[string]int{"socket": LANPort()},
+	}
+}
+
 // MeasurementResult holds the primary and secondary measurement values along
 // with the measurement status.
 type MeasurementResult struct {
@@ -6,6 +6,7 @@
 package kte4980
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -64,8 +65,10 @@ var scpiToMsrFunc = map[string]lcr.MeasurementFunction{
 	"YTR":  lcr.YThetaRad,
 }
 
-func (d *Driver) MeasurementFunction() (lcr.MeasurementFunction, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) MeasurementFunction(
+	ctx context.Context,
+) (lcr.MeasurementFunction, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, d.inst, "FUNC:IMP:TYPE?")
@@ -81,8 +84,11 @@ func (d *Driver) MeasurementFunction() (lcr.MeasurementFunction, error) {
 	return fcn, nil
 }
 
-func (d *Driver) SetMeasurementFunction(fcn lcr.MeasurementFunction) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetMeasurementFunction(
+	ctx context.Context,
+	fcn lcr.MeasurementFunction,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(msrFuncToSCPI, fcn)
@@ -95,43 +101,43 @@ func (d *Driver) SetMeasurementFunction(fcn lcr.MeasurementFunction) error {
 
 // --- Test Signal ---
 
-func (d *Driver) Frequency() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) Frequency(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "FREQ:CW?")
 }
 
-func (d *Driver) SetFrequency(freq float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetFrequency(ctx context.Context, freq float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "FREQ:CW %f", freq)
 }
 
-func (d *Driver) TestVoltageLevel() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TestVoltageLevel(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "VOLT:LEV?")
 }
 
-func (d *Driver) SetTestVoltageLevel(volts float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetTestVoltageLevel(ctx context.Context, volts float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "VOLT:LEV %f", volts)
 }
 
-func (d *Driver) TestCurrentLevel() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TestCurrentLevel(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "CURR:LEV?")
 }
 
-func (d *Driver) SetTestCurrentLevel(amps float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetTestCurrentLevel(ctx context.Context, amps float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "CURR:LEV %f", amps)
@@ -139,17 +145,23 @@ func (d *Driver) SetTestCurrentLevel(amps float64) error {
 
 // --- Impedance Range ---
 
-func (d *Driver) ImpedanceAutoRange() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ImpedanceAutoRange(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Bool(ctx, d.inst, "FUNC:IMP:RANG:AUTO?")
 }
 
-func (d *Driver) SetImpedanceAutoRange(auto bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetImpedanceAutoRange(ctx context.Context, auto bool) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetImpedanceAutoRange: %w", err)
+	}
+	defer unlock()
+
 	if auto {
 		return d.inst.Command(ctx, "FUNC:IMP:RANG:AUTO ON")
 	}
@@ -157,15 +169,15 @@ func (d *Driver) SetImpedanceAutoRange(auto bool) error {
 	return d.inst.Command(ctx, "FUNC:IMP:RANG:AUTO OFF")
 }
 
-func (d *Driver) ImpedanceRange() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ImpedanceRange(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "FUNC:IMP:RANG?")
 }
 
-func (d *Driver) SetImpedanceRange(ohms float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetImpedanceRange(ctx context.Context, ohms float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "FUNC:IMP:RANG %f", ohms)
@@ -185,8 +197,10 @@ var scpiToSpeed = map[string]lcr.MeasurementSpeed{
 	"LONG": lcr.MeasurementSpeedLong,
 }
 
-func (d *Driver) MeasurementSpeed() (lcr.MeasurementSpeed, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) MeasurementSpeed(
+	ctx context.Context,
+) (lcr.MeasurementSpeed, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, d.inst, "APER?")
@@ -206,8 +220,11 @@ func (d *Driver) MeasurementSpeed() (lcr.MeasurementSpeed, error) {
 	return speed, nil
 }
 
-func (d *Driver) SetMeasurementSpeed(speed lcr.MeasurementSpeed) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetMeasurementSpeed(
+	ctx context.Context,
+	speed lcr.MeasurementSpeed,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(speedToSCPI, speed)
@@ -216,7 +233,7 @@ func (d *Driver) SetMeasurementSpeed(speed lcr.MeasurementSpeed) error {
 	}
 
 	// Preserve the current averaging count.
-	count, err := d.AveragingCount()
+	count, err := d.AveragingCount(ctx)
 	if err != nil {
 		return err
 	}
@@ -224,8 +241,8 @@ func (d *Driver) SetMeasurementSpeed(speed lcr.MeasurementSpeed) error {
 	return d.inst.Command(ctx, "APER %s,%d", cmd, count)
 }
 
-func (d *Driver) AveragingCount() (int, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AveragingCount(ctx context.Context) (int, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, d.inst, "APER?")
@@ -246,12 +263,12 @@ func (d *Driver) AveragingCount() (int, error) {
 	return count, nil
 }
 
-func (d *Driver) SetAveragingCount(count int) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetAveragingCount(ctx context.Context, count int) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	// Preserve the current speed setting.
-	speed, err := d.MeasurementSpeed()
+	speed, err := d.MeasurementSpeed(ctx)
 	if err != nil {
 		return err
 	}
@@ -280,8 +297,8 @@ var scpiToTriggerSource = map[string]lcr.TriggerSource{
 	"HOLD": lcr.TriggerSourceHold,
 }
 
-func (d *Driver) TriggerSource() (lcr.TriggerSource, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TriggerSource(ctx context.Context) (lcr.TriggerSource, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, d.inst, "TRIG:SOUR?")
@@ -299,8 +316,11 @@ func (d *Driver) TriggerSource() (lcr.TriggerSource, error) {
 	return src, nil
 }
 
-func (d *Driver) SetTriggerSource(src lcr.TriggerSource) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetTriggerSource(
+	ctx context.Context,
+	src lcr.TriggerSource,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(triggerSourceToSCPI, src)
@@ -311,8 +331,8 @@ func (d *Driver) SetTriggerSource(src lcr.TriggerSource) error {
 	return d.inst.Command(ctx, "TRIG:SOUR %s", cmd)
 }
 
-func (d *Driver) TriggerDelay() (time.Duration, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TriggerDelay(ctx context.Context) (time.Duration, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	sec, err := query.Float64(ctx, d.inst, "TRIG:DEL?")
@@ -323,8 +343,11 @@ func (d *Driver) TriggerDelay() (time.Duration, error) {
 	return time.Duration(sec * float64(time.Second)), nil
 }
 
-func (d *Driver) SetTriggerDelay(delay time.Duration) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetTriggerDelay(
+	ctx context.Context,
+	delay time.Duration,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "TRIG:DEL %f", delay.Seconds())
@@ -332,22 +355,22 @@ func (d *Driver) SetTriggerDelay(delay time.Duration) error {
 
 // --- Measurement Control ---
 
-func (d *Driver) Initiate() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) Initiate(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "INIT:IMM")
 }
 
-func (d *Driver) Trigger() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) Trigger(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "TRIG:IMM")
 }
 
-func (d *Driver) Abort() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) Abort(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "ABOR")
@@ -358,8 +381,10 @@ func (d *Driver) Abort() error {
 // FetchMeasurement returns the primary value, secondary value, and measurement
 // status from the most recent measurement. The trigger source should be set to
 // BUS and a measurement initiated before calling this method.
-func (d *Driver) FetchMeasurement() (float64, float64, lcr.MeasurementStatus, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) FetchMeasurement(
+	ctx context.Context,
+) (float64, float64, lcr.MeasurementStatus, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, d.inst, "FETC:IMP:FORM?")
@@ -403,17 +428,23 @@ func parseMeasurementResult(
 
 // --- DC Bias ---
 
-func (d *Driver) DCBiasEnabled() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) DCBiasEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Bool(ctx, d.inst, "BIAS:STAT?")
 }
 
-func (d *Driver) SetDCBiasEnabled(enabled bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetDCBiasEnabled(ctx context.Context, enabled bool) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetDCBiasEnabled: %w", err)
+	}
+	defer unlock()
+
 	if enabled {
 		return d.inst.Command(ctx, "BIAS:STAT ON")
 	}
@@ -421,15 +452,18 @@ func (d *Driver) SetDCBiasEnabled(enabled bool) error {
 	return d.inst.Command(ctx, "BIAS:STAT OFF")
 }
 
-func (d *Driver) DCBiasVoltageLevel() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) DCBiasVoltageLevel(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, "BIAS:VOLT:LEV?")
 }
 
-func (d *Driver) SetDCBiasVoltageLevel(volts float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetDCBiasVoltageLevel(
+	ctx context.Context,
+	volts float64,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "BIAS:VOLT:LEV %f", volts)
@@ -437,17 +471,26 @@ func (d *Driver) SetDCBiasVoltageLevel(volts float64) error {
 
 // --- Compensation ---
 
-func (d *Driver) OpenCorrectionEnabled() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) OpenCorrectionEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Bool(ctx, d.inst, "CORR:OPEN:STAT?")
 }
 
-func (d *Driver) SetOpenCorrectionEnabled(enabled bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetOpenCorrectionEnabled(
+	ctx context.Context,
+	enabled bool,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetOpenCorrectionEnabled: %w", err)
+	}
+	defer unlock()
+
 	if enabled {
 		return d.inst.Command(ctx, "CORR:OPEN:STAT ON")
 	}
@@ -455,24 +498,43 @@ func (d *Driver) SetOpenCorrectionEnabled(enabled bool) error {
 	return d.inst.Command(ctx, "CORR:OPEN:STAT OFF")
 }
 
-func (d *Driver) ExecuteOpenCorrection() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) ExecuteOpenCorrection(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return d.inst.Command(ctx, "CORR:OPEN")
+	if err := d.inst.Command(ctx, "CORR:OPEN"); err != nil {
+		return err
+	}
+
+	// The correction sweeps every correction frequency, which takes
+	// seconds, so wait for it to finish before returning.
+	if err := d.WaitForOperationComplete(ctx); err != nil {
+		return fmt.Errorf("ExecuteOpenCorrection: %w", err)
+	}
+
+	return nil
 }
 
-func (d *Driver) ShortCorrectionEnabled() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ShortCorrectionEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Bool(ctx, d.inst, "CORR:SHOR:STAT?")
 }
 
-func (d *Driver) SetShortCorrectionEnabled(enabled bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetShortCorrectionEnabled(
+	ctx context.Context,
+	enabled bool,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetShortCorrectionEnabled: %w", err)
+	}
+	defer unlock()
+
 	if enabled {
 		return d.inst.Command(ctx, "CORR:SHOR:STAT ON")
 	}
@@ -480,24 +542,43 @@ func (d *Driver) SetShortCorrectionEnabled(enabled bool) error {
 	return d.inst.Command(ctx, "CORR:SHOR:STAT OFF")
 }
 
-func (d *Driver) ExecuteShortCorrection() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) ExecuteShortCorrection(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return d.inst.Command(ctx, "CORR:SHOR")
+	if err := d.inst.Command(ctx, "CORR:SHOR"); err != nil {
+		return err
+	}
+
+	// The correction sweeps every correction frequency, which takes
+	// seconds, so wait for it to finish before returning.
+	if err := d.WaitForOperationComplete(ctx); err != nil {
+		return fmt.Errorf("ExecuteShortCorrection: %w", err)
+	}
+
+	return nil
 }
 
-func (d *Driver) LoadCorrectionEnabled() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) LoadCorrectionEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Bool(ctx, d.inst, "CORR:LOAD:STAT?")
 }
 
-func (d *Driver) SetLoadCorrectionEnabled(enabled bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetLoadCorrectionEnabled(
+	ctx context.Context,
+	enabled bool,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetLoadCorrectionEnabled: %w", err)
+	}
+	defer unlock()
+
 	if enabled {
 		return d.inst.Command(ctx, "CORR:LOAD:STAT ON")
 	}
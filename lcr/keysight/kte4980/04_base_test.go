@@ -6,6 +6,7 @@
 package kte4980
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -44,7 +45,7 @@ func TestDriver_SetMeasurementFunction(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			d, _ := New(mock, ivi.WithoutIDQuery())
-			if err := d.SetMeasurementFunction(tt.fcn); err != nil {
+			if err := d.SetMeasurementFunction(t.Context(), tt.fcn); err != nil {
 				t.Fatalf("SetMeasurementFunction() error: %v", err)
 			}
 			if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != tt.want {
@@ -69,7 +70,7 @@ func TestDriver_MeasurementFunction(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{QueryResp: tt.resp}
 			d, _ := New(mock, ivi.WithoutIDQuery())
-			got, err := d.MeasurementFunction()
+			got, err := d.MeasurementFunction(t.Context())
 			if err != nil {
 				t.Fatalf("MeasurementFunction() error: %v", err)
 			}
@@ -83,7 +84,7 @@ func TestDriver_MeasurementFunction(t *testing.T) {
 func TestDriver_SetFrequency(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.SetFrequency(1000.0); err != nil {
+	if err := d.SetFrequency(t.Context(), 1000.0); err != nil {
 		t.Fatalf("SetFrequency() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -95,7 +96,7 @@ func TestDriver_SetFrequency(t *testing.T) {
 func TestDriver_SetTestVoltageLevel(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.SetTestVoltageLevel(1.0); err != nil {
+	if err := d.SetTestVoltageLevel(t.Context(), 1.0); err != nil {
 		t.Fatalf("SetTestVoltageLevel() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -118,7 +119,7 @@ func TestDriver_SetImpedanceAutoRange(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			d, _ := New(mock, ivi.WithoutIDQuery())
-			if err := d.SetImpedanceAutoRange(tt.auto); err != nil {
+			if err := d.SetImpedanceAutoRange(t.Context(), tt.auto); err != nil {
 				t.Fatalf("SetImpedanceAutoRange() error: %v", err)
 			}
 			if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != tt.want {
@@ -144,7 +145,7 @@ func TestDriver_SetTriggerSource(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			d, _ := New(mock, ivi.WithoutIDQuery())
-			if err := d.SetTriggerSource(tt.src); err != nil {
+			if err := d.SetTriggerSource(t.Context(), tt.src); err != nil {
 				t.Fatalf("SetTriggerSource() error: %v", err)
 			}
 			if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != tt.want {
@@ -168,7 +169,7 @@ func TestDriver_TriggerSource(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{QueryResp: tt.resp}
 			d, _ := New(mock, ivi.WithoutIDQuery())
-			got, err := d.TriggerSource()
+			got, err := d.TriggerSource(t.Context())
 			if err != nil {
 				t.Fatalf("TriggerSource() error: %v", err)
 			}
@@ -182,7 +183,7 @@ func TestDriver_TriggerSource(t *testing.T) {
 func TestDriver_SetTriggerDelay(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.SetTriggerDelay(100 * time.Millisecond); err != nil {
+	if err := d.SetTriggerDelay(t.Context(), 100*time.Millisecond); err != nil {
 		t.Fatalf("SetTriggerDelay() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -194,7 +195,7 @@ func TestDriver_SetTriggerDelay(t *testing.T) {
 func TestDriver_Abort(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.Abort(); err != nil {
+	if err := d.Abort(t.Context()); err != nil {
 		t.Fatalf("Abort() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != "ABOR" {
@@ -205,7 +206,7 @@ func TestDriver_Abort(t *testing.T) {
 func TestDriver_Initiate(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.Initiate(); err != nil {
+	if err := d.Initiate(t.Context()); err != nil {
 		t.Fatalf("Initiate() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != "INIT:IMM" {
@@ -216,7 +217,7 @@ func TestDriver_Initiate(t *testing.T) {
 func TestDriver_Trigger(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.Trigger(); err != nil {
+	if err := d.Trigger(t.Context()); err != nil {
 		t.Fatalf("Trigger() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != "TRIG:IMM" {
@@ -227,7 +228,7 @@ func TestDriver_Trigger(t *testing.T) {
 func TestDriver_FetchMeasurement(t *testing.T) {
 	mock := &ivitest.Mock{QueryResp: "+1.23456E-09,+3.45678E-03,+0"}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	pri, sec, status, err := d.FetchMeasurement()
+	pri, sec, status, err := d.FetchMeasurement(t.Context())
 	if err != nil {
 		t.Fatalf("FetchMeasurement() error: %v", err)
 	}
@@ -245,7 +246,7 @@ func TestDriver_FetchMeasurement(t *testing.T) {
 func TestDriver_FetchMeasurement_Overload(t *testing.T) {
 	mock := &ivitest.Mock{QueryResp: "+9.99999E+37,+9.99999E+37,+1"}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	_, _, status, err := d.FetchMeasurement()
+	_, _, status, err := d.FetchMeasurement(t.Context())
 	if err != nil {
 		t.Fatalf("FetchMeasurement() error: %v", err)
 	}
@@ -268,7 +269,7 @@ func TestDriver_SetDCBiasEnabled(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			d, _ := New(mock, ivi.WithoutIDQuery())
-			if err := d.SetDCBiasEnabled(tt.enabled); err != nil {
+			if err := d.SetDCBiasEnabled(t.Context(), tt.enabled); err != nil {
 				t.Fatalf("SetDCBiasEnabled() error: %v", err)
 			}
 			if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != tt.want {
@@ -281,7 +282,7 @@ func TestDriver_SetDCBiasEnabled(t *testing.T) {
 func TestDriver_SetOpenCorrectionEnabled(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.SetOpenCorrectionEnabled(true); err != nil {
+	if err := d.SetOpenCorrectionEnabled(t.Context(), true); err != nil {
 		t.Fatalf("SetOpenCorrectionEnabled() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -293,9 +294,9 @@ func TestDriver_SetOpenCorrectionEnabled(t *testing.T) {
 }
 
 func TestDriver_ExecuteOpenCorrection(t *testing.T) {
-	mock := &ivitest.Mock{}
+	mock := &ivitest.Mock{QueryResp: "1"}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.ExecuteOpenCorrection(); err != nil {
+	if err := d.ExecuteOpenCorrection(t.Context()); err != nil {
 		t.Fatalf("ExecuteOpenCorrection() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -304,6 +305,30 @@ func TestDriver_ExecuteOpenCorrection(t *testing.T) {
 	}
 }
 
+func TestDriver_ExecuteShortCorrection(t *testing.T) {
+	mock := &ivitest.Mock{QueryResp: "1"}
+	d, _ := New(mock, ivi.WithoutIDQuery())
+	if err := d.ExecuteShortCorrection(t.Context()); err != nil {
+		t.Fatalf("ExecuteShortCorrection() error: %v", err)
+	}
+	if len(mock.CommandsSent) != 1 ||
+		mock.CommandsSent[0] != "CORR:SHOR" {
+		t.Errorf("sent %v, want [\"CORR:SHOR\"]", mock.CommandsSent)
+	}
+}
+
+func TestDriver_ExecuteOpenCorrectionNotComplete(t *testing.T) {
+	mock := &ivitest.Mock{QueryResp: "0"}
+	d, _ := New(mock, ivi.WithoutIDQuery())
+	err := d.ExecuteOpenCorrection(t.Context())
+	if !errors.Is(err, ivi.ErrUnexpectedResponse) {
+		t.Errorf(
+			"ExecuteOpenCorrection() error = %v, want ErrUnexpectedResponse",
+			err,
+		)
+	}
+}
+
 func TestDriver_MeasurementSpeed(t *testing.T) {
 	tests := []struct {
 		name string
@@ -319,7 +344,7 @@ func TestDriver_MeasurementSpeed(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{QueryResp: tt.resp}
 			d, _ := New(mock, ivi.WithoutIDQuery())
-			got, err := d.MeasurementSpeed()
+			got, err := d.MeasurementSpeed(t.Context())
 			if err != nil {
 				t.Fatalf("MeasurementSpeed() error: %v", err)
 			}
@@ -345,7 +370,7 @@ func TestDriver_AveragingCount(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{QueryResp: tt.resp}
 			d, _ := New(mock, ivi.WithoutIDQuery())
-			got, err := d.AveragingCount()
+			got, err := d.AveragingCount(t.Context())
 			if err != nil {
 				t.Fatalf("AveragingCount() error: %v", err)
 			}
@@ -359,7 +384,7 @@ func TestDriver_AveragingCount(t *testing.T) {
 func TestDriver_CommandError(t *testing.T) {
 	mock := &ivitest.Mock{ShouldError: true}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.SetFrequency(1000); err == nil {
+	if err := d.SetFrequency(t.Context(), 1000); err == nil {
 		t.Error("expected error, got nil")
 	}
 }
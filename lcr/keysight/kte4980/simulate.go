@@ -0,0 +1,36 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package kte4980
+
+import "github.com/gotmc/ivi"
+
+// simulation describes the instrument simulated under [ivi.WithSimulate],
+// which starts as an E4980A does after *RST: Cp-D at 1 kHz and 1 V, medium
+// speed, internal triggering. Every measurement reads 1 nF with a
+// dissipation factor of 0.01.
+var simulation = ivi.Simulation{
+	Manufacturer: "Keysight Technologies",
+	Attributes: map[string]ivi.SimulatedAttribute{
+		"FUNC:IMP:TYPE":      {Default: "CPD"},
+		"FUNC:IMP:RANG:AUTO": {Default: "1"},
+		"FUNC:IMP:RANG":      {Min: 0, Max: 100000},
+		"VOLT:LEV":           {Default: "1", Min: 0, Max: 2},
+		"CURR:LEV":           {Min: 0, Max: 0.02},
+		"BIAS:VOLT:LEV":      {Min: 0, Max: 2},
+		"APER":               {Default: "MED,1"},
+		"TRIG:SOUR":          {Default: "INT"},
+		"TRIG:DEL":           {Min: 0, Max: 999},
+		"FETC:IMP:FORM":      {Default: "+1.00000E-09,+1.00000E-02,+0"},
+	},
+	ModelAttributes: map[string]map[string]ivi.SimulatedAttribute{
+		"E4980A": {
+			"FREQ:CW": {Default: "1000", Min: 20, Max: 2e6},
+		},
+		"E4980AL": {
+			"FREQ:CW": {Default: "1000", Min: 20, Max: 1e6},
+		},
+	},
+}
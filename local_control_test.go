@@ -42,7 +42,7 @@ func TestInherent_Disable(t *testing.T) {
 	mock := &mockLocalControlInst{}
 	inherent := NewInherent(mock, InherentBase{ReturnToLocal: true}, 0)
 
-	err := inherent.Disable()
+	err := inherent.Disable(t.Context())
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -63,7 +63,7 @@ func TestInherent_Disable_CustomCommand(t *testing.T) {
 		LocalControlCommand: "SYST:COMM:RLST LOC",
 	}, 0)
 
-	err := inherent.Disable()
+	err := inherent.Disable(t.Context())
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -84,7 +84,7 @@ func TestInherent_Disable_ErrorIgnored(t *testing.T) {
 	mock := &mockLocalControlInst{shouldError: true}
 	inherent := NewInherent(mock, InherentBase{ReturnToLocal: true}, 0)
 
-	err := inherent.Disable()
+	err := inherent.Disable(t.Context())
 	if err != nil {
 		t.Errorf("Expected no error (best-effort), got %v", err)
 	}
@@ -127,7 +127,7 @@ func TestInherent_ReturnToLocal_Control(t *testing.T) {
 	}
 
 	// Disable should not send commands when ReturnToLocal is false.
-	err := inherent.Disable()
+	err := inherent.Disable(t.Context())
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -141,7 +141,7 @@ func TestInherent_ReturnToLocal_Control(t *testing.T) {
 
 	// Re-enable and verify it works.
 	inherent.SetReturnToLocal(true)
-	err = inherent.Disable()
+	err = inherent.Disable(t.Context())
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -0,0 +1,123 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gotmc/query"
+)
+
+// DefaultPollInterval is the interval [Inherent.PollOperationComplete] waits
+// between *ESR? reads when the caller passes a non-positive interval.
+const DefaultPollInterval = 50 * time.Millisecond
+
+// WaitForOperationComplete blocks until the instrument has finished every
+// pending operation by issuing the IEEE 488.2 *OPC? query, which the
+// instrument answers with 1 only once those operations complete. The wait is
+// bounded by ctx; when ctx has no deadline the driver's configured timeout
+// applies, so callers waiting on long operations should pass a context with a
+// suitable deadline.
+//
+// Because *OPC? holds the bus until it is answered, instruments that must
+// stay responsive while busy should use [Inherent.PollOperationComplete].
+func (inherent *Inherent) WaitForOperationComplete(ctx context.Context) error {
+	ctx, cancel := inherent.newContext(ctx)
+	defer cancel()
+
+	resp, err := query.String(ctx, inherent.inst, "*OPC?")
+	if err != nil {
+		return fmt.Errorf("error waiting for operation complete: %w", err)
+	}
+
+	if strings.TrimSpace(resp) != "1" {
+		return fmt.Errorf(
+			"%w: *OPC? returned %q", ErrUnexpectedResponse, resp,
+		)
+	}
+
+	return nil
+}
+
+// PollOperationComplete waits for the instrument to finish every pending
+// operation without holding the bus. It sends *OPC, which sets the OPC bit of
+// the Standard Event Status Register once pending operations complete, and
+// then reads *ESR? every interval until that bit is set. Reading *ESR? clears
+// the register. A non-positive interval uses DefaultPollInterval. The wait is
+// bounded by ctx, falling back to the driver's configured timeout.
+func (inherent *Inherent) PollOperationComplete(
+	ctx context.Context,
+	interval time.Duration,
+) error {
+	ctx, cancel := inherent.newContext(ctx)
+	defer cancel()
+
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	if err := inherent.inst.Command(ctx, "*OPC"); err != nil {
+		return err
+	}
+
+	for {
+		esr, err := inherent.StandardEventStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("error polling operation complete: %w", err)
+		}
+
+		if esr.Has(StandardEventOperationComplete) {
+			return nil
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return fmt.Errorf("error polling operation complete: %w", err)
+		}
+	}
+}
+
+// AwaitOperation waits for the operation just sent to the instrument to
+// finish. When the instrument supports *OPC? (see
+// [InherentBase].SupportsOPC) it waits with
+// [Inherent.WaitForOperationComplete]; otherwise it falls back to waiting the
+// given fixed delay, which is skipped under [WithSimulate]. Either wait is cut
+// short if ctx is done.
+func (inherent *Inherent) AwaitOperation(
+	ctx context.Context,
+	fallback time.Duration,
+) error {
+	if inherent.SupportsOPC {
+		return inherent.WaitForOperationComplete(ctx)
+	}
+
+	// A simulated instrument finishes every operation immediately.
+	if inherent.simulate {
+		return ctx.Err()
+	}
+
+	return sleep(ctx, fallback)
+}
+
+// sleep pauses for d or until ctx is done, whichever comes first, returning
+// the context's error in the latter case.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
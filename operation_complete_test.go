@@ -0,0 +1,149 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// mockScriptedInst answers each query with the next entry from its scripted
+// responses, repeating the last entry once the script for that query runs
+// out. Queries without a script return an empty string.
+type mockScriptedInst struct {
+	commandsSent []string
+	queriesSent  []string
+	responses    map[string][]string
+}
+
+func (m *mockScriptedInst) ReadBinary(_ context.Context, _ []byte) (int, error) {
+	return 0, nil
+}
+
+func (m *mockScriptedInst) WriteBinary(_ context.Context, p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (m *mockScriptedInst) Command(_ context.Context, format string, a ...any) error {
+	m.commandsSent = append(m.commandsSent, FormatCommand(format, a))
+	return nil
+}
+
+func (m *mockScriptedInst) Query(_ context.Context, s string) (string, error) {
+	m.queriesSent = append(m.queriesSent, s)
+	script := m.responses[s]
+	if len(script) == 0 {
+		return "", nil
+	}
+	resp := script[0]
+	if len(script) > 1 {
+		m.responses[s] = script[1:]
+	}
+	return resp, nil
+}
+
+func (m *mockScriptedInst) Close() error { return nil }
+
+func TestWaitForOperationComplete(t *testing.T) {
+	mock := &mockScriptedInst{
+		responses: map[string][]string{"*OPC?": {"1\n"}},
+	}
+	inherent := NewInherent(mock, InherentBase{}, 0)
+	if err := inherent.WaitForOperationComplete(t.Context()); err != nil {
+		t.Fatalf("WaitForOperationComplete() error: %v", err)
+	}
+	if !slices.Equal(mock.queriesSent, []string{"*OPC?"}) {
+		t.Errorf("queries = %v, want [*OPC?]", mock.queriesSent)
+	}
+}
+
+func TestWaitForOperationComplete_UnexpectedResponse(t *testing.T) {
+	mock := &mockScriptedInst{
+		responses: map[string][]string{"*OPC?": {"0"}},
+	}
+	inherent := NewInherent(mock, InherentBase{}, 0)
+	err := inherent.WaitForOperationComplete(t.Context())
+	if !errors.Is(err, ErrUnexpectedResponse) {
+		t.Errorf("error = %v, want ErrUnexpectedResponse", err)
+	}
+}
+
+func TestPollOperationComplete(t *testing.T) {
+	mock := &mockScriptedInst{
+		responses: map[string][]string{"*ESR?": {"+0", "+32", "+33"}},
+	}
+	inherent := NewInherent(mock, InherentBase{}, 0)
+	err := inherent.PollOperationComplete(t.Context(), time.Millisecond)
+	if err != nil {
+		t.Fatalf("PollOperationComplete() error: %v", err)
+	}
+	if !slices.Equal(mock.commandsSent, []string{"*OPC"}) {
+		t.Errorf("commands = %v, want [*OPC]", mock.commandsSent)
+	}
+	if len(mock.queriesSent) != 3 {
+		t.Errorf("sent %d *ESR? queries, want 3", len(mock.queriesSent))
+	}
+}
+
+func TestPollOperationComplete_ContextDone(t *testing.T) {
+	mock := &mockScriptedInst{
+		responses: map[string][]string{"*ESR?": {"0"}},
+	}
+	inherent := NewInherent(mock, InherentBase{}, 0)
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	err := inherent.PollOperationComplete(ctx, time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestAwaitOperation_Fallback(t *testing.T) {
+	mock := &mockScriptedInst{}
+	inherent := NewInherent(mock, InherentBase{}, 0)
+	start := time.Now()
+	if err := inherent.AwaitOperation(t.Context(), 10*time.Millisecond); err != nil {
+		t.Fatalf("AwaitOperation() error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("AwaitOperation() returned after %v, want >= 10ms", elapsed)
+	}
+	if len(mock.queriesSent) != 0 {
+		t.Errorf("queries = %v, want none", mock.queriesSent)
+	}
+}
+
+func TestAwaitOperation_FallbackCanceled(t *testing.T) {
+	inherent := NewInherent(&mockScriptedInst{}, InherentBase{}, 0)
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	err := inherent.AwaitOperation(ctx, time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+}
+
+func TestReset_SupportsOPC(t *testing.T) {
+	mock := &mockScriptedInst{
+		responses: map[string][]string{"*OPC?": {"1"}},
+	}
+	inherent := NewInherent(mock, InherentBase{
+		ResetDelay:  time.Hour,
+		SupportsOPC: true,
+	}, 0)
+	if err := inherent.Reset(t.Context()); err != nil {
+		t.Fatalf("Reset() error: %v", err)
+	}
+	if !slices.Equal(mock.commandsSent, []string{"*rst"}) {
+		t.Errorf("commands = %v, want [*rst]", mock.commandsSent)
+	}
+	if !slices.Equal(mock.queriesSent, []string{"*OPC?"}) {
+		t.Errorf("queries = %v, want [*OPC?]", mock.queriesSent)
+	}
+}
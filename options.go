@@ -5,7 +5,11 @@
 
 package ivi
 
-import "time"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 // DriverOption configures the behavior of a driver's New constructor.
 type DriverOption func(*DriverConfig)
@@ -16,10 +20,20 @@ type DriverOption func(*DriverConfig)
 // driver-specific (e.g., Standalone) are ignored by drivers that don't use
 // them.
 type DriverConfig struct {
-	Timeout     time.Duration
-	SkipIDQuery bool
-	Reset       bool
-	Standalone  bool
+	Timeout               time.Duration
+	SkipIDQuery           bool
+	Reset                 bool
+	Standalone            bool
+	QueryInstrumentStatus bool
+	DefaultSetup          []string
+	Cache                 bool
+	Simulate              bool
+	SimulatedModel        string
+	SimulatedData         *Simulation
+	RangeCheck            bool
+	// IDN is the *IDN? response [Registry.Open] has already read, which the
+	// driver checks in place of querying the instrument again.
+	IDN string
 }
 
 // ApplyOptions returns a DriverConfig with all the given options applied.
@@ -70,6 +84,100 @@ func WithStandalone() DriverOption {
 	}
 }
 
+// WithQueryInstrumentStatus makes the driver read the instrument's error
+// queue after every command it sends, so that a command the instrument rejects
+// returns an [*InstrumentError] naming that command from the call that sent
+// it. Without this option such a failure typically surfaces much later as a
+// query timeout. A query sent with Command, whose response is read later, is
+// checked when the driver calls [CheckInstrumentStatus] after reading it.
+// Checking costs one extra query per command, so it is off by default. A
+// driver for an instrument without an error queue refuses the option with an
+// error wrapping [ErrFunctionNotSupported]. This corresponds to the Query
+// Instrument Status attribute described in IVI-3.2: Inherent Capabilities
+// Specification.
+func WithQueryInstrumentStatus() DriverOption {
+	return func(cfg *DriverConfig) {
+		cfg.QueryInstrumentStatus = true
+	}
+}
+
+// WithCache enables state caching: the driver remembers the attribute values
+// it sets and reads, and serves repeated reads of those attributes from the
+// cache rather than querying the instrument. The cache is cleared by Reset
+// and by [Inherent.InvalidateAllAttributes], which callers must also call
+// after changing instrument settings outside the driver. Only drivers whose
+// package documentation says state caching is implemented use the cache;
+// others ignore this option. This corresponds to the Cache attribute
+// described in IVI-3.2: Inherent Capabilities Specification.
+func WithCache() DriverOption {
+	return func(cfg *DriverConfig) {
+		cfg.Cache = true
+	}
+}
+
+// WithRangeCheck makes the driver check values against the limits of the
+// connected model before sending them, so that an out-of-range value returns
+// an error wrapping [ErrValueNotSupported], stating the allowed range, without
+// any I/O. Without it values are sent as given and the instrument is left to
+// reject them. A driver whose package documentation says range checking is
+// implemented checks the settings it lists there, and its New refuses the
+// option for a connected model whose limits it does not record, including an
+// unknown model under [WithoutIDQuery], with the error from
+// [RangeLimitsNotRecorded]; it never silently skips the check. Other drivers
+// ignore this option. This corresponds to the Range Check attribute
+// described in IVI-3.2: Inherent Capabilities Specification.
+func WithRangeCheck() DriverOption {
+	return func(cfg *DriverConfig) {
+		cfg.RangeCheck = true
+	}
+}
+
+// WithSimulate makes the driver simulate the instrument instead of
+// communicating with it, so programs can run without hardware attached. The
+// transport passed to New is ignored and may be nil. The simulated instrument
+// identifies itself as the first of the driver's supported models, remembers
+// the settings the driver sends so that reads return them, and rejects
+// out-of-range values with an error wrapping [ErrValueNotSupported]. Reset
+// and Clear return without waiting. This corresponds to the Simulate
+// attribute described in IVI-3.2: Inherent Capabilities Specification.
+func WithSimulate() DriverOption {
+	return func(cfg *DriverConfig) {
+		cfg.Simulate = true
+	}
+}
+
+// WithSimulatedModel is [WithSimulate] for a specific model, for drivers
+// whose behavior depends on the model (e.g., its number of channels). The
+// model must be one the driver supports.
+func WithSimulatedModel(model string) DriverOption {
+	return func(cfg *DriverConfig) {
+		cfg.Simulate = true
+		cfg.SimulatedModel = model
+	}
+}
+
+// WithSimulatedData is [WithSimulate] with data of the caller's own, such as a
+// waveform loaded from a file, in place of the driver's: the attributes and
+// blocks of sim replace the simulated instrument's attributes and blocks with
+// the same headers. Drivers that can describe such data as a Simulation
+// provide a function for it (e.g., infiniivision.SimulatedWaveform).
+func WithSimulatedData(sim Simulation) DriverOption {
+	return func(cfg *DriverConfig) {
+		cfg.Simulate = true
+		cfg.SimulatedData = &sim
+	}
+}
+
+// WithDefaultSetup gives the driver commands that put the instrument in the
+// caller's preferred initial state. [Inherent.ResetWithDefaults] sends them,
+// in order, after resetting the instrument. The commands are sent verbatim, so
+// they must be in the instrument's own command language.
+func WithDefaultSetup(cmds ...string) DriverOption {
+	return func(cfg *DriverConfig) {
+		cfg.DefaultSetup = append(cfg.DefaultSetup, cmds...)
+	}
+}
+
 // DriverSetup bundles the pieces a driver constructor needs after applying
 // options and performing *IDN? validation. It is returned from
 // [NewDriverSetup].
@@ -77,6 +185,13 @@ type DriverSetup struct {
 	// Inherent is the constructed Inherent ready to be embedded in the
 	// driver struct.
 	Inherent Inherent
+	// Transport is the transport the driver must use for all instrument
+	// I/O. It is the caller's transport, wrapped as required by the options
+	// (e.g., [WithQueryInstrumentStatus]) and by a [SessionTransport].
+	Transport Transport
+	// Cache is the attribute cache the driver and its channels share, or nil
+	// when the caller did not pass [WithCache].
+	Cache *AttributeCache
 	// Timeout is the resolved I/O timeout, falling back to DefaultTimeout
 	// when the caller did not pass [WithTimeout].
 	Timeout time.Duration
@@ -103,15 +218,60 @@ func NewDriverSetup(
 		timeout = DefaultTimeout
 	}
 
+	if cfg.Simulate {
+		model := cfg.SimulatedModel
+		if model == "" && len(base.SupportedInstrumentModels) > 0 {
+			model = base.SupportedInstrumentModels[0]
+		}
+
+		inst = newSimulatedTransport(base.Simulation, model, cfg.SimulatedData)
+	}
+
+	if cfg.QueryInstrumentStatus && base.NoErrorQueue {
+		return nil, fmt.Errorf(
+			"WithQueryInstrumentStatus: the instrument has no error queue: %w",
+			ErrFunctionNotSupported,
+		)
+	}
+
+	if cfg.QueryInstrumentStatus {
+		errorQuery := base.ErrorQueryCommand
+		if errorQuery == "" {
+			errorQuery = defaultErrorQueryCommand
+		}
+
+		inst = &statusCheckingTransport{Transport: inst, errorQuery: errorQuery}
+	}
+
+	// Serialize calls outermost, so that a command and the error queue
+	// check that follows it run as one.
+	inst = NewSessionTransport(inst)
+
 	inherent := NewInherent(inst, base, timeout)
+	inherent.defaultSetup = cfg.DefaultSetup
+	inherent.simulate = cfg.Simulate
+	inherent.rangeCheck = cfg.RangeCheck
+
+	if cfg.Cache {
+		inherent.cache = NewAttributeCache()
+	}
+
+	var err error
+	if cfg.IDN != "" {
+		_, err = inherent.checkIdentification(cfg.IDN)
+	} else {
+		_, err = inherent.CheckID(context.Background())
+	}
 
-	if _, err := inherent.CheckID(); err != nil && !cfg.SkipIDQuery {
+	if err != nil && !cfg.SkipIDQuery {
 		return nil, err
 	}
 
 	return &DriverSetup{
-		Inherent: inherent,
-		Timeout:  timeout,
-		Config:   cfg,
+		Inherent:  inherent,
+		Transport: inst,
+		Cache:     inherent.cache,
+		Timeout:   timeout,
+		Config:    cfg,
 	}, nil
 }
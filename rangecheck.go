@@ -0,0 +1,49 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import "fmt"
+
+// Range is the closed interval of values an instrument setting accepts. Drivers
+// record one per model in their model tables and, under [WithRangeCheck],
+// check values against it before sending them.
+type Range struct {
+	Min float64
+	Max float64
+}
+
+// Check returns nil when value lies within the range, and otherwise an error
+// wrapping [ErrValueNotSupported] that states the allowed range.
+func (r Range) Check(value float64) error {
+	if value >= r.Min && value <= r.Max {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: %g is outside %g to %g", ErrValueNotSupported, value, r.Min, r.Max,
+	)
+}
+
+// RangeCheck reports whether the driver validates values against the model's
+// limits before sending them, as enabled by [WithRangeCheck]. RangeCheck is
+// the getter for the inherent attribute Range Check described in IVI-3.2:
+// Inherent Capabilities Specification.
+func (inherent *Inherent) RangeCheck() bool {
+	return inherent.rangeCheck
+}
+
+// RangeLimitsNotRecorded returns the error a driver constructor returns when
+// [WithRangeCheck] is requested for a model whose limits the driver does not
+// record. Checking only some settings, or none, would silently pass values
+// the instrument may not accept, so drivers refuse the option instead. The
+// error wraps [ErrFunctionNotSupported].
+func RangeLimitsNotRecorded(model string) error {
+	return fmt.Errorf(
+		"%q: range checking needs limits, which are not recorded for this "+
+			"model: %w",
+		model, ErrFunctionNotSupported,
+	)
+}
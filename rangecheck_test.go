@@ -0,0 +1,64 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRange_Check(t *testing.T) {
+	r := Range{Min: -1, Max: 5}
+
+	tests := []struct {
+		value float64
+		ok    bool
+	}{
+		{-1, true},
+		{0, true},
+		{5, true},
+		{-1.001, false},
+		{5.001, false},
+		{math.NaN(), false},
+		{math.Inf(1), false},
+	}
+
+	for _, tt := range tests {
+		err := r.Check(tt.value)
+		if tt.ok && err != nil {
+			t.Errorf("Check(%g) error: %v", tt.value, err)
+		}
+		if !tt.ok && !errors.Is(err, ErrValueNotSupported) {
+			t.Errorf("Check(%g) error = %v, want ErrValueNotSupported",
+				tt.value, err)
+		}
+	}
+}
+
+func TestNewDriverSetup_RangeCheck(t *testing.T) {
+	mock := &mockScriptedInst{}
+
+	ds, err := NewDriverSetup(mock, InherentBase{}, []DriverOption{
+		WithoutIDQuery(), WithRangeCheck(),
+	})
+	if err != nil {
+		t.Fatalf("NewDriverSetup() error: %v", err)
+	}
+	if !ds.Config.RangeCheck || !ds.Inherent.RangeCheck() {
+		t.Error("WithRangeCheck() did not enable range checking")
+	}
+
+	ds, err = NewDriverSetup(mock, InherentBase{}, []DriverOption{
+		WithoutIDQuery(),
+	})
+	if err != nil {
+		t.Fatalf("NewDriverSetup() error: %v", err)
+	}
+	if ds.Inherent.RangeCheck() {
+		t.Error("RangeCheck() = true without WithRangeCheck()")
+	}
+}
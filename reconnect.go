@@ -0,0 +1,513 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Defaults for a ReconnectingTransport.
+const (
+	defaultReconnectAttempts = 5
+	defaultReconnectBackoff  = 100 * time.Millisecond
+	defaultReconnectMaxDelay = 5 * time.Second
+	defaultReconnectTimeout  = 30 * time.Second
+)
+
+// DialFunc opens a new connection to an instrument.
+type DialFunc func(ctx context.Context) (Transport, error)
+
+// ReconnectEvent describes one attempt by a [ReconnectingTransport] to
+// restore its connection.
+type ReconnectEvent struct {
+	// Attempt counts the attempts made since the connection failed,
+	// starting at 1.
+	Attempt int
+	// Cause is the I/O failure that started the reconnect.
+	Cause error
+	// Err is why the attempt failed, or nil when it succeeded.
+	Err error
+}
+
+// ReconnectOption configures a [ReconnectingTransport].
+type ReconnectOption func(*reconnectConfig)
+
+type reconnectConfig struct {
+	attempts     int
+	backoff      time.Duration
+	maxDelay     time.Duration
+	timeout      time.Duration
+	reinit       []string
+	hook         func(ReconnectEvent)
+	isFailure    func(error) bool
+	isIdempotent func(query string) bool
+}
+
+// WithReconnectAttempts sets how many times the transport tries to reconnect
+// after a failure before giving up. The default is 5.
+func WithReconnectAttempts(n int) ReconnectOption {
+	return func(cfg *reconnectConfig) {
+		cfg.attempts = max(n, 1)
+	}
+}
+
+// WithReconnectBackoff sets the delay before the first reconnect attempt,
+// which doubles after each failed attempt up to maxDelay. The defaults are
+// 100 ms and 5 s.
+func WithReconnectBackoff(initial, maxDelay time.Duration) ReconnectOption {
+	return func(cfg *reconnectConfig) {
+		cfg.backoff = initial
+		cfg.maxDelay = max(maxDelay, initial)
+	}
+}
+
+// WithReconnectTimeout sets how long the transport may spend reconnecting
+// after a failure, across all attempts and their backoff. Reconnecting runs
+// under its own context bounded by this timeout rather than under the failed
+// call's context, whose deadline may be about to pass. The default is 30 s.
+func WithReconnectTimeout(timeout time.Duration) ReconnectOption {
+	return func(cfg *reconnectConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithReinitialize sets commands sent on every new connection before any
+// other call, to restore state the instrument lost with the connection
+// (e.g., "SYST:REM" or a default setup).
+func WithReinitialize(cmds ...string) ReconnectOption {
+	return func(cfg *reconnectConfig) {
+		cfg.reinit = cmds
+	}
+}
+
+// WithReconnectHook sets a function called after each reconnect attempt, for
+// logging or counting reconnects.
+func WithReconnectHook(hook func(ReconnectEvent)) ReconnectOption {
+	return func(cfg *reconnectConfig) {
+		cfg.hook = hook
+	}
+}
+
+// WithConnectionFailure sets the function that decides whether an error
+// returned by the transport means the connection is lost. By default
+// transport-level errors count, such as a closed or reset connection, an
+// unexpected EOF, or a timeout the transport reports as
+// [os.ErrDeadlineExceeded]; errors such as an instrument error or a malformed
+// response do not. Whatever the function decides, no error returned once the
+// caller's context is done counts, since a caller's deadline expiring during a
+// slow operation says nothing about the link.
+func WithConnectionFailure(isFailure func(error) bool) ReconnectOption {
+	return func(cfg *reconnectConfig) {
+		cfg.isFailure = isFailure
+	}
+}
+
+// WithIdempotentQueries sets the function that decides whether a query may
+// be sent again after a reconnect. By default every query is retried except
+// those that change instrument state: SYST:ERR?, which removes the error it
+// reads; *ESR? and the STATus event registers, which clear what they read;
+// *OPC?, which waits on pending operations; and *TST?, which runs a self
+// test.
+func WithIdempotentQueries(
+	isIdempotent func(query string) bool,
+) ReconnectOption {
+	return func(cfg *reconnectConfig) {
+		cfg.isIdempotent = isIdempotent
+	}
+}
+
+// ReconnectingTransport wraps a connection opened by a [DialFunc] and opens
+// a new one when the connection fails, so that a long-running program
+// survives a dropped LAN or USB link. When a call fails, the transport closes
+// the failed connection, dials again with exponential backoff, and sends the
+// re-initialization commands. A query that is safe to repeat is then sent
+// again; any other failed call returns its error, and later calls use the new
+// connection.
+//
+// It works with any driver constructor:
+//
+//	inst, err := ivi.NewReconnectingTransport(ctx, dial,
+//		ivi.WithReinitialize("SYST:REM"))
+//	if err != nil {
+//		return err
+//	}
+//	supply, err := e36000.New(inst)
+type ReconnectingTransport struct {
+	dial DialFunc
+	cfg  reconnectConfig
+
+	mu         sync.Mutex
+	inst       Transport
+	generation int
+	pending    *reconnectAttempt
+	closed     bool
+}
+
+// reconnectAttempt is a reconnect in progress, which calls that fail on the
+// same connection wait for rather than starting their own.
+type reconnectAttempt struct {
+	done chan struct{}
+	err  error
+}
+
+// NewReconnectingTransport dials the first connection and returns a
+// transport that replaces it when it fails.
+func NewReconnectingTransport(
+	ctx context.Context,
+	dial DialFunc,
+	opts ...ReconnectOption,
+) (*ReconnectingTransport, error) {
+	cfg := reconnectConfig{
+		attempts:     defaultReconnectAttempts,
+		backoff:      defaultReconnectBackoff,
+		maxDelay:     defaultReconnectMaxDelay,
+		timeout:      defaultReconnectTimeout,
+		isIdempotent: isIdempotentQuery,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	t := &ReconnectingTransport{dial: dial, cfg: cfg}
+
+	inst, err := t.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t.inst = inst
+
+	return t, nil
+}
+
+// Command sends the command. A failed command is not sent again after a
+// reconnect, since the instrument may have acted on it.
+func (t *ReconnectingTransport) Command(
+	ctx context.Context,
+	cmd string,
+	a ...any,
+) error {
+	inst, gen := t.current()
+
+	return t.handleFailure(ctx, gen, inst.Command(ctx, cmd, a...))
+}
+
+// Query sends the query and reads its response, sending it once more on a
+// new connection when the first fails and the query is idempotent.
+func (t *ReconnectingTransport) Query(
+	ctx context.Context,
+	cmd string,
+) (string, error) {
+	inst, gen := t.current()
+
+	resp, err := inst.Query(ctx, cmd)
+	if !t.failed(ctx, err) {
+		return resp, err
+	}
+
+	if rerr := t.reconnect(ctx, gen, err); rerr != nil {
+		return resp, rerr
+	}
+
+	if !t.cfg.isIdempotent(cmd) || ctx.Err() != nil {
+		return resp, err
+	}
+
+	inst, _ = t.current()
+
+	return inst.Query(ctx, cmd)
+}
+
+// ReadBinary reads into p. A failed read is not retried, since the data
+// belonged to the lost connection.
+func (t *ReconnectingTransport) ReadBinary(
+	ctx context.Context,
+	p []byte,
+) (int, error) {
+	inst, gen := t.current()
+
+	n, err := inst.ReadBinary(ctx, p)
+
+	return n, t.handleFailure(ctx, gen, err)
+}
+
+// WriteBinary writes p. A failed write is not retried.
+func (t *ReconnectingTransport) WriteBinary(
+	ctx context.Context,
+	p []byte,
+) (int, error) {
+	inst, gen := t.current()
+
+	n, err := inst.WriteBinary(ctx, p)
+
+	return n, t.handleFailure(ctx, gen, err)
+}
+
+// Close closes the current connection, if it is an [io.Closer]. A reconnect
+// in progress closes the connection it opens instead of using it.
+func (t *ReconnectingTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closed = true
+
+	// A reconnect in progress has already closed the failed connection.
+	if t.pending != nil {
+		return nil
+	}
+
+	return closeTransport(t.inst)
+}
+
+// current returns the connection in use and its generation, which
+// increments with each reconnect.
+func (t *ReconnectingTransport) current() (Transport, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.inst, t.generation
+}
+
+// failed reports whether err from a call made with ctx means the connection
+// is lost. Nothing returned once ctx is done does, whether the caller canceled
+// it or its deadline passed.
+func (t *ReconnectingTransport) failed(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	if t.cfg.isFailure != nil {
+		return t.cfg.isFailure(err)
+	}
+
+	return isConnectionFailure(err)
+}
+
+// isConnectionFailure reports whether err is a transport or network error,
+// including an I/O timeout of the transport itself, that may mean the
+// connection is lost. A context's deadline is not one: it is the caller's
+// bound on the call, not a sign of a dead link.
+func isConnectionFailure(err error) bool {
+	switch {
+	// context.DeadlineExceeded is a net.Error too, so exclude it first.
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, context.Canceled):
+		return false
+	case errors.Is(err, os.ErrDeadlineExceeded),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, io.ErrClosedPipe),
+		errors.Is(err, net.ErrClosed),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNABORTED),
+		errors.Is(err, syscall.EPIPE):
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr)
+}
+
+// handleFailure reconnects when err, returned by a call on the connection of
+// generation gen, means the connection is lost. It returns err, or the
+// reconnect error when no new connection could be opened.
+func (t *ReconnectingTransport) handleFailure(
+	ctx context.Context,
+	gen int,
+	err error,
+) error {
+	if !t.failed(ctx, err) {
+		return err
+	}
+
+	if rerr := t.reconnect(ctx, gen, err); rerr != nil {
+		return rerr
+	}
+
+	return err
+}
+
+// reconnect replaces the connection of generation gen, which failed with
+// cause. When another call has already replaced it, reconnect returns at
+// once; when another call is replacing it, reconnect waits for that call's
+// result or for ctx to be done. The mutex is not held while dialing, so
+// calls on other paths, and Close, are not blocked by the backoff.
+func (t *ReconnectingTransport) reconnect(
+	ctx context.Context,
+	gen int,
+	cause error,
+) error {
+	t.mu.Lock()
+
+	if t.generation != gen {
+		t.mu.Unlock()
+		return nil
+	}
+
+	if t.closed {
+		t.mu.Unlock()
+		return fmt.Errorf(
+			"%w: transport closed after %w", ErrDisconnected, cause,
+		)
+	}
+
+	if r := t.pending; r != nil {
+		t.mu.Unlock()
+
+		select {
+		case <-r.done:
+			return r.err
+		case <-ctx.Done():
+			return fmt.Errorf(
+				"%w: waiting for reconnect after %w: %w",
+				ErrDisconnected, cause, ctx.Err(),
+			)
+		}
+	}
+
+	r := &reconnectAttempt{done: make(chan struct{})}
+	t.pending = r
+	failed := t.inst
+	t.mu.Unlock()
+
+	_ = closeTransport(failed)
+
+	inst, err := t.redial(ctx, cause)
+
+	t.mu.Lock()
+	switch {
+	case err != nil:
+	case t.closed:
+		_ = closeTransport(inst)
+		err = fmt.Errorf(
+			"%w: transport closed while reconnecting after %w",
+			ErrDisconnected, cause,
+		)
+	default:
+		t.inst = inst
+		t.generation++
+	}
+	t.pending = nil
+	r.err = err
+	t.mu.Unlock()
+
+	close(r.done)
+
+	return err
+}
+
+// redial dials with exponential backoff until a connection opens or the
+// attempts run out. It runs under its own context, bounded by the reconnect
+// timeout, since the failed call's context may be about to expire; values
+// carried by ctx are kept.
+func (t *ReconnectingTransport) redial(
+	ctx context.Context,
+	cause error,
+) (Transport, error) {
+	ctx, cancel := context.WithTimeout(
+		context.WithoutCancel(ctx), t.cfg.timeout,
+	)
+	defer cancel()
+
+	delay := t.cfg.backoff
+
+	var err error
+
+	for attempt := 1; attempt <= t.cfg.attempts; attempt++ {
+		if err = sleep(ctx, delay); err != nil {
+			break
+		}
+
+		var inst Transport
+
+		inst, err = t.connect(ctx)
+		t.report(ReconnectEvent{Attempt: attempt, Cause: cause, Err: err})
+
+		if err == nil {
+			return inst, nil
+		}
+
+		delay = min(delay*2, t.cfg.maxDelay)
+	}
+
+	return nil, fmt.Errorf(
+		"%w: reconnecting after %w: %w", ErrDisconnected, cause, err,
+	)
+}
+
+// connect dials a new connection and sends the re-initialization commands.
+func (t *ReconnectingTransport) connect(
+	ctx context.Context,
+) (Transport, error) {
+	inst, err := t.dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("dialing instrument: %w", err)
+	}
+
+	for _, cmd := range t.cfg.reinit {
+		if err := inst.Command(ctx, "%s", cmd); err != nil {
+			_ = closeTransport(inst)
+
+			return nil, fmt.Errorf("re-initializing with %q: %w", cmd, err)
+		}
+	}
+
+	return inst, nil
+}
+
+// report passes ev to the reconnect hook, if there is one.
+func (t *ReconnectingTransport) report(ev ReconnectEvent) {
+	if t.cfg.hook != nil {
+		t.cfg.hook(ev)
+	}
+}
+
+// nonIdempotentQueries are the headers, in short and long form, of the
+// queries isIdempotentQuery refuses to retry.
+var nonIdempotentQueries = []string{
+	"SYST:ERR", "SYSTEM:ERR", "SYSTEM:ERROR",
+	"STAT:OPER:EVEN", "STATUS:OPERATION:EVENT",
+	"STAT:OPER?", "STATUS:OPERATION?",
+	"STAT:QUES:EVEN", "STATUS:QUESTIONABLE:EVENT",
+	"STAT:QUES?", "STATUS:QUESTIONABLE?",
+	"*ESR?", "*OPC?", "*TST?",
+}
+
+// isIdempotentQuery reports whether query can be sent twice with the same
+// effect as once. Queries that clear what they read, wait on pending
+// operations, or run a self test cannot. The STATus event registers are
+// matched with or without their optional EVENt node.
+func isIdempotentQuery(query string) bool {
+	header := strings.ToUpper(strings.TrimSpace(query))
+	header = strings.TrimPrefix(header, ":")
+
+	for _, prefix := range nonIdempotentQueries {
+		if strings.HasPrefix(header, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// closeTransport closes inst when it is an io.Closer.
+func closeTransport(inst Transport) error {
+	if c, ok := inst.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,401 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var errLinkDown = fmt.Errorf("link down: %w", syscall.ECONNRESET)
+
+// mockConn is one connection handed out by mockDialer. Once broken, every
+// call fails with errLinkDown. A query fails with queryErr when it is set.
+type mockConn struct {
+	mockScriptedInst
+	broken   bool
+	closed   bool
+	queryErr error
+}
+
+func (c *mockConn) Command(ctx context.Context, format string, a ...any) error {
+	if c.broken {
+		return errLinkDown
+	}
+	return c.mockScriptedInst.Command(ctx, format, a...)
+}
+
+func (c *mockConn) Query(ctx context.Context, s string) (string, error) {
+	if c.broken {
+		return "", errLinkDown
+	}
+	if c.queryErr != nil {
+		return "", c.queryErr
+	}
+	return c.mockScriptedInst.Query(ctx, s)
+}
+
+func (c *mockConn) ReadBinary(ctx context.Context, p []byte) (int, error) {
+	if c.broken {
+		return 0, errLinkDown
+	}
+	return c.mockScriptedInst.ReadBinary(ctx, p)
+}
+
+func (c *mockConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+// mockDialer hands out a new mockConn per dial, failing the dials listed in
+// failDials (counted from 1).
+type mockDialer struct {
+	conns     []*mockConn
+	dials     int
+	failDials []int
+}
+
+func (d *mockDialer) dial(_ context.Context) (Transport, error) {
+	d.dials++
+	if slices.Contains(d.failDials, d.dials) {
+		return nil, errors.New("connection refused")
+	}
+	conn := &mockConn{mockScriptedInst: mockScriptedInst{
+		responses: map[string][]string{"VOLT?": {"+5.0E+00"}},
+	}}
+	d.conns = append(d.conns, conn)
+	return conn, nil
+}
+
+func (d *mockDialer) last() *mockConn { return d.conns[len(d.conns)-1] }
+
+func newTestReconnecting(
+	t *testing.T,
+	d *mockDialer,
+	opts ...ReconnectOption,
+) *ReconnectingTransport {
+	t.Helper()
+
+	opts = append([]ReconnectOption{
+		WithReconnectBackoff(time.Microsecond, time.Millisecond),
+	}, opts...)
+
+	rt, err := NewReconnectingTransport(t.Context(), d.dial, opts...)
+	if err != nil {
+		t.Fatalf("NewReconnectingTransport() error: %v", err)
+	}
+
+	return rt
+}
+
+func TestReconnectingTransport_RetriesQuery(t *testing.T) {
+	d := &mockDialer{}
+	var events []ReconnectEvent
+	rt := newTestReconnecting(t, d,
+		WithReinitialize("SYST:REM", "*CLS"),
+		WithReconnectHook(func(ev ReconnectEvent) { events = append(events, ev) }),
+	)
+
+	first := d.last()
+	first.broken = true
+
+	resp, err := rt.Query(t.Context(), "VOLT?")
+	if err != nil || resp != "+5.0E+00" {
+		t.Fatalf("Query() = %q, %v, want +5.0E+00", resp, err)
+	}
+
+	if !first.closed {
+		t.Error("failed connection was not closed")
+	}
+	if d.dials != 2 {
+		t.Errorf("dialed %d times, want 2", d.dials)
+	}
+	want := []string{"SYST:REM", "*CLS"}
+	if got := d.last().commandsSent; !slices.Equal(got, want) {
+		t.Errorf("new connection sent %q, want %q", got, want)
+	}
+	if len(events) != 1 || events[0].Attempt != 1 || events[0].Err != nil ||
+		!errors.Is(events[0].Cause, errLinkDown) {
+		t.Errorf("hook events = %+v, want one successful attempt", events)
+	}
+}
+
+func TestReconnectingTransport_CommandNotRetried(t *testing.T) {
+	d := &mockDialer{}
+	rt := newTestReconnecting(t, d)
+
+	d.last().broken = true
+
+	if err := rt.Command(t.Context(), "OUTP ON"); !errors.Is(err, errLinkDown) {
+		t.Fatalf("Command() error = %v, want %v", err, errLinkDown)
+	}
+	if got := d.last().commandsSent; len(got) != 0 {
+		t.Errorf("new connection sent %q, want nothing", got)
+	}
+
+	// Later calls use the new connection.
+	if err := rt.Command(t.Context(), "OUTP %s", "ON"); err != nil {
+		t.Errorf("Command() after reconnect error: %v", err)
+	}
+	if got := d.last().commandsSent; !slices.Equal(got, []string{"OUTP ON"}) {
+		t.Errorf("new connection sent %q, want [OUTP ON]", got)
+	}
+}
+
+func TestReconnectingTransport_NonIdempotentQuery(t *testing.T) {
+	d := &mockDialer{}
+	rt := newTestReconnecting(t, d)
+
+	d.last().broken = true
+
+	if _, err := rt.Query(t.Context(), "SYST:ERR?"); !errors.Is(err, errLinkDown) {
+		t.Errorf("Query(SYST:ERR?) error = %v, want %v", err, errLinkDown)
+	}
+	if got := d.last().queriesSent; len(got) != 0 {
+		t.Errorf("new connection was queried %q, want nothing", got)
+	}
+}
+
+func TestReconnectingTransport_Backoff(t *testing.T) {
+	d := &mockDialer{failDials: []int{2, 3}}
+	var events []ReconnectEvent
+	rt := newTestReconnecting(t, d,
+		WithReconnectHook(func(ev ReconnectEvent) { events = append(events, ev) }),
+	)
+
+	d.last().broken = true
+
+	if _, err := rt.ReadBinary(t.Context(), make([]byte, 4)); !errors.Is(err, errLinkDown) {
+		t.Fatalf("ReadBinary() error = %v, want %v", err, errLinkDown)
+	}
+	if len(events) != 3 || events[2].Err != nil || events[0].Err == nil {
+		t.Errorf("hook events = %+v, want two failures then success", events)
+	}
+}
+
+func TestReconnectingTransport_GivesUp(t *testing.T) {
+	d := &mockDialer{failDials: []int{2, 3, 4}}
+	rt := newTestReconnecting(t, d, WithReconnectAttempts(2))
+
+	d.last().broken = true
+
+	_, err := rt.Query(t.Context(), "VOLT?")
+	if !errors.Is(err, ErrDisconnected) || !errors.Is(err, errLinkDown) {
+		t.Fatalf("Query() error = %v, want %v wrapping %v",
+			err, ErrDisconnected, errLinkDown)
+	}
+
+	// The next call tries again, and this time the dial succeeds.
+	resp, err := rt.Query(t.Context(), "VOLT?")
+	if err != nil || resp != "+5.0E+00" {
+		t.Errorf("Query() after recovery = %q, %v", resp, err)
+	}
+}
+
+func TestReconnectingTransport_CallerContextDone(t *testing.T) {
+	d := &mockDialer{}
+	rt := newTestReconnecting(t, d)
+
+	d.last().broken = true
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if err := rt.Command(ctx, "VOLT 1"); !errors.Is(err, errLinkDown) {
+		t.Errorf("Command() error = %v, want %v", err, errLinkDown)
+	}
+	if d.dials != 1 {
+		t.Errorf("dialed %d times after a canceled call, want 1", d.dials)
+	}
+}
+
+func TestReconnectingTransport_InstrumentErrorNotFailure(t *testing.T) {
+	d := &mockDialer{}
+	rt := newTestReconnecting(t, d)
+
+	d.last().queryErr = &InstrumentError{
+		Code: -113, Message: "Undefined header",
+	}
+
+	if _, err := rt.Query(t.Context(), "VOLT?"); err == nil {
+		t.Fatal("Query() error = nil, want the instrument error")
+	}
+	if d.dials != 1 {
+		t.Errorf("dialed %d times after an instrument error, want 1", d.dials)
+	}
+}
+
+func TestReconnectingTransport_IOTimeoutReconnects(t *testing.T) {
+	// A dead link surfaces as a read that runs out the transport's own I/O
+	// timeout while the caller's context is still live.
+	d := &mockDialer{}
+	rt := newTestReconnecting(t, d)
+	first := d.last()
+	first.queryErr = fmt.Errorf("reading: %w", os.ErrDeadlineExceeded)
+
+	if _, err := rt.Query(t.Context(), "VOLT?"); err != nil {
+		t.Errorf("Query() error = %v, want the retry to succeed", err)
+	}
+	if d.dials != 2 || !first.closed {
+		t.Errorf("dialed %d times, closed = %v, want a reconnect",
+			d.dials, first.closed)
+	}
+}
+
+func TestReconnectingTransport_CallerDeadlineNoReconnect(t *testing.T) {
+	// A slow operation that outlasts the caller's deadline says nothing
+	// about the link, however the transport reports it.
+	for _, queryErr := range []error{
+		context.DeadlineExceeded,
+		fmt.Errorf("reading: %w", os.ErrDeadlineExceeded),
+	} {
+		d := &mockDialer{}
+		rt := newTestReconnecting(t, d)
+		first := d.last()
+		first.queryErr = queryErr
+
+		ctx, cancel := context.WithDeadline(t.Context(), time.Now())
+		_, err := rt.Query(ctx, "*OPC?")
+		cancel()
+
+		if !errors.Is(err, queryErr) {
+			t.Errorf("Query() error = %v, want %v", err, queryErr)
+		}
+		if d.dials != 1 || first.closed {
+			t.Errorf("%v: dialed %d times, closed = %v, want no reconnect",
+				queryErr, d.dials, first.closed)
+		}
+	}
+}
+
+func TestIsConnectionFailure(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{io.EOF, true},
+		{fmt.Errorf("reading: %w", io.ErrUnexpectedEOF), true},
+		{os.ErrDeadlineExceeded, true},
+		{context.DeadlineExceeded, false},
+		{fmt.Errorf("waiting: %w", context.DeadlineExceeded), false},
+		{&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, true},
+		{net.ErrClosed, true},
+		{&InstrumentError{Code: -222, Message: "Data out of range"}, false},
+		{ErrValueNotSupported, false},
+		{errors.New("unexpected response"), false},
+	}
+	for _, tt := range tests {
+		if got := isConnectionFailure(tt.err); got != tt.want {
+			t.Errorf("isConnectionFailure(%v) = %v, want %v",
+				tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestReconnectingTransport_BackoffDoesNotHoldMutex(t *testing.T) {
+	var mu sync.Mutex
+	var dials int
+	release := make(chan struct{})
+	dial := func(ctx context.Context) (Transport, error) {
+		mu.Lock()
+		dials++
+		n := dials
+		mu.Unlock()
+		if n > 1 {
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		return &mockConn{broken: n == 1}, nil
+	}
+	rt, err := NewReconnectingTransport(t.Context(), dial,
+		WithReconnectBackoff(time.Microsecond, time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewReconnectingTransport() error: %v", err)
+	}
+
+	// Several calls fail on the same connection; only one dials, and the
+	// others wait for its result.
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() { _ = rt.Command(t.Context(), "VOLT 1") })
+	}
+
+	// Close is not blocked by the reconnect in progress.
+	time.Sleep(10 * time.Millisecond)
+	closed := make(chan struct{})
+	go func() {
+		_ = rt.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close() blocked behind the reconnect")
+	}
+
+	close(release)
+	wg.Wait()
+
+	if dials != 2 {
+		t.Errorf("dialed %d times, want 2", dials)
+	}
+}
+
+func TestReconnectingTransport_InitialDialFails(t *testing.T) {
+	d := &mockDialer{failDials: []int{1}}
+
+	_, err := NewReconnectingTransport(t.Context(), d.dial)
+	if err == nil {
+		t.Fatal("NewReconnectingTransport() error = nil, want error")
+	}
+}
+
+func TestReconnectingTransport_Close(t *testing.T) {
+	d := &mockDialer{}
+	rt := newTestReconnecting(t, d)
+
+	var _ io.Closer = rt
+	if err := rt.Close(); err != nil || !d.last().closed {
+		t.Errorf("Close() = %v, closed = %v", err, d.last().closed)
+	}
+}
+
+func TestIsIdempotentQuery(t *testing.T) {
+	tests := map[string]bool{
+		"VOLT?":                      true,
+		":MEAS:VOLT?":                true,
+		"*IDN?":                      true,
+		"SYST:ERR?":                  false,
+		":syst:err?":                 false,
+		"SYSTEM:ERROR?":              false,
+		"*ESR?":                      false,
+		"*OPC?":                      false,
+		"*TST?":                      false,
+		"STAT:OPER?":                 false,
+		"STAT:OPER:EVEN?":            false,
+		"STATUS:QUESTIONABLE:EVENT?": false,
+		":STAT:QUES?":                false,
+		"STAT:OPER:COND?":            true,
+		"STAT:QUES:ENAB?":            true,
+	}
+	for query, want := range tests {
+		if got := isIdempotentQuery(query); got != want {
+			t.Errorf("isIdempotentQuery(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
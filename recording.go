@@ -0,0 +1,197 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// CallOp names the Transport method a [RecordedCall] captures.
+type CallOp string
+
+// The Transport methods a RecordingTransport captures.
+const (
+	OpCommand     CallOp = "command"
+	OpQuery       CallOp = "query"
+	OpReadBinary  CallOp = "read"
+	OpWriteBinary CallOp = "write"
+)
+
+// RecordedCall is one Transport call captured by a [RecordingTransport]. A
+// recording is a file of RecordedCall values, one JSON object per line.
+type RecordedCall struct {
+	Op CallOp `json:"op"`
+	// Command is the formatted command, or the query string.
+	Command string `json:"cmd,omitempty"`
+	// Response is the response to a query.
+	Response string `json:"resp,omitempty"`
+	// Data is the binary data read or written.
+	Data []byte `json:"data,omitempty"`
+	// Err is the text of the error the call returned, if any.
+	Err      string        `json:"err,omitempty"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+}
+
+// RecordingTransport wraps a Transport and writes every call made through it,
+// with its response and timing, to a recording that ReadRecording can load.
+// Recordings of a session with a real instrument can be replayed in tests to
+// reproduce what the instrument sent.
+//
+// RecordingTransport is safe for concurrent use, recording calls in the
+// order they complete.
+type RecordingTransport struct {
+	Transport
+
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewRecordingTransport returns a Transport that passes every call through to
+// inst and records it to w, which is typically an *os.File.
+func NewRecordingTransport(inst Transport, w io.Writer) *RecordingTransport {
+	return &RecordingTransport{Transport: inst, enc: json.NewEncoder(w)}
+}
+
+// Command sends the command and records it.
+func (t *RecordingTransport) Command(
+	ctx context.Context,
+	cmd string,
+	a ...any,
+) error {
+	start := time.Now()
+	err := t.Transport.Command(ctx, cmd, a...)
+	t.record(RecordedCall{
+		Op:      OpCommand,
+		Command: FormatCommand(cmd, a),
+	}, start, err)
+
+	return err
+}
+
+// Query sends the query and records it with its response.
+func (t *RecordingTransport) Query(
+	ctx context.Context,
+	cmd string,
+) (string, error) {
+	start := time.Now()
+	resp, err := t.Transport.Query(ctx, cmd)
+	t.record(RecordedCall{
+		Op:       OpQuery,
+		Command:  cmd,
+		Response: resp,
+	}, start, err)
+
+	return resp, err
+}
+
+// ReadBinary reads into p and records the bytes read.
+func (t *RecordingTransport) ReadBinary(
+	ctx context.Context,
+	p []byte,
+) (int, error) {
+	start := time.Now()
+	n, err := t.Transport.ReadBinary(ctx, p)
+	t.record(RecordedCall{
+		Op:   OpReadBinary,
+		Data: append([]byte(nil), p[:n]...),
+	}, start, err)
+
+	return n, err
+}
+
+// WriteBinary writes p and records the bytes written.
+func (t *RecordingTransport) WriteBinary(
+	ctx context.Context,
+	p []byte,
+) (int, error) {
+	start := time.Now()
+	n, err := t.Transport.WriteBinary(ctx, p)
+	t.record(RecordedCall{
+		Op:   OpWriteBinary,
+		Data: append([]byte(nil), p[:n]...),
+	}, start, err)
+
+	return n, err
+}
+
+// Err returns the first error writing the recording, if any. Calls made
+// through the transport succeed or fail on their own regardless.
+func (t *RecordingTransport) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.err
+}
+
+// record completes call with its timing and error and writes it out.
+func (t *RecordingTransport) record(
+	call RecordedCall,
+	start time.Time,
+	err error,
+) {
+	call.Start = start
+	call.Duration = time.Since(start)
+
+	if err != nil {
+		call.Err = err.Error()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.err != nil {
+		return
+	}
+
+	if encErr := t.enc.Encode(call); encErr != nil {
+		t.err = fmt.Errorf("writing recording: %w", encErr)
+	}
+}
+
+// ReadRecording reads the calls written by a [RecordingTransport].
+func ReadRecording(r io.Reader) ([]RecordedCall, error) {
+	var calls []RecordedCall
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64<<20)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var call RecordedCall
+		if err := json.Unmarshal(scanner.Bytes(), &call); err != nil {
+			return nil, fmt.Errorf(
+				"%w: line %d: %w", ErrInvalidRecording, line, err,
+			)
+		}
+
+		switch call.Op {
+		case OpCommand, OpQuery, OpReadBinary, OpWriteBinary:
+		default:
+			return nil, fmt.Errorf(
+				"%w: line %d: unknown op %q", ErrInvalidRecording, line, call.Op,
+			)
+		}
+
+		calls = append(calls, call)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading recording: %w", err)
+	}
+
+	return calls, nil
+}
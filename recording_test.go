@@ -0,0 +1,99 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRecordingTransport(t *testing.T) {
+	ctx := t.Context()
+	mock := &mockScriptedInst{
+		responses: map[string][]string{"VOLT?": {"+5.0E+00"}},
+	}
+
+	var buf bytes.Buffer
+	rec := NewRecordingTransport(mock, &buf)
+
+	if err := rec.Command(ctx, "VOLT %.1f", 5.0); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+	if resp, err := rec.Query(ctx, "VOLT?"); err != nil || resp != "+5.0E+00" {
+		t.Fatalf("Query() = %q, %v", resp, err)
+	}
+	if _, err := rec.WriteBinary(ctx, []byte{0, 1, 2}); err != nil {
+		t.Fatalf("WriteBinary() error: %v", err)
+	}
+	if err := rec.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	if len(mock.commandsSent) != 1 || mock.commandsSent[0] != "VOLT 5.0" {
+		t.Errorf("commands passed through = %q, want [VOLT 5.0]",
+			mock.commandsSent)
+	}
+
+	calls, err := ReadRecording(&buf)
+	if err != nil {
+		t.Fatalf("ReadRecording() error: %v", err)
+	}
+	if len(calls) != 3 {
+		t.Fatalf("ReadRecording() returned %d calls, want 3", len(calls))
+	}
+
+	if c := calls[0]; c.Op != OpCommand || c.Command != "VOLT 5.0" {
+		t.Errorf("calls[0] = %+v, want command VOLT 5.0", c)
+	}
+	if c := calls[1]; c.Op != OpQuery || c.Command != "VOLT?" ||
+		c.Response != "+5.0E+00" {
+		t.Errorf("calls[1] = %+v, want query VOLT? returning +5.0E+00", c)
+	}
+	if c := calls[2]; c.Op != OpWriteBinary || !bytes.Equal(c.Data, []byte{0, 1, 2}) {
+		t.Errorf("calls[2] = %+v, want write of 0 1 2", c)
+	}
+	for i, c := range calls {
+		if c.Start.IsZero() || c.Duration < 0 {
+			t.Errorf("calls[%d] timing = %v, %v", i, c.Start, c.Duration)
+		}
+	}
+}
+
+func TestRecordingTransport_RecordsErrors(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecordingTransport(&mockInstrument{shouldError: true}, &buf)
+
+	if _, err := rec.Query(t.Context(), "*IDN?"); err == nil {
+		t.Fatal("Query() error = nil, want error")
+	}
+
+	calls, err := ReadRecording(&buf)
+	if err != nil {
+		t.Fatalf("ReadRecording() error: %v", err)
+	}
+	if len(calls) != 1 || calls[0].Err != "mock error" {
+		t.Errorf("ReadRecording() = %+v, want one call with error %q",
+			calls, "mock error")
+	}
+}
+
+func TestReadRecording_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"not json":   "{op:command}\n",
+		"unknown op": `{"op":"poke","cmd":"VOLT 5"}` + "\n",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ReadRecording(strings.NewReader(input))
+			if !errors.Is(err, ErrInvalidRecording) {
+				t.Errorf("ReadRecording() error = %v, want %v",
+					err, ErrInvalidRecording)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gotmc/query"
+)
+
+// DriverFactory creates a driver for an instrument class and returns it as
+// the class interface T. It has the signature of a driver's New constructor.
+type DriverFactory[T any] func(inst Transport, opts ...DriverOption) (T, error)
+
+// Factory adapts a driver's New constructor, which returns the driver's own
+// type D, to a DriverFactory for the class interface T:
+//
+//	dcpwr.Drivers.Register("e36000", supportedModels(),
+//		ivi.Factory[dcpwr.Base](New))
+//
+// When New fails, the factory returns the zero T rather than New's nil *D,
+// which would convert to a non-nil T. Factory panics when D does not
+// implement T, since registering such a driver is a programming error.
+func Factory[T, D any](
+	newDriver func(Transport, ...DriverOption) (D, error),
+) DriverFactory[T] {
+	var d D
+	if _, ok := any(d).(T); !ok {
+		panic(fmt.Sprintf(
+			"ivi: %T does not implement %s", d, reflect.TypeFor[T](),
+		))
+	}
+
+	return func(inst Transport, opts ...DriverOption) (T, error) {
+		d, err := newDriver(inst, opts...)
+		if err != nil {
+			var zero T
+
+			return zero, err
+		}
+
+		t, _ := any(d).(T)
+
+		return t, nil
+	}
+}
+
+// Registry maps instrument models to the drivers of one instrument class,
+// so that a class package can open whichever driver supports the connected
+// instrument. Each class package keeps one Registry, which drivers add to
+// from an init function. The zero value is an empty registry ready to use.
+type Registry[T any] struct {
+	mu      sync.RWMutex
+	drivers []registeredDriver[T]
+}
+
+type registeredDriver[T any] struct {
+	name    string
+	models  []string
+	factory DriverFactory[T]
+}
+
+// Register adds the named driver, which supports the given instrument
+// models. Register panics when the name or one of the models is already
+// registered, since two drivers claiming a model is a programming error.
+func (r *Registry[T]) Register(
+	name string,
+	models []string,
+	factory DriverFactory[T],
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, d := range r.drivers {
+		if d.name == name {
+			panic(fmt.Sprintf("ivi: driver %q registered twice", name))
+		}
+
+		for _, model := range models {
+			if slices.Contains(d.models, model) {
+				panic(fmt.Sprintf(
+					"ivi: model %q registered by drivers %q and %q",
+					model, d.name, name,
+				))
+			}
+		}
+	}
+
+	r.drivers = append(r.drivers, registeredDriver[T]{
+		name:    name,
+		models:  slices.Clone(models),
+		factory: factory,
+	})
+}
+
+// Names returns the names of the registered drivers, sorted.
+func (r *Registry[T]) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, len(r.drivers))
+	for i, d := range r.drivers {
+		names[i] = d.name
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Lookup returns the name and factory of the driver registered for model.
+func (r *Registry[T]) Lookup(model string) (string, DriverFactory[T], bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, d := range r.drivers {
+		if slices.Contains(d.models, model) {
+			return d.name, d.factory, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// OpenDriver creates the driver registered under name, passing inst and opts
+// to its constructor, without checking the instrument's model beyond what the
+// driver itself checks. It returns an error wrapping [ErrUnknownDriver] when
+// no driver has that name.
+func (r *Registry[T]) OpenDriver(
+	name string,
+	inst Transport,
+	opts ...DriverOption,
+) (T, error) {
+	r.mu.RLock()
+	i := slices.IndexFunc(r.drivers, func(d registeredDriver[T]) bool {
+		return d.name == name
+	})
+
+	var factory DriverFactory[T]
+	if i >= 0 {
+		factory = r.drivers[i].factory
+	}
+	r.mu.RUnlock()
+
+	if factory == nil {
+		var zero T
+
+		return zero, fmt.Errorf("%w: %q", ErrUnknownDriver, name)
+	}
+
+	return factory(inst, opts...)
+}
+
+// Open queries the instrument's identity with *IDN? and creates the driver
+// registered for its model, passing opts on to the driver's constructor along
+// with the identity, so that the driver checks it without a second query. The
+// query is bounded by ctx, or by the timeout given with [WithTimeout] when
+// ctx has no deadline. With [WithSimulate], no query is sent and the model
+// given with [WithSimulatedModel] picks the driver. Open returns an error
+// wrapping [ErrUnsupportedModel] when no registered driver supports the
+// model.
+func (r *Registry[T]) Open(
+	ctx context.Context,
+	inst Transport,
+	opts ...DriverOption,
+) (T, error) {
+	var zero T
+
+	model, idn, err := identifyModel(ctx, inst, ApplyOptions(opts))
+	if err != nil {
+		return zero, err
+	}
+
+	_, factory, ok := r.Lookup(model)
+	if !ok {
+		return zero, fmt.Errorf(
+			"%w: no registered driver supports %q", ErrUnsupportedModel, model,
+		)
+	}
+
+	if idn != "" {
+		opts = slices.Concat(opts, []DriverOption{withIdentity(idn)})
+	}
+
+	return factory(inst, opts...)
+}
+
+// withIdentity passes the *IDN? response Open has read to the driver's
+// constructor, which checks it in place of querying the instrument again.
+func withIdentity(idn string) DriverOption {
+	return func(cfg *DriverConfig) {
+		cfg.IDN = idn
+	}
+}
+
+// identifyModel returns the model of the instrument inst is connected to,
+// and the *IDN? response it was read from, or the simulated model and an
+// empty response when cfg calls for simulation.
+func identifyModel(
+	ctx context.Context,
+	inst Transport,
+	cfg DriverConfig,
+) (model, idn string, err error) {
+	if cfg.Simulate {
+		if cfg.SimulatedModel == "" {
+			return "", "", fmt.Errorf(
+				"%w: WithSimulate needs WithSimulatedModel to pick a driver",
+				ErrUnsupportedModel,
+			)
+		}
+
+		return cfg.SimulatedModel, "", nil
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	ctx, cancel := ContextWithTimeout(ctx, timeout)
+	defer cancel()
+
+	idn, err = query.String(ctx, inst, "*IDN?")
+	if err != nil {
+		return "", "", fmt.Errorf("error querying instrument identity: %w", err)
+	}
+
+	idn = strings.TrimSpace(idn)
+
+	model, err = parseIdentification(idn, modelID)
+	if err != nil {
+		return "", "", err
+	}
+
+	return model, idn, nil
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+)
+
+// openedDriver records how a test driver factory was called.
+type openedDriver struct {
+	name string
+	inst Transport
+	cfg  DriverConfig
+}
+
+func testFactory(name string) DriverFactory[*openedDriver] {
+	return func(inst Transport, opts ...DriverOption) (*openedDriver, error) {
+		return &openedDriver{name: name, inst: inst, cfg: ApplyOptions(opts)}, nil
+	}
+}
+
+// setupDriver is a driver built with NewDriverSetup, as real drivers are.
+type setupDriver struct {
+	Inherent
+}
+
+func newSetupDriver(
+	inst Transport,
+	opts ...DriverOption,
+) (*setupDriver, error) {
+	s, err := NewDriverSetup(
+		inst, InherentBase{SupportedInstrumentModels: []string{"PS2"}}, opts,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &setupDriver{Inherent: s.Inherent}, nil
+}
+
+// identifier is the class interface setupDriver is registered as.
+type identifier interface {
+	InstrumentModel(ctx context.Context) (string, error)
+}
+
+func newTestRegistry() *Registry[*openedDriver] {
+	var r Registry[*openedDriver]
+	r.Register("psu", []string{"PS1", "PS2"}, testFactory("psu"))
+	r.Register("load", []string{"EL1"}, testFactory("load"))
+
+	return &r
+}
+
+func TestRegistry_Open(t *testing.T) {
+	r := newTestRegistry()
+	mock := &mockScriptedInst{
+		responses: map[string][]string{"*IDN?": {"Acme, PS2 ,SN42,1.0\n"}},
+	}
+
+	d, err := r.Open(t.Context(), mock, WithReset())
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if d.name != "psu" {
+		t.Errorf("Open() picked driver %q, want psu", d.name)
+	}
+	if d.inst != mock || !d.cfg.Reset {
+		t.Errorf("Open() did not pass the transport and options through")
+	}
+	if d.cfg.IDN != "Acme, PS2 ,SN42,1.0" {
+		t.Errorf("Open() passed identity %q to the driver", d.cfg.IDN)
+	}
+	if !slices.Equal(mock.queriesSent, []string{"*IDN?"}) {
+		t.Errorf("queries = %q, want [*IDN?]", mock.queriesSent)
+	}
+
+	if got := r.Names(); !slices.Equal(got, []string{"load", "psu"}) {
+		t.Errorf("Names() = %q, want [load psu]", got)
+	}
+}
+
+func TestRegistry_OpenUnsupported(t *testing.T) {
+	r := newTestRegistry()
+	mock := &mockScriptedInst{
+		responses: map[string][]string{"*IDN?": {"Acme,DMM1,SN42,1.0"}},
+	}
+
+	if _, err := r.Open(t.Context(), mock); !errors.Is(
+		err, ErrUnsupportedModel,
+	) {
+		t.Errorf("Open() error = %v, want ErrUnsupportedModel", err)
+	}
+
+	failing := &mockInstrument{shouldError: true}
+	if _, err := r.Open(t.Context(), failing); err == nil {
+		t.Error("Open() with a failing transport returned no error")
+	}
+}
+
+func TestRegistry_OpenSimulated(t *testing.T) {
+	r := newTestRegistry()
+
+	d, err := r.Open(t.Context(), nil, WithSimulate(), WithSimulatedModel("EL1"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if d.name != "load" {
+		t.Errorf("Open() picked driver %q, want load", d.name)
+	}
+
+	if _, err := r.Open(t.Context(), nil, WithSimulate()); !errors.Is(
+		err, ErrUnsupportedModel,
+	) {
+		t.Errorf("Open() without a model error = %v, want ErrUnsupportedModel",
+			err)
+	}
+}
+
+func TestRegistry_RegisterTwice(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver string
+		models []string
+	}{
+		{"same name", "psu", []string{"PS9"}},
+		{"same model", "other", []string{"PS9", "EL1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRegistry()
+
+			defer func() {
+				if recover() == nil {
+					t.Error("Register() did not panic")
+				}
+			}()
+
+			r.Register(tt.driver, tt.models, testFactory(tt.driver))
+		})
+	}
+}
+
+func TestRegistry_OpenDriver(t *testing.T) {
+	r := newTestRegistry()
+	mock := &mockScriptedInst{}
+
+	d, err := r.OpenDriver("load", mock, WithTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("OpenDriver() error: %v", err)
+	}
+	if d.name != "load" || d.cfg.Timeout != time.Second {
+		t.Errorf("OpenDriver() = %+v", d)
+	}
+	if len(mock.queriesSent) != 0 {
+		t.Errorf("OpenDriver() sent queries %q", mock.queriesSent)
+	}
+
+	if _, err := r.OpenDriver("scope", mock); !errors.Is(err, ErrUnknownDriver) {
+		t.Errorf("OpenDriver(scope) error = %v, want ErrUnknownDriver", err)
+	}
+}
+
+func TestRegistry_OpenQueriesIdentityOnce(t *testing.T) {
+	var r Registry[identifier]
+	r.Register("psu", []string{"PS2"}, Factory[identifier](newSetupDriver))
+	mock := &mockScriptedInst{
+		responses: map[string][]string{"*IDN?": {"Acme,PS2,SN42,1.0"}},
+	}
+
+	d, err := r.Open(t.Context(), mock)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if model, _ := d.InstrumentModel(t.Context()); model != "PS2" {
+		t.Errorf("InstrumentModel() = %q, want PS2", model)
+	}
+	if !slices.Equal(mock.queriesSent, []string{"*IDN?"}) {
+		t.Errorf("queries = %q, want a single *IDN?", mock.queriesSent)
+	}
+}
+
+func TestFactory_Error(t *testing.T) {
+	open := Factory[identifier](newSetupDriver)
+
+	d, err := open(&mockInstrument{shouldError: true})
+	if err == nil {
+		t.Fatal("factory returned no error")
+	}
+	if d != nil {
+		t.Errorf("factory returned %#v, want a nil interface", d)
+	}
+}
+
+func TestFactory_NotImplemented(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Factory() did not panic")
+		}
+	}()
+
+	Factory[io.Reader](newSetupDriver)
+}
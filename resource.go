@@ -0,0 +1,408 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// InterfaceType is the interface named by a VISA resource string.
+type InterfaceType string
+
+// Interface types of VISA resource strings.
+const (
+	InterfaceTCPIP InterfaceType = "TCPIP"
+	InterfaceGPIB  InterfaceType = "GPIB"
+	InterfaceASRL  InterfaceType = "ASRL"
+	InterfaceUSB   InterfaceType = "USB"
+)
+
+// Resource classes of VISA resource strings.
+const (
+	ClassINSTR  = "INSTR"
+	ClassSocket = "SOCKET"
+)
+
+// Resource is a parsed VISA resource string, such as
+// "TCPIP0::10.0.0.5::5025::SOCKET" or "GPIB0::5::INSTR". Only the fields of
+// its interface type are set. Addresses a string leaves for the driver to
+// supply are -1, or 0 for Port; see [Resource.Settings].
+type Resource struct {
+	Interface InterfaceType
+	// Board is the interface board number, the 0 in "GPIB0".
+	Board int
+	// Class is ClassINSTR or ClassSocket.
+	Class string
+
+	// Host is the TCPIP host name or IP address.
+	Host string
+	// Port is the TCPIP SOCKET port, or 0 when the string gives none.
+	Port int
+	// LANDevice is the TCPIP INSTR device name (e.g., "inst0" or
+	// "hislip0"), or empty for the VXI-11 default.
+	LANDevice string
+
+	// PrimaryAddress is the GPIB primary address, or -1 when the string
+	// gives none.
+	PrimaryAddress int
+	// SecondaryAddress is the GPIB secondary address, or -1 for none.
+	SecondaryAddress int
+
+	// SerialDevice is the ASRL device path (e.g., "/dev/ttyUSB0") when the
+	// string names one rather than a board number.
+	SerialDevice string
+
+	// ManufacturerID, ModelCode, and SerialNumber identify a USB device.
+	ManufacturerID uint16
+	ModelCode      uint16
+	SerialNumber   string
+	// USBInterface is the USB interface number, or -1 for the default.
+	USBInterface int
+}
+
+// ParseResource parses a VISA resource string. The interface type and
+// resource class are matched without regard to case, and a missing class
+// means INSTR. The forms accepted are:
+//
+//	TCPIP[board]::host[::LAN device][::INSTR]
+//	TCPIP[board]::host[::port]::SOCKET
+//	GPIB[board][::primary[::secondary]][::INSTR]
+//	ASRL[board][::INSTR]
+//	ASRL<device path>[::INSTR]
+//	USB[board]::manufacturer::model::serial[::interface][::INSTR]
+//
+// Unlike VISA, ParseResource accepts a GPIB resource without a primary
+// address and a SOCKET resource without a port, which [Resource.Settings]
+// fills in from the driver's defaults. Errors wrap [ErrInvalidResource].
+func ParseResource(s string) (Resource, error) {
+	r, err := parseResource(s)
+	if err != nil {
+		return Resource{}, fmt.Errorf("%w %q: %s", ErrInvalidResource, s, err)
+	}
+
+	return r, nil
+}
+
+func parseResource(s string) (Resource, error) {
+	r := Resource{PrimaryAddress: -1, SecondaryAddress: -1, USBInterface: -1}
+
+	fields := strings.Split(strings.TrimSpace(s), "::")
+	if last := strings.ToUpper(fields[len(fields)-1]); len(fields) > 1 &&
+		(last == ClassINSTR || last == ClassSocket) {
+		r.Class = last
+		fields = fields[:len(fields)-1]
+	} else {
+		r.Class = ClassINSTR
+	}
+
+	intf, fields := fields[0], fields[1:]
+	upper := strings.ToUpper(intf)
+
+	var board string
+
+	for _, t := range []InterfaceType{
+		InterfaceTCPIP, InterfaceGPIB, InterfaceASRL, InterfaceUSB,
+	} {
+		if strings.HasPrefix(upper, string(t)) {
+			r.Interface = t
+			board = intf[len(t):]
+
+			break
+		}
+	}
+
+	switch {
+	case r.Interface == "":
+		return r, fmt.Errorf("unknown interface type %q", intf)
+	case r.Class == ClassSocket && r.Interface != InterfaceTCPIP:
+		return r, fmt.Errorf("%s has no SOCKET class", r.Interface)
+	}
+
+	if r.Interface == InterfaceASRL && board != "" && !isDigits(board) {
+		r.SerialDevice = board
+		board = ""
+	}
+
+	if board != "" {
+		n, err := strconv.Atoi(board)
+		if err != nil || n < 0 {
+			return r, fmt.Errorf("invalid board number %q", board)
+		}
+
+		r.Board = n
+	}
+
+	switch r.Interface {
+	case InterfaceTCPIP:
+		return r, r.parseTCPIP(fields)
+	case InterfaceGPIB:
+		return r, r.parseGPIB(fields)
+	case InterfaceASRL:
+		if len(fields) > 0 {
+			return r, fmt.Errorf("unexpected field %q", fields[0])
+		}
+
+		return r, nil
+	default:
+		return r, r.parseUSB(fields)
+	}
+}
+
+func (r *Resource) parseTCPIP(fields []string) error {
+	if len(fields) == 0 || fields[0] == "" {
+		return errors.New("missing host")
+	}
+
+	if len(fields) > 2 {
+		return fmt.Errorf("unexpected field %q", fields[2])
+	}
+
+	r.Host = fields[0]
+	if len(fields) == 1 {
+		return nil
+	}
+
+	if r.Class == ClassINSTR {
+		r.LANDevice = fields[1]
+
+		return nil
+	}
+
+	port, err := strconv.Atoi(fields[1])
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("invalid port %q", fields[1])
+	}
+
+	r.Port = port
+
+	return nil
+}
+
+func (r *Resource) parseGPIB(fields []string) error {
+	if len(fields) > 2 {
+		return fmt.Errorf("unexpected field %q", fields[2])
+	}
+
+	addrs := []*int{&r.PrimaryAddress, &r.SecondaryAddress}
+	for i, field := range fields {
+		addr, err := strconv.Atoi(field)
+		if err != nil || addr < 0 || addr > 30 {
+			return fmt.Errorf("invalid GPIB address %q", field)
+		}
+
+		*addrs[i] = addr
+	}
+
+	return nil
+}
+
+func (r *Resource) parseUSB(fields []string) error {
+	if len(fields) < 3 {
+		return errors.New("missing manufacturer ID, model code, or serial")
+	}
+
+	if len(fields) > 4 {
+		return fmt.Errorf("unexpected field %q", fields[4])
+	}
+
+	ids := []*uint16{&r.ManufacturerID, &r.ModelCode}
+	for i, field := range fields[:2] {
+		id, err := strconv.ParseUint(field, 0, 16)
+		if err != nil {
+			return fmt.Errorf("invalid USB ID %q", field)
+		}
+
+		*ids[i] = uint16(id)
+	}
+
+	if fields[2] == "" {
+		return errors.New("missing serial number")
+	}
+
+	r.SerialNumber = fields[2]
+
+	if len(fields) == 4 {
+		n, err := strconv.Atoi(fields[3])
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid USB interface %q", fields[3])
+		}
+
+		r.USBInterface = n
+	}
+
+	return nil
+}
+
+// String returns the resource string in canonical form, with the board
+// number and class always given.
+func (r Resource) String() string {
+	board := strconv.Itoa(r.Board)
+	if r.SerialDevice != "" {
+		board = r.SerialDevice
+	}
+
+	fields := []string{string(r.Interface) + board}
+
+	switch r.Interface {
+	case InterfaceTCPIP:
+		fields = append(fields, r.Host)
+		if r.Class == ClassSocket && r.Port > 0 {
+			fields = append(fields, strconv.Itoa(r.Port))
+		}
+
+		if r.Class == ClassINSTR && r.LANDevice != "" {
+			fields = append(fields, r.LANDevice)
+		}
+	case InterfaceGPIB:
+		for _, addr := range []int{r.PrimaryAddress, r.SecondaryAddress} {
+			if addr >= 0 {
+				fields = append(fields, strconv.Itoa(addr))
+			}
+		}
+	case InterfaceUSB:
+		fields = append(fields,
+			fmt.Sprintf("0x%04X", r.ManufacturerID),
+			fmt.Sprintf("0x%04X", r.ModelCode),
+			r.SerialNumber,
+		)
+		if r.USBInterface >= 0 {
+			fields = append(fields, strconv.Itoa(r.USBInterface))
+		}
+	}
+
+	return strings.Join(append(fields, r.Class), "::")
+}
+
+// Address returns the network address of a TCPIP SOCKET resource, as
+// host:port, for [net.Dial].
+func (r Resource) Address() string {
+	return net.JoinHostPort(r.Host, strconv.Itoa(r.Port))
+}
+
+// ConnectionDefaults describes how an instrument model is connected, as
+// reported by its driver's ConnectionDefaults function. Zero fields are
+// unknown.
+type ConnectionDefaults struct {
+	// Interfaces lists the model's remote interfaces, named as the driver's
+	// AvailableCOMPorts names them: "GPIB", "LAN", "RS232", or "USB".
+	Interfaces []string
+	// GPIBAddress is the factory GPIB address.
+	GPIBAddress int
+	// LANPorts maps port types, such as "socket" and "telnet", to ports.
+	LANPorts map[string]int
+	// SerialBaudRates lists the baud rates the serial port supports.
+	SerialBaudRates []int
+	// SerialBaudRate is the factory baud rate.
+	SerialBaudRate int
+	// SerialDataFrame is the factory data frame format (e.g., "8N2").
+	SerialDataFrame string
+}
+
+// connectionDefaults holds the connection defaults registered with
+// RegisterConnectionDefaults, by driver name.
+var connectionDefaults struct {
+	mu     sync.RWMutex
+	byName map[string]ConnectionDefaults
+}
+
+// RegisterConnectionDefaults records the connection defaults of the named
+// driver, so that code that knows the driver only by name, such as the config
+// package, can complete its resources with [Resource.Settings]. Drivers call
+// it from the init function that registers them with their class package.
+// RegisterConnectionDefaults panics when the name is already registered.
+func RegisterConnectionDefaults(driver string, defaults ConnectionDefaults) {
+	connectionDefaults.mu.Lock()
+	defer connectionDefaults.mu.Unlock()
+
+	if _, ok := connectionDefaults.byName[driver]; ok {
+		panic(fmt.Sprintf(
+			"ivi: connection defaults of driver %q registered twice", driver,
+		))
+	}
+
+	if connectionDefaults.byName == nil {
+		connectionDefaults.byName = make(map[string]ConnectionDefaults)
+	}
+
+	connectionDefaults.byName[driver] = defaults
+}
+
+// LookupConnectionDefaults returns the connection defaults registered for the
+// named driver.
+func LookupConnectionDefaults(driver string) (ConnectionDefaults, bool) {
+	connectionDefaults.mu.RLock()
+	defer connectionDefaults.mu.RUnlock()
+
+	defaults, ok := connectionDefaults.byName[driver]
+
+	return defaults, ok
+}
+
+// ConnectionSettings are the complete settings for connecting to an
+// instrument, as made by [Resource.Settings].
+type ConnectionSettings struct {
+	// Resource is the resource with any address it left out filled in.
+	Resource Resource
+	// BaudRate and DataFrame are the serial settings of an ASRL resource.
+	BaudRate  int
+	DataFrame string
+}
+
+// Settings combines the resource with a driver's connection defaults. A GPIB
+// resource without a primary address takes the driver's GPIB address, and a
+// SOCKET resource without a port takes the driver's "socket" LAN port, or
+// stays 0 when the driver lists none. An ASRL resource takes the driver's
+// baud rate and data frame. Settings returns an error wrapping
+// [ErrFunctionNotSupported] when the driver's model lacks the resource's
+// interface.
+func (r Resource) Settings(
+	defaults ConnectionDefaults,
+) (ConnectionSettings, error) {
+	name := interfaceNames[r.Interface]
+	if defaults.Interfaces != nil && !slices.Contains(defaults.Interfaces, name) {
+		return ConnectionSettings{}, fmt.Errorf(
+			"resource %s: no %s interface: %w", r, name, ErrFunctionNotSupported,
+		)
+	}
+
+	settings := ConnectionSettings{Resource: r}
+
+	switch r.Interface {
+	case InterfaceGPIB:
+		if r.PrimaryAddress < 0 {
+			settings.Resource.PrimaryAddress = defaults.GPIBAddress
+		}
+	case InterfaceTCPIP:
+		if r.Class == ClassSocket && r.Port == 0 {
+			settings.Resource.Port = defaults.LANPorts["socket"]
+		}
+	case InterfaceASRL:
+		settings.BaudRate = defaults.SerialBaudRate
+		settings.DataFrame = defaults.SerialDataFrame
+	}
+
+	return settings, nil
+}
+
+// interfaceNames maps interface types to the names drivers list in
+// AvailableCOMPorts.
+var interfaceNames = map[InterfaceType]string{
+	InterfaceTCPIP: "LAN",
+	InterfaceGPIB:  "GPIB",
+	InterfaceASRL:  "RS232",
+	InterfaceUSB:   "USB",
+}
+
+// isDigits reports whether s is all ASCII digits.
+func isDigits(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseResource(t *testing.T) {
+	tests := []struct {
+		name      string
+		resource  string
+		want      Resource
+		canonical string
+	}{
+		{
+			"tcpip socket",
+			"TCPIP0::10.0.0.5::5025::SOCKET",
+			Resource{
+				Interface: InterfaceTCPIP, Class: ClassSocket,
+				Host: "10.0.0.5", Port: 5025,
+				PrimaryAddress: -1, SecondaryAddress: -1, USBInterface: -1,
+			},
+			"TCPIP0::10.0.0.5::5025::SOCKET",
+		},
+		{
+			"tcpip socket without port",
+			"tcpip::scope.local::socket",
+			Resource{
+				Interface: InterfaceTCPIP, Class: ClassSocket,
+				Host:           "scope.local",
+				PrimaryAddress: -1, SecondaryAddress: -1, USBInterface: -1,
+			},
+			"TCPIP0::scope.local::SOCKET",
+		},
+		{
+			"tcpip hislip",
+			"TCPIP1::10.0.0.5::hislip0::INSTR",
+			Resource{
+				Interface: InterfaceTCPIP, Board: 1, Class: ClassINSTR,
+				Host: "10.0.0.5", LANDevice: "hislip0",
+				PrimaryAddress: -1, SecondaryAddress: -1, USBInterface: -1,
+			},
+			"TCPIP1::10.0.0.5::hislip0::INSTR",
+		},
+		{
+			"tcpip without class",
+			"TCPIP::10.0.0.5",
+			Resource{
+				Interface: InterfaceTCPIP, Class: ClassINSTR, Host: "10.0.0.5",
+				PrimaryAddress: -1, SecondaryAddress: -1, USBInterface: -1,
+			},
+			"TCPIP0::10.0.0.5::INSTR",
+		},
+		{
+			"gpib",
+			"GPIB0::5::INSTR",
+			Resource{
+				Interface: InterfaceGPIB, Class: ClassINSTR,
+				PrimaryAddress: 5, SecondaryAddress: -1, USBInterface: -1,
+			},
+			"GPIB0::5::INSTR",
+		},
+		{
+			"gpib secondary",
+			"GPIB2::5::3",
+			Resource{
+				Interface: InterfaceGPIB, Board: 2, Class: ClassINSTR,
+				PrimaryAddress: 5, SecondaryAddress: 3, USBInterface: -1,
+			},
+			"GPIB2::5::3::INSTR",
+		},
+		{
+			"gpib without address",
+			"GPIB0::INSTR",
+			Resource{
+				Interface: InterfaceGPIB, Class: ClassINSTR,
+				PrimaryAddress: -1, SecondaryAddress: -1, USBInterface: -1,
+			},
+			"GPIB0::INSTR",
+		},
+		{
+			"asrl board",
+			"ASRL1::INSTR",
+			Resource{
+				Interface: InterfaceASRL, Board: 1, Class: ClassINSTR,
+				PrimaryAddress: -1, SecondaryAddress: -1, USBInterface: -1,
+			},
+			"ASRL1::INSTR",
+		},
+		{
+			"asrl device",
+			"ASRL/dev/ttyUSB0::INSTR",
+			Resource{
+				Interface: InterfaceASRL, Class: ClassINSTR,
+				SerialDevice:   "/dev/ttyUSB0",
+				PrimaryAddress: -1, SecondaryAddress: -1, USBInterface: -1,
+			},
+			"ASRL/dev/ttyUSB0::INSTR",
+		},
+		{
+			"usb",
+			"USB0::0x0957::0x1796::MY56789012::INSTR",
+			Resource{
+				Interface: InterfaceUSB, Class: ClassINSTR,
+				ManufacturerID: 0x0957, ModelCode: 0x1796,
+				SerialNumber: "MY56789012", USBInterface: -1,
+				PrimaryAddress: -1, SecondaryAddress: -1,
+			},
+			"USB0::0x0957::0x1796::MY56789012::INSTR",
+		},
+		{
+			"usb decimal ids and interface",
+			"usb0::2391::6038::MY56789012::1",
+			Resource{
+				Interface: InterfaceUSB, Class: ClassINSTR,
+				ManufacturerID: 0x0957, ModelCode: 0x1796,
+				SerialNumber: "MY56789012", USBInterface: 1,
+				PrimaryAddress: -1, SecondaryAddress: -1,
+			},
+			"USB0::0x0957::0x1796::MY56789012::1::INSTR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseResource(tt.resource)
+			if err != nil {
+				t.Fatalf("ParseResource(%q) error: %v", tt.resource, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseResource(%q) = %+v, want %+v",
+					tt.resource, got, tt.want)
+			}
+			if s := got.String(); s != tt.canonical {
+				t.Errorf("String() = %q, want %q", s, tt.canonical)
+			}
+		})
+	}
+}
+
+func TestParseResource_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"INSTR",
+		"VXI0::1::INSTR",
+		"TCPIP0::::INSTR",
+		"TCPIP0::10.0.0.5::port::SOCKET",
+		"TCPIP0::10.0.0.5::70000::SOCKET",
+		"TCPIP0::10.0.0.5::5025::extra::SOCKET",
+		"TCPIPx::10.0.0.5::INSTR",
+		"GPIB0::31::INSTR",
+		"GPIB0::five::INSTR",
+		"GPIB0::5::SOCKET",
+		"ASRL1::9600::INSTR",
+		"USB0::0x0957::INSTR",
+		"USB0::0x10000::0x1796::MY56789012::INSTR",
+		"USB0::0x0957::0x1796::::INSTR",
+	}
+
+	for _, resource := range tests {
+		if _, err := ParseResource(resource); !errors.Is(
+			err, ErrInvalidResource,
+		) {
+			t.Errorf("ParseResource(%q) error = %v, want ErrInvalidResource",
+				resource, err)
+		}
+	}
+}
+
+func TestResource_Settings(t *testing.T) {
+	defaults := ConnectionDefaults{
+		Interfaces:      []string{"GPIB", "LAN", "RS232"},
+		GPIBAddress:     5,
+		LANPorts:        map[string]int{"socket": 5025, "telnet": 5024},
+		SerialBaudRates: []int{9600, 4800},
+		SerialBaudRate:  9600,
+		SerialDataFrame: "8N2",
+	}
+
+	tests := []struct {
+		resource  string
+		canonical string
+		baudRate  int
+		dataFrame string
+	}{
+		{"GPIB0::INSTR", "GPIB0::5::INSTR", 0, ""},
+		{"GPIB0::9::INSTR", "GPIB0::9::INSTR", 0, ""},
+		{
+			"TCPIP0::10.0.0.5::SOCKET",
+			"TCPIP0::10.0.0.5::5025::SOCKET", 0, "",
+		},
+		{
+			"TCPIP0::10.0.0.5::5555::SOCKET",
+			"TCPIP0::10.0.0.5::5555::SOCKET", 0, "",
+		},
+		{"ASRL/dev/ttyUSB0", "ASRL/dev/ttyUSB0::INSTR", 9600, "8N2"},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseResource(tt.resource)
+		if err != nil {
+			t.Fatalf("ParseResource(%q) error: %v", tt.resource, err)
+		}
+
+		got, err := r.Settings(defaults)
+		if err != nil {
+			t.Errorf("Settings(%q) error: %v", tt.resource, err)
+
+			continue
+		}
+		if s := got.Resource.String(); s != tt.canonical {
+			t.Errorf("Settings(%q).Resource = %q, want %q",
+				tt.resource, s, tt.canonical)
+		}
+		if got.BaudRate != tt.baudRate || got.DataFrame != tt.dataFrame {
+			t.Errorf("Settings(%q) serial = %d %q, want %d %q", tt.resource,
+				got.BaudRate, got.DataFrame, tt.baudRate, tt.dataFrame)
+		}
+	}
+
+	usb, err := ParseResource("USB0::0x0957::0x1796::MY56789012::INSTR")
+	if err != nil {
+		t.Fatalf("ParseResource() error: %v", err)
+	}
+	if _, err := usb.Settings(defaults); !errors.Is(
+		err, ErrFunctionNotSupported,
+	) {
+		t.Errorf("Settings(USB) error = %v, want ErrFunctionNotSupported", err)
+	}
+	if _, err := usb.Settings(ConnectionDefaults{}); err != nil {
+		t.Errorf("Settings(USB) with unknown interfaces error: %v", err)
+	}
+
+	socket, err := ParseResource("TCPIP0::10.0.0.5::SOCKET")
+	if err != nil {
+		t.Fatalf("ParseResource() error: %v", err)
+	}
+	if got, _ := socket.Settings(ConnectionDefaults{}); got.Resource.Port != 0 {
+		t.Errorf("Settings() without LAN ports: Port = %d, want 0",
+			got.Resource.Port)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"fmt"
+	"math"
+)
+
+// CheckSafetyLimit returns an error wrapping [ErrSafetyLimit] when the
+// magnitude of value exceeds limit, naming the quantity (e.g., "voltage") and
+// unit (e.g., "V") in the message. A limit of zero means the quantity is not
+// limited. NaN always exceeds a limit. The class packages' guards use it to
+// check settings against the limits of the device under test, which are
+// separate from the instrument's own ratings.
+func CheckSafetyLimit(quantity, unit string, value, limit float64) error {
+	if limit == 0 || math.Abs(value) <= limit {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: %s of %g %s exceeds the limit of %g %s",
+		ErrSafetyLimit, quantity, value, unit, limit, unit,
+	)
+}
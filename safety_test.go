@@ -0,0 +1,40 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCheckSafetyLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		limit float64
+		ok    bool
+	}{
+		{"below", 4.9, 5, true},
+		{"at", 5, 5, true},
+		{"above", 5.1, 5, false},
+		{"negative above", -5.1, 5, false},
+		{"unlimited", 1e6, 0, true},
+		{"NaN", math.NaN(), 5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSafetyLimit("voltage", "V", tt.value, tt.limit)
+			if tt.ok && err != nil {
+				t.Errorf("CheckSafetyLimit() error: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrSafetyLimit) {
+				t.Errorf("CheckSafetyLimit() error = %v, want ErrSafetyLimit", err)
+			}
+		})
+	}
+}
@@ -10,3 +10,45 @@
 // Files are split based on the class capability groups listed in Table 2-1
 // IviScope Group Names in the IVI-4.1 IviScope Class Specification.
 package scope
+
+import (
+	"reflect"
+
+	"github.com/gotmc/ivi"
+)
+
+// Groups lists the capability groups of the IviScope class, with the Go
+// interfaces that implement each, for [ivi.Introspect] and
+// [ivi.CheckGroupCapabilities].
+var Groups = []ivi.CapabilityGroup{
+	{
+		Name:    "IviScopeBase",
+		Driver:  reflect.TypeFor[Base](),
+		Channel: reflect.TypeFor[BaseChannel](),
+	},
+	{Name: "IviScopeInterpolation", Driver: reflect.TypeFor[Interpolation]()},
+	{Name: "IviScopeTVTrigger", Driver: reflect.TypeFor[TVTriggerer]()},
+	{Name: "IviScopeRuntTrigger", Driver: reflect.TypeFor[RuntTriggerer]()},
+	{Name: "IviScopeGlitchTrigger", Driver: reflect.TypeFor[GlitchTriggerer]()},
+	{Name: "IviScopeWidthTrigger", Driver: reflect.TypeFor[WidthTriggerer]()},
+	{Name: "IviScopeAcLineTrigger", Driver: reflect.TypeFor[ACLineTriggerer]()},
+	{
+		Name:    "IviScopeWaveformMeasurement",
+		Driver:  reflect.TypeFor[WaveformMeasurer](),
+		Channel: reflect.TypeFor[WaveformMeasurerChannel](),
+	},
+	{Name: "IviScopeAutoSetup", Driver: reflect.TypeFor[AutoSetup]()},
+}
+
+// Drivers holds the IviScope drivers, by the instrument models they support.
+// Each driver registers itself from an init function, so importing a driver
+// package, if only for its side effects, makes it available to Drivers.Open,
+// which picks the driver for the connected instrument's model, and to
+// Drivers.OpenDriver, which creates a driver by name. Both return the driver as
+// a Base; assert it to other IviScope interfaces, or to the driver's own type,
+// for the capabilities beyond the base group. See [ivi.Registry].
+//
+//	import _ "github.com/gotmc/ivi/scope/keysight/infiniivision"
+//
+//	osc, err := scope.Drivers.Open(ctx, inst)
+var Drivers ivi.Registry[Base]
@@ -5,6 +5,8 @@
 
 package scope
 
+import "context"
+
 /*
 
 # Section 18 IviScopeAutoSetup Extension Group
@@ -21,3 +23,9 @@ Below are the .NET functions, since they are the basis for the Go interfaces.
 18.2.1 void Measurement.AutoSetup()
 
 */
+
+// AutoSetup provides the interface required for the IviScopeAutoSetup
+// extension group.
+type AutoSetup interface {
+	AutoSetup(ctx context.Context) error
+}
@@ -30,6 +30,17 @@ const (
 // Confirm the implemented interfaces by the driver.
 var _ scope.Base = (*Driver)(nil)
 var _ scope.BaseChannel = (*Channel)(nil)
+var _ scope.AutoSetup = (*Driver)(nil)
+
+// Register the driver with [scope.Drivers] so that Open can select it by model,
+// and its connection defaults so that a config session can complete its
+// resource.
+func init() {
+	scope.Drivers.Register(
+		"infiniivision", supportedModels(), ivi.Factory[scope.Base](New),
+	)
+	ivi.RegisterConnectionDefaults("infiniivision", ConnectionDefaults())
+}
 
 // Driver provides the IVI driver for a Keysigh InfiniiVision family of
 // oscilloscopes.
@@ -47,6 +58,11 @@ type Channel struct {
 	name    string
 	num     int
 	timeout time.Duration
+	// bandwidth is the model's analog bandwidth in Hz, or 0 for a model
+	// whose bandwidth is not recorded in modelBandwidths.
+	bandwidth float64
+	// inherent is the driver's, which waits for acquisitions to complete.
+	inherent *ivi.Inherent
 }
 
 // New creates a new InfiniiVision IVI Instrument. By default the constructor
@@ -61,39 +77,67 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		ResetDelay:            defaultResetDelay,
 		ClearDelay:            defaultClearDelay,
 		ReturnToLocal:         true,
+		SupportsOPC:           true,
 		GroupCapabilities: []string{
 			"IviScopeBase",
-			"IviScopeWaveformMeasurement",
+			// "IviScopeWaveformMeasurement",
+			"IviScopeAutoSetup",
 		},
-		SupportedInstrumentModels: []string{"DSOX3024A", "DSOX3034A", "MSOX3024A", "MSOX3034A"},
+		SupportedInstrumentModels: supportedModels(),
 		SupportedBusInterfaces:    []string{"USB", "GPIB", "LAN"},
+		Simulation:                &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	// FIXME: Need to query the instrument for the model and then determine
-	// the number of channels based on the model returned.
-	channelNames := []string{"CHAN1", "CHAN2", "CHAN3", "CHAN4"}
-	channels := make([]Channel, len(channelNames))
-	for i, name := range channelNames {
-		channels[i] = Channel{name: name, inst: inst, num: i + 1, timeout: s.Timeout}
+	model, err := s.Inherent.InstrumentModel(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error determining instrument model: %w", err)
 	}
 
-	driver := Driver{
-		inst:     inst,
-		channels: channels,
+	driver := &Driver{
+		inst:     s.Transport,
 		timeout:  s.Timeout,
 		Inherent: s.Inherent,
 	}
 
+	// FIXME: Need to query the instrument for the model and then determine
+	// the number of channels based on the model returned.
+	channelNames := []string{"CHAN1", "CHAN2", "CHAN3", "CHAN4"}
+	driver.channels = make([]Channel, len(channelNames))
+	for i, name := range channelNames {
+		driver.channels[i] = Channel{
+			name:      name,
+			inst:      s.Transport,
+			num:       i + 1,
+			timeout:   s.Timeout,
+			bandwidth: modelBandwidths[model],
+			inherent:  &driver.Inherent,
+		}
+	}
+
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
-			return &driver, err
+		if err := driver.Reset(context.Background()); err != nil {
+			return driver, err
 		}
 	}
 
-	return &driver, nil
+	return driver, nil
+}
+
+// supportedModels returns the model numbers the driver supports.
+func supportedModels() []string {
+	return []string{"DSOX3024A", "DSOX3034A", "MSOX3024A", "MSOX3034A"}
+}
+
+// modelBandwidths holds the analog bandwidth in Hz of each supported model,
+// which a channel accommodates with its bandwidth limit filter off.
+var modelBandwidths = map[string]float64{
+	"DSOX3024A": 200e6,
+	"DSOX3034A": 350e6,
+	"MSOX3024A": 200e6,
+	"MSOX3034A": 350e6,
 }
 
 // Channel returns the Channel at the given index, with bounds checking.
@@ -105,14 +149,20 @@ func (d *Driver) Channel(index int) (*Channel, error) {
 	return &d.channels[index], nil
 }
 
-// newContext creates a context with the driver's configured timeout.
-func (d *Driver) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), d.timeout)
+// newContext derives a context from ctx that carries the driver's configured
+// timeout, unless ctx already has a deadline of its own.
+func (d *Driver) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, d.timeout)
 }
 
-// newContext creates a context with the channel's configured timeout.
-func (ch *Channel) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), ch.timeout)
+// newContext derives a context from ctx that carries the channel's configured
+// timeout, unless ctx already has a deadline of its own.
+func (ch *Channel) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, ch.timeout)
 }
 
 // Close properly shuts down the oscilloscope by returning it to local control.
@@ -124,3 +174,11 @@ func (d *Driver) Close() error {
 func DefaultGPIBAddress() int {
 	return defaultGPIBAddress
 }
+
+// ConnectionDefaults returns the model's connection defaults, which
+// complete a VISA resource string with [ivi.Resource.Settings].
+func ConnectionDefaults() ivi.ConnectionDefaults {
+	return ivi.ConnectionDefaults{
+		GPIBAddress: DefaultGPIBAddress(),
+	}
+}
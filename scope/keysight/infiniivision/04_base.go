@@ -6,7 +6,11 @@
 package infiniivision
 
 import (
+	"context"
+	"encoding/binary"
 	"fmt"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -61,6 +65,39 @@ var scpiToVerticalCoupling = map[string]scope.VerticalCoupling{
 	"DC": scope.DCVerticalCoupling,
 }
 
+var triggerSlopeToSCPI = map[scope.TriggerSlope]string{
+	scope.PositiveTriggerSlope: "POS",
+	scope.NegativeTriggerSlope: "NEG",
+}
+
+var scpiToTriggerSlope = map[string]scope.TriggerSlope{
+	"POS": scope.PositiveTriggerSlope,
+	"NEG": scope.NegativeTriggerSlope,
+}
+
+var triggerSourceToSCPI = map[scope.TriggerSource]string{
+	scope.TriggerSourceExternal: "EXT",
+}
+
+var scpiToTriggerSource = map[string]scope.TriggerSource{
+	"EXT": scope.TriggerSourceExternal,
+}
+
+// waveformPoints are the numbers of points :WAVeform:POINts accepts in MAXimum
+// points mode.
+var waveformPoints = []int{
+	100, 250, 500, 1000, 2000, 5000, 10000, 20000, 50000, 100000, 200000,
+	500000, 1000000, 2000000, 4000000, 8000000,
+}
+
+const (
+	// runningBit is the Run bit of the Operation Status Condition Register,
+	// set while the oscilloscope is acquiring.
+	runningBit = 1 << 3
+	// bandwidthLimit is the frequency of the channel bandwidth limit filter.
+	bandwidthLimit = 25e6
+)
+
 // AcquisitionStartTime (also referred to as the Horizontal Time Per Record in
 // the IVI specification) queries the length of time from the trigger event to
 // the first point in the waveform record. If the value is positive, the first
@@ -71,8 +108,10 @@ var scpiToVerticalCoupling = map[string]scope.VerticalCoupling{
 // AcquisitionStartTime is the getter for the read-write IviScopeBase
 // Acquisition Start Time described in Section 4.2.1 of the IVI-4.1: IviScope
 // Class Specification.
-func (d *Driver) AcquisitionStartTime() (time.Duration, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AcquisitionStartTime(
+	ctx context.Context,
+) (time.Duration, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	// The InfiniiVision 3000 X-series scopes have 10 divisions, and the
@@ -88,15 +127,14 @@ func (d *Driver) AcquisitionStartTime() (time.Duration, error) {
 		return 0, err
 	}
 
-	if timebase.mode != "MAIN" && timebase.reference != "CENT" && timebase.position != 0.0 {
-		// FIXME: I need to handle the abnormal situations.
-		return 0, ivi.ErrValueNotSupported
+	fraction, err := timebase.referenceFraction()
+	if err != nil {
+		return 0, err
 	}
 
-	// The reference is in the center, so per IVI scope, the acquisition start
-	// time is the negative value of seconds from the left (waveform start) to
-	// the center.
-	return durationFromSeconds(-timebase.rng / 2), nil
+	// The position is the time from the trigger to the reference, so the
+	// first point is the reference's share of the range before it.
+	return durationFromSeconds(timebase.position - fraction*timebase.rng), nil
 }
 
 // SetAcquisitionStartTime sets the length of time from the trigger event to
@@ -108,8 +146,37 @@ func (d *Driver) AcquisitionStartTime() (time.Duration, error) {
 // SetAcquisitionStartTime is the setter for the read-write IviScopeBase
 // Acquisition Start Time described in Section 4.2.1 of the IVI-4.1: IviScope
 // Class Specification.
-func (d *Driver) SetAcquisitionStartTime(delay time.Duration) error {
-	return ivi.ErrFunctionNotSupported
+func (d *Driver) SetAcquisitionStartTime(
+	ctx context.Context,
+	delay time.Duration,
+) error {
+	ctx, cancel := d.newContext(ctx)
+	defer cancel()
+
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetAcquisitionStartTime: %w", err)
+	}
+	defer unlock()
+
+	timebaseInfo, err := query.String(ctx, d.inst, ":TIM?")
+	if err != nil {
+		return err
+	}
+
+	timebase, err := decodeTimebase(timebaseInfo)
+	if err != nil {
+		return err
+	}
+
+	fraction, err := timebase.referenceFraction()
+	if err != nil {
+		return err
+	}
+
+	position := delay.Seconds() + fraction*timebase.rng
+
+	return d.inst.Command(ctx, ":TIM:POS %e", position)
 }
 
 // AcquisitionStatus indicates whether an acquisition is in progress, complete,
@@ -125,8 +192,22 @@ func (d *Driver) SetAcquisitionStartTime(delay time.Duration) error {
 // AcquisitionStatus is the getter for the read-only IviScopeBase Acquisition
 // Status described in Section 4.2.2 of the IVI-4.1: IviScope Class
 // Specification.
-func (d *Driver) AcquisitionStatus() (scope.AcquisitionStatus, error) {
-	return 0, ivi.ErrNotImplemented
+func (d *Driver) AcquisitionStatus(
+	ctx context.Context,
+) (scope.AcquisitionStatus, error) {
+	ctx, cancel := d.newContext(ctx)
+	defer cancel()
+
+	cond, err := query.Int(ctx, d.inst, ":OPER:COND?")
+	if err != nil {
+		return scope.AcquisitionStatusUnknown, err
+	}
+
+	if cond&runningBit != 0 {
+		return scope.AcquisitionInprogress, nil
+	}
+
+	return scope.AcquisitionComplete, nil
 }
 
 // AcquisitionType queries how the oscilloscope acquires data and fills the
@@ -135,8 +216,10 @@ func (d *Driver) AcquisitionStatus() (scope.AcquisitionStatus, error) {
 // AcquisitionType is the getter for the read-write IviScopeBase Acquisition
 // Type described in Section 4.2.3 of the IVI-4.1: IviScope Class
 // Specification.
-func (d *Driver) AcquisitionType() (scope.AcquisitionType, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AcquisitionType(
+	ctx context.Context,
+) (scope.AcquisitionType, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, d.inst, ":ACQ:TYPE?")
@@ -158,8 +241,11 @@ func (d *Driver) AcquisitionType() (scope.AcquisitionType, error) {
 // SetAcquisitionType is the setter for the read-write IviScopeBase Acquisition
 // Type described in Section 4.2.3 of the IVI-4.1: IviScope Class
 // Specification.
-func (d *Driver) SetAcquisitionType(acType scope.AcquisitionType) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetAcquisitionType(
+	ctx context.Context,
+	acType scope.AcquisitionType,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(acquisitionTypeToSCPI, acType)
@@ -181,6 +267,35 @@ func (d *Driver) ChannelCount() int {
 	return len(d.channels)
 }
 
+// ChannelName returns the name of the channel at index, which runs from 0 to
+// ChannelCount()-1.
+//
+// ChannelName is the getter for the read-only IviScopeBase Channel Name
+// described in Section 4.2.7 of the IVI-4.1: IviScope Class Specification.
+func (d *Driver) ChannelName(index int) (string, error) {
+	ch, err := d.Channel(index)
+	if err != nil {
+		return "", fmt.Errorf("ChannelName: %w", err)
+	}
+
+	return ch.Name(), nil
+}
+
+// ChannelItem returns the channel with the given name, as returned by
+// ChannelName.
+//
+// ChannelItem is the getter for the read-only IviScopeBase Channel Item
+// described in Section 4.2.6 of the IVI-4.1: IviScope Class Specification.
+func (d *Driver) ChannelItem(name string) (scope.BaseChannel, error) {
+	for i := range d.channels {
+		if d.channels[i].Name() == name {
+			return &d.channels[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("ChannelItem: %q: %w", name, ivi.ErrChannelNotFound)
+}
+
 // AcquisitionMinNumPoints returns the minimum number of points the end-user
 // requires in the waveform record for each channel. The instrument driver uses
 // the value the end-user specifies to configure the record length that the
@@ -192,8 +307,11 @@ func (d *Driver) ChannelCount() int {
 // AcquisitionMinNumPoints is the getter for the read-write IviScopeBase
 // Horizontal Minimum Number of Points described in Section 4.2.8 of the
 // IVI-4.1: IviScope Class Specification.
-func (d *Driver) AcquisitionMinNumPoints() (int, error) {
-	return 0, ivi.ErrNotImplemented
+func (d *Driver) AcquisitionMinNumPoints(ctx context.Context) (int, error) {
+	ctx, cancel := d.newContext(ctx)
+	defer cancel()
+
+	return query.Int(ctx, d.inst, ":WAV:POIN?")
 }
 
 // SetAcquisitionMinNumPoints sets the minimum number of points the end-user
@@ -207,8 +325,19 @@ func (d *Driver) AcquisitionMinNumPoints() (int, error) {
 // SetAcquisitionMinNumPoints is the setter for the read-write IviScopeBase
 // Horizontal Minimum Number of Points described in Section 4.2.8 of the
 // IVI-4.1: IviScope Class Specification.
-func (d *Driver) SetAcquisitionMinNumPoints(numPoints int) error {
-	return ivi.ErrNotImplemented
+func (d *Driver) SetAcquisitionMinNumPoints(
+	ctx context.Context,
+	numPoints int,
+) error {
+	ctx, cancel := d.newContext(ctx)
+	defer cancel()
+
+	points, err := roundUpWaveformPoints(numPoints)
+	if err != nil {
+		return err
+	}
+
+	return d.inst.Command(ctx, ":WAV:POIN:MODE MAX;:WAV:POIN %d", points)
 }
 
 // AcquisitionRecordLength queries the actual number of points the oscilloscope
@@ -219,8 +348,8 @@ func (d *Driver) SetAcquisitionMinNumPoints(numPoints int) error {
 // AcquisitionRecordLength is the getter for the read-only IviScopeBase
 // Horizontal Record Length described in Section 4.2.9 of the IVI-4.1: IviScope
 // Class Specification.
-func (d *Driver) AcquisitionRecordLength() (int, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AcquisitionRecordLength(ctx context.Context) (int, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Int(ctx, d.inst, ":ACQ:POIN?")
@@ -232,8 +361,8 @@ func (d *Driver) AcquisitionRecordLength() (int, error) {
 // AcquisitionSampleRate is the getter for the read-only IviScopeBase
 // Horizontal Sample Rate described in Section 4.2.10 of the IVI-4.1: IviScope
 // Class Specification.
-func (d *Driver) AcquisitionSampleRate() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AcquisitionSampleRate(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, ":ACQ:SRAT?")
@@ -245,8 +374,10 @@ func (d *Driver) AcquisitionSampleRate() (float64, error) {
 // AcquisitionTimePerRecord is the getter for the read-write IviScopeBase
 // Horizontal Time Per Record described in Section 4.2.11 of the IVI-4.1:
 // IviScope Class Specification.
-func (d *Driver) AcquisitionTimePerRecord() (time.Duration, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AcquisitionTimePerRecord(
+	ctx context.Context,
+) (time.Duration, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	seconds, err := query.Float64(ctx, d.inst, ":TIM:RANG?")
@@ -264,9 +395,10 @@ func (d *Driver) AcquisitionTimePerRecord() (time.Duration, error) {
 // Horizontal Time Per Record described in Section 4.2.11 of the IVI-4.1:
 // IviScope Class Specification.
 func (d *Driver) SetAcquisitionTimePerRecord(
+	ctx context.Context,
 	timePerRecord time.Duration,
 ) error {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, ":TIM:RANG %.4e", timePerRecord.Seconds())
@@ -284,8 +416,8 @@ func (d *Driver) SetAcquisitionTimePerRecord(
 //
 // TriggerHoldoff is the getter for the read-write IviScopeBase Trigger Holdoff
 // described in Section 4.2.18 of the IVI-4.1: IviScope Class Specification.
-func (d *Driver) TriggerHoldoff() (time.Duration, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TriggerHoldoff(ctx context.Context) (time.Duration, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	seconds, err := query.Float64(ctx, d.inst, ":TRIG:HOLD?")
@@ -296,8 +428,11 @@ func (d *Driver) TriggerHoldoff() (time.Duration, error) {
 	return durationFromSeconds(seconds), nil
 }
 
-func (d *Driver) SetTriggerHoldoff(holdoff time.Duration) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetTriggerHoldoff(
+	ctx context.Context,
+	holdoff time.Duration,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	const (
@@ -318,40 +453,118 @@ func (d *Driver) SetTriggerHoldoff(holdoff time.Duration) error {
 	return d.inst.Command(ctx, ":TRIG:HOLD %e", holdoff.Seconds())
 }
 
-func (d *Driver) TriggerLevel() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TriggerLevel(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64(ctx, d.inst, ":TRIG:EDGE:LEV?")
 }
 
-func (d *Driver) SetTriggerLevel(level float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetTriggerLevel(ctx context.Context, level float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, ":TRIG:EDGE:LEV %e", level)
 }
 
-func (d *Driver) TriggerSlope() (scope.TriggerSlope, error) {
-	return 0, ivi.ErrNotImplemented
+// TriggerSlope queries whether a rising or a falling edge triggers the
+// oscilloscope.
+//
+// TriggerSlope is the getter for the read-write IviScopeBase Attribute Trigger
+// Slope described in Section 4.2.20 of the IVI-4.1: IviScope Class
+// Specification.
+func (d *Driver) TriggerSlope(ctx context.Context) (scope.TriggerSlope, error) {
+	ctx, cancel := d.newContext(ctx)
+	defer cancel()
+
+	s, err := query.String(ctx, d.inst, ":TRIG:EDGE:SLOP?")
+	if err != nil {
+		return 0, err
+	}
+
+	slope, err := ivi.ReverseLookup(scpiToTriggerSlope, strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid trigger slope %q: %w", s, err)
+	}
+
+	return slope, nil
 }
 
-func (d *Driver) SetTriggerSlope(slope scope.TriggerSlope) error {
-	// Need to determine if in TV Trigger mode, because that has a different
-	// command.
-	return ivi.ErrNotImplemented
+// SetTriggerSlope specifies whether a rising or a falling edge triggers the
+// oscilloscope.
+//
+// SetTriggerSlope is the setter for the read-write IviScopeBase Attribute
+// Trigger Slope described in Section 4.2.20 of the IVI-4.1: IviScope Class
+// Specification.
+func (d *Driver) SetTriggerSlope(
+	ctx context.Context,
+	slope scope.TriggerSlope,
+) error {
+	ctx, cancel := d.newContext(ctx)
+	defer cancel()
+
+	cmd, err := ivi.LookupSCPI(triggerSlopeToSCPI, slope)
+	if err != nil {
+		return fmt.Errorf("trigger slope %v not supported: %w", slope, err)
+	}
+
+	return d.inst.Command(ctx, ":TRIG:EDGE:SLOP %s", cmd)
 }
 
-func (d *Driver) TriggerSource() (scope.TriggerSource, error) {
-	return 0, ivi.ErrNotImplemented
+// TriggerSource queries the source of the edge trigger. The
+// [scope.TriggerSource] values name no input channels, so a trigger on a
+// channel, the AC line, or the waveform generator returns an error wrapping
+// [ivi.ErrValueNotSupported].
+//
+// TriggerSource is the getter for the read-write IviScopeBase Attribute
+// Trigger Source described in Section 4.2.21 of the IVI-4.1: IviScope Class
+// Specification.
+func (d *Driver) TriggerSource(
+	ctx context.Context,
+) (scope.TriggerSource, error) {
+	ctx, cancel := d.newContext(ctx)
+	defer cancel()
+
+	s, err := query.String(ctx, d.inst, ":TRIG:EDGE:SOUR?")
+	if err != nil {
+		return 0, err
+	}
+
+	s = strings.TrimSpace(s)
+
+	source, ok := scpiToTriggerSource[s]
+	if !ok {
+		return 0, fmt.Errorf(
+			"trigger source %s: %w", s, ivi.ErrValueNotSupported,
+		)
+	}
+
+	return source, nil
 }
 
-func (d *Driver) SetTriggerSource(source scope.TriggerSource) error {
-	return ivi.ErrNotImplemented
+// SetTriggerSource specifies the source of the edge trigger. Only the
+// external trigger input is supported.
+//
+// SetTriggerSource is the setter for the read-write IviScopeBase Attribute
+// Trigger Source described in Section 4.2.21 of the IVI-4.1: IviScope Class
+// Specification.
+func (d *Driver) SetTriggerSource(
+	ctx context.Context,
+	source scope.TriggerSource,
+) error {
+	ctx, cancel := d.newContext(ctx)
+	defer cancel()
+
+	cmd, err := ivi.LookupSCPI(triggerSourceToSCPI, source)
+	if err != nil {
+		return fmt.Errorf("trigger source %v not supported: %w", source, err)
+	}
+
+	return d.inst.Command(ctx, ":TRIG:EDGE:SOUR %s", cmd)
 }
 
-func (d *Driver) TriggerType() (scope.TriggerType, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TriggerType(ctx context.Context) (scope.TriggerType, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	mode, err := query.String(ctx, d.inst, ":TRIG:MODE?")
@@ -367,10 +580,19 @@ func (d *Driver) TriggerType() (scope.TriggerType, error) {
 	return trigType, nil
 }
 
-func (d *Driver) SetTriggerType(triggerType scope.TriggerType) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetTriggerType(
+	ctx context.Context,
+	triggerType scope.TriggerType,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetTriggerType: %w", err)
+	}
+	defer unlock()
+
 	// ImmediateTrigger uses a different command pattern.
 	if triggerType == scope.ImmediateTrigger {
 		return d.inst.Command(ctx, ":TRIG:FORC")
@@ -384,43 +606,123 @@ func (d *Driver) SetTriggerType(triggerType scope.TriggerType) error {
 	return d.inst.Command(ctx, ":TRIG:MODE %s", cmd)
 }
 
-func (d *Driver) AbortMeasurement() error {
-	return ivi.ErrNotImplemented
+// AbortMeasurement aborts an acquisition and returns the oscilloscope to the
+// idle state by stopping it.
+//
+// AbortMeasurement implements the IviScopeBase function described in Section
+// 4.3.1 of IVI-4.1: IviScope Class Specification.
+func (d *Driver) AbortMeasurement(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
+	defer cancel()
+
+	return d.inst.Command(ctx, ":STOP")
 }
 
+// ConfigureAcquisitionRecord configures the timebase range and position and
+// the number of waveform points. The start time is set after the range, since
+// the position that gives it depends on the range.
+//
+// ConfigureAcquisitionRecord implements the IviScopeBase function described
+// in Section 4.3.4 of IVI-4.1: IviScope Class Specification.
 func (d *Driver) ConfigureAcquisitionRecord(
+	ctx context.Context,
 	timePerRecord time.Duration,
 	minNumPoints int,
 	acquisitionStartTime time.Duration,
 ) error {
-	return ivi.ErrNotImplemented
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureAcquisitionRecord: %w", err)
+	}
+	defer unlock()
+
+	if err := d.SetAcquisitionTimePerRecord(ctx, timePerRecord); err != nil {
+		return err
+	}
+
+	if err := d.SetAcquisitionMinNumPoints(ctx, minNumPoints); err != nil {
+		return err
+	}
+
+	return d.SetAcquisitionStartTime(ctx, acquisitionStartTime)
 }
 
-func (d *Driver) CreateWaveform(numSamples int) error {
-	return ivi.ErrNotImplemented
+// CreateWaveform checks the oscilloscope can return a waveform of numSamples
+// points. There is nothing to allocate, since [Channel.FetchWaveform] sizes
+// the waveform it fills to the points returned.
+//
+// CreateWaveform implements the IviScopeBase function described in Section
+// 4.3.7 of IVI-4.1: IviScope Class Specification.
+func (d *Driver) CreateWaveform(ctx context.Context, numSamples int) error {
+	_, err := roundUpWaveformPoints(numSamples)
+	return err
 }
 
+// ConfigureEdgeTrigger configures the oscilloscope to trigger on an edge of
+// the given slope crossing the trigger level. The trigger type must be
+// [scope.EdgeTrigger].
+//
+// ConfigureEdgeTrigger implements the IviScopeBase function described in
+// Section 4.3.9 of IVI-4.1: IviScope Class Specification.
 func (d *Driver) ConfigureEdgeTrigger(
+	ctx context.Context,
 	triggerType scope.TriggerType,
 	level float64,
 	slope scope.TriggerSlope,
 ) error {
-	return ivi.ErrNotImplemented
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureEdgeTrigger: %w", err)
+	}
+	defer unlock()
+
+	if triggerType != scope.EdgeTrigger {
+		return fmt.Errorf(
+			"%w: edge trigger configured as %s",
+			ivi.ErrValueNotSupported, triggerType,
+		)
+	}
+
+	if err := d.SetTriggerType(ctx, triggerType); err != nil {
+		return err
+	}
+
+	if err := d.SetTriggerLevel(ctx, level); err != nil {
+		return err
+	}
+
+	return d.SetTriggerSlope(ctx, slope)
 }
 
 func (d *Driver) ConfigureTrigger(
+	ctx context.Context,
 	triggerType scope.TriggerType,
 	holdoff time.Duration,
 ) error {
-	if err := d.SetTriggerType(triggerType); err != nil {
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureTrigger: %w", err)
+	}
+	defer unlock()
+
+	if err := d.SetTriggerType(ctx, triggerType); err != nil {
 		return err
 	}
 
-	return d.SetTriggerHoldoff(holdoff)
+	return d.SetTriggerHoldoff(ctx, holdoff)
 }
 
-func (d *Driver) InitiateMeasurement() error {
-	return ivi.ErrNotImplemented
+// InitiateMeasurement starts a single acquisition, after which the
+// oscilloscope stops. Poll [Driver.AcquisitionStatus] for the acquisition to
+// complete before fetching waveforms.
+//
+// InitiateMeasurement implements the IviScopeBase function described in
+// Section 4.3.14 of IVI-4.1: IviScope Class Specification.
+func (d *Driver) InitiateMeasurement(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
+	defer cancel()
+
+	return d.inst.Command(ctx, ":SING")
 }
 
 // ChannelEnabled queries whether or not the oscilloscope acquires a waveform for
@@ -429,8 +731,8 @@ func (d *Driver) InitiateMeasurement() error {
 // ChannelEnabled is the getter for the read-write IviScopeBase Attribute
 // Channel Enabled described in Section 4.2.5 of the IVI-4.1: IviScope Class
 // Specification.
-func (ch *Channel) ChannelEnabled() (bool, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) ChannelEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Boolf(ctx, ch.inst, ":CHAN%d:DISP?", ch.num)
@@ -442,8 +744,8 @@ func (ch *Channel) ChannelEnabled() (bool, error) {
 // Enabled is the setter for the read-write IviScopeBase Attribute Channel
 // Enabled described in Section 4.2.5 of the IVI-4.1: IviScope Class
 // Specification.
-func (ch *Channel) SetChannelEnabled(b bool) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetChannelEnabled(ctx context.Context, b bool) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	cmd := "1"
@@ -468,8 +770,8 @@ func (ch *Channel) Name() string {
 // InputImpedance is the getter for the read-write IviScopeBase Attribute Input
 // Impedance described in Section 4.2.12 of the IVI-4.1: IviScope Class
 // Specification.
-func (ch *Channel) InputImpedance() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) InputImpedance(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	imped, err := query.Stringf(ctx, ch.inst, ":CHAN%d:IMP?", ch.num)
@@ -493,10 +795,19 @@ func (ch *Channel) InputImpedance() (float64, error) {
 // SetInputImpedance is the setter for the read-write IviScopeBase Attribute
 // Input Impedance described in Section 4.2.12 of the IVI-4.1: IviScope Class
 // Specification.
-func (ch *Channel) SetInputImpedance(impedance float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetInputImpedance(
+	ctx context.Context,
+	impedance float64,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("SetInputImpedance: %w", err)
+	}
+	defer unlock()
+
 	switch impedance {
 	case fiftyOhms:
 		return ch.inst.Command(ctx, ":CHAN%d:IMP FIFT", ch.num)
@@ -507,22 +818,64 @@ func (ch *Channel) SetInputImpedance(impedance float64) error {
 	}
 }
 
-// MaxInputFrequency queries the Specifies the maximum frequency for the input
-// signal you want the instrument to accommodate without attenuating it by more
-// than 3dB. If the bandwidth limit frequency of the instrument is greater than
-// this maximum frequency, the driver enables the bandwidth limit. This
-// attenuates the input signal by at least 3dB at frequencies greater than the
-// bandwidth limit.
+// MaxInputFrequency queries the maximum frequency for the input signal the
+// channel accommodates without attenuating it by more than 3dB: 25 MHz with
+// the channel's bandwidth limit filter on, and the model's bandwidth with it
+// off. For a model whose bandwidth the driver does not record, it returns an
+// error wrapping [ivi.ErrFunctionNotSupported] when the filter is off.
 //
-// MaxInputImpedance is the getter for the read-write IviScopeBase Attribute
-// Maximum Input Impedance described in Section 4.2.13 of the IVI-4.1: IviScope
-// Class Specification.
-func (ch *Channel) MaxInputFrequency() (float64, error) {
-	return 0.0, ivi.ErrNotImplemented
+// MaxInputFrequency is the getter for the read-write IviScopeBase Attribute
+// Maximum Input Frequency described in Section 4.2.13 of the IVI-4.1:
+// IviScope Class Specification.
+func (ch *Channel) MaxInputFrequency(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
+	defer cancel()
+
+	limited, err := query.Boolf(ctx, ch.inst, ":CHAN%d:BWL?", ch.num)
+	if err != nil {
+		return 0, fmt.Errorf("MaxInputFrequency: %w", err)
+	}
+
+	if limited {
+		return bandwidthLimit, nil
+	}
+
+	if ch.bandwidth == 0 {
+		return 0, fmt.Errorf(
+			"MaxInputFrequency: bandwidth of the model not known: %w",
+			ivi.ErrFunctionNotSupported,
+		)
+	}
+
+	return ch.bandwidth, nil
 }
 
-func (ch *Channel) SetMaxInputFrequency(_ float64) error {
-	return ivi.ErrNotImplemented
+// SetMaxInputFrequency specifies the maximum frequency for the input signal
+// you want the instrument to accommodate without attenuating it by more than
+// 3dB. The channel's 25 MHz bandwidth limit is enabled for a frequency at or
+// below it, and disabled otherwise.
+//
+// SetMaxInputFrequency is the setter for the read-write IviScopeBase Attribute
+// Maximum Input Frequency described in Section 4.2.13 of the IVI-4.1:
+// IviScope Class Specification.
+func (ch *Channel) SetMaxInputFrequency(
+	ctx context.Context,
+	freq float64,
+) error {
+	ctx, cancel := ch.newContext(ctx)
+	defer cancel()
+
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("SetMaxInputFrequency: %w", err)
+	}
+	defer unlock()
+
+	if freq <= bandwidthLimit {
+		return ch.inst.Command(ctx, ":CHAN%d:BWL 1", ch.num)
+	}
+
+	return ch.inst.Command(ctx, ":CHAN%d:BWL 0", ch.num)
 }
 
 // ProbeAttenuation queries the scaling factor by which the probe the end-user
@@ -536,8 +889,8 @@ func (ch *Channel) SetMaxInputFrequency(_ float64) error {
 // ProbeAttenuation is the getter for the read-write IviScopeBase Probe
 // Attenuation described in Section 4.2.16 of the IVI-4.1: IviScope Class
 // Specification.
-func (ch *Channel) ProbeAttenuation() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) ProbeAttenuation(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64f(ctx, ch.inst, ":CHAN%d:PROBE?", ch.num)
@@ -554,8 +907,11 @@ func (ch *Channel) ProbeAttenuation() (float64, error) {
 // SetProbeAttenuation is the setter for the read-write IviScopeBase Probe
 // Attenuation described in Section 4.2.16 of the IVI-4.1: IviScope Class
 // Specification.
-func (ch *Channel) SetProbeAttenuation(atten float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetProbeAttenuation(
+	ctx context.Context,
+	atten float64,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	if atten < 0.001 || atten > 10000.0 {
@@ -570,7 +926,7 @@ func (ch *Channel) SetProbeAttenuation(atten float64) error {
 // ProbeAttenuationAuto is the getter for the read-write IviScopeBase Probe
 // Attenuation described in Section 4.2.16 of the IVI-4.1: IviScope Class
 // Specification.
-func (ch *Channel) ProbeAttenuationAuto() (bool, error) {
+func (ch *Channel) ProbeAttenuationAuto(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
@@ -580,7 +936,7 @@ func (ch *Channel) ProbeAttenuationAuto() (bool, error) {
 // SetProbeAttenuationAuto is the setter for the read-write IviScopeBase Probe
 // Attenuation described in Section 4.2.16 of the IVI-4.1: IviScope Class
 // Specification.
-func (ch *Channel) SetProbeAttenuationAuto(b bool) error {
+func (ch *Channel) SetProbeAttenuationAuto(ctx context.Context, b bool) error {
 	if b {
 		return ivi.ErrValueNotSupported
 	}
@@ -588,16 +944,23 @@ func (ch *Channel) SetProbeAttenuationAuto(b bool) error {
 	return nil
 }
 
-func (ch *Channel) TriggerCoupling() (scope.TriggerCoupling, error) {
+func (ch *Channel) TriggerCoupling(
+	ctx context.Context,
+) (scope.TriggerCoupling, error) {
 	return 0, ivi.ErrNotImplemented
 }
 
-func (ch *Channel) SetTriggerCoupling(coupling scope.TriggerCoupling) error {
+func (ch *Channel) SetTriggerCoupling(
+	ctx context.Context,
+	coupling scope.TriggerCoupling,
+) error {
 	return ivi.ErrNotImplemented
 }
 
-func (ch *Channel) VerticalCoupling() (scope.VerticalCoupling, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) VerticalCoupling(
+	ctx context.Context,
+) (scope.VerticalCoupling, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	coupling, err := query.Stringf(ctx, ch.inst, ":CHAN%d:COUP?", ch.num)
@@ -613,8 +976,11 @@ func (ch *Channel) VerticalCoupling() (scope.VerticalCoupling, error) {
 	return vc, nil
 }
 
-func (ch *Channel) SetVerticalCoupling(coupling scope.VerticalCoupling) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetVerticalCoupling(
+	ctx context.Context,
+	coupling scope.VerticalCoupling,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(verticalCouplingToSCPI, coupling)
@@ -632,8 +998,8 @@ func (ch *Channel) SetVerticalCoupling(coupling scope.VerticalCoupling) error {
 //
 // VerticalOffset is the getter for the read-write IviScopeBase Vertical Offset
 // described in Section 4.2.24 of the IVI-4.1: IviScope Class Specification.
-func (ch *Channel) VerticalOffset() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) VerticalOffset(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64f(ctx, ch.inst, ":CHAN%d:OFFS?", ch.num)
@@ -647,8 +1013,11 @@ func (ch *Channel) VerticalOffset() (float64, error) {
 // SetVerticalOffset is the setter for the read-write IviScopeBase Vertical
 // Offset described in Section 4.2.24 of the IVI-4.1: IviScope Class
 // Specification.
-func (ch *Channel) SetVerticalOffset(offset float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetVerticalOffset(
+	ctx context.Context,
+	offset float64,
+) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return ch.inst.Command(ctx, ":CHAN%d:OFFS %E", ch.num, offset)
@@ -660,8 +1029,8 @@ func (ch *Channel) SetVerticalOffset(offset float64) error {
 //
 // VerticalRange is the getter for the read-write IviScopeBase Vertical Range
 // described in Section 4.2.25 of the IVI-4.1: IviScope Class Specification.
-func (ch *Channel) VerticalRange() (float64, error) {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) VerticalRange(ctx context.Context) (float64, error) {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	return query.Float64f(ctx, ch.inst, ":CHAN%d:RANG?", ch.num)
@@ -675,8 +1044,8 @@ func (ch *Channel) VerticalRange() (float64, error) {
 // SetVerticalRange is the setter for the read-write IviScopeBase Vertical
 // Range described in Section 4.2.25 of the IVI-4.1: IviScope Class
 // Specification.
-func (ch *Channel) SetVerticalRange(rng float64) error {
-	ctx, cancel := ch.newContext()
+func (ch *Channel) SetVerticalRange(ctx context.Context, rng float64) error {
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	if rng < 0.008 || rng > 40.0 {
@@ -687,6 +1056,7 @@ func (ch *Channel) SetVerticalRange(rng float64) error {
 }
 
 func (ch *Channel) Configure(
+	ctx context.Context,
 	rng float64,
 	offset float64,
 	coupling scope.VerticalCoupling,
@@ -694,46 +1064,319 @@ func (ch *Channel) Configure(
 	probeAttenuation float64,
 	enabled bool,
 ) error {
-	if err := ch.SetVerticalRange(rng); err != nil {
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("Configure: %w", err)
+	}
+	defer unlock()
+
+	if err := ch.SetVerticalRange(ctx, rng); err != nil {
 		return err
 	}
 
-	if err := ch.SetVerticalOffset(offset); err != nil {
+	if err := ch.SetVerticalOffset(ctx, offset); err != nil {
 		return err
 	}
 
-	if err := ch.SetVerticalCoupling(coupling); err != nil {
+	if err := ch.SetVerticalCoupling(ctx, coupling); err != nil {
 		return err
 	}
 
 	if !autoProbeAttenuation {
-		if err := ch.SetProbeAttenuation(probeAttenuation); err != nil {
+		if err := ch.SetProbeAttenuation(ctx, probeAttenuation); err != nil {
 			return err
 		}
 	}
 
-	return ch.SetChannelEnabled(enabled)
+	return ch.SetChannelEnabled(ctx, enabled)
 }
 
+// ConfigureCharacteristics configures the input impedance and the maximum
+// input frequency of the channel.
+//
+// ConfigureCharacteristics implements the IviScopeBase function described in
+// Section 4.3.8 of IVI-4.1: IviScope Class Specification.
 func (ch *Channel) ConfigureCharacteristics(
-	inputImepdance, inputFreqMax float64,
+	ctx context.Context,
+	inputImpedance, inputFreqMax float64,
 ) error {
-	return ivi.ErrNotImplemented
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureCharacteristics: %w", err)
+	}
+	defer unlock()
+
+	if err := ch.SetInputImpedance(ctx, inputImpedance); err != nil {
+		return err
+	}
+
+	return ch.SetMaxInputFrequency(ctx, inputFreqMax)
 }
 
-func (ch *Channel) FetchWaveform(waveform *ivi.Waveform) error {
-	return ivi.ErrNotImplemented
+// FetchWaveform fills waveform with the channel's most recently acquired
+// waveform, in volts on a time axis in seconds relative to the trigger. Points
+// the oscilloscope did not acquire are marked [ivi.SampleInvalid] and points
+// clipped at the top or bottom of the screen [ivi.SampleOverRange] or
+// [ivi.SampleUnderRange].
+//
+// FetchWaveform implements the IviScopeBase function described in Section
+// 4.3.13 of IVI-4.1: IviScope Class Specification.
+func (ch *Channel) FetchWaveform(
+	ctx context.Context,
+	waveform *ivi.Waveform,
+) error {
+	ctx, cancel := ch.newContext(ctx)
+	defer cancel()
+
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("FetchWaveform: %w", err)
+	}
+	defer unlock()
+
+	if err := ch.inst.Command(
+		ctx, ":WAV:SOUR %s;FORM WORD;BYT MSBF;UNS 1", ch.name,
+	); err != nil {
+		return fmt.Errorf("FetchWaveform: %w", err)
+	}
+
+	resp, err := query.String(ctx, ch.inst, ":WAV:PRE?")
+	if err != nil {
+		return fmt.Errorf("FetchWaveform: %w", err)
+	}
+
+	pre, err := decodePreamble(resp)
+	if err != nil {
+		return fmt.Errorf("FetchWaveform: %w", err)
+	}
+
+	if err := ch.inst.Command(ctx, ":WAV:DATA?"); err != nil {
+		return fmt.Errorf("FetchWaveform: %w", err)
+	}
+
+	data, err := ivi.ReadBlock(ctx, ch.inst)
+	if err != nil {
+		return fmt.Errorf("FetchWaveform: %w", err)
+	}
+
+	if err := ivi.CheckInstrumentStatus(ctx, ch.inst); err != nil {
+		return fmt.Errorf("FetchWaveform: %w", err)
+	}
+
+	codes, err := pre.codes(data)
+	if err != nil {
+		return fmt.Errorf("FetchWaveform: %w", err)
+	}
+
+	if len(codes) != pre.points {
+		return fmt.Errorf(
+			"FetchWaveform: %w: %d points of data, preamble says %d",
+			ivi.ErrUnexpectedResponse, len(codes), pre.points,
+		)
+	}
+
+	items := make([]float64, len(codes))
+	for i, code := range codes {
+		items[i] = pre.volts(code)
+	}
+
+	waveform.Configure(items, ivi.WaveformInfo{
+		InitialX:   pre.seconds(0),
+		XIncrement: pre.xIncrement,
+		XUnits:     "s",
+		YUnits:     "V",
+		Timestamp:  time.Now(),
+		Source:     ch.name,
+	})
+
+	for i, code := range codes {
+		waveform.SetStatus(i, pre.status(code))
+	}
+
+	return nil
 }
 
+// ReadWaveform acquires a single waveform on the channel with :DIGitize,
+// waiting up to maximumTime for the acquisition to complete, and then fills
+// waveform as [Channel.FetchWaveform] does. The wait is never shorter than
+// the driver's I/O timeout, and a deadline on ctx that comes sooner still
+// applies. Digitizing stops the oscilloscope running.
+//
+// ReadWaveform implements the IviScopeBase function described in Section
+// 4.3.16 of IVI-4.1: IviScope Class Specification.
 func (ch *Channel) ReadWaveform(
+	ctx context.Context,
 	maximumTime time.Duration,
 	waveform *ivi.Waveform,
 ) error {
-	return ivi.ErrNotImplemented
+	// Hold the session from the acquisition through the fetch, so that no
+	// other channel can digitize or fetch in between.
+	ctx, unlock, err := ivi.LockSession(ctx, ch.inst, ch.timeout)
+	if err != nil {
+		return fmt.Errorf("ReadWaveform: %w", err)
+	}
+	defer unlock()
+
+	if err := ch.digitize(ctx, maximumTime); err != nil {
+		return fmt.Errorf("ReadWaveform: %w", err)
+	}
+
+	return ch.FetchWaveform(ctx, waveform)
 }
 
+// digitize acquires a single waveform on the channel, waiting up to maxTime
+// for the acquisition to complete.
+func (ch *Channel) digitize(ctx context.Context, maxTime time.Duration) error {
+	ctx, cancel := ch.measure(ctx, maxTime)
+	defer cancel()
+
+	if err := ch.inst.Command(ctx, ":DIG %s", ch.name); err != nil {
+		return err
+	}
+
+	return ch.inherent.WaitForOperationComplete(ctx)
+}
+
+// measure returns a context for an acquisition lasting up to maxTime, or the
+// I/O timeout when that is longer. Unlike newContext it applies even when ctx
+// has a deadline of its own, so that the earlier of the two wins.
+func (ch *Channel) measure(
+	ctx context.Context,
+	maxTime time.Duration,
+) (context.Context, context.CancelFunc) {
+	timeout := max(maxTime, ch.timeout)
+	if timeout <= 0 {
+		timeout = ivi.DefaultTimeout
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// durationFromSeconds converts seconds to the nearest nanosecond.
 func durationFromSeconds(seconds float64) time.Duration {
-	return time.Duration(seconds * float64(time.Second))
+	return time.Duration(math.Round(seconds * float64(time.Second)))
+}
+
+// roundUpWaveformPoints returns the smallest number of waveform points the
+// oscilloscope accepts that is at least numPoints.
+func roundUpWaveformPoints(numPoints int) (int, error) {
+	i, _ := slices.BinarySearch(waveformPoints, numPoints)
+	if numPoints < 1 || i == len(waveformPoints) {
+		return 0, fmt.Errorf(
+			"%w: %d waveform points, must be between 1 and %d",
+			ivi.ErrValueNotSupported, numPoints,
+			waveformPoints[len(waveformPoints)-1],
+		)
+	}
+
+	return waveformPoints[i], nil
+}
+
+// Waveform data formats reported in the :WAVeform:PREamble.
+const (
+	byteFormat = 0
+	wordFormat = 1
+)
+
+// preamble holds the :WAVeform:PREamble, which describes how to convert the
+// unsigned codes of :WAVeform:DATA to time and voltage.
+type preamble struct {
+	format     int
+	points     int
+	xIncrement float64
+	xOrigin    float64
+	xReference float64
+	yIncrement float64
+	yOrigin    float64
+	yReference float64
+}
+
+// decodePreamble decodes the ten comma separated fields of the preamble:
+// format, type, points, count, and the x and y increment, origin, and
+// reference.
+func decodePreamble(s string) (preamble, error) {
+	parts := strings.Split(strings.TrimSpace(s), ",")
+	if len(parts) != 10 {
+		return preamble{}, fmt.Errorf(
+			"%w: preamble has %d fields, want 10",
+			ivi.ErrUnexpectedResponse, len(parts),
+		)
+	}
+
+	values := make([]float64, len(parts))
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return preamble{}, fmt.Errorf(
+				"%w: preamble field %q", ivi.ErrUnexpectedResponse, part,
+			)
+		}
+
+		values[i] = v
+	}
+
+	return preamble{
+		format:     int(values[0]),
+		points:     int(values[2]),
+		xIncrement: values[4],
+		xOrigin:    values[5],
+		xReference: values[6],
+		yIncrement: values[7],
+		yOrigin:    values[8],
+		yReference: values[9],
+	}, nil
+}
+
+// codes decodes waveform data in the preamble's format as unsigned codes,
+// widening BYTE data to the upper byte of a WORD so both share the code
+// values marking holes and clipping.
+func (p preamble) codes(data []byte) ([]uint16, error) {
+	switch p.format {
+	case wordFormat:
+		return ivi.DecodeBlockValues[uint16](data, binary.BigEndian)
+	case byteFormat:
+		codes := make([]uint16, len(data))
+		for i, b := range data {
+			codes[i] = uint16(b) << 8
+		}
+
+		return codes, nil
+	default:
+		return nil, fmt.Errorf(
+			"%w: waveform format %d", ivi.ErrUnexpectedResponse, p.format,
+		)
+	}
+}
+
+// volts converts a code to volts, scaling BYTE data back from the upper byte
+// of a WORD.
+func (p preamble) volts(code uint16) float64 {
+	v := float64(code)
+	if p.format == byteFormat {
+		v /= 256
+	}
+
+	return (v-p.yReference)*p.yIncrement + p.yOrigin
+}
+
+// seconds returns the time in seconds of the given point.
+func (p preamble) seconds(point int) float64 {
+	return (float64(point)-p.xReference)*p.xIncrement + p.xOrigin
+}
+
+// status classifies a code by its upper byte: 0 is a hole where no data was
+// acquired, 1 is clipped low, and 255 clipped high.
+func (preamble) status(code uint16) ivi.SampleStatus {
+	switch code >> 8 {
+	case 0:
+		return ivi.SampleInvalid
+	case 1:
+		return ivi.SampleUnderRange
+	case 0xff:
+		return ivi.SampleOverRange
+	default:
+		return ivi.SampleValid
+	}
 }
 
 type timebase struct {
@@ -743,6 +1386,30 @@ type timebase struct {
 	position  float64
 }
 
+// referenceFraction returns the fraction of the range from the left of the
+// screen to the timebase reference, which is at the center or one of the ten
+// divisions in from the left or right.
+func (tb timebase) referenceFraction() (float64, error) {
+	if tb.mode != "MAIN" {
+		return 0, fmt.Errorf(
+			"%w: timebase mode %s", ivi.ErrValueNotSupported, tb.mode,
+		)
+	}
+
+	switch tb.reference {
+	case "LEFT":
+		return 0.1, nil
+	case "CENT":
+		return 0.5, nil
+	case "RIGH":
+		return 0.9, nil
+	default:
+		return 0, fmt.Errorf(
+			"%w: timebase reference %s", ivi.ErrValueNotSupported, tb.reference,
+		)
+	}
+}
+
 func decodeTimebase(s string) (timebase, error) {
 	parts := strings.Split(s, ";")
 	if len(parts) != 4 {
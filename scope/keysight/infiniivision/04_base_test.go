@@ -6,8 +6,15 @@
 package infiniivision
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -71,7 +78,7 @@ func TestDriver_AcquisitionType(t *testing.T) {
 			mock := &ivitest.Mock{QueryResp: tt.resp}
 			d := newTestDriver(mock)
 
-			got, err := d.AcquisitionType()
+			got, err := d.AcquisitionType(t.Context())
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -107,7 +114,7 @@ func TestDriver_SetAcquisitionType(t *testing.T) {
 			mock := &ivitest.Mock{}
 			d := newTestDriver(mock)
 
-			err := d.SetAcquisitionType(tt.acType)
+			err := d.SetAcquisitionType(t.Context(), tt.acType)
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -144,7 +151,7 @@ func TestDriver_TriggerType(t *testing.T) {
 			mock := &ivitest.Mock{QueryResp: tt.resp}
 			d := newTestDriver(mock)
 
-			got, err := d.TriggerType()
+			got, err := d.TriggerType(t.Context())
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -179,7 +186,7 @@ func TestDriver_SetTriggerType(t *testing.T) {
 			mock := &ivitest.Mock{}
 			d := newTestDriver(mock)
 
-			err := d.SetTriggerType(tt.trig)
+			err := d.SetTriggerType(t.Context(), tt.trig)
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -199,7 +206,7 @@ func TestDriver_SetTriggerType(t *testing.T) {
 func TestDriver_SetTriggerLevel(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
-	err := d.SetTriggerLevel(1.5)
+	err := d.SetTriggerLevel(t.Context(), 1.5)
 	if err != nil {
 		t.Errorf("SetTriggerLevel() error: %v", err)
 	}
@@ -225,7 +232,7 @@ func TestDriver_SetTriggerHoldoff(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			d := newTestDriver(mock)
-			err := d.SetTriggerHoldoff(tt.holdoff)
+			err := d.SetTriggerHoldoff(t.Context(), tt.holdoff)
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -253,7 +260,7 @@ func TestChannel_SetChannelEnabled(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			ch := Channel{inst: mock, name: "CHAN1", num: 1}
-			err := ch.SetChannelEnabled(tt.enabled)
+			err := ch.SetChannelEnabled(t.Context(), tt.enabled)
 			if err != nil {
 				t.Errorf("SetChannelEnabled() error: %v", err)
 			}
@@ -280,7 +287,7 @@ func TestChannel_SetInputImpedance(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			ch := Channel{inst: mock, name: "CHAN1", num: 1}
-			err := ch.SetInputImpedance(tt.impedance)
+			err := ch.SetInputImpedance(t.Context(), tt.impedance)
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -314,7 +321,7 @@ func TestChannel_SetVerticalRange(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			ch := Channel{inst: mock, name: "CHAN1", num: 1}
-			err := ch.SetVerticalRange(tt.rng)
+			err := ch.SetVerticalRange(t.Context(), tt.rng)
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -347,7 +354,7 @@ func TestChannel_SetProbeAttenuation(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			ch := Channel{inst: mock, name: "CHAN1", num: 1}
-			err := ch.SetProbeAttenuation(tt.atten)
+			err := ch.SetProbeAttenuation(t.Context(), tt.atten)
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -366,13 +373,13 @@ func TestChannel_SetProbeAttenuationAuto(t *testing.T) {
 	ch := Channel{inst: mock, name: "CHAN1", num: 1}
 
 	// false should succeed
-	err := ch.SetProbeAttenuationAuto(false)
+	err := ch.SetProbeAttenuationAuto(t.Context(), false)
 	if err != nil {
 		t.Errorf("SetProbeAttenuationAuto(false) error: %v", err)
 	}
 
 	// true should fail (not supported)
-	err = ch.SetProbeAttenuationAuto(true)
+	err = ch.SetProbeAttenuationAuto(t.Context(), true)
 	if !errors.Is(err, ivi.ErrValueNotSupported) {
 		t.Errorf("SetProbeAttenuationAuto(true) = %v, want ErrValueNotSupported", err)
 	}
@@ -394,7 +401,7 @@ func TestChannel_SetVerticalCoupling(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			ch := Channel{inst: mock, name: "CHAN1", num: 1}
-			err := ch.SetVerticalCoupling(tt.coupling)
+			err := ch.SetVerticalCoupling(t.Context(), tt.coupling)
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -472,49 +479,282 @@ func TestDurationFromSeconds(t *testing.T) {
 	}
 }
 
-func TestDriver_NotImplemented(t *testing.T) {
-	mock := &ivitest.Mock{}
+func TestDriver_AcquisitionStartTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		resp    string
+		want    time.Duration
+		wantErr bool
+	}{
+		{
+			"center",
+			":TIM:MODE MAIN;REF CENT;MAIN:RANG +2.00E-03;POS +5.0E-04",
+			-500 * time.Microsecond,
+			false,
+		},
+		{
+			"left",
+			":TIM:MODE MAIN;REF LEFT;MAIN:RANG +1.00E-03;POS +0.0E+00",
+			-100 * time.Microsecond,
+			false,
+		},
+		{
+			"right",
+			":TIM:MODE MAIN;REF RIGH;MAIN:RANG +1.00E-03;POS +1.0E-03",
+			100 * time.Microsecond,
+			false,
+		},
+		{
+			"roll mode",
+			":TIM:MODE ROLL;REF CENT;MAIN:RANG +1.00E-03;POS +0.0E+00",
+			0,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestDriver(&ivitest.Mock{QueryResp: tt.resp})
+			got, err := d.AcquisitionStartTime(t.Context())
+			if tt.wantErr {
+				if !errors.Is(err, ivi.ErrValueNotSupported) {
+					t.Errorf("error = %v, want ErrValueNotSupported", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDriver_SetAcquisitionStartTime(t *testing.T) {
+	mock := &ivitest.Mock{
+		QueryResp: ":TIM:MODE MAIN;REF CENT;MAIN:RANG +2.00E-03;POS +0.0E+00",
+	}
 	d := newTestDriver(mock)
+	err := d.SetAcquisitionStartTime(t.Context(), -500*time.Microsecond)
+	if err != nil {
+		t.Fatalf("SetAcquisitionStartTime() error: %v", err)
+	}
+	want := []string{":TIM:POS 5.000000e-04"}
+	if !slices.Equal(mock.CommandsSent, want) {
+		t.Errorf("sent %q, want %q", mock.CommandsSent, want)
+	}
+}
+
+func TestDriver_AcquisitionStatus(t *testing.T) {
+	tests := []struct {
+		resp string
+		want scope.AcquisitionStatus
+	}{
+		{"+8", scope.AcquisitionInprogress},
+		{"+40", scope.AcquisitionInprogress},
+		{"+0", scope.AcquisitionComplete},
+		{"+32", scope.AcquisitionComplete},
+	}
+
+	for _, tt := range tests {
+		d := newTestDriver(&ivitest.Mock{QueryResp: tt.resp})
+		got, err := d.AcquisitionStatus(t.Context())
+		if err != nil || got != tt.want {
+			t.Errorf(":OPER:COND? %s: AcquisitionStatus() = %v, %v, want %v",
+				tt.resp, got, err, tt.want)
+		}
+	}
+}
+
+func TestDriver_SetAcquisitionMinNumPoints(t *testing.T) {
+	tests := []struct {
+		numPoints int
+		want      string
+		wantErr   bool
+	}{
+		{1, ":WAV:POIN:MODE MAX;:WAV:POIN 100", false},
+		{1000, ":WAV:POIN:MODE MAX;:WAV:POIN 1000", false},
+		{62500, ":WAV:POIN:MODE MAX;:WAV:POIN 100000", false},
+		{0, "", true},
+		{8000001, "", true},
+	}
+
+	for _, tt := range tests {
+		mock := &ivitest.Mock{}
+		d := newTestDriver(mock)
+		err := d.SetAcquisitionMinNumPoints(t.Context(), tt.numPoints)
+		if tt.wantErr {
+			if !errors.Is(err, ivi.ErrValueNotSupported) {
+				t.Errorf("SetAcquisitionMinNumPoints(%d) error = %v, want %v",
+					tt.numPoints, err, ivi.ErrValueNotSupported)
+			}
+			continue
+		}
+		if err != nil || len(mock.CommandsSent) != 1 ||
+			mock.CommandsSent[0] != tt.want {
+			t.Errorf("SetAcquisitionMinNumPoints(%d) sent %q, %v, want %q",
+				tt.numPoints, mock.CommandsSent, err, tt.want)
+		}
+	}
+}
+
+func TestDriver_TriggerSlope(t *testing.T) {
+	d := newTestDriver(&ivitest.Mock{QueryResp: "NEG\n"})
+	got, err := d.TriggerSlope(t.Context())
+	if err != nil || got != scope.NegativeTriggerSlope {
+		t.Errorf("TriggerSlope() = %v, %v, want negative", got, err)
+	}
+
+	d = newTestDriver(&ivitest.Mock{QueryResp: "EITH"})
+	if _, err := d.TriggerSlope(t.Context()); err == nil {
+		t.Error("TriggerSlope() of EITH: expected error, got nil")
+	}
+}
 
-	_, err := d.AcquisitionStatus()
-	if !errors.Is(err, ivi.ErrNotImplemented) {
-		t.Errorf("AcquisitionStatus() = %v, want ErrNotImplemented", err)
+func TestDriver_TriggerSource(t *testing.T) {
+	d := newTestDriver(&ivitest.Mock{QueryResp: "EXT\n"})
+	got, err := d.TriggerSource(t.Context())
+	if err != nil || got != scope.TriggerSourceExternal {
+		t.Errorf("TriggerSource() = %v, %v, want external", got, err)
+	}
+
+	d = newTestDriver(&ivitest.Mock{QueryResp: "CHAN1\n"})
+	_, err = d.TriggerSource(t.Context())
+	if !errors.Is(err, ivi.ErrValueNotSupported) {
+		t.Errorf("TriggerSource() of CHAN1 error = %v, want %v",
+			err, ivi.ErrValueNotSupported)
+	}
+
+	mock := &ivitest.Mock{}
+	d = newTestDriver(mock)
+	err = d.SetTriggerSource(t.Context(), scope.TriggerSourceExternal)
+	if err != nil {
+		t.Fatalf("SetTriggerSource() error: %v", err)
+	}
+	want := []string{":TRIG:EDGE:SOUR EXT"}
+	if !slices.Equal(mock.CommandsSent, want) {
+		t.Errorf("sent %q, want %q", mock.CommandsSent, want)
 	}
 
-	_, err = d.TriggerSlope()
-	if !errors.Is(err, ivi.ErrNotImplemented) {
-		t.Errorf("TriggerSlope() = %v, want ErrNotImplemented", err)
+	err = d.SetTriggerSource(t.Context(), scope.TriggerSourceTTL0)
+	if !errors.Is(err, ivi.ErrValueNotSupported) {
+		t.Errorf("SetTriggerSource(TTL0) error = %v, want %v",
+			err, ivi.ErrValueNotSupported)
 	}
+}
+
+func TestDriver_AbortAndInitiate(t *testing.T) {
+	mock := &ivitest.Mock{}
+	d := newTestDriver(mock)
 
-	_, err = d.TriggerSource()
-	if !errors.Is(err, ivi.ErrNotImplemented) {
-		t.Errorf("TriggerSource() = %v, want ErrNotImplemented", err)
+	if err := d.InitiateMeasurement(t.Context()); err != nil {
+		t.Fatalf("InitiateMeasurement() error: %v", err)
+	}
+	if err := d.AbortMeasurement(t.Context()); err != nil {
+		t.Fatalf("AbortMeasurement() error: %v", err)
 	}
 
-	err = d.AbortMeasurement()
-	if !errors.Is(err, ivi.ErrNotImplemented) {
-		t.Errorf("AbortMeasurement() = %v, want ErrNotImplemented", err)
+	want := []string{":SING", ":STOP"}
+	if !slices.Equal(mock.CommandsSent, want) {
+		t.Errorf("sent %q, want %q", mock.CommandsSent, want)
 	}
+}
 
-	err = d.InitiateMeasurement()
-	if !errors.Is(err, ivi.ErrNotImplemented) {
-		t.Errorf("InitiateMeasurement() = %v, want ErrNotImplemented", err)
+func TestDriver_CreateWaveform(t *testing.T) {
+	d := newTestDriver(&ivitest.Mock{})
+	if err := d.CreateWaveform(t.Context(), 62500); err != nil {
+		t.Errorf("CreateWaveform(62500) error: %v", err)
+	}
+	err := d.CreateWaveform(t.Context(), 0)
+	if !errors.Is(err, ivi.ErrValueNotSupported) {
+		t.Errorf("CreateWaveform(0) error = %v, want ErrValueNotSupported", err)
 	}
 }
 
-func TestDriver_SetAcquisitionStartTime_NotSupported(t *testing.T) {
+func TestDriver_ConfigureEdgeTrigger(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
-	err := d.SetAcquisitionStartTime(100 * time.Microsecond)
-	if !errors.Is(err, ivi.ErrFunctionNotSupported) {
-		t.Errorf("SetAcquisitionStartTime() = %v, want ErrFunctionNotSupported", err)
+	err := d.ConfigureEdgeTrigger(
+		t.Context(), scope.EdgeTrigger, 1.5, scope.NegativeTriggerSlope,
+	)
+	if err != nil {
+		t.Fatalf("ConfigureEdgeTrigger() error: %v", err)
+	}
+
+	want := []string{
+		":TRIG:MODE EDGE", ":TRIG:EDGE:LEV 1.500000e+00", ":TRIG:EDGE:SLOP NEG",
+	}
+	if !slices.Equal(mock.CommandsSent, want) {
+		t.Errorf("sent %q, want %q", mock.CommandsSent, want)
+	}
+
+	err = d.ConfigureEdgeTrigger(
+		t.Context(), scope.WidthTrigger, 1.5, scope.PositiveTriggerSlope,
+	)
+	if !errors.Is(err, ivi.ErrValueNotSupported) {
+		t.Errorf("ConfigureEdgeTrigger(width) error = %v, want %v",
+			err, ivi.ErrValueNotSupported)
+	}
+}
+
+func TestChannel_ConfigureCharacteristics(t *testing.T) {
+	tests := []struct {
+		impedance float64
+		freqMax   float64
+		want      []string
+	}{
+		{fiftyOhms, 20e6, []string{":CHAN2:IMP FIFT", ":CHAN2:BWL 1"}},
+		{oneMeg, 200e6, []string{":CHAN2:IMP ONEM", ":CHAN2:BWL 0"}},
+	}
+
+	for _, tt := range tests {
+		mock := &ivitest.Mock{}
+		ch := Channel{inst: mock, name: "CHAN2", num: 2}
+		err := ch.ConfigureCharacteristics(
+			t.Context(), tt.impedance, tt.freqMax,
+		)
+		if err != nil || !slices.Equal(mock.CommandsSent, tt.want) {
+			t.Errorf("ConfigureCharacteristics(%g, %g) sent %q, %v, want %q",
+				tt.impedance, tt.freqMax, mock.CommandsSent, err, tt.want)
+		}
+	}
+}
+
+func TestChannel_MaxInputFrequency(t *testing.T) {
+	tests := []struct {
+		bwl       string
+		bandwidth float64
+		want      float64
+		wantErr   error
+	}{
+		{"1", 350e6, 25e6, nil},
+		{"0", 350e6, 350e6, nil},
+		{"1", 0, 25e6, nil},
+		{"0", 0, 0, ivi.ErrFunctionNotSupported},
+	}
+
+	for _, tt := range tests {
+		ch := Channel{
+			inst:      &ivitest.Mock{QueryResp: tt.bwl + "\n"},
+			name:      "CHAN2",
+			num:       2,
+			bandwidth: tt.bandwidth,
+		}
+		got, err := ch.MaxInputFrequency(t.Context())
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("MaxInputFrequency() with BWL %s and bandwidth %g "+
+				"= %g, %v, want %g, %v",
+				tt.bwl, tt.bandwidth, got, err, tt.want, tt.wantErr)
+		}
 	}
 }
 
 func TestChannel_SetVerticalOffset(t *testing.T) {
 	mock := &ivitest.Mock{}
 	ch := Channel{inst: mock, name: "CHAN1", num: 1}
-	err := ch.SetVerticalOffset(2.5)
+	err := ch.SetVerticalOffset(t.Context(), 2.5)
 	if err != nil {
 		t.Errorf("SetVerticalOffset() error: %v", err)
 	}
@@ -526,7 +766,7 @@ func TestChannel_SetVerticalOffset(t *testing.T) {
 func TestDriver_ConfigureTrigger(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
-	err := d.ConfigureTrigger(scope.EdgeTrigger, 100*time.Microsecond)
+	err := d.ConfigureTrigger(t.Context(), scope.EdgeTrigger, 100*time.Microsecond)
 	if err != nil {
 		t.Errorf("ConfigureTrigger() error: %v", err)
 	}
@@ -544,7 +784,7 @@ func TestDriver_ConfigureTrigger(t *testing.T) {
 func TestDriver_SetAcquisitionTimePerRecord(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d := newTestDriver(mock)
-	err := d.SetAcquisitionTimePerRecord(1 * time.Millisecond)
+	err := d.SetAcquisitionTimePerRecord(t.Context(), 1*time.Millisecond)
 	if err != nil {
 		t.Errorf("SetAcquisitionTimePerRecord() error: %v", err)
 	}
@@ -552,3 +792,351 @@ func TestDriver_SetAcquisitionTimePerRecord(t *testing.T) {
 		t.Errorf("sent %v, want :TIM:RANG command", mock.CommandsSent)
 	}
 }
+
+// TestReplay_Timebase replays a session recorded from a DSOX3034A, whose :TIM?
+// response carries signed mantissas, and checks the timebase decodes from it.
+func TestReplay_Timebase(t *testing.T) {
+	replay, err := ivitest.LoadReplay("testdata/timebase.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := New(replay)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	start, err := d.AcquisitionStartTime(t.Context())
+	if err != nil {
+		t.Fatalf("AcquisitionStartTime() error: %v", err)
+	}
+
+	if want := -time.Millisecond; start != want {
+		t.Errorf("AcquisitionStartTime() = %v, want %v", start, want)
+	}
+
+	perRecord, err := d.AcquisitionTimePerRecord(t.Context())
+	if err != nil {
+		t.Fatalf("AcquisitionTimePerRecord() error: %v", err)
+	}
+
+	if want := 2 * time.Millisecond; perRecord != want {
+		t.Errorf("AcquisitionTimePerRecord() = %v, want %v", perRecord, want)
+	}
+
+	replay.Check(t)
+}
+
+func TestDecodePreamble(t *testing.T) {
+	got, err := decodePreamble(
+		"+1,+0,+4,+1,+2.0E-06,-4.0E-06,+0,+1.0E-03,+5.0E-01,+32768\n",
+	)
+	if err != nil {
+		t.Fatalf("decodePreamble() error: %v", err)
+	}
+
+	want := preamble{
+		format:     wordFormat,
+		points:     4,
+		xIncrement: 2e-6,
+		xOrigin:    -4e-6,
+		yIncrement: 1e-3,
+		yOrigin:    0.5,
+		yReference: 32768,
+	}
+	if got != want {
+		t.Errorf("decodePreamble() = %+v, want %+v", got, want)
+	}
+
+	for _, input := range []string{"+1,+0,+4", "+1,+0,+4,+1,x,+0,+0,+0,+0,+0"} {
+		if _, err := decodePreamble(input); !errors.Is(
+			err, ivi.ErrUnexpectedResponse,
+		) {
+			t.Errorf("decodePreamble(%q) error = %v, want %v",
+				input, err, ivi.ErrUnexpectedResponse)
+		}
+	}
+}
+
+func TestChannel_FetchWaveform(t *testing.T) {
+	tests := []struct {
+		name     string
+		preamble string
+		data     []byte
+	}{
+		{
+			"word",
+			"+1,+0,+5,+1,+2.0E-06,-4.0E-06,+0,+1.0E-03,+5.0E-01,+32768",
+			[]byte{0x80, 0x00, 0x81, 0x00, 0xff, 0x00, 0x01, 0x00, 0, 0},
+		},
+		{
+			"byte",
+			"+0,+0,+5,+1,+2.0E-06,-4.0E-06,+0,+2.56E-01,+5.0E-01,+128",
+			[]byte{0x80, 0x81, 0xff, 0x01, 0},
+		},
+	}
+
+	wantStatus := []ivi.SampleStatus{
+		ivi.SampleValid, ivi.SampleValid, ivi.SampleOverRange,
+		ivi.SampleUnderRange, ivi.SampleInvalid,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := ivitest.NewSimulator(ivitest.SimModel{
+				Attributes: map[string]ivitest.SimAttribute{
+					"WAV:PRE": {Default: tt.preamble},
+				},
+				Blocks: map[string][]byte{"WAV:DATA": tt.data},
+			})
+			ch := Channel{inst: sim, name: "CHAN2", num: 2}
+
+			var wf ivi.Waveform
+			if err := ch.FetchWaveform(t.Context(), &wf); err != nil {
+				t.Fatalf("FetchWaveform() error: %v", err)
+			}
+
+			sim.CheckErrors(t)
+
+			want := ":WAV:SOUR CHAN2;FORM WORD;BYT MSBF;UNS 1"
+			if sim.CommandsSent[0] != want {
+				t.Errorf("first command = %q, want %q",
+					sim.CommandsSent[0], want)
+			}
+
+			info := wf.Info()
+			if info.InitialX != -4e-6 || info.XIncrement != 2e-6 ||
+				info.XUnits != "s" || info.YUnits != "V" ||
+				info.Source != "CHAN2" {
+				t.Errorf("Info() = %+v", info)
+			}
+
+			// 0x8100 is 256 codes, or 0.256 V, above the 0.5 V reference.
+			for i, want := range []float64{0.5, 0.756} {
+				if _, got := wf.At(i); math.Abs(got-want) > 1e-12 {
+					t.Errorf("point %d = %g V, want %g", i, got, want)
+				}
+			}
+
+			for i, want := range wantStatus {
+				if got := wf.Status(i); got != want {
+					t.Errorf("Status(%d) = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestChannel_FetchWaveform_PointsMismatch(t *testing.T) {
+	sim := ivitest.NewSimulator(ivitest.SimModel{
+		Attributes: map[string]ivitest.SimAttribute{
+			"WAV:PRE": {Default: "+1,+0,+3,+1,+1,+0,+0,+1,+0,+0"},
+		},
+		Blocks: map[string][]byte{"WAV:DATA": {0x80, 0x00}},
+	})
+	ch := Channel{inst: sim, name: "CHAN1", num: 1}
+
+	err := ch.FetchWaveform(t.Context(), &ivi.Waveform{})
+	if !errors.Is(err, ivi.ErrUnexpectedResponse) {
+		t.Errorf("FetchWaveform() error = %v, want ErrUnexpectedResponse", err)
+	}
+}
+
+// newTestChannel returns a channel on inst with an Inherent of its own, as
+// ReadWaveform needs to wait for the acquisition.
+func newTestChannel(inst ivi.Transport, name string, num int) *Channel {
+	inherent := ivi.NewInherent(inst, ivi.InherentBase{}, 0)
+
+	return &Channel{inst: inst, name: name, num: num, inherent: &inherent}
+}
+
+// slowScope is a mock oscilloscope that never completes an acquisition: its
+// *OPC? query returns only once ctx is done.
+type slowScope struct {
+	ivitest.Mock
+}
+
+func (s *slowScope) Query(ctx context.Context, cmd string) (string, error) {
+	if strings.TrimSpace(cmd) == "*OPC?" {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+
+	return s.Mock.Query(ctx, cmd)
+}
+
+func TestChannel_ReadWaveform_MaximumTime(t *testing.T) {
+	ch := newTestChannel(&slowScope{}, "CHAN1", 1)
+	ch.timeout = 10 * time.Millisecond
+
+	// The caller's deadline is far off, so maximumTime bounds the wait.
+	ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	err := ch.ReadWaveform(ctx, 50*time.Millisecond, &ivi.Waveform{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ReadWaveform() error = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("ReadWaveform() waited %v, want about 50ms", elapsed)
+	}
+}
+
+func TestChannel_ReadWaveform(t *testing.T) {
+	sim := ivitest.NewSimulator(ivitest.SimModel{
+		Attributes: map[string]ivitest.SimAttribute{
+			"WAV:PRE": {Default: "+1,+0,+1,+1,+1,+0,+0,+1,+0,+0"},
+		},
+		Blocks: map[string][]byte{"WAV:DATA": {0x80, 0x00}},
+	})
+	ch := newTestChannel(sim, "CHAN3", 3)
+
+	var wf ivi.Waveform
+	if err := ch.ReadWaveform(t.Context(), time.Second, &wf); err != nil {
+		t.Fatalf("ReadWaveform() error: %v", err)
+	}
+
+	if sim.CommandsSent[0] != ":DIG CHAN3" || sim.QueriesSent[0] != "*OPC?" {
+		t.Errorf("sent %q and queried %q, want :DIG CHAN3 then *OPC?",
+			sim.CommandsSent, sim.QueriesSent)
+	}
+	if wf.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", wf.Len())
+	}
+}
+
+// sharedOutputScope answers the waveform queries the way an oscilloscope
+// does, where the simulator does not: all responses, including those to
+// queries sent with Command, come from one output buffer, and :WAV:SOUR and
+// :DIG change state that later queries depend on. Only the last channel
+// digitized holds data; any other reads as holes. Nothing holds a sequence
+// of calls together, so fetches interleaved on two channels read each
+// other's responses.
+type sharedOutputScope struct {
+	mu        sync.Mutex
+	source    string
+	digitized string
+	out       bytes.Buffer
+}
+
+func (s *sharedOutputScope) Command(
+	_ context.Context,
+	cmd string,
+	a ...any,
+) error {
+	defer runtime.Gosched()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for unit := range strings.SplitSeq(ivi.FormatCommand(cmd, a), ";") {
+		switch {
+		case strings.HasPrefix(unit, ":WAV:SOUR "):
+			s.source = strings.TrimPrefix(unit, ":WAV:SOUR ")
+		case strings.HasPrefix(unit, ":DIG "):
+			s.digitized = strings.TrimPrefix(unit, ":DIG ")
+		case unit == ":WAV:DATA?":
+			var code byte
+			if s.source == s.digitized {
+				code = map[string]byte{"CHAN1": 0x10, "CHAN2": 0x20}[s.source]
+			}
+			s.out.Write(ivi.AppendBlock(nil, []byte{code, 0}))
+			s.out.WriteByte('\n')
+		}
+	}
+
+	return nil
+}
+
+func (s *sharedOutputScope) Query(
+	_ context.Context,
+	cmd string,
+) (string, error) {
+	defer runtime.Gosched()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch strings.TrimSpace(cmd) {
+	case "*OPC?":
+		s.out.WriteString("1\n")
+	case ":WAV:PRE?":
+		s.out.WriteString("+1,+0,+1,+1,+1,+0,+0,+1,+0,+0\n")
+	default:
+		return "", fmt.Errorf("unexpected query %q", cmd)
+	}
+
+	line, err := s.out.ReadString('\n')
+
+	return strings.TrimSuffix(line, "\n"), err
+}
+
+func (s *sharedOutputScope) ReadBinary(
+	_ context.Context,
+	p []byte,
+) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.out.Read(p)
+}
+
+func (s *sharedOutputScope) WriteBinary(
+	_ context.Context,
+	p []byte,
+) (int, error) {
+	return len(p), nil
+}
+
+func TestChannel_ReadWaveform_Concurrent(t *testing.T) {
+	inst := ivi.NewSessionTransport(&sharedOutputScope{})
+	want := map[string]float64{"CHAN1": 0x1000, "CHAN2": 0x2000}
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"CHAN1", "CHAN2"} {
+		ch := newTestChannel(inst, name, 0)
+		ch.timeout = time.Second
+		wg.Go(func() {
+			for range 50 {
+				var wf ivi.Waveform
+				err := ch.ReadWaveform(t.Context(), time.Second, &wf)
+				if err != nil {
+					t.Errorf("%s ReadWaveform() error: %v", name, err)
+					return
+				}
+				if _, y := wf.At(0); wf.Len() != 1 || y != want[name] ||
+					wf.Status(0) != ivi.SampleValid {
+					t.Errorf("%s waveform = %v (%v), want %g",
+						name, y, wf.Status(0), want[name])
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestScopeOpen(t *testing.T) {
+	osc, err := scope.Drivers.Open(t.Context(), nil,
+		ivi.WithSimulate(), ivi.WithSimulatedModel("DSOX3024A"))
+	if err != nil {
+		t.Fatalf("scope.Drivers.Open() error: %v", err)
+	}
+
+	name, err := osc.ChannelName(0)
+	if err != nil {
+		t.Fatalf("ChannelName(0) error: %v", err)
+	}
+	ch, err := osc.ChannelItem(name)
+	if err != nil {
+		t.Fatalf("ChannelItem(%q) error: %v", name, err)
+	}
+	if _, err := ch.ChannelEnabled(t.Context()); err != nil {
+		t.Errorf("ChannelEnabled() error: %v", err)
+	}
+	if _, err := osc.ChannelName(4); !errors.Is(err, ivi.ErrChannelNotFound) {
+		t.Errorf("ChannelName(4) error = %v, want %v",
+			err, ivi.ErrChannelNotFound)
+	}
+}
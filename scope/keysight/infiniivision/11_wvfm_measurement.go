@@ -6,6 +6,7 @@
 package infiniivision
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gotmc/ivi"
@@ -28,9 +29,10 @@ var waveformMeasurementToSCPI = map[scope.WaveformMeasurement]string{
 // FetchWaveformMeasurement implements the IviScopeWaveformMeasurement
 // function described in Section 11 of IVI-4.1: IviScope Class Specification.
 func (ch *Channel) FetchWaveformMeasurement(
+	ctx context.Context,
 	msrmnt scope.WaveformMeasurement,
 ) (float64, error) {
-	ctx, cancel := ch.newContext()
+	ctx, cancel := ch.newContext(ctx)
 	defer cancel()
 
 	scpiCmd, err := ivi.LookupSCPI(waveformMeasurementToSCPI, msrmnt)
@@ -0,0 +1,33 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package infiniivision
+
+import (
+	"context"
+	"fmt"
+)
+
+// AutoSetup automatically configures the oscilloscope's vertical, timebase,
+// and trigger settings for the signals on the enabled channels. Autoscale
+// takes several seconds, so AutoSetup waits on *OPC? before returning; pass a
+// context with a suitable deadline if the driver's timeout is too short.
+//
+// AutoSetup implements the IviScopeAutoSetup function Auto Setup described in
+// Section 18.2.1 of IVI-4.1: IviScope Class Specification.
+func (d *Driver) AutoSetup(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
+	defer cancel()
+
+	if err := d.inst.Command(ctx, ":AUT"); err != nil {
+		return err
+	}
+
+	if err := d.WaitForOperationComplete(ctx); err != nil {
+		return fmt.Errorf("AutoSetup: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package infiniivision
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/internal/ivitest"
+)
+
+func TestDriver_AutoSetup(t *testing.T) {
+	mock := &ivitest.Mock{QueryResp: "1"}
+	d := newTestDriver(mock)
+	if err := d.AutoSetup(t.Context()); err != nil {
+		t.Fatalf("AutoSetup() error: %v", err)
+	}
+	if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != ":AUT" {
+		t.Errorf("sent %v, want [\":AUT\"]", mock.CommandsSent)
+	}
+}
+
+func TestDriver_AutoSetupNotComplete(t *testing.T) {
+	d := newTestDriver(&ivitest.Mock{QueryResp: "0"})
+	err := d.AutoSetup(t.Context())
+	if !errors.Is(err, ivi.ErrUnexpectedResponse) {
+		t.Errorf("AutoSetup() error = %v, want ErrUnexpectedResponse", err)
+	}
+}
+
+func TestDriver_AutoSetupCommandError(t *testing.T) {
+	d := newTestDriver(&ivitest.Mock{ShouldError: true})
+	if err := d.AutoSetup(t.Context()); err == nil {
+		t.Error("AutoSetup() expected error, got nil")
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package infiniivision
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/gotmc/ivi"
+)
+
+// simulation describes the instrument simulated under [ivi.WithSimulate],
+// which starts as after *RST with channel 1 displayed. Waveforms and waveform
+// measurements read as if every channel were connected to the 1 kHz, 2.5 Vpp
+// probe compensation signal.
+var simulation = ivi.Simulation{
+	Manufacturer: "KEYSIGHT TECHNOLOGIES",
+	Attributes:   simulatedAttributes(),
+	Blocks:       map[string][]byte{"WAV:DATA": simulatedWaveform()},
+}
+
+// The simulated waveform is one period of the probe compensation signal,
+// from 0.5 ms before the trigger to 0.5 ms after it, as WORD data.
+const (
+	simulatedPoints   = 1000
+	simulatedPreamble = "+1,+0,+1000,+1,+1.0E-06,-5.0E-04,+0," +
+		"+6.103515625E-04,+0.0E+00,+32768"
+	// simulatedHigh and simulatedLow are the codes of +1.25 V and -1.25 V.
+	simulatedHigh = 32768 + 2048
+	simulatedLow  = 32768 - 2048
+)
+
+// simulatedWaveform returns the :WAVeform:DATA of the simulated oscilloscope,
+// a square wave rising at the trigger.
+func simulatedWaveform() []byte {
+	codes := make([]uint16, simulatedPoints)
+	for i := range codes {
+		codes[i] = simulatedLow
+		if i >= simulatedPoints/2 {
+			codes[i] = simulatedHigh
+		}
+	}
+
+	return ivi.EncodeBlockValues(codes, binary.BigEndian)
+}
+
+// Codes SimulatedWaveform encodes samples with. Valid samples span the codes
+// whose upper byte is neither 0, a hole, 1, clipped low, nor 255, clipped
+// high, centered on yReferenceCode.
+const (
+	yReferenceCode  = 32768
+	maxCodeOffset   = 0xfe00 - yReferenceCode
+	holeCode        = 0x0000
+	clippedLowCode  = 0x0100
+	clippedHighCode = 0xff00
+)
+
+// SimulatedWaveform returns simulation data that makes a simulated oscilloscope
+// acquire w, such as a waveform loaded with the wavefile package, on every
+// channel. Pass it to [ivi.WithSimulatedData]; FetchWaveform and ReadWaveform
+// then return w's samples, quantized to 16 bits, on w's time axis, with the
+// status of each sample preserved. SimulatedWaveform returns an error
+// wrapping [ivi.ErrValueNotSupported] for a waveform without samples or with
+// a spacing that is not positive.
+func SimulatedWaveform(w *ivi.Waveform) (ivi.Simulation, error) {
+	if w.Len() == 0 || !(w.XIncrement() > 0) {
+		return ivi.Simulation{}, fmt.Errorf(
+			"SimulatedWaveform: %w: %d samples %g apart",
+			ivi.ErrValueNotSupported, w.Len(), w.XIncrement(),
+		)
+	}
+
+	items, _ := w.AllElements()
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for i, v := range items {
+		if w.Valid(i) {
+			low, high = min(low, v), max(high, v)
+		}
+	}
+
+	yOrigin, yIncrement := 0.0, 1.0
+	if low <= high {
+		yOrigin = (low + high) / 2
+		if high > low {
+			yIncrement = (high - low) / (2 * maxCodeOffset)
+		}
+	}
+
+	codes := make([]uint16, len(items))
+	for i, v := range items {
+		switch w.Status(i) {
+		case ivi.SampleInvalid:
+			codes[i] = holeCode
+		case ivi.SampleUnderRange:
+			codes[i] = clippedLowCode
+		case ivi.SampleOverRange:
+			codes[i] = clippedHighCode
+		default:
+			codes[i] = uint16(
+				yReferenceCode + math.Round((v-yOrigin)/yIncrement),
+			)
+		}
+	}
+
+	f := func(v float64) string { return strconv.FormatFloat(v, 'E', -1, 64) }
+	pre := fmt.Sprintf("%d,0,%d,1,%s,%s,0,%s,%s,%d",
+		wordFormat, len(codes), f(w.XIncrement()), f(w.InitialX()),
+		f(yIncrement), f(yOrigin), yReferenceCode,
+	)
+
+	return ivi.Simulation{
+		Attributes: map[string]ivi.SimulatedAttribute{
+			"WAV:PRE":  {Default: pre},
+			"WAV:POIN": {Default: strconv.Itoa(len(codes))},
+		},
+		Blocks: map[string][]byte{
+			"WAV:DATA": ivi.EncodeBlockValues(codes, binary.BigEndian),
+		},
+	}, nil
+}
+
+// simulatedAttributes returns the attributes of the simulated oscilloscope.
+func simulatedAttributes() map[string]ivi.SimulatedAttribute {
+	const simulatedChannels = 4
+
+	attrs := map[string]ivi.SimulatedAttribute{
+		"TIM": {
+			Default: ":TIM:MODE MAIN;REF {TIM:REF};MAIN:RANG {TIM:RANG};" +
+				"POS {TIM:POS}",
+		},
+		"TIM:RANG":       {Default: "1.0E-03", Min: 20e-9, Max: 500},
+		"TIM:REF":        {Default: "CENT"},
+		"TIM:POS":        {Default: "+0.0E+00", Min: -500, Max: 500},
+		"OPER:COND":      {Default: "+0"},
+		"ACQ:TYPE":       {Default: "NORM"},
+		"ACQ:POIN":       {Default: "62500"},
+		"ACQ:SRAT":       {Default: "4.0E+09"},
+		"WAV:PRE":        {Default: simulatedPreamble},
+		"WAV:POIN":       {Default: "+1000"},
+		"TRIG:EDGE:SLOP": {Default: "POS"},
+		"TRIG:EDGE:SOUR": {Default: "CHAN1"},
+		"TRIG:MODE":      {Default: "EDGE"},
+		"TRIG:HOLD":      {Default: "40E-9", Min: 40e-9, Max: 10},
+		"TRIG:EDGE:LEV":  {Min: -40, Max: 40},
+		"MEAS:FREQ":      {Default: "1.0E+03"},
+		"MEAS:PER":       {Default: "1.0E-03"},
+		"MEAS:VPP":       {Default: "2.5E+00"},
+		"MEAS:VRMS":      {Default: "1.25E+00"},
+		"MEAS:RIS":       {Default: "1.0E-06"},
+		"MEAS:FALL":      {Default: "1.0E-06"},
+	}
+
+	for i := 1; i <= simulatedChannels; i++ {
+		chanAttr := func(node string, a ivi.SimulatedAttribute) {
+			attrs[fmt.Sprintf("CHAN%d:%s", i, node)] = a
+		}
+
+		display := "0"
+		if i == 1 {
+			display = "1"
+		}
+
+		chanAttr("DISP", ivi.SimulatedAttribute{Default: display})
+		chanAttr("IMP", ivi.SimulatedAttribute{Default: "ONEM"})
+		chanAttr("COUP", ivi.SimulatedAttribute{Default: "DC"})
+		chanAttr("PROBE", ivi.SimulatedAttribute{
+			Default: "10", Min: 0.001, Max: 10000,
+		})
+		chanAttr("RANG", ivi.SimulatedAttribute{
+			Default: "40", Min: 8e-3, Max: 400,
+		})
+		chanAttr("OFFS", ivi.SimulatedAttribute{Min: -200, Max: 200})
+		chanAttr("BWL", ivi.SimulatedAttribute{})
+	}
+
+	return attrs
+}
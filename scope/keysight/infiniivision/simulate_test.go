@@ -0,0 +1,172 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package infiniivision
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/scope"
+	"github.com/gotmc/ivi/wavefile"
+)
+
+func TestSimulate_ReadWaveform(t *testing.T) {
+	d, err := New(nil, ivi.WithSimulate())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	ch, _ := d.Channel(0)
+
+	var wf ivi.Waveform
+	if err := ch.ReadWaveform(t.Context(), time.Second, &wf); err != nil {
+		t.Fatalf("ReadWaveform() error: %v", err)
+	}
+
+	if wf.Len() != simulatedPoints || wf.InitialX() != -0.5e-3 ||
+		wf.XIncrement() != 1e-6 {
+		t.Fatalf("ReadWaveform() = %d points from %g by %g",
+			wf.Len(), wf.InitialX(), wf.XIncrement())
+	}
+
+	if _, got := wf.At(0); got != -1.25 {
+		t.Errorf("before the trigger = %g V, want -1.25", got)
+	}
+	if _, got := wf.At(wf.Index(0)); got != 1.25 {
+		t.Errorf("at the trigger = %g V, want 1.25", got)
+	}
+}
+
+// TestSimulate_ConfigureInitiateFetch runs a full acquisition through the
+// class interfaces alone.
+func TestSimulate_ConfigureInitiateFetch(t *testing.T) {
+	ctx := t.Context()
+
+	d, err := New(nil, ivi.WithSimulate())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	var base scope.Base = d
+
+	err = base.ConfigureAcquisitionRecord(
+		ctx, 2*time.Millisecond, 1000, -500*time.Microsecond,
+	)
+	if err != nil {
+		t.Fatalf("ConfigureAcquisitionRecord() error: %v", err)
+	}
+
+	if got, err := base.AcquisitionStartTime(ctx); err != nil ||
+		got != -500*time.Microsecond {
+		t.Errorf("AcquisitionStartTime() = %v, %v, want -500µs", got, err)
+	}
+
+	err = base.ConfigureEdgeTrigger(
+		ctx, scope.EdgeTrigger, 0.5, scope.NegativeTriggerSlope,
+	)
+	if err != nil {
+		t.Fatalf("ConfigureEdgeTrigger() error: %v", err)
+	}
+
+	if got, err := base.TriggerSlope(ctx); err != nil ||
+		got != scope.NegativeTriggerSlope {
+		t.Errorf("TriggerSlope() = %v, %v, want negative", got, err)
+	}
+
+	if err := base.InitiateMeasurement(ctx); err != nil {
+		t.Fatalf("InitiateMeasurement() error: %v", err)
+	}
+
+	if got, err := base.AcquisitionStatus(ctx); err != nil ||
+		got != scope.AcquisitionComplete {
+		t.Errorf("AcquisitionStatus() = %v, %v, want complete", got, err)
+	}
+
+	var ch scope.BaseChannel
+	ch, _ = d.Channel(0)
+
+	if err := ch.ConfigureCharacteristics(ctx, 1e6, 20e6); err != nil {
+		t.Fatalf("ConfigureCharacteristics() error: %v", err)
+	}
+
+	var wf ivi.Waveform
+	if err := ch.FetchWaveform(ctx, &wf); err != nil {
+		t.Fatalf("FetchWaveform() error: %v", err)
+	}
+	if wf.Len() != simulatedPoints {
+		t.Errorf("Len() = %d, want %d", wf.Len(), simulatedPoints)
+	}
+}
+
+// TestSimulatedWaveform saves a waveform to a file, loads it back, and
+// replays it through the simulated oscilloscope.
+func TestSimulatedWaveform(t *testing.T) {
+	items := make([]float64, 500)
+	for i := range items {
+		items[i] = 0.3 + 2*math.Sin(2*math.Pi*float64(i)/100)
+	}
+	saved := ivi.NewWaveform(items, ivi.WaveformInfo{
+		InitialX: -1e-3, XIncrement: 4e-6, XUnits: "s", YUnits: "V",
+	})
+	saved.SetStatus(10, ivi.SampleOverRange)
+	saved.SetStatus(20, ivi.SampleInvalid)
+
+	var file bytes.Buffer
+	if err := wavefile.WriteCSV(&file, saved); err != nil {
+		t.Fatalf("WriteCSV() error: %v", err)
+	}
+	loaded, err := wavefile.ReadCSV(&file)
+	if err != nil {
+		t.Fatalf("ReadCSV() error: %v", err)
+	}
+
+	sim, err := SimulatedWaveform(loaded)
+	if err != nil {
+		t.Fatalf("SimulatedWaveform() error: %v", err)
+	}
+	d, err := New(nil, ivi.WithSimulatedData(sim))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	ch, _ := d.Channel(0)
+
+	var wf ivi.Waveform
+	if err := ch.ReadWaveform(t.Context(), time.Second, &wf); err != nil {
+		t.Fatalf("ReadWaveform() error: %v", err)
+	}
+
+	if wf.Len() != len(items) || wf.InitialX() != -1e-3 ||
+		wf.XIncrement() != 4e-6 {
+		t.Fatalf("ReadWaveform() = %d points from %g by %g",
+			wf.Len(), wf.InitialX(), wf.XIncrement())
+	}
+
+	// One code step is 4 V over 64512 codes.
+	const tolerance = 4.0 / 64512
+	for i, want := range items {
+		if got := wf.Status(i); got != saved.Status(i) {
+			t.Errorf("Status(%d) = %v, want %v", i, got, saved.Status(i))
+		}
+		if !wf.Valid(i) {
+			continue
+		}
+		if _, got := wf.At(i); math.Abs(got-want) > tolerance {
+			t.Errorf("At(%d) = %g V, want %g", i, got, want)
+		}
+	}
+}
+
+func TestSimulatedWaveform_Empty(t *testing.T) {
+	_, err := SimulatedWaveform(ivi.NewWaveform(nil, ivi.WaveformInfo{}))
+	if !errors.Is(err, ivi.ErrValueNotSupported) {
+		t.Errorf("SimulatedWaveform() error = %v, want ErrValueNotSupported",
+			err)
+	}
+}
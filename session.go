@@ -0,0 +1,292 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SessionTransport wraps a Transport so that it can be shared by goroutines.
+// Each Command, Query, ReadBinary, and WriteBinary call runs alone, so a
+// query's response is always read by the goroutine that sent the query.
+// [NewDriverSetup] installs one in front of every driver's transport.
+//
+// Sequences of calls that must not be interleaved, such as selecting an
+// output and then programming it, or sending a query with Command and reading
+// its block response with ReadBinary, hold the session lock, as a VISA
+// session is locked with viLock. [SessionTransport.Lock] returns a context
+// that carries the lock; calls made with that context, or with one derived
+// from it, proceed while every other call waits for Unlock.
+type SessionTransport struct {
+	Transport
+
+	// sem holds a token while a call or a lock holder owns the transport.
+	sem chan struct{}
+
+	mu     sync.Mutex
+	holder *sessionLock
+}
+
+// sessionLock identifies one acquisition of a session lock. A context holds
+// the lock while the lock it carries is the session's current holder. It has
+// a field so that each acquisition has a distinct address.
+type sessionLock struct {
+	session *SessionTransport
+}
+
+// sessionLockKey is the context key for the session locks a context holds.
+type sessionLockKey struct{}
+
+// NewSessionTransport returns a Transport that serializes calls to inst.
+func NewSessionTransport(inst Transport) *SessionTransport {
+	return &SessionTransport{Transport: inst, sem: make(chan struct{}, 1)}
+}
+
+// Lock acquires the session lock, waiting until it is free or ctx is done.
+// It returns a context carrying the lock, which the caller passes to the
+// calls it makes while holding the lock and then to Unlock. Locking with a
+// context that already holds the lock returns it unchanged; locks do not
+// nest.
+func (t *SessionTransport) Lock(ctx context.Context) (context.Context, error) {
+	if t.holds(ctx) {
+		return ctx, nil
+	}
+
+	if err := t.acquire(ctx); err != nil {
+		return ctx, fmt.Errorf("acquiring session lock: %w", err)
+	}
+
+	lock := &sessionLock{session: t}
+
+	t.mu.Lock()
+	t.holder = lock
+	t.mu.Unlock()
+
+	locks, _ := ctx.Value(sessionLockKey{}).([]*sessionLock)
+	locks = append(locks[:len(locks):len(locks)], lock)
+
+	return context.WithValue(ctx, sessionLockKey{}, locks), nil
+}
+
+// Unlock releases the session lock held by ctx, which must be the context
+// returned by Lock or one derived from it.
+func (t *SessionTransport) Unlock(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.holder == nil || !carries(ctx, t.holder) {
+		return ErrNotLocked
+	}
+
+	t.holder = nil
+	<-t.sem
+
+	return nil
+}
+
+// Command sends the command once no other goroutine holds the transport.
+func (t *SessionTransport) Command(
+	ctx context.Context,
+	cmd string,
+	a ...any,
+) error {
+	release, err := t.claim(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return t.Transport.Command(ctx, cmd, a...)
+}
+
+// Query sends the query and reads its response once no other goroutine holds
+// the transport.
+func (t *SessionTransport) Query(
+	ctx context.Context,
+	cmd string,
+) (string, error) {
+	release, err := t.claim(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	return t.Transport.Query(ctx, cmd)
+}
+
+// ReadBinary reads into p once no other goroutine holds the transport.
+func (t *SessionTransport) ReadBinary(
+	ctx context.Context,
+	p []byte,
+) (int, error) {
+	release, err := t.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	return t.Transport.ReadBinary(ctx, p)
+}
+
+// WriteBinary writes p once no other goroutine holds the transport.
+func (t *SessionTransport) WriteBinary(
+	ctx context.Context,
+	p []byte,
+) (int, error) {
+	release, err := t.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	return t.Transport.WriteBinary(ctx, p)
+}
+
+// checkStatus checks the instrument status, as [CheckInstrumentStatus] does,
+// once no other goroutine holds the transport.
+func (t *SessionTransport) checkStatus(ctx context.Context) error {
+	c, ok := t.Transport.(statusChecker)
+	if !ok {
+		return nil
+	}
+
+	release, err := t.claim(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return c.checkStatus(ctx)
+}
+
+// claim takes the transport for one call, unless ctx holds the session lock,
+// and returns the function that gives it back.
+func (t *SessionTransport) claim(ctx context.Context) (func(), error) {
+	if t.holds(ctx) {
+		return func() {}, nil
+	}
+
+	if err := t.acquire(ctx); err != nil {
+		return nil, fmt.Errorf("waiting for session: %w", err)
+	}
+
+	return func() { <-t.sem }, nil
+}
+
+// acquire waits for the transport to be free or ctx to be done.
+func (t *SessionTransport) acquire(ctx context.Context) error {
+	select {
+	case t.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// holds reports whether ctx holds the session lock.
+func (t *SessionTransport) holds(ctx context.Context) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.holder != nil && carries(ctx, t.holder)
+}
+
+// carries reports whether ctx carries lock.
+func carries(ctx context.Context, lock *sessionLock) bool {
+	locks, _ := ctx.Value(sessionLockKey{}).([]*sessionLock)
+
+	return slices.Contains(locks, lock)
+}
+
+// LockSession holds the session lock of inst for a driver method whose calls
+// to the instrument must run without another goroutine's calls between them,
+// such as a read-modify-write of a setting or a query sent with Command and
+// answered with a binary block. It waits for the lock as [Inherent.Lock]
+// does, for at most timeout when ctx has no deadline, and returns the context
+// to make the calls with and a function that releases the lock. When ctx
+// already holds the lock, as after Inherent.Lock, the lock stays held on
+// release, so driver methods can call each other. LockSession does nothing
+// when inst is not a [SessionTransport].
+func LockSession(
+	ctx context.Context,
+	inst Transport,
+	timeout time.Duration,
+) (context.Context, func(), error) {
+	t, ok := inst.(*SessionTransport)
+	if !ok || t.holds(ctx) {
+		return ctx, func() {}, nil
+	}
+
+	lockCtx, err := t.lockFor(ctx, timeout)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	return lockCtx, func() { _ = t.Unlock(lockCtx) }, nil
+}
+
+// lockFor acquires the session lock, waiting for at most timeout when ctx has
+// no deadline, and returns ctx carrying the lock. The timeout bounds only the
+// wait, not the calls made with the returned context.
+func (t *SessionTransport) lockFor(
+	ctx context.Context,
+	timeout time.Duration,
+) (context.Context, error) {
+	waitCtx, cancel := ContextWithTimeout(ctx, timeout)
+	defer cancel()
+
+	lockCtx, err := t.Lock(waitCtx)
+	if err != nil {
+		return ctx, err
+	}
+
+	locks, _ := lockCtx.Value(sessionLockKey{}).([]*sessionLock)
+
+	return context.WithValue(ctx, sessionLockKey{}, locks), nil
+}
+
+// Lock acquires the driver's session lock, so that the calls made with the
+// returned context run without calls from other goroutines between them. It
+// waits until the lock is free, until ctx is done, or, when ctx has no
+// deadline, for the driver's timeout. Release the lock by passing the
+// returned context to Unlock:
+//
+//	ctx, err := supply.Lock(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	defer supply.Unlock(ctx)
+//
+// Each call to the instrument runs alone, and a driver method whose calls
+// must not be interleaved with another goroutine's, such as selecting a
+// waveform source and then reading its data, holds the lock for its own
+// sequence with [LockSession]. Nothing holds the lock between methods, so
+// a sequence of methods that must see no other goroutine's changes between
+// them, such as reading a setting and then writing one derived from it,
+// needs Lock. Lock returns [ErrFunctionNotSupported] for an Inherent not
+// created by [NewDriverSetup], whose transport is not a [SessionTransport].
+func (inherent *Inherent) Lock(ctx context.Context) (context.Context, error) {
+	if inherent.session == nil {
+		return ctx, fmt.Errorf("Lock: %w", ErrFunctionNotSupported)
+	}
+
+	return inherent.session.lockFor(ctx, inherent.timeout)
+}
+
+// Unlock releases the session lock held by ctx, the context returned by Lock
+// or one derived from it. It returns [ErrNotLocked] when ctx does not hold
+// the lock.
+func (inherent *Inherent) Unlock(ctx context.Context) error {
+	if inherent.session == nil {
+		return fmt.Errorf("Unlock: %w", ErrFunctionNotSupported)
+	}
+
+	return inherent.session.Unlock(ctx)
+}
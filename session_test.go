@@ -0,0 +1,250 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// mockEchoInst answers each query with the last command sent, so a query
+// whose response belongs to another goroutine's command is detectable. It
+// also counts how many calls are in progress at once.
+type mockEchoInst struct {
+	mu       sync.Mutex
+	last     string
+	inFlight atomic.Int32
+	overlaps atomic.Int32
+}
+
+func (m *mockEchoInst) enter() func() {
+	if m.inFlight.Add(1) > 1 {
+		m.overlaps.Add(1)
+	}
+
+	return func() { m.inFlight.Add(-1) }
+}
+
+func (m *mockEchoInst) ReadBinary(_ context.Context, _ []byte) (int, error) {
+	defer m.enter()()
+	return 0, nil
+}
+
+func (m *mockEchoInst) WriteBinary(_ context.Context, p []byte) (int, error) {
+	defer m.enter()()
+	return len(p), nil
+}
+
+func (m *mockEchoInst) Command(_ context.Context, format string, a ...any) error {
+	defer m.enter()()
+	m.mu.Lock()
+	m.last = FormatCommand(format, a)
+	m.mu.Unlock()
+	time.Sleep(50 * time.Microsecond)
+	return nil
+}
+
+func (m *mockEchoInst) Query(_ context.Context, _ string) (string, error) {
+	defer m.enter()()
+	time.Sleep(50 * time.Microsecond)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.last, nil
+}
+
+func TestSessionTransport_SerializesCalls(t *testing.T) {
+	mock := &mockEchoInst{}
+	session := NewSessionTransport(mock)
+
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Go(func() {
+			for range 20 {
+				_, _ = session.Query(t.Context(), "VOLT?")
+				_ = session.Command(t.Context(), "VOLT %d", g)
+				_, _ = session.WriteBinary(t.Context(), []byte("x"))
+			}
+		})
+	}
+	wg.Wait()
+
+	if n := mock.overlaps.Load(); n != 0 {
+		t.Errorf("%d calls overlapped another call, want 0", n)
+	}
+}
+
+func TestSessionTransport_LockSpansCalls(t *testing.T) {
+	mock := &mockEchoInst{}
+	session := NewSessionTransport(mock)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for g := range 8 {
+		wg.Go(func() {
+			for range 20 {
+				ctx, err := session.Lock(t.Context())
+				if err != nil {
+					errs <- err
+					return
+				}
+				want := fmt.Sprintf("VOLT %d", g)
+				_ = session.Command(ctx, "%s", want)
+				got, _ := session.Query(ctx, "VOLT?")
+				if err := session.Unlock(ctx); err != nil {
+					errs <- err
+					return
+				}
+				if got != want {
+					errs <- fmt.Errorf("goroutine %d read %q", g, got)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestSessionTransport_LockBlocksOthers(t *testing.T) {
+	session := NewSessionTransport(&mockEchoInst{})
+
+	lockCtx, err := session.Lock(t.Context())
+	if err != nil {
+		t.Fatalf("Lock() error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := session.Command(ctx, "VOLT 1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Command() while locked error = %v, want %v",
+			err, context.DeadlineExceeded)
+	}
+	if _, err := session.Lock(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Lock() while locked error = %v, want %v",
+			err, context.DeadlineExceeded)
+	}
+
+	// The holder's own calls proceed, including with a derived context.
+	derived, cancel := context.WithTimeout(lockCtx, time.Second)
+	defer cancel()
+	if err := session.Command(derived, "VOLT 2"); err != nil {
+		t.Errorf("Command() by holder error: %v", err)
+	}
+
+	if err := session.Unlock(t.Context()); !errors.Is(err, ErrNotLocked) {
+		t.Errorf("Unlock() without the lock error = %v, want %v",
+			err, ErrNotLocked)
+	}
+	if err := session.Unlock(derived); err != nil {
+		t.Fatalf("Unlock() error: %v", err)
+	}
+	if err := session.Unlock(lockCtx); !errors.Is(err, ErrNotLocked) {
+		t.Errorf("second Unlock() error = %v, want %v", err, ErrNotLocked)
+	}
+
+	// A context that held an earlier lock no longer bypasses the session.
+	if err := session.Command(lockCtx, "VOLT 3"); err != nil {
+		t.Errorf("Command() after Unlock error: %v", err)
+	}
+}
+
+func TestInherent_Lock(t *testing.T) {
+	ds, err := NewDriverSetup(&mockEchoInst{}, InherentBase{},
+		[]DriverOption{WithoutIDQuery(), WithTimeout(10 * time.Millisecond)})
+	if err != nil {
+		t.Fatalf("NewDriverSetup() error: %v", err)
+	}
+	inherent := &ds.Inherent
+
+	ctx, err := inherent.Lock(t.Context())
+	if err != nil {
+		t.Fatalf("Lock() error: %v", err)
+	}
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("Lock() returned a context with the wait's deadline")
+	}
+
+	// A second Lock gives up after the driver's timeout.
+	if _, err := inherent.Lock(t.Context()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second Lock() error = %v, want %v",
+			err, context.DeadlineExceeded)
+	}
+
+	if err := ds.Transport.Command(ctx, "*CLS"); err != nil {
+		t.Errorf("Command() by holder error: %v", err)
+	}
+	if err := inherent.Unlock(ctx); err != nil {
+		t.Errorf("Unlock() error: %v", err)
+	}
+}
+
+func TestInherent_LockWithoutSession(t *testing.T) {
+	inherent := NewInherent(&mockEchoInst{}, InherentBase{}, 0)
+
+	if _, err := inherent.Lock(t.Context()); !errors.Is(err, ErrFunctionNotSupported) {
+		t.Errorf("Lock() error = %v, want %v", err, ErrFunctionNotSupported)
+	}
+	if err := inherent.Unlock(t.Context()); !errors.Is(err, ErrFunctionNotSupported) {
+		t.Errorf("Unlock() error = %v, want %v", err, ErrFunctionNotSupported)
+	}
+}
+
+func TestLockSession(t *testing.T) {
+	session := NewSessionTransport(&mockEchoInst{})
+
+	ctx, unlock, err := LockSession(t.Context(), session, time.Second)
+	if err != nil {
+		t.Fatalf("LockSession() error: %v", err)
+	}
+	if !session.holds(ctx) {
+		t.Fatal("LockSession() context does not hold the lock")
+	}
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("LockSession() returned a context with the wait's deadline")
+	}
+
+	// Another caller gives up after the timeout.
+	_, _, err = LockSession(t.Context(), session, time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second LockSession() error = %v, want %v",
+			err, context.DeadlineExceeded)
+	}
+
+	// A nested LockSession, as when one driver method calls another, leaves
+	// the outer lock held when it releases.
+	inner, unlockInner, err := LockSession(ctx, session, time.Second)
+	if err != nil {
+		t.Fatalf("nested LockSession() error: %v", err)
+	}
+	unlockInner()
+	if !session.holds(inner) {
+		t.Error("nested release dropped the outer lock")
+	}
+
+	unlock()
+	if session.holds(ctx) {
+		t.Error("release left the lock held")
+	}
+
+	// Transports without a session are left alone.
+	plain := &mockEchoInst{}
+	got, unlock, err := LockSession(t.Context(), plain, time.Second)
+	if err != nil || got != t.Context() {
+		t.Errorf("LockSession(plain) = %v, %v, want the context unchanged",
+			got, err)
+	}
+	unlock()
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/gotmc/ivi/internal/scpisim"
+)
+
+// defaultSimulatedManufacturer is reported by a simulated instrument's *IDN?
+// when the driver's Simulation does not name a manufacturer.
+const defaultSimulatedManufacturer = "Simulated"
+
+// Simulation describes the instrument a driver simulates when it is created
+// with [WithSimulate]. Drivers set it in their [InherentBase].
+type Simulation struct {
+	// Manufacturer is reported in the simulated *IDN? response.
+	Manufacturer string
+	// Attributes describes the instrument settings the driver reads, keyed
+	// by command header as the driver spells it, without a leading colon or
+	// trailing question mark (e.g., "SOUR1:FREQ"). Settings the driver only
+	// reads as numbers or booleans, whose default of "0" is plausible, need
+	// no entry.
+	Attributes map[string]SimulatedAttribute
+	// ModelAttributes holds, per model, attributes that apply only to that
+	// model (e.g., output ratings). They override Attributes with the same
+	// key.
+	ModelAttributes map[string]map[string]SimulatedAttribute
+	// Blocks holds the data of queries answered with IEEE 488.2 definite
+	// length blocks, such as a waveform, keyed by query header (e.g.,
+	// "WAV:DATA"). The driver sends the query with Command and reads the
+	// block with ReadBinary.
+	Blocks map[string][]byte
+}
+
+// SimulatedAttribute describes one simulated instrument setting.
+type SimulatedAttribute struct {
+	// Default is the value read before the setting is first set. It may
+	// include the current value of another header as {HEADER} (e.g.,
+	// "MAIN:RANG {TIM:RANG}").
+	Default string
+	// Min and Max, when Min < Max, bound the numeric values the simulated
+	// instrument accepts. Setting a value outside them returns an error
+	// wrapping [ErrValueNotSupported].
+	Min, Max float64
+	// Follows names another header whose value this one reads, so that a
+	// measurement such as MEAS:VOLT reports the VOLT setting.
+	Follows string
+	// Sets is a command the simulated instrument applies along with every
+	// command with this header, for commands that change other settings. Its
+	// units are separated by semicolons, and $1, $2, and so on stand for the
+	// parameters of the triggering command (e.g., "FUNC SIN;FREQ $1").
+	Sets string
+}
+
+// Simulate reports whether the driver simulates the instrument, as enabled by
+// [WithSimulate]. Simulate is the getter for the inherent attribute Simulate
+// described in IVI-3.2: Inherent Capabilities Specification.
+func (inherent *Inherent) Simulate() bool {
+	return inherent.simulate
+}
+
+// simulatedTransport adapts a scpisim.Instrument to Transport, translating
+// its range errors to ErrValueNotSupported.
+type simulatedTransport struct {
+	*scpisim.Instrument
+}
+
+// newSimulatedTransport returns a transport simulating the given model as
+// described by sim, with the attributes and blocks of data replacing those of
+// sim. Either may be nil.
+func newSimulatedTransport(
+	sim *Simulation,
+	model string,
+	data *Simulation,
+) *simulatedTransport {
+	manufacturer := defaultSimulatedManufacturer
+
+	attrs := make(map[string]scpisim.Attribute)
+	blocks := make(map[string][]byte)
+
+	if sim != nil && sim.Manufacturer != "" {
+		manufacturer = sim.Manufacturer
+	}
+
+	for _, s := range []*Simulation{sim, data} {
+		if s == nil {
+			continue
+		}
+
+		maps.Copy(blocks, s.Blocks)
+
+		for _, m := range []map[string]SimulatedAttribute{
+			s.Attributes, s.ModelAttributes[model],
+		} {
+			for header, a := range m {
+				attrs[header] = scpisim.Attribute{
+					Default: a.Default,
+					Min:     a.Min,
+					Max:     a.Max,
+					Follows: a.Follows,
+					Sets:    a.Sets,
+				}
+			}
+		}
+	}
+
+	idn := fmt.Sprintf("%s,%s,SIM000000,SIM-1.0", manufacturer, model)
+
+	return &simulatedTransport{
+		Instrument: scpisim.New(scpisim.Config{
+			IDN:        idn,
+			Attributes: attrs,
+			Blocks:     blocks,
+		}),
+	}
+}
+
+// Command applies the command to the simulated instrument.
+func (t *simulatedTransport) Command(
+	ctx context.Context,
+	cmd string,
+	a ...any,
+) error {
+	err := t.Instrument.Command(ctx, FormatCommand(cmd, a))
+	if errors.Is(err, scpisim.ErrDataOutOfRange) {
+		return fmt.Errorf("simulated instrument: %w: %w", ErrValueNotSupported, err)
+	}
+
+	return err
+}
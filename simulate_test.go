@@ -0,0 +1,121 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func simulatedBase() InherentBase {
+	return InherentBase{
+		SupportedInstrumentModels: []string{"M100", "M200"},
+		ResetDelay:                time.Hour,
+		Simulation: &Simulation{
+			Manufacturer: "Acme",
+			Attributes: map[string]SimulatedAttribute{
+				"VOLT":      {Default: "1.0", Min: 0, Max: 10},
+				"MEAS:VOLT": {Follows: "VOLT"},
+			},
+			ModelAttributes: map[string]map[string]SimulatedAttribute{
+				"M200": {"VOLT": {Default: "2.0", Min: 0, Max: 20}},
+			},
+			Blocks: map[string][]byte{"TRAC:DATA": {0x01, 0x02}},
+		},
+	}
+}
+
+func TestNewDriverSetup_Simulate(t *testing.T) {
+	ds, err := NewDriverSetup(nil, simulatedBase(), []DriverOption{WithSimulate()})
+	if err != nil {
+		t.Fatalf("NewDriverSetup() error: %v", err)
+	}
+	ctx := t.Context()
+
+	mfr, err := ds.Inherent.InstrumentManufacturer(ctx)
+	if err != nil || mfr != "Acme" {
+		t.Errorf("InstrumentManufacturer() = %q, %v, want Acme", mfr, err)
+	}
+	model, err := ds.Inherent.InstrumentModel(ctx)
+	if err != nil || model != "M100" {
+		t.Errorf("InstrumentModel() = %q, %v, want M100", model, err)
+	}
+
+	if got, _ := ds.Transport.Query(ctx, "VOLT?"); got != "1.0" {
+		t.Errorf("VOLT? = %q, want 1.0", got)
+	}
+	if err := ds.Transport.Command(ctx, "VOLT %.1f", 7.5); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+	if got, _ := ds.Transport.Query(ctx, "MEAS:VOLT?"); got != "7.5" {
+		t.Errorf("MEAS:VOLT? = %q, want 7.5", got)
+	}
+
+	err = ds.Transport.Command(ctx, "VOLT %.1f", 15.0)
+	if !errors.Is(err, ErrValueNotSupported) {
+		t.Errorf("out of range Command() error = %v, want ErrValueNotSupported", err)
+	}
+
+	if err := ds.Transport.Command(ctx, "TRAC:DATA?"); err != nil {
+		t.Fatalf("Command(TRAC:DATA?) error: %v", err)
+	}
+	if got, err := ReadBlock(ctx, ds.Transport); err != nil ||
+		string(got) != "\x01\x02" {
+		t.Errorf("ReadBlock() = %q, %v, want \\x01\\x02", got, err)
+	}
+}
+
+func TestNewDriverSetup_SimulatedModel(t *testing.T) {
+	ds, err := NewDriverSetup(nil, simulatedBase(), []DriverOption{
+		WithSimulate(), WithSimulatedModel("M200"),
+	})
+	if err != nil {
+		t.Fatalf("NewDriverSetup() error: %v", err)
+	}
+	ctx := t.Context()
+
+	model, err := ds.Inherent.InstrumentModel(ctx)
+	if err != nil || model != "M200" {
+		t.Errorf("InstrumentModel() = %q, %v, want M200", model, err)
+	}
+	if got, _ := ds.Transport.Query(ctx, "VOLT?"); got != "2.0" {
+		t.Errorf("VOLT? = %q, want 2.0", got)
+	}
+	if err := ds.Transport.Command(ctx, "VOLT 15"); err != nil {
+		t.Errorf("Command(VOLT 15) error = %v, want nil", err)
+	}
+}
+
+func TestNewDriverSetup_SimulateWithoutSimulation(t *testing.T) {
+	base := InherentBase{SupportedInstrumentModels: []string{"M100"}}
+
+	ds, err := NewDriverSetup(nil, base, []DriverOption{WithSimulate()})
+	if err != nil {
+		t.Fatalf("NewDriverSetup() error: %v", err)
+	}
+
+	mfr, err := ds.Inherent.InstrumentManufacturer(t.Context())
+	if err != nil || mfr != defaultSimulatedManufacturer {
+		t.Errorf("InstrumentManufacturer() = %q, %v, want %q",
+			mfr, err, defaultSimulatedManufacturer)
+	}
+}
+
+func TestSimulate_ResetSkipsDelay(t *testing.T) {
+	ds, err := NewDriverSetup(nil, simulatedBase(), []DriverOption{WithSimulate()})
+	if err != nil {
+		t.Fatalf("NewDriverSetup() error: %v", err)
+	}
+
+	start := time.Now()
+	if err := ds.Inherent.Reset(t.Context()); err != nil {
+		t.Fatalf("Reset() error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Reset() took %v, want the delay skipped", elapsed)
+	}
+}
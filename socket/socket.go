@@ -0,0 +1,251 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+// Package socket provides an [ivi.Transport] for instruments that accept SCPI
+// over a raw TCP socket, conventionally on port 5025. Messages are newline
+// terminated in both directions, and binary responses are IEEE 488.2 definite
+// length blocks.
+//
+// Dial a connection and pass it to a driver constructor:
+//
+//	conn, err := socket.Dial(ctx, "192.168.1.100")
+//	if err != nil {
+//		return err
+//	}
+//	defer conn.Close()
+//	dmm, err := kt34400.New(conn)
+package socket
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gotmc/ivi"
+)
+
+// DefaultPort is the port used when the address given to Dial has none. It
+// is the SCPI raw socket port of most LAN instruments.
+const DefaultPort = "5025"
+
+// Conn is a SCPI raw socket connection. It implements [ivi.Transport]. Each
+// call honors its context's deadline and cancellation. Like the instrument at
+// the other end, a Conn handles one exchange at a time; drivers serialize
+// their calls, so share a Conn between goroutines only through a driver or an
+// [ivi.SessionTransport].
+type Conn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+var _ ivi.Transport = (*Conn)(nil)
+
+// Dial connects to the instrument at address, a host or host:port. The
+// connection attempt is bounded by ctx.
+func Dial(ctx context.Context, address string) (*Conn, error) {
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "tcp", dialAddress(address))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConn(conn), nil
+}
+
+// DialResource connects to a TCPIP SOCKET resource, such as one parsed by
+// [ivi.ParseResource] and completed by [ivi.Resource.Settings]. A resource
+// without a port is dialed on DefaultPort. Other resources return an error
+// wrapping [ivi.ErrInvalidResource].
+func DialResource(ctx context.Context, r ivi.Resource) (*Conn, error) {
+	if r.Interface != ivi.InterfaceTCPIP || r.Class != ivi.ClassSocket {
+		return nil, fmt.Errorf(
+			"%w: %s is not a TCPIP SOCKET resource", ivi.ErrInvalidResource, r,
+		)
+	}
+
+	if r.Port == 0 {
+		return Dial(ctx, r.Host)
+	}
+
+	return Dial(ctx, r.Address())
+}
+
+// dialAddress adds DefaultPort to address when it names no port.
+func dialAddress(address string) string {
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address
+	}
+
+	return net.JoinHostPort(strings.Trim(address, "[]"), DefaultPort)
+}
+
+// NewConn returns a Conn communicating over an established connection.
+func NewConn(conn net.Conn) *Conn {
+	return &Conn{conn: conn, r: bufio.NewReader(conn)}
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// Command formats the command, when arguments are given, and sends it with a
+// newline terminator.
+func (c *Conn) Command(ctx context.Context, cmd string, a ...any) error {
+	return c.send(ctx, formatCommand(cmd, a))
+}
+
+// Query sends cmd and returns the response, without its terminator.
+func (c *Conn) Query(ctx context.Context, cmd string) (string, error) {
+	if err := c.send(ctx, cmd); err != nil {
+		return "", err
+	}
+
+	done, err := c.begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer done()
+
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", ioErr(ctx, "reading response to "+strconv.Quote(cmd), err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// ReadBinary reads the raw bytes of a response, such as block data requested
+// by a query sent with Command. Bytes are read in order after any part of a
+// response already buffered, so Command and ReadBinary can be mixed freely
+// with Query.
+func (c *Conn) ReadBinary(ctx context.Context, p []byte) (int, error) {
+	done, err := c.begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer done()
+
+	n, err := c.r.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = ioErr(ctx, "reading", err)
+	}
+
+	return n, err
+}
+
+// WriteBinary writes p as is, with no terminator added.
+func (c *Conn) WriteBinary(ctx context.Context, p []byte) (int, error) {
+	done, err := c.begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer done()
+
+	n, err := c.conn.Write(p)
+	if err != nil {
+		err = ioErr(ctx, "writing", err)
+	}
+
+	return n, err
+}
+
+// ReadBlock reads one IEEE 488.2 block response and its terminator with
+// [ivi.ReadBlock], and returns the block's data. A response that is not block
+// data returns an error wrapping [ivi.ErrInvalidBlock].
+func (c *Conn) ReadBlock(ctx context.Context) ([]byte, error) {
+	return ivi.ReadBlock(ctx, c)
+}
+
+// send writes msg with a newline terminator.
+func (c *Conn) send(ctx context.Context, msg string) error {
+	done, err := c.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if !strings.HasSuffix(msg, "\n") {
+		msg += "\n"
+	}
+
+	if _, err := io.WriteString(c.conn, msg); err != nil {
+		return ioErr(ctx, "sending "+strconv.Quote(msg), err)
+	}
+
+	return nil
+}
+
+// begin applies ctx's deadline to the connection, and arranges for
+// cancellation of ctx to interrupt blocked I/O, until done is called.
+func (c *Conn) begin(ctx context.Context) (func(), error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	// Once done is called, a late cancellation must not cut short the next
+	// call's I/O.
+	var (
+		mu       sync.Mutex
+		finished bool
+	)
+
+	stop := context.AfterFunc(ctx, func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !finished {
+			_ = c.conn.SetDeadline(time.Now())
+		}
+	})
+
+	return func() {
+		stop()
+		mu.Lock()
+		finished = true
+		mu.Unlock()
+	}, nil
+}
+
+// ioErr describes an I/O error, reporting the context's error instead when
+// the I/O failed because ctx was canceled or its deadline passed.
+func ioErr(ctx context.Context, what string, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("%s: %w", what, ctxErr)
+	}
+
+	// The connection's deadline, which is ctx's, can pass just before ctx
+	// reports it.
+	if _, ok := ctx.Deadline(); ok && errors.Is(err, os.ErrDeadlineExceeded) {
+		return fmt.Errorf("%s: %w", what, context.DeadlineExceeded)
+	}
+
+	return fmt.Errorf("%s: %w", what, err)
+}
+
+// formatCommand applies the format arguments, if any, to cmd. It takes the
+// arguments as a slice rather than variadically so that vet does not treat
+// Conn.Command as a printf wrapper.
+func formatCommand(cmd string, a []any) string {
+	if len(a) > 0 {
+		return fmt.Sprintf(cmd, a...)
+	}
+
+	return cmd
+}
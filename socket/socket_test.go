@@ -0,0 +1,287 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package socket
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/internal/ivitest"
+)
+
+// serve accepts one connection on a loopback listener and passes each
+// newline-terminated message received to handle, along with the connection
+// for any response. It returns the listener's address.
+func serve(t *testing.T, handle func(w io.Writer, msg string)) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			handle(conn, strings.TrimSuffix(line, "\n"))
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
+// serveSimulator serves sim, writing the response to each message whose last
+// program message unit is a query. A message carrying block data is passed
+// to sim as a binary write.
+func serveSimulator(t *testing.T, sim *ivitest.Simulator) string {
+	t.Helper()
+
+	return serve(t, func(w io.Writer, msg string) {
+		if strings.Contains(msg, " #") {
+			_, _ = sim.WriteBinary(context.Background(), []byte(msg+"\n"))
+			return
+		}
+
+		units := strings.Split(msg, ";")
+		if !strings.HasSuffix(units[len(units)-1], "?") {
+			_ = sim.Command(context.Background(), "%s", msg)
+			return
+		}
+
+		resp, err := sim.Query(context.Background(), msg)
+		if err != nil {
+			return
+		}
+		_, _ = io.WriteString(w, resp+"\n")
+	})
+}
+
+func dial(t *testing.T, address string) *Conn {
+	t.Helper()
+
+	conn, err := Dial(t.Context(), address)
+	if err != nil {
+		t.Fatalf("Dial() error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestDialAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{"192.168.1.100", "192.168.1.100:5025"},
+		{"192.168.1.100:5555", "192.168.1.100:5555"},
+		{"scope.local", "scope.local:5025"},
+		{"fe80::1", "[fe80::1]:5025"},
+		{"[fe80::1]", "[fe80::1]:5025"},
+		{"[fe80::1]:5555", "[fe80::1]:5555"},
+	}
+	for _, tc := range tests {
+		if got := dialAddress(tc.address); got != tc.want {
+			t.Errorf("dialAddress(%q) = %q, want %q", tc.address, got, tc.want)
+		}
+	}
+}
+
+func TestDialResource(t *testing.T) {
+	sim := ivitest.NewSimulator(ivitest.SimModel{IDN: "Acme,PS1,SN0,1.0"})
+	host, port, err := net.SplitHostPort(serveSimulator(t, sim))
+	if err != nil {
+		t.Fatalf("SplitHostPort() error: %v", err)
+	}
+
+	r, err := ivi.ParseResource("TCPIP0::" + host + "::" + port + "::SOCKET")
+	if err != nil {
+		t.Fatalf("ParseResource() error: %v", err)
+	}
+	conn, err := DialResource(t.Context(), r)
+	if err != nil {
+		t.Fatalf("DialResource() error: %v", err)
+	}
+	defer conn.Close()
+
+	if idn, err := conn.Query(t.Context(), "*IDN?"); err != nil ||
+		idn != "Acme,PS1,SN0,1.0" {
+		t.Errorf("Query(*IDN?) = %q, %v", idn, err)
+	}
+
+	gpib, err := ivi.ParseResource("GPIB0::5::INSTR")
+	if err != nil {
+		t.Fatalf("ParseResource() error: %v", err)
+	}
+	if _, err := DialResource(t.Context(), gpib); !errors.Is(
+		err, ivi.ErrInvalidResource,
+	) {
+		t.Errorf("DialResource(GPIB) error = %v, want ErrInvalidResource", err)
+	}
+}
+
+func TestConn_CommandAndQuery(t *testing.T) {
+	ctx := t.Context()
+	sim := ivitest.NewSimulator(ivitest.SimModel{
+		IDN: "Acme,PS1,SN0,1.0",
+		Attributes: map[string]ivitest.SimAttribute{
+			"VOLT": {Min: 0, Max: 30},
+		},
+	})
+	conn := dial(t, serveSimulator(t, sim))
+
+	if err := conn.Command(ctx, "VOLT %.2f", 12.5); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+	got, err := conn.Query(ctx, "VOLT?")
+	if err != nil || got != "12.50" {
+		t.Errorf("Query(VOLT?) = %q, %v, want 12.50", got, err)
+	}
+	idn, err := conn.Query(ctx, "*IDN?")
+	if err != nil || idn != "Acme,PS1,SN0,1.0" {
+		t.Errorf("Query(*IDN?) = %q, %v", idn, err)
+	}
+	sim.CheckErrors(t)
+}
+
+func TestConn_ReadBlock(t *testing.T) {
+	ctx := t.Context()
+	data := []byte("1,2\n3,4\r\n\x00\xff")
+	sim := ivitest.NewSimulator(ivitest.SimModel{
+		Blocks: map[string][]byte{"WAV:DATA": data},
+	})
+	conn := dial(t, serveSimulator(t, sim))
+
+	for range 2 {
+		if err := conn.Command(ctx, "WAV:DATA?"); err != nil {
+			t.Fatalf("Command() error: %v", err)
+		}
+		got, err := conn.ReadBlock(ctx)
+		if err != nil {
+			t.Fatalf("ReadBlock() error: %v", err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("ReadBlock() = %q, want %q", got, data)
+		}
+	}
+
+	// The block's terminator has been consumed, so the next response reads
+	// cleanly.
+	if got, err := conn.Query(ctx, "*OPC?"); err != nil || got != "1" {
+		t.Errorf("Query(*OPC?) = %q, %v, want 1", got, err)
+	}
+}
+
+func TestConn_ReadBlockIndefinite(t *testing.T) {
+	ctx := t.Context()
+	addr := serve(t, func(w io.Writer, _ string) {
+		_, _ = io.WriteString(w, "#0abc\n")
+	})
+	conn := dial(t, addr)
+
+	if err := conn.Command(ctx, "DATA?"); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+	got, err := conn.ReadBlock(ctx)
+	if err != nil || string(got) != "abc" {
+		t.Errorf("ReadBlock() = %q, %v, want abc", got, err)
+	}
+}
+
+func TestConn_ReadBlockInvalid(t *testing.T) {
+	ctx := t.Context()
+	addr := serve(t, func(w io.Writer, _ string) {
+		_, _ = io.WriteString(w, "+1.0E+00\n")
+	})
+	conn := dial(t, addr)
+
+	if err := conn.Command(ctx, "DATA?"); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+	if _, err := conn.ReadBlock(ctx); !errors.Is(err, ivi.ErrInvalidBlock) {
+		t.Errorf("ReadBlock() error = %v, want ErrInvalidBlock", err)
+	}
+}
+
+func TestConn_BinaryReadWrite(t *testing.T) {
+	ctx := t.Context()
+	sim := ivitest.NewSimulator(ivitest.SimModel{
+		Blocks: map[string][]byte{"DATA": nil},
+	})
+	conn := dial(t, serveSimulator(t, sim))
+
+	if _, err := conn.WriteBinary(ctx, []byte("DATA #14wxyz\n")); err != nil {
+		t.Fatalf("WriteBinary() error: %v", err)
+	}
+	if err := conn.Command(ctx, "DATA?"); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+
+	want := "#14wxyz\n"
+	got := make([]byte, len(want))
+	if _, err := io.ReadFull(readerFunc(func(p []byte) (int, error) {
+		return conn.ReadBinary(ctx, p)
+	}), got); err != nil {
+		t.Fatalf("ReadBinary() error: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("ReadBinary() = %q, want %q", got, want)
+	}
+}
+
+func TestConn_Deadline(t *testing.T) {
+	// The server never answers.
+	conn := dial(t, serve(t, func(io.Writer, string) {}))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := conn.Query(ctx, "*IDN?"); !errors.Is(
+		err, context.DeadlineExceeded,
+	) {
+		t.Errorf("Query() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestConn_Cancel(t *testing.T) {
+	conn := dial(t, serve(t, func(io.Writer, string) {}))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	if _, err := conn.Query(ctx, "*IDN?"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Query() error = %v, want context.Canceled", err)
+	}
+
+	// A canceled call does not leave its deadline on the connection.
+	if err := conn.Command(t.Context(), "*CLS"); err != nil {
+		t.Errorf("Command() after cancel error: %v", err)
+	}
+}
+
+// readerFunc adapts a function to io.Reader.
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) { return f(p) }
@@ -10,3 +10,29 @@
 // Files are split based on the class capability groups listed in Table 2-1
 // IviSpecAn Group Names in the IVI-4.8 IviSpecAn Class Specification.
 package specan
+
+import (
+	"reflect"
+
+	"github.com/gotmc/ivi"
+)
+
+// Groups lists the capability groups of the IviSpecAn class, with the Go
+// interfaces that implement each, for [ivi.Introspect] and
+// [ivi.CheckGroupCapabilities].
+var Groups = []ivi.CapabilityGroup{
+	{Name: "IviSpecAnBase", Driver: reflect.TypeFor[Base]()},
+}
+
+// Drivers holds the IviSpecAn drivers, by the instrument models they support.
+// Each driver registers itself from an init function, so importing a driver
+// package, if only for its side effects, makes it available to Drivers.Open,
+// which picks the driver for the connected instrument's model, and to
+// Drivers.OpenDriver, which creates a driver by name. Both return the driver as
+// a Base; assert it to other IviSpecAn interfaces, or to the driver's own type,
+// for the capabilities beyond the base group. See [ivi.Registry].
+//
+//	import _ "github.com/gotmc/ivi/specan/keysight/esa"
+//
+//	analyzer, err := specan.Drivers.Open(ctx, inst)
+var Drivers ivi.Registry[Base]
@@ -6,6 +6,7 @@
 package specan
 
 import (
+	"context"
 	"time"
 )
 
@@ -68,72 +69,97 @@ Below are the .NET attributes, since they are the basis for the Go interfaces.
 // Base provides the interface required for the IviSpecAnBase capability group.
 type Base interface {
 	// Amplitude and level
-	AmplitudeUnits() (AmplitudeUnits, error)
-	SetAmplitudeUnits(units AmplitudeUnits) error
-	ReferenceLevel() (float64, error)
-	SetReferenceLevel(level float64) error
-	ReferenceLevelOffset() (float64, error)
-	SetReferenceLevelOffset(offset float64) error
-	InputImpedance() (float64, error)
-	SetInputImpedance(impedance float64) error
-	VerticalScale() (VerticalScale, error)
-	SetVerticalScale(scale VerticalScale) error
+	AmplitudeUnits(ctx context.Context) (AmplitudeUnits, error)
+	SetAmplitudeUnits(ctx context.Context, units AmplitudeUnits) error
+	ReferenceLevel(ctx context.Context) (float64, error)
+	SetReferenceLevel(ctx context.Context, level float64) error
+	ReferenceLevelOffset(ctx context.Context) (float64, error)
+	SetReferenceLevelOffset(ctx context.Context, offset float64) error
+	InputImpedance(ctx context.Context) (float64, error)
+	SetInputImpedance(ctx context.Context, impedance float64) error
+	VerticalScale(ctx context.Context) (VerticalScale, error)
+	SetVerticalScale(ctx context.Context, scale VerticalScale) error
 
 	// Attenuation
-	Attenuation() (float64, error)
-	SetAttenuation(attenuation float64) error
-	AttenuationAuto() (bool, error)
-	SetAttenuationAuto(auto bool) error
+	Attenuation(ctx context.Context) (float64, error)
+	SetAttenuation(ctx context.Context, attenuation float64) error
+	AttenuationAuto(ctx context.Context) (bool, error)
+	SetAttenuationAuto(ctx context.Context, auto bool) error
 
 	// Frequency
-	FrequencyStart() (float64, error)
-	SetFrequencyStart(freq float64) error
-	FrequencyStop() (float64, error)
-	SetFrequencyStop(freq float64) error
-	FrequencyOffset() (float64, error)
-	SetFrequencyOffset(offset float64) error
+	FrequencyStart(ctx context.Context) (float64, error)
+	SetFrequencyStart(ctx context.Context, freq float64) error
+	FrequencyStop(ctx context.Context) (float64, error)
+	SetFrequencyStop(ctx context.Context, freq float64) error
+	FrequencyOffset(ctx context.Context) (float64, error)
+	SetFrequencyOffset(ctx context.Context, offset float64) error
 
 	// Bandwidth
-	ResolutionBandwidth() (float64, error)
-	SetResolutionBandwidth(bw float64) error
-	ResolutionBandwidthAuto() (bool, error)
-	SetResolutionBandwidthAuto(auto bool) error
-	VideoBandwidth() (float64, error)
-	SetVideoBandwidth(bw float64) error
-	VideoBandwidthAuto() (bool, error)
-	SetVideoBandwidthAuto(auto bool) error
+	ResolutionBandwidth(ctx context.Context) (float64, error)
+	SetResolutionBandwidth(ctx context.Context, bw float64) error
+	ResolutionBandwidthAuto(ctx context.Context) (bool, error)
+	SetResolutionBandwidthAuto(ctx context.Context, auto bool) error
+	VideoBandwidth(ctx context.Context) (float64, error)
+	SetVideoBandwidth(ctx context.Context, bw float64) error
+	VideoBandwidthAuto(ctx context.Context) (bool, error)
+	SetVideoBandwidthAuto(ctx context.Context, auto bool) error
 
 	// Sweep
-	SweepModeContinuous() (bool, error)
-	SetSweepModeContinuous(continuous bool) error
-	SweepTime() (float64, error)
-	SetSweepTime(sweepTime float64) error
-	SweepTimeAuto() (bool, error)
-	SetSweepTimeAuto(auto bool) error
-	NumberOfSweeps() (int, error)
-	SetNumberOfSweeps(num int) error
+	SweepModeContinuous(ctx context.Context) (bool, error)
+	SetSweepModeContinuous(ctx context.Context, continuous bool) error
+	SweepTime(ctx context.Context) (float64, error)
+	SetSweepTime(ctx context.Context, sweepTime float64) error
+	SweepTimeAuto(ctx context.Context) (bool, error)
+	SetSweepTimeAuto(ctx context.Context, auto bool) error
+	NumberOfSweeps(ctx context.Context) (int, error)
+	SetNumberOfSweeps(ctx context.Context, num int) error
 
 	// Trace
 	TraceCount() int
-	TraceType(traceName string) (TraceType, error)
-	SetTraceType(traceName string, traceType TraceType) error
-	DetectorType(traceName string) (DetectorType, error)
-	SetDetectorType(traceName string, detector DetectorType) error
-	DetectorTypeAuto(traceName string) (bool, error)
-	SetDetectorTypeAuto(traceName string, auto bool) error
+	TraceType(ctx context.Context, traceName string) (TraceType, error)
+	SetTraceType(
+		ctx context.Context,
+		traceName string,
+		traceType TraceType,
+	) error
+	DetectorType(ctx context.Context, traceName string) (DetectorType, error)
+	SetDetectorType(
+		ctx context.Context,
+		traceName string,
+		detector DetectorType,
+	) error
+	DetectorTypeAuto(ctx context.Context, traceName string) (bool, error)
+	SetDetectorTypeAuto(ctx context.Context, traceName string, auto bool) error
 
 	// Acquisition control
-	Abort() error
-	AcquisitionStatus() (AcquisitionStatus, error)
-	Initiate() error
+	Abort(ctx context.Context) error
+	AcquisitionStatus(ctx context.Context) (AcquisitionStatus, error)
+	Initiate(ctx context.Context) error
 
 	// Configuration helpers
-	ConfigureFrequencyCenterSpan(centerFreq, span float64) error
-	ConfigureFrequencyStartStop(startFreq, stopFreq float64) error
-	ConfigureLevel(units AmplitudeUnits, refLevel float64) error
-	ConfigureSweepCoupling(resBW, videoBW, sweepTime float64) error
+	ConfigureFrequencyCenterSpan(
+		ctx context.Context,
+		centerFreq, span float64,
+	) error
+	ConfigureFrequencyStartStop(
+		ctx context.Context,
+		startFreq, stopFreq float64,
+	) error
+	ConfigureLevel(
+		ctx context.Context,
+		units AmplitudeUnits,
+		refLevel float64,
+	) error
+	ConfigureSweepCoupling(
+		ctx context.Context,
+		resBW, videoBW, sweepTime float64,
+	) error
 
 	// Trace data
-	FetchYTrace(traceName string) ([]float64, error)
-	ReadYTrace(traceName string, maxTime time.Duration) ([]float64, error)
+	FetchYTrace(ctx context.Context, traceName string) ([]float64, error)
+	ReadYTrace(
+		ctx context.Context,
+		traceName string,
+		maxTime time.Duration,
+	) ([]float64, error)
 }
@@ -7,11 +7,19 @@
 // spectrum analyzers. The driver also supports the PSA, EMC, and X-Series
 // analyzers that share compatible SCPI command sets.
 //
-// State Caching: Not implemented
+// State Caching: Implemented for the IviSpecAnBase amplitude, frequency,
+// bandwidth, and sweep attributes when the driver is created with
+// [ivi.WithCache].
+//
+// Range Checking: Resolution bandwidth when the driver is created with
+// [ivi.WithRangeCheck]. For a model whose resolution bandwidths are not
+// recorded, New returns an error wrapping [ivi.ErrFunctionNotSupported].
 package esa
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gotmc/ivi"
@@ -27,11 +35,21 @@ const (
 // Confirm the interfaces implemented by the driver.
 var _ specan.Base = (*Driver)(nil)
 
+// Register the driver with [specan.Drivers] so that Open can select it by
+// model.
+func init() {
+	specan.Drivers.Register(
+		"esa", supportedModels(), ivi.Factory[specan.Base](New),
+	)
+}
+
 // Driver provides the IVI driver for Keysight/Agilent ESA, PSA, EMC, and
 // X-Series spectrum analyzers.
 type Driver struct {
 	inst    ivi.Transport
+	cache   *ivi.AttributeCache // nil unless ivi.WithCache is given
 	timeout time.Duration
+	rbw     *ivi.Range // nil unless range checking the resolution bandwidth
 	ivi.Inherent
 }
 
@@ -42,41 +60,45 @@ type Driver struct {
 // default I/O timeout.
 func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	s, err := ivi.NewDriverSetup(inst, ivi.InherentBase{
-		ClassSpecMajorVersion: specMajorVersion,
-		ClassSpecMinorVersion: specMinorVersion,
-		ClassSpecRevision:     specRevision,
-		ResetDelay:            500 * time.Millisecond,
-		ClearDelay:            500 * time.Millisecond,
-		ReturnToLocal:         true,
-		GroupCapabilities:     []string{"IviSpecAnBase"},
-		SupportedInstrumentModels: []string{
-			// ESA-L Series
-			"E4411B",
-			// ESA-E Series
-			"E4401B", "E4402B", "E4403B", "E4404B",
-			"E4405B", "E4407B", "E4408B",
-			// EMC Series
-			"E7401A", "E7402A", "E7403A", "E7404A", "E7405A",
-			// PSA Series
-			"E4440A", "E4443A", "E4445A", "E4446A",
-			"E4447A", "E4448A", "N8201A",
-			// X-Series
-			"N9030A", "N9020A", "N9010A", "N9000A",
-		},
-		SupportedBusInterfaces: []string{"GPIB", "TCPIP"},
+		ClassSpecMajorVersion:     specMajorVersion,
+		ClassSpecMinorVersion:     specMinorVersion,
+		ClassSpecRevision:         specRevision,
+		ResetDelay:                500 * time.Millisecond,
+		ClearDelay:                500 * time.Millisecond,
+		ReturnToLocal:             true,
+		SupportsOPC:               true,
+		GroupCapabilities:         []string{"IviSpecAnBase"},
+		SupportedInstrumentModels: supportedModels(),
+		SupportedBusInterfaces:    []string{"GPIB", "TCPIP"},
+		Simulation:                &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	driver := Driver{
-		inst:     inst,
+		inst:     s.Transport,
+		cache:    s.Cache,
 		timeout:  s.Timeout,
 		Inherent: s.Inherent,
 	}
 
+	if s.Config.RangeCheck {
+		model, err := driver.InstrumentModel(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error determining instrument model: %w", err)
+		}
+
+		rbw, ok := resolutionBandwidth(model)
+		if !ok {
+			return nil, ivi.RangeLimitsNotRecorded(model)
+		}
+
+		driver.rbw = &rbw
+	}
+
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
+		if err := driver.Reset(context.Background()); err != nil {
 			return &driver, err
 		}
 	}
@@ -84,9 +106,48 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	return &driver, nil
 }
 
-// newContext creates a context with the driver's configured timeout.
-func (d *Driver) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), d.timeout)
+// supportedModels returns the model numbers the driver supports.
+func supportedModels() []string {
+	return []string{
+		// ESA-L Series
+		"E4411B",
+		// ESA-E Series
+		"E4401B", "E4402B", "E4403B", "E4404B",
+		"E4405B", "E4407B", "E4408B",
+		// EMC Series
+		"E7401A", "E7402A", "E7403A", "E7404A", "E7405A",
+		// PSA Series
+		"E4440A", "E4443A", "E4445A", "E4446A",
+		"E4447A", "E4448A", "N8201A",
+		// X-Series
+		"N9030A", "N9020A", "N9010A", "N9000A",
+	}
+}
+
+// resolutionBandwidth returns the range of resolution bandwidths the model
+// offers with every bandwidth option installed, since the options installed
+// cannot be read from *IDN?.
+func resolutionBandwidth(model string) (ivi.Range, bool) {
+	switch {
+	case strings.HasPrefix(model, "E44") && strings.HasSuffix(model, "B"),
+		strings.HasPrefix(model, "E740"):
+		// ESA and EMC Series.
+		return ivi.Range{Min: 1, Max: 5e6}, true
+	case strings.HasPrefix(model, "E444"), model == "N8201A",
+		strings.HasPrefix(model, "N90"):
+		// PSA and X-Series.
+		return ivi.Range{Min: 1, Max: 8e6}, true
+	default:
+		return ivi.Range{}, false
+	}
+}
+
+// newContext derives a context from ctx that carries the driver's configured
+// timeout, unless ctx already has a deadline of its own.
+func (d *Driver) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, d.timeout)
 }
 
 // Close properly shuts down the spectrum analyzer by returning it to local
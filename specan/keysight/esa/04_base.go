@@ -37,25 +37,37 @@ var scpiToAmplitudeUnits = map[string]specan.AmplitudeUnits{
 	"A":    specan.AmplitudeUnitsWatt, // treat Amps as Watt equivalent
 }
 
-func (d *Driver) AmplitudeUnits() (specan.AmplitudeUnits, error) {
-	ctx, cancel := d.newContext()
-	defer cancel()
-
-	s, err := query.String(ctx, d.inst, "UNIT:POW?")
-	if err != nil {
-		return 0, fmt.Errorf("AmplitudeUnits: %w", err)
-	}
-
-	units, err := ivi.ReverseLookup(scpiToAmplitudeUnits, strings.TrimSpace(s))
-	if err != nil {
-		return 0, fmt.Errorf("AmplitudeUnits: %w", err)
-	}
-
-	return units, nil
-}
-
-func (d *Driver) SetAmplitudeUnits(units specan.AmplitudeUnits) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) AmplitudeUnits(
+	ctx context.Context,
+) (specan.AmplitudeUnits, error) {
+	ctx, cancel := d.newContext(ctx)
+	defer cancel()
+
+	return ivi.CachedGet(
+		d.cache, "AmplitudeUnits",
+		func() (specan.AmplitudeUnits, error) {
+			s, err := query.String(ctx, d.inst, "UNIT:POW?")
+			if err != nil {
+				return 0, fmt.Errorf("AmplitudeUnits: %w", err)
+			}
+
+			units, err := ivi.ReverseLookup(
+				scpiToAmplitudeUnits, strings.TrimSpace(s),
+			)
+			if err != nil {
+				return 0, fmt.Errorf("AmplitudeUnits: %w", err)
+			}
+
+			return units, nil
+		},
+	)
+}
+
+func (d *Driver) SetAmplitudeUnits(
+	ctx context.Context,
+	units specan.AmplitudeUnits,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(amplitudeUnitsToSCPI, units)
@@ -63,53 +75,98 @@ func (d *Driver) SetAmplitudeUnits(units specan.AmplitudeUnits) error {
 		return fmt.Errorf("SetAmplitudeUnits: %w", err)
 	}
 
-	return d.inst.Command(ctx, "UNIT:POW %s", cmd)
+	return ivi.CachedSet(
+		d.cache, "AmplitudeUnits", units,
+		func() error {
+			return d.inst.Command(ctx, "UNIT:POW %s", cmd)
+		},
+		"ReferenceLevel",
+	)
 }
 
 // --- Reference Level ---
 
-func (d *Driver) ReferenceLevel() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ReferenceLevel(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, d.inst, "DISP:WIND:TRAC:Y:RLEV?")
+	return ivi.CachedGet(
+		d.cache, "ReferenceLevel",
+		func() (float64, error) {
+			return query.Float64(ctx, d.inst, "DISP:WIND:TRAC:Y:RLEV?")
+		},
+	)
 }
 
-func (d *Driver) SetReferenceLevel(level float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetReferenceLevel(ctx context.Context, level float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return d.inst.Command(ctx, "DISP:WIND:TRAC:Y:RLEV %f", level)
+	return ivi.CoercedSet(
+		d.cache, "ReferenceLevel",
+		func() error {
+			return d.inst.Command(ctx, "DISP:WIND:TRAC:Y:RLEV %f", level)
+		},
+		"Attenuation",
+	)
 }
 
-func (d *Driver) ReferenceLevelOffset() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ReferenceLevelOffset(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, d.inst, "DISP:WIND:TRAC:Y:RLEV:OFFS?")
+	return ivi.CachedGet(
+		d.cache, "ReferenceLevelOffset",
+		func() (float64, error) {
+			return query.Float64(ctx, d.inst, "DISP:WIND:TRAC:Y:RLEV:OFFS?")
+		},
+	)
 }
 
-func (d *Driver) SetReferenceLevelOffset(offset float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetReferenceLevelOffset(
+	ctx context.Context,
+	offset float64,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return d.inst.Command(ctx, "DISP:WIND:TRAC:Y:RLEV:OFFS %f", offset)
+	return ivi.CoercedSet(
+		d.cache, "ReferenceLevelOffset",
+		func() error {
+			return d.inst.Command(ctx, "DISP:WIND:TRAC:Y:RLEV:OFFS %f", offset)
+		},
+		"ReferenceLevel",
+	)
 }
 
 // --- Input Impedance ---
 
-func (d *Driver) InputImpedance() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) InputImpedance(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, d.inst, "CORR:IMP:INP:MAGN?")
+	return ivi.CachedGet(
+		d.cache, "InputImpedance",
+		func() (float64, error) {
+			return query.Float64(ctx, d.inst, "CORR:IMP:INP:MAGN?")
+		},
+	)
 }
 
-func (d *Driver) SetInputImpedance(impedance float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetInputImpedance(
+	ctx context.Context,
+	impedance float64,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return d.inst.Command(ctx, "CORR:IMP:INP:MAGN %f", impedance)
+	return ivi.CachedSet(
+		d.cache, "InputImpedance", impedance,
+		func() error {
+			return d.inst.Command(ctx, "CORR:IMP:INP:MAGN %f", impedance)
+		},
+		"ReferenceLevel",
+	)
 }
 
 // --- Vertical Scale ---
@@ -124,25 +181,37 @@ var scpiToVerticalScale = map[string]specan.VerticalScale{
 	"LOG": specan.VerticalScaleLogarithmic,
 }
 
-func (d *Driver) VerticalScale() (specan.VerticalScale, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) VerticalScale(
+	ctx context.Context,
+) (specan.VerticalScale, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	s, err := query.String(ctx, d.inst, "DISP:WIND:TRAC:Y:SPAC?")
-	if err != nil {
-		return 0, fmt.Errorf("VerticalScale: %w", err)
-	}
+	return ivi.CachedGet(
+		d.cache, "VerticalScale",
+		func() (specan.VerticalScale, error) {
+			s, err := query.String(ctx, d.inst, "DISP:WIND:TRAC:Y:SPAC?")
+			if err != nil {
+				return 0, fmt.Errorf("VerticalScale: %w", err)
+			}
 
-	scale, err := ivi.ReverseLookup(scpiToVerticalScale, strings.TrimSpace(s))
-	if err != nil {
-		return 0, fmt.Errorf("VerticalScale: %w", err)
-	}
+			scale, err := ivi.ReverseLookup(
+				scpiToVerticalScale, strings.TrimSpace(s),
+			)
+			if err != nil {
+				return 0, fmt.Errorf("VerticalScale: %w", err)
+			}
 
-	return scale, nil
+			return scale, nil
+		},
+	)
 }
 
-func (d *Driver) SetVerticalScale(scale specan.VerticalScale) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetVerticalScale(
+	ctx context.Context,
+	scale specan.VerticalScale,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(verticalScaleToSCPI, scale)
@@ -150,217 +219,425 @@ func (d *Driver) SetVerticalScale(scale specan.VerticalScale) error {
 		return fmt.Errorf("SetVerticalScale: %w", err)
 	}
 
-	return d.inst.Command(ctx, "DISP:WIND:TRAC:Y:SPAC %s", cmd)
+	return ivi.CachedSet(
+		d.cache, "VerticalScale", scale,
+		func() error {
+			return d.inst.Command(ctx, "DISP:WIND:TRAC:Y:SPAC %s", cmd)
+		},
+	)
 }
 
 // --- Attenuation ---
 
-func (d *Driver) Attenuation() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) Attenuation(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, d.inst, "POW:ATT?")
+	return ivi.CachedGet(
+		d.cache, "Attenuation",
+		func() (float64, error) {
+			return query.Float64(ctx, d.inst, "POW:ATT?")
+		},
+	)
 }
 
-func (d *Driver) SetAttenuation(attenuation float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetAttenuation(
+	ctx context.Context,
+	attenuation float64,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return d.inst.Command(ctx, "POW:ATT %f", attenuation)
+	return ivi.CoercedSet(
+		d.cache, "Attenuation",
+		func() error {
+			return d.inst.Command(ctx, "POW:ATT %f", attenuation)
+		},
+		"AttenuationAuto",
+		"ReferenceLevel",
+	)
 }
 
-func (d *Driver) AttenuationAuto() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AttenuationAuto(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Bool(ctx, d.inst, "POW:ATT:AUTO?")
+	return ivi.CachedGet(
+		d.cache, "AttenuationAuto",
+		func() (bool, error) {
+			return query.Bool(ctx, d.inst, "POW:ATT:AUTO?")
+		},
+	)
 }
 
-func (d *Driver) SetAttenuationAuto(auto bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetAttenuationAuto(ctx context.Context, auto bool) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	if auto {
-		return d.inst.Command(ctx, "POW:ATT:AUTO ON")
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetAttenuationAuto: %w", err)
 	}
+	defer unlock()
+
+	return ivi.CachedSet(
+		d.cache, "AttenuationAuto", auto,
+		func() error {
+			if auto {
+				return d.inst.Command(ctx, "POW:ATT:AUTO ON")
+			}
 
-	return d.inst.Command(ctx, "POW:ATT:AUTO OFF")
+			return d.inst.Command(ctx, "POW:ATT:AUTO OFF")
+		},
+		"Attenuation",
+	)
 }
 
 // --- Frequency ---
 
-func (d *Driver) FrequencyStart() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) FrequencyStart(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, d.inst, "FREQ:STAR?")
+	return ivi.CachedGet(
+		d.cache, "FrequencyStart",
+		func() (float64, error) {
+			return query.Float64(ctx, d.inst, "FREQ:STAR?")
+		},
+	)
 }
 
-func (d *Driver) SetFrequencyStart(freq float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetFrequencyStart(ctx context.Context, freq float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return d.inst.Command(ctx, "FREQ:STAR %f", freq)
+	return ivi.CoercedSet(
+		d.cache, "FrequencyStart",
+		func() error {
+			return d.inst.Command(ctx, "FREQ:STAR %f", freq)
+		},
+		"FrequencyStop",
+		"ResolutionBandwidth",
+		"VideoBandwidth",
+		"SweepTime",
+	)
 }
 
-func (d *Driver) FrequencyStop() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) FrequencyStop(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, d.inst, "FREQ:STOP?")
+	return ivi.CachedGet(
+		d.cache, "FrequencyStop",
+		func() (float64, error) {
+			return query.Float64(ctx, d.inst, "FREQ:STOP?")
+		},
+	)
 }
 
-func (d *Driver) SetFrequencyStop(freq float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetFrequencyStop(ctx context.Context, freq float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return d.inst.Command(ctx, "FREQ:STOP %f", freq)
+	return ivi.CoercedSet(
+		d.cache, "FrequencyStop",
+		func() error {
+			return d.inst.Command(ctx, "FREQ:STOP %f", freq)
+		},
+		"FrequencyStart",
+		"ResolutionBandwidth",
+		"VideoBandwidth",
+		"SweepTime",
+	)
 }
 
-func (d *Driver) FrequencyOffset() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) FrequencyOffset(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, d.inst, "DISP:WIND:TRAC:X:OFFS?")
+	return ivi.CachedGet(
+		d.cache, "FrequencyOffset",
+		func() (float64, error) {
+			return query.Float64(ctx, d.inst, "DISP:WIND:TRAC:X:OFFS?")
+		},
+	)
 }
 
-func (d *Driver) SetFrequencyOffset(offset float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetFrequencyOffset(ctx context.Context, offset float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return d.inst.Command(ctx, "DISP:WIND:TRAC:X:OFFS %f", offset)
+	return ivi.CoercedSet(
+		d.cache, "FrequencyOffset",
+		func() error {
+			return d.inst.Command(ctx, "DISP:WIND:TRAC:X:OFFS %f", offset)
+		},
+		"FrequencyStart",
+		"FrequencyStop",
+	)
 }
 
 // --- Bandwidth ---
 
-func (d *Driver) ResolutionBandwidth() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ResolutionBandwidth(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, d.inst, "BAND?")
+	return ivi.CachedGet(
+		d.cache, "ResolutionBandwidth",
+		func() (float64, error) {
+			return query.Float64(ctx, d.inst, "BAND?")
+		},
+	)
 }
 
-func (d *Driver) SetResolutionBandwidth(bw float64) error {
-	ctx, cancel := d.newContext()
+// SetResolutionBandwidth sets the width of the IF filter in hertz. Under
+// [ivi.WithRangeCheck], a bandwidth outside the model's range returns an error
+// wrapping [ivi.ErrValueNotSupported] without being sent.
+func (d *Driver) SetResolutionBandwidth(ctx context.Context, bw float64) error {
+	if d.rbw != nil {
+		if err := d.rbw.Check(bw); err != nil {
+			return fmt.Errorf("SetResolutionBandwidth: %w", err)
+		}
+	}
+
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return d.inst.Command(ctx, "BAND %f", bw)
+	return ivi.CoercedSet(
+		d.cache, "ResolutionBandwidth",
+		func() error {
+			return d.inst.Command(ctx, "BAND %f", bw)
+		},
+		"ResolutionBandwidthAuto",
+		"VideoBandwidth",
+		"SweepTime",
+	)
 }
 
-func (d *Driver) ResolutionBandwidthAuto() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ResolutionBandwidthAuto(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Bool(ctx, d.inst, "BAND:AUTO?")
+	return ivi.CachedGet(
+		d.cache, "ResolutionBandwidthAuto",
+		func() (bool, error) {
+			return query.Bool(ctx, d.inst, "BAND:AUTO?")
+		},
+	)
 }
 
-func (d *Driver) SetResolutionBandwidthAuto(auto bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetResolutionBandwidthAuto(
+	ctx context.Context,
+	auto bool,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	if auto {
-		return d.inst.Command(ctx, "BAND:AUTO ON")
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetResolutionBandwidthAuto: %w", err)
 	}
+	defer unlock()
 
-	return d.inst.Command(ctx, "BAND:AUTO OFF")
+	return ivi.CachedSet(
+		d.cache, "ResolutionBandwidthAuto", auto,
+		func() error {
+			if auto {
+				return d.inst.Command(ctx, "BAND:AUTO ON")
+			}
+
+			return d.inst.Command(ctx, "BAND:AUTO OFF")
+		},
+		"ResolutionBandwidth",
+		"VideoBandwidth",
+		"SweepTime",
+	)
 }
 
-func (d *Driver) VideoBandwidth() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) VideoBandwidth(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, d.inst, "BAND:VID?")
+	return ivi.CachedGet(
+		d.cache, "VideoBandwidth",
+		func() (float64, error) {
+			return query.Float64(ctx, d.inst, "BAND:VID?")
+		},
+	)
 }
 
-func (d *Driver) SetVideoBandwidth(bw float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetVideoBandwidth(ctx context.Context, bw float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return d.inst.Command(ctx, "BAND:VID %f", bw)
+	return ivi.CoercedSet(
+		d.cache, "VideoBandwidth",
+		func() error {
+			return d.inst.Command(ctx, "BAND:VID %f", bw)
+		},
+		"VideoBandwidthAuto",
+		"SweepTime",
+	)
 }
 
-func (d *Driver) VideoBandwidthAuto() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) VideoBandwidthAuto(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Bool(ctx, d.inst, "BAND:VID:AUTO?")
+	return ivi.CachedGet(
+		d.cache, "VideoBandwidthAuto",
+		func() (bool, error) {
+			return query.Bool(ctx, d.inst, "BAND:VID:AUTO?")
+		},
+	)
 }
 
-func (d *Driver) SetVideoBandwidthAuto(auto bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetVideoBandwidthAuto(ctx context.Context, auto bool) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	if auto {
-		return d.inst.Command(ctx, "BAND:VID:AUTO ON")
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetVideoBandwidthAuto: %w", err)
 	}
+	defer unlock()
 
-	return d.inst.Command(ctx, "BAND:VID:AUTO OFF")
+	return ivi.CachedSet(
+		d.cache, "VideoBandwidthAuto", auto,
+		func() error {
+			if auto {
+				return d.inst.Command(ctx, "BAND:VID:AUTO ON")
+			}
+
+			return d.inst.Command(ctx, "BAND:VID:AUTO OFF")
+		},
+		"VideoBandwidth",
+		"SweepTime",
+	)
 }
 
 // --- Sweep ---
 
-func (d *Driver) SweepModeContinuous() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) SweepModeContinuous(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Bool(ctx, d.inst, "INIT:CONT?")
+	return ivi.CachedGet(
+		d.cache, "SweepModeContinuous",
+		func() (bool, error) {
+			return query.Bool(ctx, d.inst, "INIT:CONT?")
+		},
+	)
 }
 
-func (d *Driver) SetSweepModeContinuous(continuous bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetSweepModeContinuous(
+	ctx context.Context,
+	continuous bool,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	if continuous {
-		return d.inst.Command(ctx, "INIT:CONT ON")
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetSweepModeContinuous: %w", err)
 	}
+	defer unlock()
+
+	return ivi.CachedSet(
+		d.cache, "SweepModeContinuous", continuous,
+		func() error {
+			if continuous {
+				return d.inst.Command(ctx, "INIT:CONT ON")
+			}
 
-	return d.inst.Command(ctx, "INIT:CONT OFF")
+			return d.inst.Command(ctx, "INIT:CONT OFF")
+		},
+	)
 }
 
-func (d *Driver) SweepTime() (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) SweepTime(ctx context.Context) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Float64(ctx, d.inst, "SWE:TIME?")
+	return ivi.CachedGet(
+		d.cache, "SweepTime",
+		func() (float64, error) {
+			return query.Float64(ctx, d.inst, "SWE:TIME?")
+		},
+	)
 }
 
-func (d *Driver) SetSweepTime(sweepTime float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetSweepTime(ctx context.Context, sweepTime float64) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return d.inst.Command(ctx, "SWE:TIME %f", sweepTime)
+	return ivi.CoercedSet(
+		d.cache, "SweepTime",
+		func() error {
+			return d.inst.Command(ctx, "SWE:TIME %f", sweepTime)
+		},
+		"SweepTimeAuto",
+	)
 }
 
-func (d *Driver) SweepTimeAuto() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) SweepTimeAuto(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Bool(ctx, d.inst, "SWE:TIME:AUTO?")
+	return ivi.CachedGet(
+		d.cache, "SweepTimeAuto",
+		func() (bool, error) {
+			return query.Bool(ctx, d.inst, "SWE:TIME:AUTO?")
+		},
+	)
 }
 
-func (d *Driver) SetSweepTimeAuto(auto bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetSweepTimeAuto(ctx context.Context, auto bool) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	if auto {
-		return d.inst.Command(ctx, "SWE:TIME:AUTO ON")
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetSweepTimeAuto: %w", err)
 	}
+	defer unlock()
 
-	return d.inst.Command(ctx, "SWE:TIME:AUTO OFF")
+	return ivi.CachedSet(
+		d.cache, "SweepTimeAuto", auto,
+		func() error {
+			if auto {
+				return d.inst.Command(ctx, "SWE:TIME:AUTO ON")
+			}
+
+			return d.inst.Command(ctx, "SWE:TIME:AUTO OFF")
+		},
+		"SweepTime",
+	)
 }
 
-func (d *Driver) NumberOfSweeps() (int, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) NumberOfSweeps(ctx context.Context) (int, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return query.Int(ctx, d.inst, "AVER:COUN?")
+	return ivi.CachedGet(
+		d.cache, "NumberOfSweeps",
+		func() (int, error) {
+			return query.Int(ctx, d.inst, "AVER:COUN?")
+		},
+	)
 }
 
-func (d *Driver) SetNumberOfSweeps(num int) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetNumberOfSweeps(ctx context.Context, num int) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
-	return d.inst.Command(ctx, "AVER:COUN %d", num)
+	return ivi.CoercedSet(
+		d.cache, "NumberOfSweeps",
+		func() error {
+			return d.inst.Command(ctx, "AVER:COUN %d", num)
+		},
+	)
 }
 
 // --- Trace ---
@@ -388,8 +665,11 @@ var scpiToTraceType = map[string]specan.TraceType{
 	"BLAN": specan.TraceTypeStore,
 }
 
-func (d *Driver) TraceType(traceName string) (specan.TraceType, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TraceType(
+	ctx context.Context,
+	traceName string,
+) (specan.TraceType, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.Stringf(ctx, d.inst, "TRAC%s:MODE?", traceName)
@@ -406,9 +686,10 @@ func (d *Driver) TraceType(traceName string) (specan.TraceType, error) {
 }
 
 func (d *Driver) SetTraceType(
+	ctx context.Context,
 	traceName string, traceType specan.TraceType,
 ) error {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(traceTypeToSCPI, traceType)
@@ -437,8 +718,11 @@ var scpiToDetectorType = map[string]specan.DetectorType{
 	"RMS":  specan.DetectorTypeRMS,
 }
 
-func (d *Driver) DetectorType(_ string) (specan.DetectorType, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) DetectorType(
+	ctx context.Context,
+	_ string,
+) (specan.DetectorType, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, d.inst, "DET?")
@@ -455,9 +739,10 @@ func (d *Driver) DetectorType(_ string) (specan.DetectorType, error) {
 }
 
 func (d *Driver) SetDetectorType(
+	ctx context.Context,
 	_ string, detector specan.DetectorType,
 ) error {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(detectorTypeToSCPI, detector)
@@ -468,17 +753,27 @@ func (d *Driver) SetDetectorType(
 	return d.inst.Command(ctx, "DET %s", cmd)
 }
 
-func (d *Driver) DetectorTypeAuto(_ string) (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) DetectorTypeAuto(ctx context.Context, _ string) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Bool(ctx, d.inst, "DET:AUTO?")
 }
 
-func (d *Driver) SetDetectorTypeAuto(_ string, auto bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetDetectorTypeAuto(
+	ctx context.Context,
+	_ string,
+	auto bool,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetDetectorTypeAuto: %w", err)
+	}
+	defer unlock()
+
 	if auto {
 		return d.inst.Command(ctx, "DET:AUTO ON")
 	}
@@ -488,15 +783,17 @@ func (d *Driver) SetDetectorTypeAuto(_ string, auto bool) error {
 
 // --- Acquisition Control ---
 
-func (d *Driver) Abort() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) Abort(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "ABOR")
 }
 
-func (d *Driver) AcquisitionStatus() (specan.AcquisitionStatus, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AcquisitionStatus(
+	ctx context.Context,
+) (specan.AcquisitionStatus, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.String(ctx, d.inst, "STAT:OPER:COND?")
@@ -517,8 +814,8 @@ func (d *Driver) AcquisitionStatus() (specan.AcquisitionStatus, error) {
 	return specan.AcquisitionStatusComplete, nil
 }
 
-func (d *Driver) Initiate() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) Initiate(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "INIT")
@@ -526,10 +823,29 @@ func (d *Driver) Initiate() error {
 
 // --- Configuration Helpers ---
 
-func (d *Driver) ConfigureFrequencyCenterSpan(centerFreq, span float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) ConfigureFrequencyCenterSpan(
+	ctx context.Context,
+	centerFreq, span float64,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureFrequencyCenterSpan: %w", err)
+	}
+	defer unlock()
+
+	// Center and span determine start and stop, and the auto-coupled
+	// bandwidths and sweep time follow the span.
+	d.cache.Invalidate(
+		"FrequencyStart",
+		"FrequencyStop",
+		"ResolutionBandwidth",
+		"VideoBandwidth",
+		"SweepTime",
+	)
+
 	if err := d.inst.Command(ctx, "FREQ:CENT %f", centerFreq); err != nil {
 		return err
 	}
@@ -538,45 +854,69 @@ func (d *Driver) ConfigureFrequencyCenterSpan(centerFreq, span float64) error {
 }
 
 func (d *Driver) ConfigureFrequencyStartStop(
+	ctx context.Context,
 	startFreq, stopFreq float64,
 ) error {
-	if err := d.SetFrequencyStart(startFreq); err != nil {
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureFrequencyStartStop: %w", err)
+	}
+	defer unlock()
+
+	if err := d.SetFrequencyStart(ctx, startFreq); err != nil {
 		return err
 	}
 
-	return d.SetFrequencyStop(stopFreq)
+	return d.SetFrequencyStop(ctx, stopFreq)
 }
 
 func (d *Driver) ConfigureLevel(
+	ctx context.Context,
 	units specan.AmplitudeUnits, refLevel float64,
 ) error {
-	if err := d.SetAmplitudeUnits(units); err != nil {
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureLevel: %w", err)
+	}
+	defer unlock()
+
+	if err := d.SetAmplitudeUnits(ctx, units); err != nil {
 		return err
 	}
 
-	return d.SetReferenceLevel(refLevel)
+	return d.SetReferenceLevel(ctx, refLevel)
 }
 
 func (d *Driver) ConfigureSweepCoupling(
+	ctx context.Context,
 	resBW, videoBW, sweepTime float64,
 ) error {
-	if err := d.SetResolutionBandwidth(resBW); err != nil {
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("ConfigureSweepCoupling: %w", err)
+	}
+	defer unlock()
+
+	if err := d.SetResolutionBandwidth(ctx, resBW); err != nil {
 		return err
 	}
 
-	if err := d.SetVideoBandwidth(videoBW); err != nil {
+	if err := d.SetVideoBandwidth(ctx, videoBW); err != nil {
 		return err
 	}
 
-	return d.SetSweepTime(sweepTime)
+	return d.SetSweepTime(ctx, sweepTime)
 }
 
 // --- Trace Data ---
 
 // FetchYTrace returns the trace amplitude data as a slice of float64 values.
 // The traceName should be "1", "2", or "3" for the ESA series.
-func (d *Driver) FetchYTrace(traceName string) ([]float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) FetchYTrace(
+	ctx context.Context,
+	traceName string,
+) ([]float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.Stringf(ctx, d.inst, "TRAC:DATA? TRACE%s", traceName)
@@ -590,26 +930,33 @@ func (d *Driver) FetchYTrace(traceName string) ([]float64, error) {
 // ReadYTrace initiates a sweep, waits for it to complete (up to maxTime), and
 // returns the trace amplitude data. The traceName should be "1", "2", or "3".
 func (d *Driver) ReadYTrace(
+	ctx context.Context,
 	traceName string,
 	maxTime time.Duration,
 ) ([]float64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), maxTime)
+	ctx, cancel := context.WithTimeout(ctx, maxTime)
 	defer cancel()
 
-	if err := d.SetSweepModeContinuous(false); err != nil {
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("ReadYTrace: %w", err)
+	}
+	defer unlock()
+
+	if err := d.SetSweepModeContinuous(ctx, false); err != nil {
 		return nil, err
 	}
 
-	if err := d.Initiate(); err != nil {
+	if err := d.Initiate(ctx); err != nil {
 		return nil, err
 	}
 
 	// Wait for operation complete.
-	if _, err := query.String(ctx, d.inst, "*OPC?"); err != nil {
+	if err := d.WaitForOperationComplete(ctx); err != nil {
 		return nil, fmt.Errorf("ReadYTrace: waiting for sweep complete: %w", err)
 	}
 
-	return d.FetchYTrace(traceName)
+	return d.FetchYTrace(ctx, traceName)
 }
 
 // parseCSVFloat64 parses a comma-separated string of floating point values.
@@ -6,6 +6,7 @@
 package esa
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -36,7 +37,7 @@ func TestDriver_TraceCount(t *testing.T) {
 func TestDriver_SetFrequencyStart(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.SetFrequencyStart(1e6); err != nil {
+	if err := d.SetFrequencyStart(t.Context(), 1e6); err != nil {
 		t.Fatalf("SetFrequencyStart() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -48,7 +49,7 @@ func TestDriver_SetFrequencyStart(t *testing.T) {
 func TestDriver_SetFrequencyStop(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.SetFrequencyStop(1.5e9); err != nil {
+	if err := d.SetFrequencyStop(t.Context(), 1.5e9); err != nil {
 		t.Fatalf("SetFrequencyStop() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -60,7 +61,7 @@ func TestDriver_SetFrequencyStop(t *testing.T) {
 func TestDriver_ConfigureFrequencyCenterSpan(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.ConfigureFrequencyCenterSpan(750e6, 1.5e9); err != nil {
+	if err := d.ConfigureFrequencyCenterSpan(t.Context(), 750e6, 1.5e9); err != nil {
 		t.Fatalf("ConfigureFrequencyCenterSpan() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 2 {
@@ -77,7 +78,7 @@ func TestDriver_ConfigureFrequencyCenterSpan(t *testing.T) {
 func TestDriver_SetReferenceLevel(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.SetReferenceLevel(-20.0); err != nil {
+	if err := d.SetReferenceLevel(t.Context(), -20.0); err != nil {
 		t.Fatalf("SetReferenceLevel() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -89,7 +90,7 @@ func TestDriver_SetReferenceLevel(t *testing.T) {
 func TestDriver_SetResolutionBandwidth(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.SetResolutionBandwidth(10e3); err != nil {
+	if err := d.SetResolutionBandwidth(t.Context(), 10e3); err != nil {
 		t.Fatalf("SetResolutionBandwidth() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 ||
@@ -112,7 +113,7 @@ func TestDriver_SetSweepModeContinuous(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			d, _ := New(mock, ivi.WithoutIDQuery())
-			if err := d.SetSweepModeContinuous(tt.continuous); err != nil {
+			if err := d.SetSweepModeContinuous(t.Context(), tt.continuous); err != nil {
 				t.Fatalf("SetSweepModeContinuous() error: %v", err)
 			}
 			if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != tt.want {
@@ -137,7 +138,7 @@ func TestDriver_SetAmplitudeUnits(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			d, _ := New(mock, ivi.WithoutIDQuery())
-			if err := d.SetAmplitudeUnits(tt.units); err != nil {
+			if err := d.SetAmplitudeUnits(t.Context(), tt.units); err != nil {
 				t.Fatalf("SetAmplitudeUnits() error: %v", err)
 			}
 			if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != tt.want {
@@ -162,7 +163,7 @@ func TestDriver_AmplitudeUnits(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{QueryResp: tt.resp}
 			d, _ := New(mock, ivi.WithoutIDQuery())
-			got, err := d.AmplitudeUnits()
+			got, err := d.AmplitudeUnits(t.Context())
 			if err != nil {
 				t.Fatalf("AmplitudeUnits() error: %v", err)
 			}
@@ -187,7 +188,7 @@ func TestDriver_VerticalScale(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{QueryResp: tt.resp}
 			d, _ := New(mock, ivi.WithoutIDQuery())
-			got, err := d.VerticalScale()
+			got, err := d.VerticalScale(t.Context())
 			if err != nil {
 				t.Fatalf("VerticalScale() error: %v", err)
 			}
@@ -212,7 +213,7 @@ func TestDriver_SetAttenuationAuto(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := &ivitest.Mock{}
 			d, _ := New(mock, ivi.WithoutIDQuery())
-			if err := d.SetAttenuationAuto(tt.auto); err != nil {
+			if err := d.SetAttenuationAuto(t.Context(), tt.auto); err != nil {
 				t.Fatalf("SetAttenuationAuto() error: %v", err)
 			}
 			if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != tt.want {
@@ -225,7 +226,7 @@ func TestDriver_SetAttenuationAuto(t *testing.T) {
 func TestDriver_Abort(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.Abort(); err != nil {
+	if err := d.Abort(t.Context()); err != nil {
 		t.Fatalf("Abort() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != "ABOR" {
@@ -236,7 +237,7 @@ func TestDriver_Abort(t *testing.T) {
 func TestDriver_Initiate(t *testing.T) {
 	mock := &ivitest.Mock{}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.Initiate(); err != nil {
+	if err := d.Initiate(t.Context()); err != nil {
 		t.Fatalf("Initiate() error: %v", err)
 	}
 	if len(mock.CommandsSent) != 1 || mock.CommandsSent[0] != "INIT" {
@@ -247,7 +248,118 @@ func TestDriver_Initiate(t *testing.T) {
 func TestDriver_CommandError(t *testing.T) {
 	mock := &ivitest.Mock{ShouldError: true}
 	d, _ := New(mock, ivi.WithoutIDQuery())
-	if err := d.SetFrequencyStart(1e6); err == nil {
+	if err := d.SetFrequencyStart(t.Context(), 1e6); err == nil {
 		t.Error("expected error, got nil")
 	}
 }
+
+func TestDriver_CachedReferenceLevel_Coerced(t *testing.T) {
+	// The instrument clamps and rounds the reference level, so a set must
+	// not cache the requested value: the next read returns what the
+	// instrument applied, and only later reads come from the cache.
+	strict := &ivitest.Strict{Mock: ivitest.Mock{QueryResp: "10"}}
+	d, _ := New(strict, ivi.WithoutIDQuery(), ivi.WithCache())
+	strict.QueriesSent = nil
+
+	if err := d.SetReferenceLevel(t.Context(), 10.004); err != nil {
+		t.Fatalf("SetReferenceLevel() error: %v", err)
+	}
+	for range 2 {
+		got, err := d.ReferenceLevel(t.Context())
+		if err != nil {
+			t.Fatalf("ReferenceLevel() error: %v", err)
+		}
+		if got != 10 {
+			t.Errorf("ReferenceLevel() = %g, want coerced 10", got)
+		}
+	}
+	if len(strict.QueriesSent) != 1 {
+		t.Errorf("queries = %v, want one DISP:WIND:TRAC:Y:RLEV?",
+			strict.QueriesSent)
+	}
+	strict.Check(t)
+}
+
+func TestDriver_CachedCoupledAttenuation(t *testing.T) {
+	strict := &ivitest.Strict{Mock: ivitest.Mock{QueryResp: "10"}}
+	d, _ := New(strict, ivi.WithoutIDQuery(), ivi.WithCache())
+	strict.QueriesSent = nil
+
+	for range 2 {
+		if _, err := d.Attenuation(t.Context()); err != nil {
+			t.Fatalf("Attenuation() error: %v", err)
+		}
+	}
+	if len(strict.QueriesSent) != 1 {
+		t.Fatalf("queries = %v, want one POW:ATT?", strict.QueriesSent)
+	}
+
+	// Auto attenuation follows the reference level, so setting the
+	// reference level must force the next Attenuation read to the instrument.
+	if err := d.SetReferenceLevel(t.Context(), -20); err != nil {
+		t.Fatalf("SetReferenceLevel() error: %v", err)
+	}
+	if _, err := d.Attenuation(t.Context()); err != nil {
+		t.Fatalf("Attenuation() error: %v", err)
+	}
+	if len(strict.QueriesSent) != 2 {
+		t.Errorf("queries = %v, want POW:ATT? twice", strict.QueriesSent)
+	}
+}
+
+func TestDriver_UncachedByDefault(t *testing.T) {
+	strict := &ivitest.Strict{Mock: ivitest.Mock{QueryResp: "10"}}
+	d, _ := New(strict, ivi.WithoutIDQuery())
+	strict.QueriesSent = nil
+
+	if err := d.SetReferenceLevel(t.Context(), -20); err != nil {
+		t.Fatalf("SetReferenceLevel() error: %v", err)
+	}
+	if got, _ := d.ReferenceLevel(t.Context()); got != 10 {
+		t.Errorf("ReferenceLevel() = %g, want 10 from the instrument", got)
+	}
+}
+
+func TestDriver_SetResolutionBandwidth_RangeCheck(t *testing.T) {
+	tests := []struct {
+		model   string
+		bw      float64
+		wantErr bool
+	}{
+		{"E4402B", 5e6, false},
+		{"E4402B", 8e6, true},
+		{"E4440A", 8e6, false},
+		{"N9020A", 0.5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			mock := &ivitest.Mock{
+				QueryResp: "Agilent Technologies," + tt.model + ",US1234,A.01",
+			}
+			d, err := New(mock, ivi.WithRangeCheck())
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+			mock.CommandsSent = nil
+
+			err = d.SetResolutionBandwidth(t.Context(), tt.bw)
+			if tt.wantErr != errors.Is(err, ivi.ErrValueNotSupported) {
+				t.Errorf("SetResolutionBandwidth(%g) error = %v", tt.bw, err)
+			}
+			if tt.wantErr && len(mock.CommandsSent) != 0 {
+				t.Errorf("out of range bandwidth sent %q", mock.CommandsSent)
+			}
+		})
+	}
+}
+
+func TestNew_RangeCheckUnknownModel(t *testing.T) {
+	mock := &ivitest.Mock{
+		QueryResp: "Agilent Technologies,E4411A,US1234,A.01",
+	}
+	_, err := New(mock, ivi.WithoutIDQuery(), ivi.WithRangeCheck())
+	if !errors.Is(err, ivi.ErrFunctionNotSupported) {
+		t.Errorf("New() error = %v, want ErrFunctionNotSupported", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package esa
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gotmc/ivi"
+)
+
+// simulation describes the instrument simulated under [ivi.WithSimulate],
+// which starts as after a preset, sweeping the model's full frequency range
+// with auto-coupled bandwidths, attenuation, and sweep time. Each model's
+// frequency range bounds its start and stop frequencies, and every trace
+// reads as 401 points at a -90 dBm noise floor.
+var simulation = ivi.Simulation{
+	Manufacturer: "Agilent Technologies",
+	Attributes: map[string]ivi.SimulatedAttribute{
+		"UNIT:POW":              {Default: "DBM"},
+		"DISP:WIND:TRAC:Y:SPAC": {Default: "LOG"},
+		"DISP:WIND:TRAC:Y:RLEV": {Min: -170, Max: 30},
+		"POW:ATT":               {Default: "10", Min: 0, Max: 75},
+		"POW:ATT:AUTO":          {Default: "1"},
+		"BAND":                  {Default: "3000000", Min: 1, Max: 5e6},
+		"BAND:AUTO":             {Default: "1"},
+		"BAND:VID":              {Default: "3000000", Min: 1, Max: 3e6},
+		"BAND:VID:AUTO":         {Default: "1"},
+		"SWE:TIME":              {Default: "0.004", Min: 1e-3, Max: 4000},
+		"SWE:TIME:AUTO":         {Default: "1"},
+		"DET":                   {Default: "POS"},
+		"DET:AUTO":              {Default: "1"},
+		"INIT:CONT":             {Default: "1"},
+		"AVER:COUN":             {Default: "100", Min: 1, Max: 8192},
+		"CORR:IMP:INP:MAGN":     {Default: "50"},
+		"TRAC1:MODE":            {Default: "WRIT"},
+		"TRAC2:MODE":            {Default: "BLAN"},
+		"TRAC3:MODE":            {Default: "BLAN"},
+		"TRAC:DATA":             {Default: simulatedTrace},
+	},
+	ModelAttributes: simulatedModels(),
+}
+
+// simulatedTrace is the trace data returned under [ivi.WithSimulate].
+var simulatedTrace = strings.TrimSuffix(strings.Repeat("-90,", 401), ",")
+
+// simulatedModels returns the frequency range of each supported model.
+func simulatedModels() map[string]map[string]ivi.SimulatedAttribute {
+	maxFrequency := map[string]float64{
+		"E4411B": 1.5e9,
+		"E4401B": 1.5e9, "E4402B": 3e9, "E4403B": 3e9, "E4404B": 6.7e9,
+		"E4405B": 13.2e9, "E4407B": 26.5e9, "E4408B": 26.5e9,
+		"E7401A": 1.5e9, "E7402A": 3e9, "E7403A": 6.7e9, "E7404A": 13.2e9,
+		"E7405A": 26.5e9,
+		"E4440A": 26.5e9, "E4443A": 6.7e9, "E4445A": 13.2e9, "E4446A": 44e9,
+		"E4447A": 42.98e9, "E4448A": 50e9, "N8201A": 26.5e9,
+		"N9030A": 3.6e9, "N9020A": 3.6e9, "N9010A": 3.6e9, "N9000A": 3e9,
+	}
+
+	models := make(map[string]map[string]ivi.SimulatedAttribute)
+
+	for model, fmax := range maxFrequency {
+		stop := strconv.FormatFloat(fmax, 'g', -1, 64)
+		models[model] = map[string]ivi.SimulatedAttribute{
+			"FREQ:STAR": {Min: -80e6, Max: fmax},
+			"FREQ:STOP": {Default: stop, Min: -80e6, Max: fmax},
+		}
+	}
+
+	return models
+}
@@ -0,0 +1,405 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gotmc/query"
+)
+
+// StatusByte models the IEEE 488.2 Status Byte Register, read with *STB?, and
+// the Service Request Enable Register, which shares its bit layout and is read
+// with *SRE? and written with *SRE.
+type StatusByte uint8
+
+// The StatusByte bits. Bits 2, 3, and 7 are the SCPI assignments for the
+// error/event queue and the summaries of the QUEStionable and OPERation status
+// registers; bits 0 and 1 are instrument-defined.
+const (
+	StatusByteErrorQueue       StatusByte = 1 << 2 // error/event queue not empty
+	StatusByteQuestionable     StatusByte = 1 << 3 // QUEStionable summary
+	StatusByteMessageAvailable StatusByte = 1 << 4 // MAV: output queue not empty
+	StatusByteEventStatus      StatusByte = 1 << 5 // ESB: Standard Event summary
+	StatusByteRequestService   StatusByte = 1 << 6 // RQS/MSS: service request
+	StatusByteOperation        StatusByte = 1 << 7 // OPERation summary
+)
+
+var statusByteNames = []string{
+	0: "bit 0",
+	1: "bit 1",
+	2: "error queue",
+	3: "questionable",
+	4: "message available",
+	5: "event status",
+	6: "request service",
+	7: "operation",
+}
+
+// Has reports whether every bit set in bits is also set in the status byte.
+func (sb StatusByte) Has(bits StatusByte) bool {
+	return sb&bits == bits
+}
+
+// String implements the Stringer interface for StatusByte.
+func (sb StatusByte) String() string {
+	return bitsString(uint16(sb), statusByteNames)
+}
+
+// StandardEvent models the IEEE 488.2 Standard Event Status Register, read
+// (and cleared) with *ESR?, and the Standard Event Status Enable Register,
+// which shares its bit layout and is read with *ESE? and written with *ESE.
+type StandardEvent uint8
+
+// The StandardEvent bits defined by IEEE 488.2.
+const (
+	StandardEventOperationComplete    StandardEvent = 1 << 0 // OPC
+	StandardEventRequestControl       StandardEvent = 1 << 1 // RQC
+	StandardEventQueryError           StandardEvent = 1 << 2 // QYE
+	StandardEventDeviceDependentError StandardEvent = 1 << 3 // DDE
+	StandardEventExecutionError       StandardEvent = 1 << 4 // EXE
+	StandardEventCommandError         StandardEvent = 1 << 5 // CME
+	StandardEventUserRequest          StandardEvent = 1 << 6 // URQ
+	StandardEventPowerOn              StandardEvent = 1 << 7 // PON
+)
+
+var standardEventNames = []string{
+	0: "operation complete",
+	1: "request control",
+	2: "query error",
+	3: "device dependent error",
+	4: "execution error",
+	5: "command error",
+	6: "user request",
+	7: "power on",
+}
+
+// Has reports whether every bit set in bits is also set in the register.
+func (se StandardEvent) Has(bits StandardEvent) bool {
+	return se&bits == bits
+}
+
+// String implements the Stringer interface for StandardEvent.
+func (se StandardEvent) String() string {
+	return bitsString(uint16(se), standardEventNames)
+}
+
+// OperationStatus models the SCPI STATus:OPERation condition, event, and
+// enable registers, which report what the instrument is currently doing.
+type OperationStatus uint16
+
+// The OperationStatus bits defined by SCPI. Bits 8 through 12 are
+// instrument-defined; test them with Has using a raw bit mask such as
+// OperationStatus(1 << 8).
+const (
+	OperationCalibrating       OperationStatus = 1 << 0
+	OperationSettling          OperationStatus = 1 << 1
+	OperationRanging           OperationStatus = 1 << 2
+	OperationSweeping          OperationStatus = 1 << 3
+	OperationMeasuring         OperationStatus = 1 << 4
+	OperationWaitingForTrigger OperationStatus = 1 << 5
+	OperationWaitingForArm     OperationStatus = 1 << 6
+	OperationCorrecting        OperationStatus = 1 << 7
+	OperationInstrumentSummary OperationStatus = 1 << 13
+	OperationProgramRunning    OperationStatus = 1 << 14
+)
+
+var operationStatusNames = []string{
+	0:  "calibrating",
+	1:  "settling",
+	2:  "ranging",
+	3:  "sweeping",
+	4:  "measuring",
+	5:  "waiting for trigger",
+	6:  "waiting for arm",
+	7:  "correcting",
+	8:  "bit 8",
+	9:  "bit 9",
+	10: "bit 10",
+	11: "bit 11",
+	12: "bit 12",
+	13: "instrument summary",
+	14: "program running",
+	15: "bit 15",
+}
+
+// Has reports whether every bit set in bits is also set in the register.
+func (op OperationStatus) Has(bits OperationStatus) bool {
+	return op&bits == bits
+}
+
+// String implements the Stringer interface for OperationStatus.
+func (op OperationStatus) String() string {
+	return bitsString(uint16(op), operationStatusNames)
+}
+
+// QuestionableStatus models the SCPI STATus:QUEStionable condition, event,
+// and enable registers, which report signals whose quality is in doubt, such
+// as an output that has tripped its overvoltage or overcurrent protection.
+type QuestionableStatus uint16
+
+// The QuestionableStatus bits defined by SCPI. Bits 9 through 12 are
+// instrument-defined; test them with Has using a raw bit mask such as
+// QuestionableStatus(1 << 9).
+const (
+	QuestionableVoltage           QuestionableStatus = 1 << 0
+	QuestionableCurrent           QuestionableStatus = 1 << 1
+	QuestionableTime              QuestionableStatus = 1 << 2
+	QuestionablePower             QuestionableStatus = 1 << 3
+	QuestionableTemperature       QuestionableStatus = 1 << 4
+	QuestionableFrequency         QuestionableStatus = 1 << 5
+	QuestionablePhase             QuestionableStatus = 1 << 6
+	QuestionableModulation        QuestionableStatus = 1 << 7
+	QuestionableCalibration       QuestionableStatus = 1 << 8
+	QuestionableInstrumentSummary QuestionableStatus = 1 << 13
+	QuestionableCommandWarning    QuestionableStatus = 1 << 14
+)
+
+var questionableStatusNames = []string{
+	0:  "voltage",
+	1:  "current",
+	2:  "time",
+	3:  "power",
+	4:  "temperature",
+	5:  "frequency",
+	6:  "phase",
+	7:  "modulation",
+	8:  "calibration",
+	9:  "bit 9",
+	10: "bit 10",
+	11: "bit 11",
+	12: "bit 12",
+	13: "instrument summary",
+	14: "command warning",
+	15: "bit 15",
+}
+
+// Has reports whether every bit set in bits is also set in the register.
+func (qs QuestionableStatus) Has(bits QuestionableStatus) bool {
+	return qs&bits == bits
+}
+
+// String implements the Stringer interface for QuestionableStatus.
+func (qs QuestionableStatus) String() string {
+	return bitsString(uint16(qs), questionableStatusNames)
+}
+
+// bitsString lists the names of the set bits separated by "|", or returns
+// "none" when no bit is set.
+func bitsString(bits uint16, names []string) string {
+	var set []string
+
+	for i, name := range names {
+		if bits&(1<<i) != 0 {
+			set = append(set, name)
+		}
+	}
+
+	if len(set) == 0 {
+		return "none"
+	}
+
+	return strings.Join(set, "|")
+}
+
+// StatusByte reads the IEEE 488.2 Status Byte Register using *STB?. Unlike a
+// serial poll, *STB? reports the Master Summary Status in bit 6 and does not
+// clear the register.
+func (inherent *Inherent) StatusByte(ctx context.Context) (StatusByte, error) {
+	v, err := inherent.queryRegister(ctx, "*STB?", math.MaxUint8)
+	return StatusByte(v), err
+}
+
+// ServiceRequestEnable reads the Service Request Enable Register using *SRE?.
+func (inherent *Inherent) ServiceRequestEnable(
+	ctx context.Context,
+) (StatusByte, error) {
+	v, err := inherent.queryRegister(ctx, "*SRE?", math.MaxUint8)
+	return StatusByte(v), err
+}
+
+// SetServiceRequestEnable writes the Service Request Enable Register using
+// *SRE, selecting which status byte bits generate a service request. Bit 6 is
+// ignored by the instrument.
+func (inherent *Inherent) SetServiceRequestEnable(
+	ctx context.Context,
+	mask StatusByte,
+) error {
+	return inherent.commandRegister(ctx, "*SRE %d", uint16(mask))
+}
+
+// StandardEventStatus reads the Standard Event Status Register using *ESR?.
+// Reading the register clears it.
+func (inherent *Inherent) StandardEventStatus(
+	ctx context.Context,
+) (StandardEvent, error) {
+	v, err := inherent.queryRegister(ctx, "*ESR?", math.MaxUint8)
+	return StandardEvent(v), err
+}
+
+// StandardEventEnable reads the Standard Event Status Enable Register using
+// *ESE?.
+func (inherent *Inherent) StandardEventEnable(
+	ctx context.Context,
+) (StandardEvent, error) {
+	v, err := inherent.queryRegister(ctx, "*ESE?", math.MaxUint8)
+	return StandardEvent(v), err
+}
+
+// SetStandardEventEnable writes the Standard Event Status Enable Register
+// using *ESE, selecting which standard events set the event status bit of the
+// status byte.
+func (inherent *Inherent) SetStandardEventEnable(
+	ctx context.Context,
+	mask StandardEvent,
+) error {
+	return inherent.commandRegister(ctx, "*ESE %d", uint16(mask))
+}
+
+// OperationCondition reads the SCPI STATus:OPERation:CONDition register,
+// which reflects the instrument's state at the moment it is read.
+func (inherent *Inherent) OperationCondition(
+	ctx context.Context,
+) (OperationStatus, error) {
+	v, err := inherent.queryRegister(ctx, "STAT:OPER:COND?", math.MaxUint16)
+	return OperationStatus(v), err
+}
+
+// OperationEvent reads the SCPI STATus:OPERation:EVENt register, which latches
+// every condition that has occurred since it was last read. Reading the
+// register clears it.
+func (inherent *Inherent) OperationEvent(
+	ctx context.Context,
+) (OperationStatus, error) {
+	v, err := inherent.queryRegister(ctx, "STAT:OPER:EVEN?", math.MaxUint16)
+	return OperationStatus(v), err
+}
+
+// OperationEnable reads the SCPI STATus:OPERation:ENABle register.
+func (inherent *Inherent) OperationEnable(
+	ctx context.Context,
+) (OperationStatus, error) {
+	v, err := inherent.queryRegister(ctx, "STAT:OPER:ENAB?", math.MaxUint16)
+	return OperationStatus(v), err
+}
+
+// SetOperationEnable writes the SCPI STATus:OPERation:ENABle register,
+// selecting which operation events set the operation bit of the status byte.
+func (inherent *Inherent) SetOperationEnable(
+	ctx context.Context,
+	mask OperationStatus,
+) error {
+	return inherent.commandRegister(ctx, "STAT:OPER:ENAB %d", uint16(mask))
+}
+
+// QuestionableCondition reads the SCPI STATus:QUEStionable:CONDition
+// register, which reflects the instrument's state at the moment it is read.
+func (inherent *Inherent) QuestionableCondition(
+	ctx context.Context,
+) (QuestionableStatus, error) {
+	v, err := inherent.queryRegister(ctx, "STAT:QUES:COND?", math.MaxUint16)
+	return QuestionableStatus(v), err
+}
+
+// QuestionableEvent reads the SCPI STATus:QUEStionable:EVENt register, which
+// latches every condition that has occurred since it was last read. Reading
+// the register clears it.
+func (inherent *Inherent) QuestionableEvent(
+	ctx context.Context,
+) (QuestionableStatus, error) {
+	v, err := inherent.queryRegister(ctx, "STAT:QUES:EVEN?", math.MaxUint16)
+	return QuestionableStatus(v), err
+}
+
+// QuestionableEnable reads the SCPI STATus:QUEStionable:ENABle register.
+func (inherent *Inherent) QuestionableEnable(
+	ctx context.Context,
+) (QuestionableStatus, error) {
+	v, err := inherent.queryRegister(ctx, "STAT:QUES:ENAB?", math.MaxUint16)
+	return QuestionableStatus(v), err
+}
+
+// SetQuestionableEnable writes the SCPI STATus:QUEStionable:ENABle register,
+// selecting which questionable events set the questionable bit of the status
+// byte.
+func (inherent *Inherent) SetQuestionableEnable(
+	ctx context.Context,
+	mask QuestionableStatus,
+) error {
+	return inherent.commandRegister(ctx, "STAT:QUES:ENAB %d", uint16(mask))
+}
+
+// PresetStatus sends the SCPI STATus:PRESet command, which returns the
+// OPERation and QUEStionable enable registers and transition filters to their
+// power-on defaults.
+func (inherent *Inherent) PresetStatus(ctx context.Context) error {
+	ctx, cancel := inherent.newContext(ctx)
+	defer cancel()
+
+	return inherent.inst.Command(ctx, "STAT:PRES")
+}
+
+// queryRegister sends the given register query and parses the response as an
+// unsigned register value no greater than limit.
+func (inherent *Inherent) queryRegister(
+	ctx context.Context,
+	cmd string,
+	limit uint64,
+) (uint64, error) {
+	ctx, cancel := inherent.newContext(ctx)
+	defer cancel()
+
+	resp, err := query.String(ctx, inherent.inst, cmd)
+	if err != nil {
+		return 0, fmt.Errorf("error querying %s: %w", cmd, err)
+	}
+
+	v, err := parseRegister(resp, limit)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s returned %q", err, cmd, resp)
+	}
+
+	return v, nil
+}
+
+// commandRegister writes a register value using the given command format.
+func (inherent *Inherent) commandRegister(
+	ctx context.Context,
+	format string,
+	value uint16,
+) error {
+	ctx, cancel := inherent.newContext(ctx)
+	defer cancel()
+
+	return inherent.inst.Command(ctx, format, value)
+}
+
+// parseRegister parses a register response in any of the IEEE 488.2 decimal
+// numeric forms instruments use for it, such as "32", "+32", or
+// "+3.20000000E+01".
+func parseRegister(s string, limit uint64) (uint64, error) {
+	s = strings.TrimSpace(s)
+
+	v, err := strconv.ParseUint(strings.TrimPrefix(s, "+"), 10, 64)
+	if err == nil {
+		if v > limit {
+			return 0, ErrUnexpectedResponse
+		}
+
+		return v, nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 || f > float64(limit) || f != math.Trunc(f) {
+		return 0, ErrUnexpectedResponse
+	}
+
+	return uint64(f), nil
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestParseRegister(t *testing.T) {
+	tests := map[string]struct {
+		given string
+		want  uint64
+	}{
+		"plain":      {"32", 32},
+		"signed":     {"+32\n", 32},
+		"nr3":        {"+3.20000000E+01", 32},
+		"zero":       {"+0", 0},
+		"max uint16": {"65535", 65535},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseRegister(tt.given, math.MaxUint16)
+			if err != nil {
+				t.Fatalf("parseRegister(%q) error: %v", tt.given, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseRegister(%q) = %d, want %d", tt.given, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRegister_Invalid(t *testing.T) {
+	for _, given := range []string{"", "abc", "-1", "2.5", "256"} {
+		_, err := parseRegister(given, math.MaxUint8)
+		if !errors.Is(err, ErrUnexpectedResponse) {
+			t.Errorf("parseRegister(%q) error = %v, want ErrUnexpectedResponse",
+				given, err)
+		}
+	}
+}
+
+func TestStatusByte_String(t *testing.T) {
+	tests := map[StatusByte]string{
+		0: "none",
+		StatusByteEventStatus | StatusByteMessageAvailable: "message available|event status",
+		StatusByteOperation: "operation",
+	}
+	for given, want := range tests {
+		if got := given.String(); got != want {
+			t.Errorf("StatusByte(%d).String() = %q, want %q", uint8(given), got, want)
+		}
+	}
+}
+
+func TestInherent_StatusRegisters(t *testing.T) {
+	mock := &mockScriptedInst{
+		responses: map[string][]string{
+			"*STB?":           {"+96"},
+			"*ESR?":           {"+33"},
+			"STAT:OPER:COND?": {"+16"},
+			"STAT:QUES:EVEN?": {"+1"},
+		},
+	}
+	inherent := NewInherent(mock, InherentBase{}, 0)
+	ctx := t.Context()
+
+	stb, err := inherent.StatusByte(ctx)
+	if err != nil {
+		t.Fatalf("StatusByte() error: %v", err)
+	}
+	if !stb.Has(StatusByteEventStatus|StatusByteRequestService) ||
+		stb.Has(StatusByteMessageAvailable) {
+		t.Errorf("StatusByte() = %v, want event status|request service", stb)
+	}
+
+	esr, err := inherent.StandardEventStatus(ctx)
+	if err != nil {
+		t.Fatalf("StandardEventStatus() error: %v", err)
+	}
+	if esr != StandardEventOperationComplete|StandardEventCommandError {
+		t.Errorf("StandardEventStatus() = %v, want operation complete|command error", esr)
+	}
+
+	oper, err := inherent.OperationCondition(ctx)
+	if err != nil {
+		t.Fatalf("OperationCondition() error: %v", err)
+	}
+	if oper != OperationMeasuring {
+		t.Errorf("OperationCondition() = %v, want measuring", oper)
+	}
+
+	ques, err := inherent.QuestionableEvent(ctx)
+	if err != nil {
+		t.Fatalf("QuestionableEvent() error: %v", err)
+	}
+	if !ques.Has(QuestionableVoltage) {
+		t.Errorf("QuestionableEvent() = %v, want voltage", ques)
+	}
+}
+
+func TestInherent_StatusRegisterOutOfRange(t *testing.T) {
+	mock := &mockScriptedInst{
+		responses: map[string][]string{"*ESR?": {"512"}},
+	}
+	inherent := NewInherent(mock, InherentBase{}, 0)
+	_, err := inherent.StandardEventStatus(t.Context())
+	if !errors.Is(err, ErrUnexpectedResponse) {
+		t.Errorf("error = %v, want ErrUnexpectedResponse", err)
+	}
+}
+
+func TestInherent_SetStatusEnables(t *testing.T) {
+	mock := &mockScriptedInst{}
+	inherent := NewInherent(mock, InherentBase{}, 0)
+	ctx := t.Context()
+
+	if err := inherent.SetServiceRequestEnable(ctx, StatusByteQuestionable); err != nil {
+		t.Fatalf("SetServiceRequestEnable() error: %v", err)
+	}
+	if err := inherent.SetStandardEventEnable(ctx, StandardEventExecutionError); err != nil {
+		t.Fatalf("SetStandardEventEnable() error: %v", err)
+	}
+	if err := inherent.SetOperationEnable(ctx, OperationMeasuring); err != nil {
+		t.Fatalf("SetOperationEnable() error: %v", err)
+	}
+	if err := inherent.SetQuestionableEnable(ctx, QuestionableVoltage); err != nil {
+		t.Fatalf("SetQuestionableEnable() error: %v", err)
+	}
+	if err := inherent.PresetStatus(ctx); err != nil {
+		t.Fatalf("PresetStatus() error: %v", err)
+	}
+
+	want := []string{
+		"*SRE 8", "*ESE 16", "STAT:OPER:ENAB 16", "STAT:QUES:ENAB 1", "STAT:PRES",
+	}
+	if !slices.Equal(mock.commandsSent, want) {
+		t.Errorf("commands = %v, want %v", mock.commandsSent, want)
+	}
+}
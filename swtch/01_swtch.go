@@ -10,3 +10,33 @@
 // Files are split based on the class capability groups listed in Table 2-1
 // IviSwtch Group Names in the IVI-4.6 IviSwtch Class Specification.
 package swtch
+
+import (
+	"reflect"
+
+	"github.com/gotmc/ivi"
+)
+
+// Groups lists the capability groups of the IviSwtch class, with the Go
+// interfaces that implement each, for [ivi.Introspect] and
+// [ivi.CheckGroupCapabilities]. Base returns its channels as BaseChannel, so
+// the driver implementing Base shows the channels implement it. The groups
+// without interfaces are never reported as implemented.
+var Groups = []ivi.CapabilityGroup{
+	{Name: "IviSwtchBase", Driver: reflect.TypeFor[Base]()},
+	{Name: "IviSwtchScanner"},
+	{Name: "IviSwtchSoftwareTrigger"},
+}
+
+// Drivers holds the IviSwtch drivers, by the instrument models they support.
+// Each driver registers itself from an init function, so importing a driver
+// package, if only for its side effects, makes it available to Drivers.Open,
+// which picks the driver for the connected instrument's model, and to
+// Drivers.OpenDriver, which creates a driver by name. Both return the driver as
+// a Base; assert it to other IviSwtch interfaces, or to the driver's own type,
+// for the capabilities beyond the base group. See [ivi.Registry].
+//
+//	import _ "github.com/gotmc/ivi/swtch/keysight/u2751a"
+//
+//	matrix, err := swtch.Drivers.Open(ctx, inst)
+var Drivers ivi.Registry[Base]
@@ -15,17 +15,17 @@ import (
 
 // Base provides the interface required for the IviSwtchBase capability group.
 type Base interface {
-	CanConnect(ch1, ch2 string) (bool, error)
+	CanConnect(ctx context.Context, ch1, ch2 string) (bool, error)
 	Channel(name string) (BaseChannel, error)
 	ChannelByID(id int) (BaseChannel, error)
 	ChannelCount() int
 	Channels() ([]BaseChannel, error)
-	Connect(ch1, ch2 string) error
-	Disconnect(ch1, ch2 string) error
-	DisconnectAll() error
-	GetPath(ch1, ch2 string) ([]string, error)
-	SetPath(chs []string) error
-	WaitForDebounce(maxTime time.Duration) error
+	Connect(ctx context.Context, ch1, ch2 string) error
+	Disconnect(ctx context.Context, ch1, ch2 string) error
+	DisconnectAll(ctx context.Context) error
+	GetPath(ctx context.Context, ch1, ch2 string) ([]string, error)
+	SetPath(ctx context.Context, chs []string) error
+	WaitForDebounce(ctx context.Context, maxTime time.Duration) error
 	SetVirtualNames(names map[string]string) error
 }
 
@@ -29,6 +29,13 @@ const (
 	specRevision     = "4.0"
 )
 
+// Register the driver with [swtch.Drivers] so that Open can select it by model.
+func init() {
+	swtch.Drivers.Register(
+		"u2751a", supportedModels(), ivi.Factory[swtch.Base](New),
+	)
+}
+
 // ChannelType is used to determine if the channel is a row or a column.
 type ChannelType int
 
@@ -74,9 +81,10 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		GroupCapabilities: []string{
 			"IviSwtchBase",
 			"IviSwtchScanner",
-			"IviSwtchSoftware",
+			"IviSwtchSoftwareTrigger",
 		},
-		SupportedInstrumentModels: []string{"U2751A"},
+		SupportedInstrumentModels: supportedModels(),
+		Simulation:                &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
@@ -99,21 +107,21 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 			ch.name,
 			ch.chType,
 			ch.switchID,
-			inst,
+			s.Transport,
 			s.Timeout,
 			s.Config.Standalone,
 		)
 	}
 
 	driver := Driver{
-		inst:     inst,
+		inst:     s.Transport,
 		channels: channels,
 		timeout:  s.Timeout,
 		Inherent: s.Inherent,
 	}
 
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
+		if err := driver.Reset(context.Background()); err != nil {
 			return &driver, err
 		}
 	}
@@ -121,6 +129,11 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	return &driver, nil
 }
 
+// supportedModels returns the model numbers the driver supports.
+func supportedModels() []string {
+	return []string{"U2751A"}
+}
+
 // Channel represents a repeated capability of an output channel for the
 // function generator.
 type Channel struct {
@@ -150,14 +163,20 @@ type Channel struct {
 	numWires              int
 }
 
-// newContext creates a context with the driver's configured timeout.
-func (d *Driver) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), d.timeout)
+// newContext derives a context from ctx that carries the driver's configured
+// timeout, unless ctx already has a deadline of its own.
+func (d *Driver) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, d.timeout)
 }
 
-// newContext creates a context with the channel's configured timeout.
-func (ch *Channel) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), ch.timeout)
+// newContext derives a context from ctx that carries the channel's configured
+// timeout, unless ctx already has a deadline of its own.
+func (ch *Channel) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, ch.timeout)
 }
 
 // Close properly shuts down the switch matrix by returning it to local
@@ -167,8 +186,8 @@ func (d *Driver) Close() error {
 }
 
 // Disable causes the switch to disconnect all paths.
-func (d *Driver) Disable() error {
-	ctx, cancel := d.newContext()
+func (d *Driver) Disable(ctx context.Context) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return ivi.Set(ctx, d.inst, "rout:open (@101:408)\n")
@@ -0,0 +1,14 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package u2751a
+
+import "github.com/gotmc/ivi"
+
+// simulation describes the instrument simulated under [ivi.WithSimulate].
+// The driver only closes relays, so no attributes need describing.
+var simulation = ivi.Simulation{
+	Manufacturer: "Agilent Technologies",
+}
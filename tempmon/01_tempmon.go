@@ -11,3 +11,35 @@ interface is designed based on the capabilities common to multi-channel
 temperature monitoring instruments such as the SRS SR630.
 */
 package tempmon
+
+import (
+	"reflect"
+
+	"github.com/gotmc/ivi"
+)
+
+// Groups lists the capability groups of the temperature monitor class, with the
+// Go interfaces that implement each, for [ivi.Introspect] and
+// [ivi.CheckGroupCapabilities].
+var Groups = []ivi.CapabilityGroup{
+	{Name: "TempMonBase", Driver: reflect.TypeFor[Base]()},
+	{Name: "TempMonScanner", Driver: reflect.TypeFor[Scanner]()},
+	{
+		Name:   "TempMonRelativeTemperature",
+		Driver: reflect.TypeFor[RelativeTemperature](),
+	},
+}
+
+// Drivers holds the temperature monitor drivers, by the instrument models they
+// support. Each driver registers itself from an init function, so importing a
+// driver package, if only for its side effects, makes it available to
+// Drivers.Open, which picks the driver for the connected instrument's model,
+// and to Drivers.OpenDriver, which creates a driver by name. Both return the
+// driver as a Base; assert it to other temperature monitor interfaces, or to
+// the driver's own type, for the capabilities beyond the base group. See
+// [ivi.Registry].
+//
+//	import _ "github.com/gotmc/ivi/tempmon/srs/sr630"
+//
+//	monitor, err := tempmon.Drivers.Open(ctx, inst)
+var Drivers ivi.Registry[Base]
@@ -5,7 +5,11 @@
 
 package tempmon
 
-import "time"
+import (
+	"context"
+
+	"time"
+)
 
 /*
 
@@ -24,36 +28,48 @@ RTD, thermistor, etc.) and measurement units.
 type Base interface {
 	// Channel configuration
 	ChannelCount() int
-	ThermocoupleType(channel int) (ThermocoupleType, error)
-	SetThermocoupleType(channel int, tcType ThermocoupleType) error
-	TemperatureUnits(channel int) (TemperatureUnits, error)
-	SetTemperatureUnits(channel int, units TemperatureUnits) error
+	ThermocoupleType(ctx context.Context, channel int) (ThermocoupleType, error)
+	SetThermocoupleType(
+		ctx context.Context,
+		channel int,
+		tcType ThermocoupleType,
+	) error
+	TemperatureUnits(ctx context.Context, channel int) (TemperatureUnits, error)
+	SetTemperatureUnits(
+		ctx context.Context,
+		channel int,
+		units TemperatureUnits,
+	) error
 
 	// Measurement
-	MeasureTemperature(channel int) (float64, error)
+	MeasureTemperature(ctx context.Context, channel int) (float64, error)
 
 	// Alarm limits
-	AlarmEnabled(channel int) (bool, error)
-	SetAlarmEnabled(channel int, enabled bool) error
-	AlarmHighLimit(channel int) (float64, error)
-	SetAlarmHighLimit(channel int, limit float64) error
-	AlarmLowLimit(channel int) (float64, error)
-	SetAlarmLowLimit(channel int, limit float64) error
+	AlarmEnabled(ctx context.Context, channel int) (bool, error)
+	SetAlarmEnabled(ctx context.Context, channel int, enabled bool) error
+	AlarmHighLimit(ctx context.Context, channel int) (float64, error)
+	SetAlarmHighLimit(ctx context.Context, channel int, limit float64) error
+	AlarmLowLimit(ctx context.Context, channel int) (float64, error)
+	SetAlarmLowLimit(ctx context.Context, channel int, limit float64) error
 }
 
 // Scanner provides the interface for automated multi-channel scanning.
 type Scanner interface {
-	ScanEnabled() (bool, error)
-	SetScanEnabled(enabled bool) error
-	ChannelScanEnabled(channel int) (bool, error)
-	SetChannelScanEnabled(channel int, enabled bool) error
-	DwellTime() (time.Duration, error)
-	SetDwellTime(dwell time.Duration) error
+	ScanEnabled(ctx context.Context) (bool, error)
+	SetScanEnabled(ctx context.Context, enabled bool) error
+	ChannelScanEnabled(ctx context.Context, channel int) (bool, error)
+	SetChannelScanEnabled(ctx context.Context, channel int, enabled bool) error
+	DwellTime(ctx context.Context) (time.Duration, error)
+	SetDwellTime(ctx context.Context, dwell time.Duration) error
 }
 
 // RelativeTemperature provides the interface for nominal/delta-T measurements.
 type RelativeTemperature interface {
-	NominalTemperature(channel int) (float64, error)
-	SetNominalTemperature(channel int, nominal float64) error
-	DeltaTemperature(channel int) (float64, error)
+	NominalTemperature(ctx context.Context, channel int) (float64, error)
+	SetNominalTemperature(
+		ctx context.Context,
+		channel int,
+		nominal float64,
+	) error
+	DeltaTemperature(ctx context.Context, channel int) (float64, error)
 }
@@ -15,6 +15,7 @@ package sr630
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/gotmc/ivi"
@@ -32,6 +33,16 @@ var _ tempmon.Base = (*Driver)(nil)
 var _ tempmon.Scanner = (*Driver)(nil)
 var _ tempmon.RelativeTemperature = (*Driver)(nil)
 
+// Register the driver with [tempmon.Drivers] so that Open can select it by
+// model, and its connection defaults so that a config session can complete its
+// resource.
+func init() {
+	tempmon.Drivers.Register(
+		"sr630", supportedModels(), ivi.Factory[tempmon.Base](New),
+	)
+	ivi.RegisterConnectionDefaults("sr630", ConnectionDefaults())
+}
+
 // Driver provides the IVI driver for the SRS SR630 thermocouple monitor.
 type Driver struct {
 	inst    ivi.Transport
@@ -52,26 +63,28 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 		ResetDelay:            2 * time.Second,
 		ClearDelay:            500 * time.Millisecond,
 		ReturnToLocal:         false, // SR630 has no SYST:LOC equivalent
+		NoErrorQueue:          true,  // not SCPI, so there is no SYST:ERR?
 		GroupCapabilities: []string{
 			"TempMonBase",
 			"TempMonScanner",
 			"TempMonRelativeTemperature",
 		},
-		SupportedInstrumentModels: []string{"SR630"},
+		SupportedInstrumentModels: supportedModels(),
 		SupportedBusInterfaces:    []string{"GPIB", "RS232"},
+		Simulation:                &simulation,
 	}, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	driver := Driver{
-		inst:     inst,
+		inst:     s.Transport,
 		timeout:  s.Timeout,
 		Inherent: s.Inherent,
 	}
 
 	if s.Config.Reset {
-		if err := driver.Reset(); err != nil {
+		if err := driver.Reset(context.Background()); err != nil {
 			return &driver, err
 		}
 	}
@@ -79,9 +92,17 @@ func New(inst ivi.Transport, opts ...ivi.DriverOption) (*Driver, error) {
 	return &driver, nil
 }
 
-// newContext creates a context with the driver's configured timeout.
-func (d *Driver) newContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), d.timeout)
+// supportedModels returns the model numbers the driver supports.
+func supportedModels() []string {
+	return []string{"SR630"}
+}
+
+// newContext derives a context from ctx that carries the driver's configured
+// timeout, unless ctx already has a deadline of its own.
+func (d *Driver) newContext(
+	ctx context.Context,
+) (context.Context, context.CancelFunc) {
+	return ivi.ContextWithTimeout(ctx, d.timeout)
 }
 
 // Close returns the instrument to its default state. The SR630 has no
@@ -90,6 +111,13 @@ func (d *Driver) Close() error {
 	return d.Inherent.Close()
 }
 
+// SelfTest overrides [ivi.Inherent.SelfTest] because the SR630 has no remote
+// self-test command; it tests itself only at power on. SelfTest returns
+// [ivi.ErrFunctionNotSupported] without sending anything to the instrument.
+func (d *Driver) SelfTest(_ context.Context) (int, string, error) {
+	return 0, "", fmt.Errorf("SelfTest: SR630: %w", ivi.ErrFunctionNotSupported)
+}
+
 // DefaultGPIBAddress returns the default GPIB address for the SR630.
 func DefaultGPIBAddress() int {
 	return defaultGPIBAddr
@@ -99,3 +127,12 @@ func DefaultGPIBAddress() int {
 func DefaultBaudRate() int {
 	return defaultBaudRate
 }
+
+// ConnectionDefaults returns the model's connection defaults, which
+// complete a VISA resource string with [ivi.Resource.Settings].
+func ConnectionDefaults() ivi.ConnectionDefaults {
+	return ivi.ConnectionDefaults{
+		GPIBAddress:    DefaultGPIBAddress(),
+		SerialBaudRate: DefaultBaudRate(),
+	}
+}
@@ -6,6 +6,7 @@
 package sr630
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -44,8 +45,11 @@ var scpiToTCType = map[string]tempmon.ThermocoupleType{
 	"T": tempmon.TypeT,
 }
 
-func (d *Driver) ThermocoupleType(channel int) (tempmon.ThermocoupleType, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ThermocoupleType(
+	ctx context.Context,
+	channel int,
+) (tempmon.ThermocoupleType, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.Stringf(ctx, d.inst, "TTYP? %d", channel)
@@ -62,9 +66,10 @@ func (d *Driver) ThermocoupleType(channel int) (tempmon.ThermocoupleType, error)
 }
 
 func (d *Driver) SetThermocoupleType(
+	ctx context.Context,
 	channel int, tcType tempmon.ThermocoupleType,
 ) error {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(tcTypeToSCPI, tcType)
@@ -90,8 +95,11 @@ var scpiToUnits = map[string]tempmon.TemperatureUnits{
 	"ABS":  tempmon.Kelvin,
 }
 
-func (d *Driver) TemperatureUnits(channel int) (tempmon.TemperatureUnits, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) TemperatureUnits(
+	ctx context.Context,
+	channel int,
+) (tempmon.TemperatureUnits, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.Stringf(ctx, d.inst, "UNIT? %d", channel)
@@ -108,9 +116,10 @@ func (d *Driver) TemperatureUnits(channel int) (tempmon.TemperatureUnits, error)
 }
 
 func (d *Driver) SetTemperatureUnits(
+	ctx context.Context,
 	channel int, units tempmon.TemperatureUnits,
 ) error {
-	ctx, cancel := d.newContext()
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	cmd, err := ivi.LookupSCPI(unitsToSCPI, units)
@@ -123,8 +132,11 @@ func (d *Driver) SetTemperatureUnits(
 
 // --- Measurement ---
 
-func (d *Driver) MeasureTemperature(channel int) (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) MeasureTemperature(
+	ctx context.Context,
+	channel int,
+) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64f(ctx, d.inst, "MEAS? %d", channel)
@@ -132,8 +144,8 @@ func (d *Driver) MeasureTemperature(channel int) (float64, error) {
 
 // --- Alarm Limits ---
 
-func (d *Driver) AlarmEnabled(channel int) (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AlarmEnabled(ctx context.Context, channel int) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.Stringf(ctx, d.inst, "ALRM? %d", channel)
@@ -144,10 +156,20 @@ func (d *Driver) AlarmEnabled(channel int) (bool, error) {
 	return strings.TrimSpace(s) == "YES", nil
 }
 
-func (d *Driver) SetAlarmEnabled(channel int, enabled bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetAlarmEnabled(
+	ctx context.Context,
+	channel int,
+	enabled bool,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetAlarmEnabled: %w", err)
+	}
+	defer unlock()
+
 	if enabled {
 		return d.inst.Command(ctx, "ALRM %d, YES", channel)
 	}
@@ -155,29 +177,43 @@ func (d *Driver) SetAlarmEnabled(channel int, enabled bool) error {
 	return d.inst.Command(ctx, "ALRM %d, NO", channel)
 }
 
-func (d *Driver) AlarmHighLimit(channel int) (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AlarmHighLimit(
+	ctx context.Context,
+	channel int,
+) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64f(ctx, d.inst, "TMAX? %d", channel)
 }
 
-func (d *Driver) SetAlarmHighLimit(channel int, limit float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetAlarmHighLimit(
+	ctx context.Context,
+	channel int,
+	limit float64,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "TMAX %d, %f", channel, limit)
 }
 
-func (d *Driver) AlarmLowLimit(channel int) (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) AlarmLowLimit(
+	ctx context.Context,
+	channel int,
+) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64f(ctx, d.inst, "TMIN? %d", channel)
 }
 
-func (d *Driver) SetAlarmLowLimit(channel int, limit float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetAlarmLowLimit(
+	ctx context.Context,
+	channel int,
+	limit float64,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "TMIN %d, %f", channel, limit)
@@ -185,17 +221,23 @@ func (d *Driver) SetAlarmLowLimit(channel int, limit float64) error {
 
 // --- Scanner ---
 
-func (d *Driver) ScanEnabled() (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ScanEnabled(ctx context.Context) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Bool(ctx, d.inst, "SCAN?")
 }
 
-func (d *Driver) SetScanEnabled(enabled bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetScanEnabled(ctx context.Context, enabled bool) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetScanEnabled: %w", err)
+	}
+	defer unlock()
+
 	if enabled {
 		return d.inst.Command(ctx, "SCAN 1")
 	}
@@ -203,8 +245,11 @@ func (d *Driver) SetScanEnabled(enabled bool) error {
 	return d.inst.Command(ctx, "SCAN 0")
 }
 
-func (d *Driver) ChannelScanEnabled(channel int) (bool, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) ChannelScanEnabled(
+	ctx context.Context,
+	channel int,
+) (bool, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	s, err := query.Stringf(ctx, d.inst, "SCNE? %d", channel)
@@ -215,10 +260,20 @@ func (d *Driver) ChannelScanEnabled(channel int) (bool, error) {
 	return strings.TrimSpace(s) == "YES", nil
 }
 
-func (d *Driver) SetChannelScanEnabled(channel int, enabled bool) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetChannelScanEnabled(
+	ctx context.Context,
+	channel int,
+	enabled bool,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
+	ctx, unlock, err := ivi.LockSession(ctx, d.inst, d.timeout)
+	if err != nil {
+		return fmt.Errorf("SetChannelScanEnabled: %w", err)
+	}
+	defer unlock()
+
 	if enabled {
 		return d.inst.Command(ctx, "SCNE %d, YES", channel)
 	}
@@ -226,8 +281,8 @@ func (d *Driver) SetChannelScanEnabled(channel int, enabled bool) error {
 	return d.inst.Command(ctx, "SCNE %d, NO", channel)
 }
 
-func (d *Driver) DwellTime() (time.Duration, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) DwellTime(ctx context.Context) (time.Duration, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	sec, err := query.Float64(ctx, d.inst, "DWEL?")
@@ -238,8 +293,8 @@ func (d *Driver) DwellTime() (time.Duration, error) {
 	return time.Duration(sec * float64(time.Second)), nil
 }
 
-func (d *Driver) SetDwellTime(dwell time.Duration) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetDwellTime(ctx context.Context, dwell time.Duration) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	sec := int(dwell.Seconds())
@@ -255,22 +310,32 @@ func (d *Driver) SetDwellTime(dwell time.Duration) error {
 
 // --- Relative Temperature ---
 
-func (d *Driver) NominalTemperature(channel int) (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) NominalTemperature(
+	ctx context.Context,
+	channel int,
+) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64f(ctx, d.inst, "TNOM? %d", channel)
 }
 
-func (d *Driver) SetNominalTemperature(channel int, nominal float64) error {
-	ctx, cancel := d.newContext()
+func (d *Driver) SetNominalTemperature(
+	ctx context.Context,
+	channel int,
+	nominal float64,
+) error {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return d.inst.Command(ctx, "TNOM %d, %f", channel, nominal)
 }
 
-func (d *Driver) DeltaTemperature(channel int) (float64, error) {
-	ctx, cancel := d.newContext()
+func (d *Driver) DeltaTemperature(
+	ctx context.Context,
+	channel int,
+) (float64, error) {
+	ctx, cancel := d.newContext(ctx)
 	defer cancel()
 
 	return query.Float64f(ctx, d.inst, "TDLT? %d", channel)
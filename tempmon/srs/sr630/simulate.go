@@ -0,0 +1,24 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package sr630
+
+import "github.com/gotmc/ivi"
+
+// simulation describes the instrument simulated under [ivi.WithSimulate].
+// Every channel starts with a type J thermocouple in degrees Celsius, alarms
+// and scanning disabled, and reads 25 degrees.
+var simulation = ivi.Simulation{
+	Manufacturer: "Stanford_Research_Systems",
+	Attributes: map[string]ivi.SimulatedAttribute{
+		"TTYP": {Default: "J"},
+		"UNIT": {Default: "CENT"},
+		"MEAS": {Default: "25.0"},
+		"ALRM": {Default: "NO"},
+		"SCNE": {Default: "NO"},
+		"DWEL": {Default: "10", Min: 10, Max: 9999},
+		"TMAX": {Default: "100.0"},
+	},
+}
@@ -5,7 +5,34 @@
 
 package ivi
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // DefaultTimeout is the default timeout for instrument operations.
 const DefaultTimeout = 10 * time.Second
+
+// ContextWithTimeout returns a copy of ctx bounded by the given timeout when
+// ctx carries no deadline of its own. When ctx already has a deadline, the
+// caller's deadline wins and the returned context is only cancelable. This is
+// how drivers apply their configured timeout as a fallback: a caller that
+// passes [context.Background] gets the driver's timeout, while a caller that
+// passes a context with its own deadline or cancellation gets exactly that.
+//
+// A non-positive timeout falls back to DefaultTimeout. As with
+// [context.WithTimeout], the caller must call the returned CancelFunc.
+func ContextWithTimeout(
+	ctx context.Context,
+	timeout time.Duration,
+) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
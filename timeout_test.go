@@ -158,3 +158,45 @@ func TestConcurrentOperations(t *testing.T) {
 		}
 	}
 }
+
+func TestContextWithTimeout_AppliesFallback(t *testing.T) {
+	ctx, cancel := ContextWithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected the fallback timeout to set a deadline")
+	}
+	if remaining := time.Until(deadline); remaining > 50*time.Millisecond {
+		t.Errorf("deadline %v away, want at most 50ms", remaining)
+	}
+}
+
+func TestContextWithTimeout_CallerDeadlineWins(t *testing.T) {
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelParent()
+
+	ctx, cancel := ContextWithTimeout(parent, 10*time.Millisecond)
+	defer cancel()
+
+	want, _ := parent.Deadline()
+	got, ok := ctx.Deadline()
+	if !ok || !got.Equal(want) {
+		t.Errorf("Deadline() = %v, %v; want the caller's %v", got, ok, want)
+	}
+}
+
+func TestContextWithTimeout_CallerCancellation(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+
+	ctx, cancel := ContextWithTimeout(parent, time.Hour)
+	defer cancel()
+
+	cancelParent()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("cancelling the caller's context did not cancel the derived one")
+	}
+}
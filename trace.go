@@ -0,0 +1,203 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// defaultTraceMaxBytes is how many bytes of binary data a TraceTransport logs
+// unless [WithTraceMaxBytes] says otherwise.
+const defaultTraceMaxBytes = 64
+
+// TraceOption configures a [TraceTransport].
+type TraceOption func(*traceConfig)
+
+type traceConfig struct {
+	level      slog.Level
+	errorLevel slog.Level
+	maxBytes   int
+}
+
+// WithTraceLevel sets the level at which successful calls are logged. The
+// default is [slog.LevelDebug].
+func WithTraceLevel(level slog.Level) TraceOption {
+	return func(cfg *traceConfig) {
+		cfg.level = level
+	}
+}
+
+// WithTraceErrorLevel sets the level at which failed calls are logged. The
+// default is [slog.LevelError].
+func WithTraceErrorLevel(level slog.Level) TraceOption {
+	return func(cfg *traceConfig) {
+		cfg.errorLevel = level
+	}
+}
+
+// WithTraceMaxBytes sets how many bytes of the data passed to ReadBinary and
+// WriteBinary are logged, with the byte count always logged in full. Zero
+// logs the count alone. The default is 64.
+func WithTraceMaxBytes(n int) TraceOption {
+	return func(cfg *traceConfig) {
+		cfg.maxBytes = max(n, 0)
+	}
+}
+
+// TraceTransport wraps a Transport and logs every call made through it with
+// log/slog: the command or query, the response, the number of bytes read or
+// written, how long the call took, and any error. Wrap the transport passed
+// to a driver constructor to see the SCPI traffic the driver generates:
+//
+//	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+//		Level: slog.LevelDebug,
+//	}))
+//	dmm, err := kt34400.New(ivi.NewTraceTransport(inst, logger))
+type TraceTransport struct {
+	Transport
+
+	logger *slog.Logger
+	cfg    traceConfig
+}
+
+// NewTraceTransport returns a Transport that passes every call through to
+// inst and logs it to logger, or to [slog.Default] when logger is nil.
+func NewTraceTransport(
+	inst Transport,
+	logger *slog.Logger,
+	opts ...TraceOption,
+) *TraceTransport {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	cfg := traceConfig{
+		level:      slog.LevelDebug,
+		errorLevel: slog.LevelError,
+		maxBytes:   defaultTraceMaxBytes,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &TraceTransport{Transport: inst, logger: logger, cfg: cfg}
+}
+
+// Command sends the command and logs it.
+func (t *TraceTransport) Command(
+	ctx context.Context,
+	cmd string,
+	a ...any,
+) error {
+	start := time.Now()
+	err := t.Transport.Command(ctx, cmd, a...)
+
+	if t.enabled(ctx, err) {
+		t.log(ctx, OpCommand, start, err,
+			slog.String("cmd", FormatCommand(cmd, a)),
+		)
+	}
+
+	return err
+}
+
+// Query sends the query and logs it with its response.
+func (t *TraceTransport) Query(
+	ctx context.Context,
+	cmd string,
+) (string, error) {
+	start := time.Now()
+	resp, err := t.Transport.Query(ctx, cmd)
+
+	if t.enabled(ctx, err) {
+		t.log(ctx, OpQuery, start, err,
+			slog.String("cmd", cmd),
+			slog.String("resp", resp),
+		)
+	}
+
+	return resp, err
+}
+
+// ReadBinary reads into p and logs the bytes read.
+func (t *TraceTransport) ReadBinary(
+	ctx context.Context,
+	p []byte,
+) (int, error) {
+	start := time.Now()
+	n, err := t.Transport.ReadBinary(ctx, p)
+
+	if t.enabled(ctx, err) {
+		t.log(ctx, OpReadBinary, start, err, t.dataAttrs(p[:n])...)
+	}
+
+	return n, err
+}
+
+// WriteBinary writes p and logs the bytes written.
+func (t *TraceTransport) WriteBinary(
+	ctx context.Context,
+	p []byte,
+) (int, error) {
+	start := time.Now()
+	n, err := t.Transport.WriteBinary(ctx, p)
+
+	if t.enabled(ctx, err) {
+		t.log(ctx, OpWriteBinary, start, err, t.dataAttrs(p[:n])...)
+	}
+
+	return n, err
+}
+
+// enabled reports whether a call returning err would be logged, so that
+// attributes are only built for calls that are.
+func (t *TraceTransport) enabled(ctx context.Context, err error) bool {
+	return t.logger.Enabled(ctx, t.levelFor(err))
+}
+
+func (t *TraceTransport) levelFor(err error) slog.Level {
+	if err != nil {
+		return t.cfg.errorLevel
+	}
+
+	return t.cfg.level
+}
+
+// log writes one record for a call that started at start.
+func (t *TraceTransport) log(
+	ctx context.Context,
+	op CallOp,
+	start time.Time,
+	err error,
+	attrs ...slog.Attr,
+) {
+	attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+	if err != nil {
+		attrs = append(attrs, slog.Any("err", err))
+	}
+
+	t.logger.LogAttrs(ctx, t.levelFor(err), "ivi "+string(op), attrs...)
+}
+
+// dataAttrs describes binary data: its length, and as much of it as the
+// configured maximum allows.
+func (t *TraceTransport) dataAttrs(data []byte) []slog.Attr {
+	attrs := []slog.Attr{slog.Int("bytes", len(data))}
+	if t.cfg.maxBytes == 0 || len(data) == 0 {
+		return attrs
+	}
+
+	if len(data) > t.cfg.maxBytes {
+		return append(attrs,
+			slog.String("data", string(data[:t.cfg.maxBytes])),
+			slog.Bool("truncated", true),
+		)
+	}
+
+	return append(attrs, slog.String("data", string(data)))
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// traceRecords decodes the JSON log lines written to buf.
+func traceRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+	for line := range strings.SplitSeq(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("decoding log line %q: %v", line, err)
+		}
+		records = append(records, rec)
+	}
+
+	return records
+}
+
+func newTraceLogger(buf *bytes.Buffer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		Level: level,
+	}))
+}
+
+func TestTraceTransport(t *testing.T) {
+	ctx := t.Context()
+	mock := &mockScriptedInst{
+		responses: map[string][]string{"*IDN?": {"Acme,M1,SN0,1.0"}},
+	}
+
+	var buf bytes.Buffer
+	tr := NewTraceTransport(mock, newTraceLogger(&buf, slog.LevelDebug))
+
+	if err := tr.Command(ctx, "VOLT %.1f", 5.0); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+	if _, err := tr.Query(ctx, "*IDN?"); err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+	if _, err := tr.WriteBinary(ctx, []byte("#14abcd")); err != nil {
+		t.Fatalf("WriteBinary() error: %v", err)
+	}
+
+	records := traceRecords(t, &buf)
+	if len(records) != 3 {
+		t.Fatalf("logged %d records, want 3", len(records))
+	}
+
+	want := []map[string]any{
+		{"msg": "ivi command", "cmd": "VOLT 5.0", "level": "DEBUG"},
+		{"msg": "ivi query", "cmd": "*IDN?", "resp": "Acme,M1,SN0,1.0"},
+		{"msg": "ivi write", "bytes": 7.0, "data": "#14abcd"},
+	}
+	for i, w := range want {
+		for k, v := range w {
+			if records[i][k] != v {
+				t.Errorf("record %d %s = %v, want %v", i, k, records[i][k], v)
+			}
+		}
+		if _, ok := records[i]["duration"]; !ok {
+			t.Errorf("record %d has no duration", i)
+		}
+	}
+}
+
+func TestTraceTransport_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	tr := NewTraceTransport(
+		&mockInstrument{shouldError: true},
+		newTraceLogger(&buf, slog.LevelInfo),
+		WithTraceErrorLevel(slog.LevelWarn),
+	)
+
+	_ = tr.Command(t.Context(), "VOLT 5")
+	_, _ = tr.Query(t.Context(), "VOLT?")
+
+	records := traceRecords(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("logged %d records, want 2", len(records))
+	}
+	for i, rec := range records {
+		if rec["level"] != "WARN" || rec["err"] != "mock error" {
+			t.Errorf("record %d = %v, want WARN with err mock error", i, rec)
+		}
+	}
+}
+
+func TestTraceTransport_Level(t *testing.T) {
+	var buf bytes.Buffer
+	tr := NewTraceTransport(&mockScriptedInst{},
+		newTraceLogger(&buf, slog.LevelInfo))
+
+	if err := tr.Command(t.Context(), "VOLT 5"); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("logged %q below the handler's level", buf.String())
+	}
+
+	tr = NewTraceTransport(&mockScriptedInst{},
+		newTraceLogger(&buf, slog.LevelInfo), WithTraceLevel(slog.LevelInfo))
+
+	if err := tr.Command(t.Context(), "VOLT 5"); err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+	if records := traceRecords(t, &buf); len(records) != 1 {
+		t.Errorf("logged %d records at Info, want 1", len(records))
+	}
+}
+
+func TestTraceTransport_TruncatesBinary(t *testing.T) {
+	ctx := t.Context()
+
+	tests := []struct {
+		name      string
+		maxBytes  int
+		wantData  any
+		truncated any
+	}{
+		{"default keeps short data", -1, "test response", nil},
+		{"truncated", 4, "test", true},
+		{"count only", 0, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			var opts []TraceOption
+			if tt.maxBytes >= 0 {
+				opts = append(opts, WithTraceMaxBytes(tt.maxBytes))
+			}
+			tr := NewTraceTransport(&mockInstrument{},
+				newTraceLogger(&buf, slog.LevelDebug), opts...)
+
+			p := make([]byte, 32)
+			if _, err := tr.ReadBinary(ctx, p); err != nil {
+				t.Fatalf("ReadBinary() error: %v", err)
+			}
+
+			rec := traceRecords(t, &buf)[0]
+			if rec["msg"] != "ivi read" || rec["bytes"] != 13.0 {
+				t.Errorf("record = %v, want ivi read of 13 bytes", rec)
+			}
+			if rec["data"] != tt.wantData {
+				t.Errorf("data = %v, want %v", rec["data"], tt.wantData)
+			}
+			if rec["truncated"] != tt.truncated {
+				t.Errorf("truncated = %v, want %v", rec["truncated"], tt.truncated)
+			}
+		})
+	}
+}
@@ -0,0 +1,380 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+// Package watchdog switches off the outputs of sources when a test program
+// stops unexpectedly, so that a program that is cancelled, interrupted,
+// panics, or hangs does not leave a device under test energized.
+//
+// Register the channels to switch off, and the drivers to return to a
+// quiescent state, then start the watchdog with the program's context:
+//
+//	wd := watchdog.New(
+//		watchdog.WithSignals(),
+//		watchdog.WithHeartbeat(5*time.Second),
+//	)
+//	wd.AddDCPwr(supplyChannel)
+//	wd.AddFgen(generatorChannel)
+//	wd.AddInstrument(supply)
+//	wd.Start(ctx)
+//	defer wd.Stop()
+//	defer wd.Recover()
+//
+// The watchdog trips when ctx is done, when one of the signals arrives, when
+// a panic passes through Recover, or when Heartbeat is not called within the
+// heartbeat timeout. Tripping disables every registered output, then calls
+// Disable on every registered instrument, and closes the channel returned by
+// Done.
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/dcload"
+	"github.com/gotmc/ivi/dcpwr"
+	"github.com/gotmc/ivi/fgen"
+)
+
+// Causes reported by [Watchdog.Cause].
+var (
+	// ErrHeartbeatMissed indicates the watchdog tripped because Heartbeat
+	// was not called within the heartbeat timeout.
+	ErrHeartbeatMissed = errors.New("watchdog heartbeat missed")
+	// ErrSignal indicates the watchdog tripped because the process received
+	// one of the signals it watches.
+	ErrSignal = errors.New("watchdog received signal")
+	// ErrPanic indicates the watchdog tripped because a panic passed through
+	// [Watchdog.Recover].
+	ErrPanic = errors.New("watchdog recovered panic")
+)
+
+// Instrument is a driver that can be placed in a quiescent state, as every
+// driver embedding [ivi.Inherent] can.
+type Instrument interface {
+	Disable(ctx context.Context) error
+}
+
+// Option configures a [Watchdog].
+type Option func(*Watchdog)
+
+// WithHeartbeat makes the watchdog trip when [Watchdog.Heartbeat] is not
+// called for longer than timeout after [Watchdog.Start], which catches a
+// program that hangs rather than exits.
+func WithHeartbeat(timeout time.Duration) Option {
+	return func(w *Watchdog) {
+		w.heartbeat = timeout
+	}
+}
+
+// WithSignals makes the watchdog trip when the process receives one of sigs,
+// or os.Interrupt or SIGTERM when none are given. After switching the
+// outputs off, the watchdog stops handling the signal and sends it to the
+// process again, so the process ends as it would have without the watchdog.
+func WithSignals(sigs ...os.Signal) Option {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	return func(w *Watchdog) {
+		w.signals = sigs
+	}
+}
+
+// WithTimeout bounds how long tripping may spend switching outputs off when
+// the context it uses has no deadline. It defaults to [ivi.DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(w *Watchdog) {
+		w.timeout = timeout
+	}
+}
+
+// Watchdog switches off registered outputs when it trips. Create one with
+// [New]. Its methods are safe for concurrent use.
+type Watchdog struct {
+	heartbeat time.Duration
+	signals   []os.Signal
+	timeout   time.Duration
+	raise     func(os.Signal)
+
+	mu          sync.Mutex
+	outputs     []output
+	instruments []Instrument
+	cause       error
+	err         error
+
+	beat     chan struct{}
+	stop     chan struct{}
+	exited   chan struct{}
+	done     chan struct{}
+	started  bool
+	stopOnce sync.Once
+	tripOnce sync.Once
+}
+
+// output is one registered output, with the function that switches it off.
+type output struct {
+	name    string
+	disable func(ctx context.Context) error
+}
+
+// New returns a watchdog configured by opts. It watches nothing until
+// [Watchdog.Start] is called.
+func New(opts ...Option) *Watchdog {
+	w := &Watchdog{
+		timeout: ivi.DefaultTimeout,
+		raise:   raise,
+		beat:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		exited:  make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// AddDCPwr registers a power supply output, which tripping disables with
+// DisableOutput.
+func (w *Watchdog) AddDCPwr(ch dcpwr.BaseChannel) {
+	w.AddFunc(ch.Name(), ch.DisableOutput)
+}
+
+// AddFgen registers a function generator output, which tripping disables by
+// setting Output Enabled to false.
+func (w *Watchdog) AddFgen(ch fgen.BaseChannel) {
+	w.AddFunc(ch.Name(), func(ctx context.Context) error {
+		return ch.SetOutputEnabled(ctx, false)
+	})
+}
+
+// AddDCLoad registers an electronic load input, which tripping disables by
+// setting Input Enabled to false.
+func (w *Watchdog) AddDCLoad(ch dcload.LevelChannel) {
+	name := "load input"
+	if named, ok := ch.(interface{ Name() string }); ok {
+		name = named.Name()
+	}
+
+	w.AddFunc(name, func(ctx context.Context) error {
+		return ch.SetInputEnabled(ctx, false)
+	})
+}
+
+// AddInstrument registers a driver, whose Disable tripping calls after
+// disabling every registered output.
+func (w *Watchdog) AddInstrument(inst Instrument) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.instruments = append(w.instruments, inst)
+}
+
+// AddFunc registers a named function that tripping calls to switch an output
+// off, for outputs the other Add methods do not cover.
+func (w *Watchdog) AddFunc(name string, disable func(context.Context) error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.outputs = append(w.outputs, output{name: name, disable: disable})
+}
+
+// Start starts watching ctx, the signals, and the heartbeat. Start must be
+// called at most once.
+func (w *Watchdog) Start(ctx context.Context) {
+	var sigs chan os.Signal
+	if len(w.signals) > 0 {
+		sigs = make(chan os.Signal, 1)
+		signal.Notify(sigs, w.signals...)
+	}
+
+	w.mu.Lock()
+	w.started = true
+	w.mu.Unlock()
+
+	go w.watch(ctx, sigs)
+}
+
+// watch trips the watchdog on the first event, unless the watchdog is
+// stopped or tripped by Recover first.
+func (w *Watchdog) watch(ctx context.Context, sigs chan os.Signal) {
+	defer close(w.exited)
+
+	if sigs != nil {
+		defer signal.Stop(sigs)
+	}
+
+	var (
+		timer  *time.Timer
+		missed <-chan time.Time
+	)
+
+	if w.heartbeat > 0 {
+		timer = time.NewTimer(w.heartbeat)
+		defer timer.Stop()
+
+		missed = timer.C
+	}
+
+	for {
+		select {
+		case <-w.beat:
+			if timer != nil {
+				timer.Reset(w.heartbeat)
+			}
+
+			continue
+		case <-missed:
+			w.trip(ctx, ErrHeartbeatMissed)
+		case <-ctx.Done():
+			w.trip(ctx, context.Cause(ctx))
+		case sig := <-sigs:
+			w.tripOnSignal(ctx, sigs, sig)
+		case <-w.stop:
+		case <-w.done:
+		}
+
+		return
+	}
+}
+
+// tripOnSignal trips the watchdog and then sends sig to the process again
+// with the watchdog no longer handling it.
+func (w *Watchdog) tripOnSignal(
+	ctx context.Context,
+	sigs chan os.Signal,
+	sig os.Signal,
+) {
+	w.trip(ctx, fmt.Errorf("%w: %v", ErrSignal, sig))
+	signal.Stop(sigs)
+	w.raise(sig)
+}
+
+// raise sends sig to the process, or exits when the platform cannot.
+func raise(sig os.Signal) {
+	p, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		err = p.Signal(sig)
+	}
+
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// Heartbeat tells a watchdog created with [WithHeartbeat] that the program
+// is still making progress, restarting the heartbeat timeout.
+func (w *Watchdog) Heartbeat() {
+	select {
+	case w.beat <- struct{}{}:
+	default:
+	}
+}
+
+// Recover trips the watchdog when the goroutine is panicking and then
+// continues the panic. It must be called directly by a deferred statement:
+//
+//	defer wd.Recover()
+func (w *Watchdog) Recover() {
+	if r := recover(); r != nil {
+		w.trip(context.Background(), fmt.Errorf("%w: %v", ErrPanic, r))
+		panic(r)
+	}
+}
+
+// Stop stops watching without tripping, for a program that finishes
+// normally. Stop waits for the watchdog to stop, including any trip in
+// progress.
+func (w *Watchdog) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+
+	w.mu.Lock()
+	started := w.started
+	w.mu.Unlock()
+
+	if started {
+		<-w.exited
+	}
+}
+
+// Quiesce disables every registered output and then every registered
+// instrument, continuing past errors, and returns the errors joined. Tripping
+// calls Quiesce; a program may also call it directly.
+func (w *Watchdog) Quiesce(ctx context.Context) error {
+	ctx, cancel := ivi.ContextWithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	w.mu.Lock()
+	outputs := w.outputs
+	instruments := w.instruments
+	w.mu.Unlock()
+
+	var errs []error
+
+	for _, o := range outputs {
+		if err := o.disable(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", o.name, err))
+		}
+	}
+
+	for _, inst := range instruments {
+		if err := inst.Disable(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// trip quiesces the outputs, once, with a context that outlives ctx, and
+// records why.
+func (w *Watchdog) trip(ctx context.Context, cause error) {
+	w.tripOnce.Do(func() {
+		err := w.Quiesce(context.WithoutCancel(ctx))
+
+		w.mu.Lock()
+		w.cause = cause
+		w.err = err
+		w.mu.Unlock()
+
+		close(w.done)
+	})
+}
+
+// Done returns a channel that is closed once the watchdog has tripped and
+// switched the outputs off.
+func (w *Watchdog) Done() <-chan struct{} {
+	return w.done
+}
+
+// Cause returns why the watchdog tripped: the cause of the context being
+// done, or an error wrapping [ErrHeartbeatMissed], [ErrSignal], or
+// [ErrPanic]. It returns nil until the watchdog trips.
+func (w *Watchdog) Cause() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.cause
+}
+
+// Err returns the errors from switching the outputs off when the watchdog
+// tripped, or nil.
+func (w *Watchdog) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.err
+}
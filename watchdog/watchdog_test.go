@@ -0,0 +1,244 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gotmc/ivi"
+	"github.com/gotmc/ivi/dcload/siglent/sdl1000x"
+	"github.com/gotmc/ivi/dcpwr/keysight/e36000"
+	"github.com/gotmc/ivi/fgen/keysight/kt33000"
+	"github.com/gotmc/ivi/internal/ivitest"
+)
+
+// bench is a supply, a function generator, and a load registered with a
+// watchdog, with mocks recording the commands sent.
+type bench struct {
+	supplyMock *ivitest.Mock
+	fgenMock   *ivitest.Mock
+	loadMock   *ivitest.Mock
+}
+
+func newBench(t *testing.T, w *Watchdog) *bench {
+	t.Helper()
+
+	b := &bench{
+		supplyMock: &ivitest.Mock{
+			QueryResp: "Keysight Technologies,E36312A,MY12345678,2.1.0",
+		},
+		fgenMock: &ivitest.Mock{
+			QueryResp: "Keysight Technologies,33522B,MY12345678,5.03",
+		},
+		loadMock: &ivitest.Mock{
+			QueryResp: "Siglent Technologies,SDL1030X,SDL13GCC1R0001,1.1.1.21",
+		},
+	}
+
+	supply, err := e36000.New(b.supplyMock)
+	if err != nil {
+		t.Fatalf("e36000.New() error: %v", err)
+	}
+	gen, err := kt33000.New(b.fgenMock)
+	if err != nil {
+		t.Fatalf("kt33000.New() error: %v", err)
+	}
+	load, err := sdl1000x.New(b.loadMock)
+	if err != nil {
+		t.Fatalf("sdl1000x.New() error: %v", err)
+	}
+
+	for i := range supply.OutputChannelCount() {
+		ch, _ := supply.Channel(i)
+		w.AddDCPwr(ch)
+	}
+	ch, _ := gen.Channel(1)
+	w.AddFgen(ch)
+	input, _ := load.Channel(0)
+	w.AddDCLoad(input)
+	w.AddInstrument(supply)
+
+	b.supplyMock.CommandsSent = nil
+	b.fgenMock.CommandsSent = nil
+	b.loadMock.CommandsSent = nil
+
+	return b
+}
+
+// checkQuiesced checks that every registered output was switched off and the
+// supply returned to local control afterward.
+func (b *bench) checkQuiesced(t *testing.T) {
+	t.Helper()
+
+	// The E36312A switches its outputs together, so each channel sends the
+	// same command.
+	want := []string{"OUTP OFF", "OUTP OFF", "OUTP OFF", "SYST:LOC"}
+	if !slices.Equal(b.supplyMock.CommandsSent, want) {
+		t.Errorf("supply commands = %q, want %q", b.supplyMock.CommandsSent, want)
+	}
+	if !slices.Equal(b.fgenMock.CommandsSent, []string{"OUTP2 OFF"}) {
+		t.Errorf("fgen commands = %q, want [OUTP2 OFF]", b.fgenMock.CommandsSent)
+	}
+	want = []string{":SOUR:INP:STAT OFF"}
+	if !slices.Equal(b.loadMock.CommandsSent, want) {
+		t.Errorf("load commands = %q, want %q", b.loadMock.CommandsSent, want)
+	}
+}
+
+func waitDone(t *testing.T, w *Watchdog) {
+	t.Helper()
+
+	select {
+	case <-w.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchdog did not trip")
+	}
+}
+
+func TestWatchdog_ContextCancelled(t *testing.T) {
+	w := New()
+	b := newBench(t, w)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	w.Start(ctx)
+	defer w.Stop()
+
+	if w.Cause() != nil {
+		t.Fatalf("Cause() = %v before tripping", w.Cause())
+	}
+
+	cancel()
+	waitDone(t, w)
+
+	b.checkQuiesced(t)
+	if !errors.Is(w.Cause(), context.Canceled) {
+		t.Errorf("Cause() = %v, want context.Canceled", w.Cause())
+	}
+	if w.Err() != nil {
+		t.Errorf("Err() = %v", w.Err())
+	}
+}
+
+func TestWatchdog_Heartbeat(t *testing.T) {
+	w := New(WithHeartbeat(200 * time.Millisecond))
+	b := newBench(t, w)
+	w.Start(t.Context())
+	defer w.Stop()
+
+	for range 10 {
+		time.Sleep(40 * time.Millisecond)
+		w.Heartbeat()
+	}
+	select {
+	case <-w.Done():
+		t.Fatalf("watchdog tripped despite heartbeats: %v", w.Cause())
+	default:
+	}
+
+	waitDone(t, w)
+	b.checkQuiesced(t)
+	if !errors.Is(w.Cause(), ErrHeartbeatMissed) {
+		t.Errorf("Cause() = %v, want ErrHeartbeatMissed", w.Cause())
+	}
+}
+
+func TestWatchdog_Signal(t *testing.T) {
+	raised := make(chan os.Signal, 1)
+	w := New(WithSignals(os.Interrupt))
+	w.raise = func(sig os.Signal) { raised <- sig }
+	b := newBench(t, w)
+	w.Start(t.Context())
+	defer w.Stop()
+
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Signal(os.Interrupt); err != nil {
+		t.Skipf("cannot send os.Interrupt on this platform: %v", err)
+	}
+
+	waitDone(t, w)
+	b.checkQuiesced(t)
+	if !errors.Is(w.Cause(), ErrSignal) {
+		t.Errorf("Cause() = %v, want ErrSignal", w.Cause())
+	}
+	if sig := <-raised; sig != os.Interrupt {
+		t.Errorf("raised %v, want %v", sig, os.Interrupt)
+	}
+}
+
+func TestWatchdog_Recover(t *testing.T) {
+	w := New()
+	b := newBench(t, w)
+	w.Start(t.Context())
+	defer w.Stop()
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recovered %v, want the panic to continue", r)
+			}
+		}()
+		defer w.Recover()
+
+		panic("boom")
+	}()
+
+	waitDone(t, w)
+	b.checkQuiesced(t)
+	if !errors.Is(w.Cause(), ErrPanic) {
+		t.Errorf("Cause() = %v, want ErrPanic", w.Cause())
+	}
+}
+
+func TestWatchdog_Stop(t *testing.T) {
+	w := New()
+	b := newBench(t, w)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	w.Start(ctx)
+	w.Stop()
+	cancel()
+
+	time.Sleep(10 * time.Millisecond)
+	if len(b.supplyMock.CommandsSent) != 0 ||
+		len(b.loadMock.CommandsSent) != 0 {
+		t.Errorf("stopped watchdog sent %q and %q",
+			b.supplyMock.CommandsSent, b.loadMock.CommandsSent)
+	}
+}
+
+func TestWatchdog_QuiesceErrors(t *testing.T) {
+	w := New()
+	w.AddFunc("rail", func(context.Context) error {
+		return ivi.ErrNotImplemented
+	})
+	mock := &ivitest.Mock{
+		QueryResp: "Siglent Technologies,SDL1030X,SDL13GCC1R0001,1.1.1.21",
+	}
+	load, err := sdl1000x.New(mock)
+	if err != nil {
+		t.Fatalf("sdl1000x.New() error: %v", err)
+	}
+	input, _ := load.Channel(0)
+	w.AddDCLoad(input)
+	mock.CommandsSent = nil
+
+	err = w.Quiesce(t.Context())
+	if !errors.Is(err, ivi.ErrNotImplemented) {
+		t.Errorf("Quiesce() error = %v, want ErrNotImplemented", err)
+	}
+	if !slices.Equal(mock.CommandsSent, []string{":SOUR:INP:STAT OFF"}) {
+		t.Errorf("Quiesce() stopped at the first error, sent %q",
+			mock.CommandsSent)
+	}
+}
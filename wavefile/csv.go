@@ -0,0 +1,222 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package wavefile
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gotmc/ivi"
+)
+
+// Column names of the CSV table.
+const (
+	columnTime      = "time"
+	columnFrequency = "frequency"
+	columnValue     = "value"
+	columnStatus    = "status"
+)
+
+// WriteCSV writes wf as CSV: a "# key: value" comment line for each part of
+// its description, then a header and one time,value row per sample. The X
+// column is named frequency rather than time for a waveform in Hz. When any
+// sample is not valid, a third column gives the status of each sample.
+//
+//	# source: CHAN1
+//	# timestamp: 2026-01-02T03:04:05Z
+//	# initial_x: -0.002
+//	# x_increment: 0.001
+//	# x_units: s
+//	# y_units: V
+//	time,value
+//	-0.002,0.1
+//	-0.001,0.12
+func WriteCSV(w io.Writer, wf *ivi.Waveform) error {
+	bw := bufio.NewWriter(w)
+
+	for _, e := range infoEntries(wf.Info()) {
+		fmt.Fprintf(bw, "# %s: %s\n", e[0], e[1])
+	}
+
+	withStatus := false
+	for i := range wf.Len() {
+		if !wf.Valid(i) {
+			withStatus = true
+			break
+		}
+	}
+
+	cw := csv.NewWriter(bw)
+
+	header := []string{columnTime, columnValue}
+	if wf.XUnits() == "Hz" {
+		header[0] = columnFrequency
+	}
+
+	if withStatus {
+		header = append(header, columnStatus)
+	}
+
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+	for i := range wf.Len() {
+		x, y := wf.At(i)
+		record[0] = formatFloat(x)
+		record[1] = formatFloat(y)
+
+		if withStatus {
+			record[2] = wf.Status(i).String()
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// ReadCSV reads a waveform written by [WriteCSV]. It also reads a plain
+// two-column x,value table, with or without a header, deriving the initial X
+// and the X increment from the first two rows.
+func ReadCSV(r io.Reader) (*ivi.Waveform, error) {
+	br := bufio.NewReader(r)
+
+	var (
+		info         ivi.WaveformInfo
+		hasIncrement bool
+		hasInitialX  bool
+	)
+
+	for {
+		b, err := br.Peek(1)
+		if err != nil || b[0] != '#' {
+			break
+		}
+
+		line, err := br.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		key, value, ok := splitEntry(strings.TrimPrefix(line, "#"))
+		if !ok {
+			continue
+		}
+
+		if err := setInfo(&info, key, value); err != nil {
+			return nil, err
+		}
+
+		hasInitialX = hasInitialX || key == keyInitialX
+		hasIncrement = hasIncrement || key == keyXIncrement
+	}
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+
+	statusColumn := -1
+	if len(records) > 0 && !isNumber(records[0][0]) {
+		statusColumn = slices.IndexFunc(records[0], func(h string) bool {
+			return strings.EqualFold(h, columnStatus)
+		})
+		records = records[1:]
+	}
+
+	var (
+		xs       = make([]float64, 0, len(records))
+		items    = make([]float64, 0, len(records))
+		statuses []ivi.SampleStatus
+	)
+
+	for n, record := range records {
+		if len(record) < 2 {
+			return nil, fmt.Errorf(
+				"%w: row %d has %d columns, want 2", ErrInvalidFile, n+1,
+				len(record),
+			)
+		}
+
+		x, err := strconv.ParseFloat(record[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: %w", ErrInvalidFile, n+1, err)
+		}
+
+		y, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: %w", ErrInvalidFile, n+1, err)
+		}
+
+		xs = append(xs, x)
+		items = append(items, y)
+
+		if statusColumn >= 0 && statusColumn < len(record) {
+			status, err := parseStatus(record[statusColumn])
+			if err != nil {
+				return nil, fmt.Errorf("%w: row %d: %w", ErrInvalidFile, n+1, err)
+			}
+
+			statuses = append(statuses, status)
+		}
+	}
+
+	if !hasInitialX && len(xs) > 0 {
+		info.InitialX = xs[0]
+	}
+
+	if !hasIncrement && len(xs) > 1 {
+		info.XIncrement = xs[1] - xs[0]
+	}
+
+	wf := ivi.NewWaveform(items, info)
+	for i, status := range statuses {
+		wf.SetStatus(i, status)
+	}
+
+	return wf, nil
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+
+	return err == nil
+}
+
+// parseStatus parses the status names written by [ivi.SampleStatus.String].
+func parseStatus(s string) (ivi.SampleStatus, error) {
+	for _, status := range []ivi.SampleStatus{
+		ivi.SampleValid,
+		ivi.SampleInvalid,
+		ivi.SampleOverRange,
+		ivi.SampleUnderRange,
+	} {
+		if s == status.String() {
+			return status, nil
+		}
+	}
+
+	return ivi.SampleValid, fmt.Errorf("unknown sample status %q", s)
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package wavefile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gotmc/ivi"
+)
+
+const (
+	// npyMagic starts every NumPy .npy file.
+	npyMagic = "\x93NUMPY"
+	// maxNPYHeader bounds the header ReadNPY accepts; numpy itself writes
+	// headers of a few hundred bytes.
+	maxNPYHeader = 1 << 16
+)
+
+var (
+	npyDescr   = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	npyFortran = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyShape   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+// WriteNPY writes the values of wf as a NumPy .npy file holding a
+// one-dimensional little-endian float64 array, which numpy.load reads. The
+// format has no place for the waveform's description, so only the values are
+// written.
+func WriteNPY(w io.Writer, wf *ivi.Waveform) error {
+	items, _ := wf.AllElements()
+
+	header := fmt.Sprintf(
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (%d,), }",
+		len(items),
+	)
+
+	// The magic, version, and header length take 10 bytes, and the header
+	// ends with a newline and is padded so that the data is 64-byte aligned.
+	pad := 63 - (10+len(header))%64
+	header += strings.Repeat(" ", pad) + "\n"
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(npyMagic)
+	bw.Write([]byte{1, 0})
+	binary.Write(bw, binary.LittleEndian, uint16(len(header)))
+	bw.WriteString(header)
+
+	var b [8]byte
+	for _, v := range items {
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+		bw.Write(b[:])
+	}
+
+	return bw.Flush()
+}
+
+// ReadNPY reads a NumPy .npy file holding a one-dimensional array of
+// floating point or integer values, as a waveform with an empty description.
+// An array with more than one dimension longer than one is
+// [ErrUnsupported].
+func ReadNPY(r io.Reader) (*ivi.Waveform, error) {
+	var prefix [8]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+
+	if string(prefix[:6]) != npyMagic {
+		return nil, fmt.Errorf("%w: not a .npy file", ErrInvalidFile)
+	}
+
+	var headerLen uint32
+
+	switch prefix[6] {
+	case 1:
+		var n uint16
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+		}
+
+		headerLen = uint32(n)
+	case 2, 3:
+		if err := binary.Read(r, binary.LittleEndian, &headerLen); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+		}
+	default:
+		return nil, fmt.Errorf(
+			"%w: .npy version %d.%d", ErrUnsupported, prefix[6], prefix[7],
+		)
+	}
+
+	if headerLen > maxNPYHeader {
+		return nil, fmt.Errorf(
+			"%w: .npy header of %d bytes", ErrInvalidFile, headerLen,
+		)
+	}
+
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+
+	order, kind, size, count, err := parseNPYHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the data a value at a time rather than trusting the header's
+	// count to size a buffer.
+	br := bufio.NewReader(r)
+	items := make([]float64, 0, min(count, 1<<16))
+	b := make([]byte, size)
+
+	for range count {
+		if _, err := io.ReadFull(br, b); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+		}
+
+		items = append(items, decodeNPYValue(order, kind, b))
+	}
+
+	return ivi.NewWaveform(items, ivi.WaveformInfo{}), nil
+}
+
+// parseNPYHeader returns the byte order, kind, and size of the array's
+// elements, and their number.
+func parseNPYHeader(
+	header []byte,
+) (binary.ByteOrder, byte, int, int, error) {
+	descr := npyDescr.FindSubmatch(header)
+	fortran := npyFortran.FindSubmatch(header)
+	shape := npyShape.FindSubmatch(header)
+
+	if descr == nil || fortran == nil || shape == nil {
+		return nil, 0, 0, 0, fmt.Errorf(
+			"%w: .npy header %q", ErrInvalidFile, bytes.TrimSpace(header),
+		)
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	d := string(descr[1])
+
+	if len(d) == 3 {
+		if d[0] == '>' {
+			order = binary.BigEndian
+		}
+
+		d = d[1:]
+	}
+
+	if len(d) < 2 {
+		return nil, 0, 0, 0, fmt.Errorf(
+			"%w: .npy element type %q", ErrUnsupported, descr[1],
+		)
+	}
+
+	size, err := strconv.Atoi(d[1:])
+	kind := d[0]
+
+	switch {
+	case err != nil,
+		kind == 'f' && size != 4 && size != 8,
+		(kind == 'i' || kind == 'u') &&
+			size != 1 && size != 2 && size != 4 && size != 8,
+		kind != 'f' && kind != 'i' && kind != 'u':
+		return nil, 0, 0, 0, fmt.Errorf(
+			"%w: .npy element type %q", ErrUnsupported, descr[1],
+		)
+	}
+
+	count := 1
+	longDims := 0
+
+	for dim := range strings.SplitSeq(string(shape[1]), ",") {
+		dim = strings.TrimSpace(dim)
+		if dim == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(dim)
+		if err != nil || n < 0 || (n > 0 && count > math.MaxInt/n) {
+			return nil, 0, 0, 0, fmt.Errorf(
+				"%w: .npy shape (%s)", ErrInvalidFile, shape[1],
+			)
+		}
+
+		if n != 1 {
+			longDims++
+		}
+
+		count *= n
+	}
+
+	if longDims > 1 {
+		return nil, 0, 0, 0, fmt.Errorf(
+			"%w: .npy shape (%s) is not one-dimensional", ErrUnsupported,
+			shape[1],
+		)
+	}
+
+	return order, kind, size, count, nil
+}
+
+// decodeNPYValue decodes one array element of the given kind.
+func decodeNPYValue(order binary.ByteOrder, kind byte, b []byte) float64 {
+	switch kind {
+	case 'f':
+		if len(b) == 4 {
+			return float64(math.Float32frombits(order.Uint32(b)))
+		}
+
+		return math.Float64frombits(order.Uint64(b))
+	case 'i':
+		switch len(b) {
+		case 1:
+			return float64(int8(b[0]))
+		case 2:
+			return float64(int16(order.Uint16(b)))
+		case 4:
+			return float64(int32(order.Uint32(b)))
+		default:
+			return float64(int64(order.Uint64(b)))
+		}
+	default:
+		switch len(b) {
+		case 1:
+			return float64(b[0])
+		case 2:
+			return float64(order.Uint16(b))
+		case 4:
+			return float64(order.Uint32(b))
+		default:
+			return float64(order.Uint64(b))
+		}
+	}
+}
@@ -0,0 +1,384 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package wavefile
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gotmc/ivi"
+)
+
+// WAV format codes.
+const (
+	wavPCM        = 1
+	wavFloat      = 3
+	wavExtensible = 0xfffe
+)
+
+// wavInfoChunk is the ID of the chunk holding the waveform's description.
+// Readers ignore chunks they do not know.
+const wavInfoChunk = "ivi "
+
+// WriteWAV writes wf as a mono WAV file of 16 or 32-bit PCM samples. The
+// sample rate is the reciprocal of the X increment, rounded to a whole
+// number of hertz as the format requires. A value of fullScale, or its
+// negative, maps to the largest sample; values beyond it are clipped, and
+// samples that are not valid are written as zero. A fullScale of zero uses
+// the largest magnitude in wf. The description, including fullScale, is
+// written to an extra chunk so that [ReadWAV] restores the values and the
+// exact X increment.
+func WriteWAV(
+	w io.Writer,
+	wf *ivi.Waveform,
+	bits int,
+	fullScale float64,
+) error {
+	if bits != 16 && bits != 32 {
+		return fmt.Errorf("%w: %d-bit WAV", ErrUnsupported, bits)
+	}
+
+	rate := math.Round(1 / wf.XIncrement())
+	if wf.XIncrement() <= 0 || rate < 1 || rate > math.MaxUint32 {
+		return fmt.Errorf(
+			"%w: X increment %g is not a WAV sample rate", ErrUnsupported,
+			wf.XIncrement(),
+		)
+	}
+
+	items, _ := wf.AllElements()
+
+	if fullScale == 0 {
+		fullScale = peak(wf)
+	}
+
+	fullScale = math.Abs(fullScale)
+
+	var info strings.Builder
+	for _, e := range infoEntries(wf.Info()) {
+		fmt.Fprintf(&info, "%s: %s\n", e[0], e[1])
+	}
+
+	fmt.Fprintf(&info, "%s: %s\n", keyFullScale, formatFloat(fullScale))
+
+	if info.Len()%2 == 1 {
+		info.WriteByte('\n')
+	}
+
+	bytesPerSample := bits / 8
+	dataSize := len(items) * bytesPerSample
+	riffSize := 4 + (8 + 16) + (8 + info.Len()) + (8 + dataSize)
+
+	if int64(riffSize) > math.MaxUint32 {
+		return fmt.Errorf(
+			"%w: %d samples are too many for a WAV file", ErrUnsupported,
+			len(items),
+		)
+	}
+
+	le := binary.LittleEndian
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("RIFF")
+	binary.Write(bw, le, uint32(riffSize))
+	bw.WriteString("WAVE")
+
+	bw.WriteString("fmt ")
+	binary.Write(bw, le, struct {
+		Size          uint32
+		Format        uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+	}{
+		Size:          16,
+		Format:        wavPCM,
+		Channels:      1,
+		SampleRate:    uint32(rate),
+		ByteRate:      uint32(rate) * uint32(bytesPerSample),
+		BlockAlign:    uint16(bytesPerSample),
+		BitsPerSample: uint16(bits),
+	})
+
+	bw.WriteString(wavInfoChunk)
+	binary.Write(bw, le, uint32(info.Len()))
+	bw.WriteString(info.String())
+
+	bw.WriteString("data")
+	binary.Write(bw, le, uint32(dataSize))
+
+	maxSample := float64(int64(1)<<(bits-1) - 1)
+
+	for i, v := range items {
+		s := 0.0
+		if wf.Valid(i) && !math.IsNaN(v) && fullScale > 0 {
+			s = math.Round(max(-1, min(1, v/fullScale)) * maxSample)
+		}
+
+		if bits == 16 {
+			binary.Write(bw, le, int16(s))
+		} else {
+			binary.Write(bw, le, int32(s))
+		}
+	}
+
+	return bw.Flush()
+}
+
+// peak returns the largest magnitude of the valid samples of wf.
+func peak(wf *ivi.Waveform) float64 {
+	var p float64
+
+	items, _ := wf.AllElements()
+	for i, v := range items {
+		if wf.Valid(i) && !math.IsInf(v, 0) && !math.IsNaN(v) {
+			p = max(p, math.Abs(v))
+		}
+	}
+
+	return p
+}
+
+// wavFormat is the sample format from a WAV file's fmt chunk.
+type wavFormat struct {
+	format   uint16
+	channels uint16
+	rate     uint32
+	bits     uint16
+}
+
+// ReadWAV reads a mono WAV file of 8, 16, 24, or 32-bit PCM samples, or 32
+// or 64-bit floating point samples. The samples of a file written by
+// [WriteWAV] are scaled back to their original values, and its description
+// restored. For other files, PCM samples are scaled to between -1 and 1, and
+// the X increment is the reciprocal of the sample rate.
+func ReadWAV(r io.Reader) (*ivi.Waveform, error) {
+	var riff [12]byte
+	if _, err := io.ReadFull(r, riff[:]); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+
+	if string(riff[:4]) != "RIFF" || string(riff[8:]) != "WAVE" {
+		return nil, fmt.Errorf("%w: not a WAV file", ErrInvalidFile)
+	}
+
+	var (
+		format       *wavFormat
+		data         []byte
+		info         ivi.WaveformInfo
+		hasIncrement bool
+		fullScale    = 1.0
+	)
+
+	for {
+		var header [8]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				if data == nil {
+					return nil, fmt.Errorf("%w: no data chunk", ErrInvalidFile)
+				}
+
+				break
+			}
+
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+		}
+
+		id := string(header[:4])
+		size := int64(binary.LittleEndian.Uint32(header[4:]))
+		chunk := io.LimitReader(r, size)
+
+		var err error
+
+		switch id {
+		case "fmt ":
+			format, err = readWAVFormat(chunk)
+		case wavInfoChunk:
+			hasIncrement, fullScale, err = readWAVInfo(chunk, &info)
+		case "data":
+			data, err = io.ReadAll(chunk)
+			if err == nil && int64(len(data)) < size {
+				err = io.ErrUnexpectedEOF
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s chunk: %w", ErrInvalidFile, id, err)
+		}
+
+		// Skip the rest of the chunk and its pad byte.
+		if _, err := io.Copy(io.Discard, chunk); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+		}
+
+		if size%2 == 1 {
+			if _, err := io.ReadFull(r, make([]byte, 1)); err != nil &&
+				id != "data" {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+			}
+		}
+	}
+
+	if format == nil {
+		return nil, fmt.Errorf("%w: no fmt chunk", ErrInvalidFile)
+	}
+
+	items, err := decodeWAVSamples(format, data, fullScale)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasIncrement && format.rate > 0 {
+		info.XIncrement = 1 / float64(format.rate)
+	}
+
+	return ivi.NewWaveform(items, info), nil
+}
+
+// readWAVFormat reads a fmt chunk.
+func readWAVFormat(r io.Reader) (*wavFormat, error) {
+	var fmtChunk struct {
+		Format        uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &fmtChunk); err != nil {
+		return nil, err
+	}
+
+	format := &wavFormat{
+		format:   fmtChunk.Format,
+		channels: fmtChunk.Channels,
+		rate:     fmtChunk.SampleRate,
+		bits:     fmtChunk.BitsPerSample,
+	}
+
+	if format.format == wavExtensible {
+		// The extension holds its size, the valid bits per sample, the
+		// channel mask, and then a GUID starting with the format code.
+		var ext struct {
+			Size      uint16
+			ValidBits uint16
+			Mask      uint32
+			SubFormat uint16
+		}
+
+		if err := binary.Read(r, binary.LittleEndian, &ext); err != nil {
+			return nil, err
+		}
+
+		format.format = ext.SubFormat
+	}
+
+	return format, nil
+}
+
+// readWAVInfo reads the description chunk written by WriteWAV.
+func readWAVInfo(
+	r io.Reader,
+	info *ivi.WaveformInfo,
+) (hasIncrement bool, fullScale float64, err error) {
+	fullScale = 1
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return false, 0, err
+	}
+
+	for line := range strings.Lines(string(b)) {
+		key, value, ok := splitEntry(line)
+		if !ok {
+			continue
+		}
+
+		if key == keyFullScale {
+			fullScale, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				return false, 0, fmt.Errorf("%s: %w", key, err)
+			}
+
+			continue
+		}
+
+		if err := setInfo(info, key, value); err != nil {
+			return false, 0, err
+		}
+
+		hasIncrement = hasIncrement || key == keyXIncrement
+	}
+
+	return hasIncrement, fullScale, nil
+}
+
+// decodeWAVSamples converts the data chunk to values, scaling PCM samples so
+// that the largest sample is fullScale.
+func decodeWAVSamples(
+	format *wavFormat,
+	data []byte,
+	fullScale float64,
+) ([]float64, error) {
+	if format.channels != 1 {
+		return nil, fmt.Errorf(
+			"%w: %d-channel WAV", ErrUnsupported, format.channels,
+		)
+	}
+
+	bits := int(format.bits)
+	pcm := format.format == wavPCM &&
+		(bits == 8 || bits == 16 || bits == 24 || bits == 32)
+	float := format.format == wavFloat && (bits == 32 || bits == 64)
+
+	if !pcm && !float {
+		return nil, fmt.Errorf(
+			"%w: WAV format %d with %d-bit samples", ErrUnsupported,
+			format.format, bits,
+		)
+	}
+
+	size := bits / 8
+	items := make([]float64, 0, len(data)/size)
+	maxSample := float64(int64(1)<<(bits-1) - 1)
+	le := binary.LittleEndian
+
+	for b := range slices.Chunk(data[:len(data)/size*size], size) {
+		var v float64
+
+		switch {
+		case float && bits == 32:
+			v = float64(math.Float32frombits(le.Uint32(b)))
+		case float:
+			v = math.Float64frombits(le.Uint64(b))
+		case bits == 8:
+			// 8-bit samples are unsigned, centred on 128.
+			v = float64(int(b[0])-128) / maxSample
+		case bits == 16:
+			v = float64(int16(le.Uint16(b))) / maxSample
+		case bits == 24:
+			// Shift the 24 bits to the top of an int32 to sign-extend them.
+			v = float64(int32(uint32(b[0])<<8|uint32(b[1])<<16|
+				uint32(b[2])<<24)>>8) / maxSample
+		default:
+			v = float64(int32(le.Uint32(b))) / maxSample
+		}
+
+		items = append(items, v*fullScale)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+// Package wavefile saves [ivi.Waveform] values to files for offline analysis
+// and loads them back, in three formats:
+//
+//   - CSV, with the waveform's description in "#" comment lines ahead of a
+//     time,value table, readable by spreadsheets and most analysis tools.
+//   - NumPy .npy, holding the values as a one-dimensional float64 array.
+//   - WAV, holding the values as 16 or 32-bit PCM samples at the waveform's
+//     sample rate, with the description in an extra chunk that other
+//     readers ignore.
+//
+// A loaded waveform can be analyzed like a fetched one, replayed through a
+// simulated oscilloscope with
+// [github.com/gotmc/ivi/scope/keysight/infiniivision.SimulatedWaveform], or
+// converted into data for a function generator's arbitrary waveform with
+// [github.com/gotmc/ivi/fgen.ArbitraryData].
+package wavefile
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gotmc/ivi"
+)
+
+// Errors returned by the wavefile package.
+var (
+	// ErrInvalidFile indicates the data read is not a valid file of the
+	// format expected.
+	ErrInvalidFile = errors.New("invalid waveform file")
+	// ErrUnsupported indicates a valid file, or a waveform, that the format
+	// cannot hold or this package does not handle, such as a WAV file with
+	// more than one channel.
+	ErrUnsupported = errors.New("unsupported waveform file")
+)
+
+// Names of the description entries written to CSV comments and WAV
+// description chunks.
+const (
+	keySource     = "source"
+	keyTimestamp  = "timestamp"
+	keyInitialX   = "initial_x"
+	keyXIncrement = "x_increment"
+	keyXUnits     = "x_units"
+	keyYUnits     = "y_units"
+	keyFullScale  = "full_scale"
+)
+
+// infoEntries returns the description of a waveform as key, value pairs.
+func infoEntries(info ivi.WaveformInfo) [][2]string {
+	var entries [][2]string
+
+	if info.Source != "" {
+		entries = append(entries, [2]string{keySource, info.Source})
+	}
+
+	if !info.Timestamp.IsZero() {
+		entries = append(entries, [2]string{
+			keyTimestamp, info.Timestamp.Format(time.RFC3339Nano),
+		})
+	}
+
+	entries = append(entries,
+		[2]string{keyInitialX, formatFloat(info.InitialX)},
+		[2]string{keyXIncrement, formatFloat(info.XIncrement)},
+	)
+
+	if info.XUnits != "" {
+		entries = append(entries, [2]string{keyXUnits, info.XUnits})
+	}
+
+	if info.YUnits != "" {
+		entries = append(entries, [2]string{keyYUnits, info.YUnits})
+	}
+
+	return entries
+}
+
+// setInfo sets the description entry key to value. Keys other than those
+// infoEntries writes are ignored.
+func setInfo(info *ivi.WaveformInfo, key, value string) error {
+	var err error
+
+	switch key {
+	case keySource:
+		info.Source = value
+	case keyTimestamp:
+		info.Timestamp, err = time.Parse(time.RFC3339Nano, value)
+	case keyInitialX:
+		info.InitialX, err = strconv.ParseFloat(value, 64)
+	case keyXIncrement:
+		info.XIncrement, err = strconv.ParseFloat(value, 64)
+	case keyXUnits:
+		info.XUnits = value
+	case keyYUnits:
+		info.YUnits = value
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidFile, key, err)
+	}
+
+	return nil
+}
+
+// splitEntry splits a "key: value" description line.
+func splitEntry(line string) (key, value string, ok bool) {
+	key, value, ok = strings.Cut(line, ":")
+
+	return strings.TrimSpace(key), strings.TrimSpace(value), ok
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
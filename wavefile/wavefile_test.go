@@ -0,0 +1,276 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package wavefile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gotmc/ivi"
+)
+
+// newWaveform returns a 1 kHz sine sampled at 48 kHz for 1 ms, starting
+// 0.5 ms before the trigger.
+func newWaveform() *ivi.Waveform {
+	items := make([]float64, 48)
+	for i := range items {
+		items[i] = 2.5 * math.Sin(2*math.Pi*float64(i)/48)
+	}
+
+	return ivi.NewWaveform(items, ivi.WaveformInfo{
+		InitialX:   -0.5e-3,
+		XIncrement: 1.0 / 48000,
+		XUnits:     "s",
+		YUnits:     "V",
+		Timestamp:  time.Date(2026, 1, 2, 3, 4, 5, 600, time.UTC),
+		Source:     "CHAN1",
+	})
+}
+
+func checkWaveform(
+	t *testing.T,
+	got, want *ivi.Waveform,
+	tolerance float64,
+) {
+	t.Helper()
+
+	if got.Info() != want.Info() {
+		t.Errorf("Info() = %+v, want %+v", got.Info(), want.Info())
+	}
+
+	gotItems, _ := got.AllElements()
+	wantItems, _ := want.AllElements()
+
+	if len(gotItems) != len(wantItems) {
+		t.Fatalf("Len() = %d, want %d", len(gotItems), len(wantItems))
+	}
+
+	for i := range wantItems {
+		if math.Abs(gotItems[i]-wantItems[i]) > tolerance {
+			t.Errorf("sample %d = %g, want %g", i, gotItems[i], wantItems[i])
+		}
+		if got.Status(i) != want.Status(i) {
+			t.Errorf("Status(%d) = %v, want %v", i, got.Status(i), want.Status(i))
+		}
+	}
+}
+
+func TestCSV_RoundTrip(t *testing.T) {
+	wf := newWaveform()
+	wf.SetStatus(3, ivi.SampleOverRange)
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, wf); err != nil {
+		t.Fatalf("WriteCSV() error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "# source: CHAN1\n") ||
+		!strings.Contains(buf.String(), "time,value,status\n") {
+		t.Errorf("WriteCSV() wrote:\n%s", buf.String())
+	}
+
+	got, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatalf("ReadCSV() error: %v", err)
+	}
+
+	checkWaveform(t, got, wf, 0)
+}
+
+func TestReadCSV_Plain(t *testing.T) {
+	for _, input := range []string{
+		"0.5,1\n1.5,2\n2.5,3\n",
+		"t,v\n0.5,1\n1.5,2\n2.5,3\n",
+	} {
+		wf, err := ReadCSV(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ReadCSV(%q) error: %v", input, err)
+		}
+
+		got, _ := wf.AllElements()
+		if !slices.Equal(got, []float64{1, 2, 3}) ||
+			wf.InitialX() != 0.5 || wf.XIncrement() != 1 {
+			t.Errorf("ReadCSV(%q) = %v from %g by %g", input, got,
+				wf.InitialX(), wf.XIncrement())
+		}
+	}
+
+	_, err := ReadCSV(strings.NewReader("0,1\n1,x\n"))
+	if !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("ReadCSV() error = %v, want ErrInvalidFile", err)
+	}
+}
+
+func TestNPY_RoundTrip(t *testing.T) {
+	wf := newWaveform()
+
+	var buf bytes.Buffer
+	if err := WriteNPY(&buf, wf); err != nil {
+		t.Fatalf("WriteNPY() error: %v", err)
+	}
+
+	// numpy requires the data to start on a 64-byte boundary.
+	headerLen := int(binary.LittleEndian.Uint16(buf.Bytes()[8:]))
+	if (10+headerLen)%64 != 0 || buf.Bytes()[9+headerLen] != '\n' {
+		t.Errorf("header of %d bytes misaligns the data", headerLen)
+	}
+
+	got, err := ReadNPY(&buf)
+	if err != nil {
+		t.Fatalf("ReadNPY() error: %v", err)
+	}
+
+	want, _ := wf.AllElements()
+	checkWaveform(t, got, ivi.NewWaveform(want, ivi.WaveformInfo{}), 0)
+}
+
+func TestReadNPY(t *testing.T) {
+	npy := func(header string, data ...byte) []byte {
+		b := []byte("\x93NUMPY\x01\x00")
+		b = binary.LittleEndian.AppendUint16(b, uint16(len(header)))
+		b = append(b, header...)
+
+		return append(b, data...)
+	}
+
+	testCases := []struct {
+		name    string
+		input   []byte
+		want    []float64
+		wantErr error
+	}{
+		{
+			"int16",
+			npy("{'descr': '<i2', 'fortran_order': False, 'shape': (2,), }\n",
+				0xff, 0xff, 0x02, 0x00),
+			[]float64{-1, 2},
+			nil,
+		},
+		{
+			"big-endian float32 column",
+			npy("{'descr': '>f4', 'fortran_order': False, 'shape': (1, 1), }\n",
+				0x3f, 0xc0, 0x00, 0x00),
+			[]float64{1.5},
+			nil,
+		},
+		{
+			"two-dimensional",
+			npy("{'descr': '<f8', 'fortran_order': False, 'shape': (2, 2), }\n"),
+			nil,
+			ErrUnsupported,
+		},
+		{
+			"complex",
+			npy("{'descr': '<c16', 'fortran_order': False, 'shape': (1,), }\n"),
+			nil,
+			ErrUnsupported,
+		},
+		{
+			"truncated",
+			npy("{'descr': '<f8', 'fortran_order': False, 'shape': (1,), }\n",
+				0, 0),
+			nil,
+			ErrInvalidFile,
+		},
+		{"not npy", []byte("PK\x03\x04 zip file"), nil, ErrInvalidFile},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			wf, err := ReadNPY(bytes.NewReader(tc.input))
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("ReadNPY() error = %v, want %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got, _ := wf.AllElements()
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("ReadNPY() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWAV_RoundTrip(t *testing.T) {
+	for _, bits := range []int{16, 32} {
+		wf := newWaveform()
+		wf.SetStatus(0, ivi.SampleInvalid)
+
+		var buf bytes.Buffer
+		if err := WriteWAV(&buf, wf, bits, 0); err != nil {
+			t.Fatalf("WriteWAV(%d) error: %v", bits, err)
+		}
+
+		got, err := ReadWAV(&buf)
+		if err != nil {
+			t.Fatalf("ReadWAV(%d) error: %v", bits, err)
+		}
+
+		// WAV has no sample status, so the invalid sample reads back as 0.
+		items, _ := wf.AllElements()
+		want := ivi.NewWaveform(
+			append([]float64{0}, items[1:]...), wf.Info(),
+		)
+		checkWaveform(t, got, want, 2.5/float64(int64(1)<<(bits-1)-1))
+	}
+}
+
+func TestReadWAV_Plain(t *testing.T) {
+	// A 44.1 kHz mono 16-bit file holding full scale, zero, and negative
+	// full scale, as other tools write it.
+	var b []byte
+	b = append(b, "RIFF"...)
+	b = binary.LittleEndian.AppendUint32(b, 4+24+8+6)
+	b = append(b, "WAVEfmt "...)
+	b = binary.LittleEndian.AppendUint32(b, 16)
+	b = binary.LittleEndian.AppendUint16(b, 1)
+	b = binary.LittleEndian.AppendUint16(b, 1)
+	b = binary.LittleEndian.AppendUint32(b, 44100)
+	b = binary.LittleEndian.AppendUint32(b, 88200)
+	b = binary.LittleEndian.AppendUint16(b, 2)
+	b = binary.LittleEndian.AppendUint16(b, 16)
+	b = append(b, "data"...)
+	b = binary.LittleEndian.AppendUint32(b, 6)
+	b = append(b, 0xff, 0x7f, 0x00, 0x00, 0x01, 0x80)
+
+	wf, err := ReadWAV(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("ReadWAV() error: %v", err)
+	}
+
+	got, _ := wf.AllElements()
+	if !slices.Equal(got, []float64{1, 0, -1}) {
+		t.Errorf("ReadWAV() = %v, want [1 0 -1]", got)
+	}
+	if wf.XIncrement() != 1.0/44100 {
+		t.Errorf("XIncrement() = %g, want 1/44100", wf.XIncrement())
+	}
+}
+
+func TestWriteWAV_Unsupported(t *testing.T) {
+	wf := newWaveform()
+
+	if err := WriteWAV(&bytes.Buffer{}, wf, 24, 0); !errors.Is(
+		err, ErrUnsupported,
+	) {
+		t.Errorf("WriteWAV(24 bits) error = %v, want ErrUnsupported", err)
+	}
+
+	wf.Configure([]float64{1}, ivi.WaveformInfo{XIncrement: 10})
+	if err := WriteWAV(&bytes.Buffer{}, wf, 16, 0); !errors.Is(
+		err, ErrUnsupported,
+	) {
+		t.Errorf("WriteWAV(0.1 Hz) error = %v, want ErrUnsupported", err)
+	}
+}
@@ -5,12 +5,274 @@
 
 package ivi
 
-// Waveform represents acquired waveform data from an oscilloscope channel.
+import (
+	"iter"
+	"math"
+	"time"
+)
+
+// SampleStatus describes whether a waveform sample holds a measured value.
+type SampleStatus uint8
+
+// These are the sample statuses. A sample that is not [SampleValid] holds
+// NaN or the value the instrument reported, depending on the driver.
+const (
+	SampleValid SampleStatus = iota
+	// SampleInvalid marks a sample the instrument did not acquire, such as a
+	// hole in the record or a point before the first trigger.
+	SampleInvalid
+	// SampleOverRange marks a sample clipped at the top of the input range.
+	SampleOverRange
+	// SampleUnderRange marks a sample clipped at the bottom of the input
+	// range.
+	SampleUnderRange
+)
+
+// String implements the Stringer interface for SampleStatus.
+func (s SampleStatus) String() string {
+	switch s {
+	case SampleValid:
+		return "valid"
+	case SampleInvalid:
+		return "invalid"
+	case SampleOverRange:
+		return "over range"
+	case SampleUnderRange:
+		return "under range"
+	default:
+		return "unknown"
+	}
+}
+
+// WaveformInfo describes the samples of a [Waveform]: where they lie on the
+// X axis, the units of both axes, and where they came from.
+type WaveformInfo struct {
+	// InitialX is the X value of the first sample. For a waveform in the
+	// time domain it is the time in seconds relative to the trigger, so a
+	// negative value means the record starts before the trigger.
+	InitialX float64
+	// XIncrement is the spacing between samples, in XUnits.
+	XIncrement float64
+	// XUnits is the unit of the X axis, such as "s" or "Hz".
+	XUnits string
+	// YUnits is the unit of the sample values, such as "V" or "dBVrms".
+	YUnits string
+	// Timestamp is when the waveform was acquired, or the zero time when
+	// unknown.
+	Timestamp time.Time
+	// Source names the channel or trace that acquired the waveform.
+	Source string
+}
+
+// Waveform represents acquired waveform data, such as from an oscilloscope
+// channel or an analyzer trace: evenly spaced samples, with the X value of
+// the first sample, the spacing, the units, and the status of each sample.
+// Drivers fill a Waveform with [NewWaveform] or [Waveform.Configure].
 type Waveform struct {
-	items []float64
+	items  []float64
+	status []SampleStatus // nil when every sample is valid
+	info   WaveformInfo
+}
+
+// NewWaveform returns a waveform holding items, described by info. The
+// waveform keeps items rather than a copy.
+func NewWaveform(items []float64, info WaveformInfo) *Waveform {
+	w := &Waveform{}
+	w.Configure(items, info)
+
+	return w
+}
+
+// Configure replaces the samples and description of w, marking every sample
+// valid. FetchWaveform methods use it to fill the waveform they are given.
+func (w *Waveform) Configure(items []float64, info WaveformInfo) {
+	w.items = items
+	w.status = nil
+	w.info = info
 }
 
 // AllElements returns all waveform elements.
 func (w *Waveform) AllElements() ([]float64, error) {
 	return w.items, nil
 }
+
+// Len returns the number of samples.
+func (w *Waveform) Len() int {
+	return len(w.items)
+}
+
+// Info returns the description of the samples.
+func (w *Waveform) Info() WaveformInfo {
+	return w.info
+}
+
+// InitialX returns the X value of the first sample.
+func (w *Waveform) InitialX() float64 {
+	return w.info.InitialX
+}
+
+// XIncrement returns the spacing between samples.
+func (w *Waveform) XIncrement() float64 {
+	return w.info.XIncrement
+}
+
+// XUnits returns the unit of the X axis.
+func (w *Waveform) XUnits() string {
+	return w.info.XUnits
+}
+
+// YUnits returns the unit of the sample values.
+func (w *Waveform) YUnits() string {
+	return w.info.YUnits
+}
+
+// Timestamp returns when the waveform was acquired.
+func (w *Waveform) Timestamp() time.Time {
+	return w.info.Timestamp
+}
+
+// Source returns the name of the channel or trace that acquired the
+// waveform.
+func (w *Waveform) Source() string {
+	return w.info.Source
+}
+
+// X returns the X value of sample i.
+func (w *Waveform) X(i int) float64 {
+	return w.info.InitialX + float64(i)*w.info.XIncrement
+}
+
+// At returns the X value and the value of sample i. It panics if i is out of
+// range.
+func (w *Waveform) At(i int) (x, y float64) {
+	return w.X(i), w.items[i]
+}
+
+// Points returns an iterator over the X value and value of every sample.
+func (w *Waveform) Points() iter.Seq2[float64, float64] {
+	return func(yield func(float64, float64) bool) {
+		for i, y := range w.items {
+			if !yield(w.X(i), y) {
+				return
+			}
+		}
+	}
+}
+
+// Index returns the index of the sample nearest to x, which may be out of
+// range when x lies outside the waveform.
+func (w *Waveform) Index(x float64) int {
+	if w.info.XIncrement == 0 {
+		return 0
+	}
+
+	return int(math.Round((x - w.info.InitialX) / w.info.XIncrement))
+}
+
+// SetStatus sets the status of sample i. It panics if i is out of range.
+func (w *Waveform) SetStatus(i int, status SampleStatus) {
+	_ = w.items[i]
+
+	if w.status == nil {
+		if status == SampleValid {
+			return
+		}
+
+		w.status = make([]SampleStatus, len(w.items))
+	}
+
+	w.status[i] = status
+}
+
+// Status returns the status of sample i.
+func (w *Waveform) Status(i int) SampleStatus {
+	if w.status == nil {
+		return SampleValid
+	}
+
+	return w.status[i]
+}
+
+// Valid reports whether sample i holds a measured value.
+func (w *Waveform) Valid(i int) bool {
+	return w.Status(i) == SampleValid
+}
+
+// ValidRange returns the index of the first valid sample and the number of
+// samples from it through the last valid sample, as the IVI waveform's
+// FirstValidPoint and ValidPointCount. Samples inside the range may still be
+// invalid. It returns 0, 0 when no sample is valid.
+func (w *Waveform) ValidRange() (first, count int) {
+	if w.status == nil {
+		return 0, len(w.items)
+	}
+
+	first = -1
+	last := -1
+
+	for i, s := range w.status {
+		if s != SampleValid {
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+
+		last = i
+	}
+
+	if first < 0 {
+		return 0, 0
+	}
+
+	return first, last - first + 1
+}
+
+// Slice returns the samples whose X values lie in [start, end), as a
+// waveform whose InitialX is the X value of its first sample. The returned
+// waveform shares its samples with w.
+func (w *Waveform) Slice(start, end float64) *Waveform {
+	lo := w.firstAtOrAfter(start)
+	hi := max(w.firstAtOrAfter(end), lo)
+
+	info := w.info
+	info.InitialX = w.X(lo)
+
+	s := &Waveform{items: w.items[lo:hi:hi], info: info}
+	if w.status != nil {
+		s.status = w.status[lo:hi:hi]
+	}
+
+	return s
+}
+
+// firstAtOrAfter returns the index of the first sample whose X value is not
+// less than x, clamped to [0, Len()].
+func (w *Waveform) firstAtOrAfter(x float64) int {
+	n := len(w.items)
+	if w.info.XIncrement <= 0 {
+		if x <= w.info.InitialX {
+			return 0
+		}
+
+		return n
+	}
+
+	f := math.Ceil((x - w.info.InitialX) / w.info.XIncrement)
+
+	switch {
+	case f <= 0:
+		return 0
+	case f >= float64(n), math.IsNaN(f):
+		return n
+	}
+
+	// Guard against rounding putting x one sample late.
+	i := int(f)
+	if w.X(i-1) >= x {
+		i--
+	}
+
+	return i
+}
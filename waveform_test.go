@@ -0,0 +1,129 @@
+// Copyright (c) 2017-2026 The ivi developers. All rights reserved.
+// Project site: https://github.com/gotmc/ivi
+// Use of this source code is governed by a MIT-style license that
+// can be found in the LICENSE.txt file for the project.
+
+package ivi
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// newTestWaveform returns ten samples valued 0 through 9, taken every
+// millisecond starting 2 ms before the trigger.
+func newTestWaveform() *Waveform {
+	items := make([]float64, 10)
+	for i := range items {
+		items[i] = float64(i)
+	}
+
+	return NewWaveform(items, WaveformInfo{
+		InitialX:   -2e-3,
+		XIncrement: 1e-3,
+		XUnits:     "s",
+		YUnits:     "V",
+		Timestamp:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Source:     "CHAN1",
+	})
+}
+
+func TestWaveform_Points(t *testing.T) {
+	w := newTestWaveform()
+
+	if w.Len() != 10 {
+		t.Errorf("Len() = %d, want 10", w.Len())
+	}
+
+	x, y := w.At(3)
+	if !closeTo(x, 1e-3) || y != 3 {
+		t.Errorf("At(3) = %g, %g, want 0.001, 3", x, y)
+	}
+
+	var n int
+	for x, y := range w.Points() {
+		if !closeTo(x, w.X(n)) || y != float64(n) {
+			t.Errorf("point %d = %g, %g", n, x, y)
+		}
+		n++
+	}
+	if n != 10 {
+		t.Errorf("Points() yielded %d points, want 10", n)
+	}
+
+	if got := w.Index(0); got != 2 {
+		t.Errorf("Index(0) = %d, want 2", got)
+	}
+	if w.Source() != "CHAN1" || w.YUnits() != "V" || w.XUnits() != "s" {
+		t.Errorf("Info() = %+v", w.Info())
+	}
+}
+
+func TestWaveform_Slice(t *testing.T) {
+	testCases := []struct {
+		name       string
+		start, end float64
+		want       []float64
+	}{
+		{"around trigger", -1e-3, 2e-3, []float64{1, 2, 3}},
+		{"between samples", -1.5e-3, 1.5e-3, []float64{1, 2, 3}},
+		{"before start", -1, -1.5e-3, []float64{0}},
+		{"after end", 6.5e-3, 1, []float64{9}},
+		{"outside", 1, 2, nil},
+		{"reversed", 2e-3, -1e-3, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestWaveform().Slice(tc.start, tc.end)
+
+			got, _ := s.AllElements()
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("Slice() = %v, want %v", got, tc.want)
+			}
+			if len(tc.want) > 0 && !closeTo(s.InitialX(), s.X(0)) {
+				t.Errorf("InitialX() = %g", s.InitialX())
+			}
+			if s.Source() != "CHAN1" {
+				t.Errorf("Source() = %q, want CHAN1", s.Source())
+			}
+		})
+	}
+}
+
+func TestWaveform_Status(t *testing.T) {
+	w := newTestWaveform()
+
+	if first, count := w.ValidRange(); first != 0 || count != 10 {
+		t.Errorf("ValidRange() = %d, %d, want 0, 10", first, count)
+	}
+
+	w.SetStatus(0, SampleInvalid)
+	w.SetStatus(1, SampleInvalid)
+	w.SetStatus(5, SampleOverRange)
+	w.SetStatus(9, SampleUnderRange)
+
+	if w.Valid(5) || w.Status(5) != SampleOverRange {
+		t.Errorf("Status(5) = %v, want over range", w.Status(5))
+	}
+	if first, count := w.ValidRange(); first != 2 || count != 7 {
+		t.Errorf("ValidRange() = %d, %d, want 2, 7", first, count)
+	}
+
+	s := w.Slice(2e-3, 1)
+	if s.Status(1) != SampleOverRange {
+		t.Errorf("sliced Status(1) = %v, want over range", s.Status(1))
+	}
+
+	w.Configure([]float64{1, 2}, w.Info())
+	if !w.Valid(0) {
+		t.Error("Configure() kept the old sample status")
+	}
+}
+
+func closeTo(a, b float64) bool {
+	const tolerance = 1e-12
+
+	return a-b < tolerance && b-a < tolerance
+}